@@ -0,0 +1,48 @@
+// Copyright 2025 Woodpecker Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package agent
+
+import (
+	"context"
+	"io"
+
+	"github.com/rs/zerolog"
+
+	"go.woodpecker-ci.org/woodpecker/v3/pipeline"
+	backend "go.woodpecker-ci.org/woodpecker/v3/pipeline/backend/types"
+	"go.woodpecker-ci.org/woodpecker/v3/pipeline/rpc"
+)
+
+func (r *Runner) createArtifactUploader(ctx context.Context, _logger zerolog.Logger, workflow *rpc.Workflow) pipeline.ArtifactUploader {
+	return func(step *backend.Step, name string, size int64, content io.Reader) error {
+		logger := _logger.With().
+			Str("step", step.Name).
+			Str("artifact", name).
+			Logger()
+
+		logger.Debug().Int64("size", size).Msg("upload artifact")
+
+		artifact := rpc.Artifact{
+			StepUUID: step.UUID,
+			Name:     name,
+			Size:     size,
+		}
+		if err := r.client.UploadArtifact(ctx, workflow.ID, artifact, content); err != nil {
+			logger.Error().Err(err).Msg("could not upload artifact")
+			return err
+		}
+		return nil
+	}
+}
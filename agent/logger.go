@@ -17,6 +17,7 @@ package agent
 import (
 	"io"
 	"sync"
+	"sync/atomic"
 
 	"github.com/rs/zerolog"
 
@@ -26,7 +27,51 @@ import (
 	"go.woodpecker-ci.org/woodpecker/v3/pipeline/rpc"
 )
 
-func (r *Runner) createLogger(_logger zerolog.Logger, uploads *sync.WaitGroup, workflow *rpc.Workflow) pipeline.Logger {
+// logStreams keeps the log writers of the steps of a workflow. A step is logged multiple times
+// if it gets restarted, its writer is kept so line numbers continue.
+type logStreams struct {
+	sync.Mutex
+	streams map[string]*logStream
+}
+
+// logStream counts the lines written to the log of a step.
+type logStream struct {
+	io.Writer
+	lines atomic.Int64
+}
+
+func (s *logStream) Write(p []byte) (int, error) {
+	s.lines.Add(1)
+	return s.Writer.Write(p)
+}
+
+func newLogStreams() *logStreams {
+	return &logStreams{streams: make(map[string]*logStream)}
+}
+
+// get returns the writer of the step, it is created using newWriter on first use.
+func (s *logStreams) get(stepUUID string, newWriter func() io.Writer) *logStream {
+	s.Lock()
+	defer s.Unlock()
+	stream, ok := s.streams[stepUUID]
+	if !ok {
+		stream = &logStream{Writer: newWriter()}
+		s.streams[stepUUID] = stream
+	}
+	return stream
+}
+
+// lines returns the number of lines written to the log of the step.
+func (s *logStreams) lines(stepUUID string) int {
+	s.Lock()
+	defer s.Unlock()
+	if stream, ok := s.streams[stepUUID]; ok {
+		return int(stream.lines.Load())
+	}
+	return 0
+}
+
+func (r *Runner) createLogger(_logger zerolog.Logger, uploads *sync.WaitGroup, workflow *rpc.Workflow, streams *logStreams) pipeline.Logger {
 	return func(step *backend.Step, rc io.ReadCloser) error {
 		defer rc.Close()
 
@@ -43,7 +88,13 @@ func (r *Runner) createLogger(_logger zerolog.Logger, uploads *sync.WaitGroup, w
 
 		logger.Debug().Msg("log stream opened")
 
-		logStream := log.NewLineWriter(r.client, step.UUID, secrets...)
+		logStream := streams.get(step.UUID, func() io.Writer {
+			return &countingWriter{
+				Writer:  log.NewLineWriter(r.client, step.UUID, secrets...),
+				counter: logBytes.WithLabelValues((*r.backend).Name()),
+			}
+		})
+
 		if err := log.CopyLineByLine(logStream, rc, pipeline.MaxLogLineLength); err != nil {
 			logger.Error().Err(err).Msg("copy limited logStream part")
 		}
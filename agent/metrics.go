@@ -0,0 +1,79 @@
+// Copyright 2025 Woodpecker Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package agent
+
+import (
+	"io"
+
+	"github.com/prometheus/client_golang/prometheus"
+	prometheus_auto "github.com/prometheus/client_golang/prometheus/promauto"
+)
+
+const (
+	workflowStatusSuccess  = "success"
+	workflowStatusFailure  = "failure"
+	workflowStatusCanceled = "canceled"
+)
+
+var (
+	workflowsExecuted = prometheus_auto.NewCounterVec(prometheus.CounterOpts{
+		Namespace: "woodpecker",
+		Subsystem: "agent",
+		Name:      "workflows_total",
+		Help:      "Total number of executed workflows.",
+	}, []string{"backend", "status"})
+	workflowsRunning = prometheus_auto.NewGaugeVec(prometheus.GaugeOpts{
+		Namespace: "woodpecker",
+		Subsystem: "agent",
+		Name:      "running_workflows",
+		Help:      "Number of workflows currently running.",
+	}, []string{"backend"})
+	workflowsMax = prometheus_auto.NewGaugeVec(prometheus.GaugeOpts{
+		Namespace: "woodpecker",
+		Subsystem: "agent",
+		Name:      "max_workflows",
+		Help:      "Number of workflows the agent can run in parallel.",
+	}, []string{"backend"})
+	stepDuration = prometheus_auto.NewHistogramVec(prometheus.HistogramOpts{
+		Namespace: "woodpecker",
+		Subsystem: "agent",
+		Name:      "step_duration_seconds",
+		Help:      "Duration of executed steps.",
+		Buckets:   prometheus.ExponentialBuckets(1, 2, 14), //nolint:mnd
+	}, []string{"backend"})
+	logBytes = prometheus_auto.NewCounterVec(prometheus.CounterOpts{
+		Namespace: "woodpecker",
+		Subsystem: "agent",
+		Name:      "log_bytes_total",
+		Help:      "Total number of log bytes shipped to the server.",
+	}, []string{"backend"})
+)
+
+// SetMaxWorkflows reports the number of workflows the agent can run in parallel.
+func SetMaxWorkflows(backend string, maxWorkflows int) {
+	workflowsMax.WithLabelValues(backend).Set(float64(maxWorkflows))
+}
+
+// countingWriter counts the bytes written to a writer.
+type countingWriter struct {
+	io.Writer
+	counter prometheus.Counter
+}
+
+func (w *countingWriter) Write(p []byte) (int, error) {
+	n, err := w.Writer.Write(p)
+	w.counter.Add(float64(n))
+	return n, err
+}
@@ -17,6 +17,8 @@ package rpc
 import (
 	"context"
 	"encoding/json"
+	"errors"
+	"io"
 	"strings"
 	"time"
 
@@ -24,12 +26,14 @@ import (
 	"github.com/rs/zerolog/log"
 	"google.golang.org/grpc"
 	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
 	"google.golang.org/grpc/status"
 	grpcproto "google.golang.org/protobuf/proto"
 
 	backend "go.woodpecker-ci.org/woodpecker/v3/pipeline/backend/types"
 	"go.woodpecker-ci.org/woodpecker/v3/pipeline/rpc"
 	"go.woodpecker-ci.org/woodpecker/v3/pipeline/rpc/proto"
+	"go.woodpecker-ci.org/woodpecker/v3/shared/tracing"
 )
 
 const (
@@ -40,6 +44,9 @@ const (
 	// Picked to prevent it from going over GRPC size limit (4 MiB) with a large safety margin.
 	maxLogBatchSize int = 1 * 1024 * 1024
 
+	// Size of the chunks artifacts are uploaded in.
+	artifactChunkSize int = 1 * 1024 * 1024
+
 	// Maximum amount of time between sending consecutive batched log messages.
 	// Controls the delay between the CI job generating a log record, and web users receiving it.
 	maxLogFlushPeriod time.Duration = time.Second
@@ -66,11 +73,11 @@ func (c *client) Close() error {
 	return c.conn.Close()
 }
 
-func (c *client) newBackOff() backoff.BackOff {
+func (c *client) newBackOff(method string) backoff.BackOff {
 	b := backoff.NewExponentialBackOff()
 	b.MaxInterval = 10 * time.Second          //nolint:mnd
 	b.InitialInterval = 10 * time.Millisecond //nolint:mnd
-	return b
+	return &countingBackOff{BackOff: b, retries: rpcRetries.WithLabelValues(method)}
 }
 
 // Version returns the server- & grpc-version.
@@ -89,12 +96,13 @@ func (c *client) Version(ctx context.Context) (*rpc.Version, error) {
 func (c *client) Next(ctx context.Context, filter rpc.Filter) (*rpc.Workflow, error) {
 	var res *proto.NextResponse
 	var err error
-	retry := c.newBackOff()
+	retry := c.newBackOff("next")
 	req := new(proto.NextRequest)
 	req.Filter = new(proto.Filter)
 	req.Filter.Labels = filter.Labels
+	var header metadata.MD
 	for {
-		res, err = c.client.Next(ctx, req)
+		res, err = c.client.Next(ctx, req, grpc.Header(&header))
 		if err == nil {
 			break
 		}
@@ -141,6 +149,7 @@ func (c *client) Next(ctx context.Context, filter rpc.Filter) (*rpc.Workflow, er
 	w := new(rpc.Workflow)
 	w.ID = res.GetWorkflow().GetId()
 	w.Timeout = res.GetWorkflow().GetTimeout()
+	w.TraceContext = tracing.FromMetadata(header)
 	w.Config = new(backend.Config)
 	if err := json.Unmarshal(res.GetWorkflow().GetPayload(), w.Config); err != nil {
 		log.Error().Err(err).Msgf("could not unmarshal workflow config of '%s'", w.ID)
@@ -150,7 +159,7 @@ func (c *client) Next(ctx context.Context, filter rpc.Filter) (*rpc.Workflow, er
 
 // Wait blocks until the workflow is complete.
 func (c *client) Wait(ctx context.Context, workflowID string) (err error) {
-	retry := c.newBackOff()
+	retry := c.newBackOff("wait")
 	req := new(proto.WaitRequest)
 	req.Id = workflowID
 	for {
@@ -192,7 +201,7 @@ func (c *client) Wait(ctx context.Context, workflowID string) (err error) {
 
 // Init signals the workflow is initialized.
 func (c *client) Init(ctx context.Context, workflowID string, state rpc.WorkflowState) (err error) {
-	retry := c.newBackOff()
+	retry := c.newBackOff("init")
 	req := new(proto.InitRequest)
 	req.Id = workflowID
 	req.State = new(proto.WorkflowState)
@@ -240,7 +249,7 @@ func (c *client) Init(ctx context.Context, workflowID string, state rpc.Workflow
 
 // Done signals the workflow is complete.
 func (c *client) Done(ctx context.Context, workflowID string, state rpc.WorkflowState) (err error) {
-	retry := c.newBackOff()
+	retry := c.newBackOff("done")
 	req := new(proto.DoneRequest)
 	req.Id = workflowID
 	req.State = new(proto.WorkflowState)
@@ -288,7 +297,7 @@ func (c *client) Done(ctx context.Context, workflowID string, state rpc.Workflow
 
 // Extend extends the workflow deadline.
 func (c *client) Extend(ctx context.Context, workflowID string) (err error) {
-	retry := c.newBackOff()
+	retry := c.newBackOff("extend")
 	req := new(proto.ExtendRequest)
 	req.Id = workflowID
 	for {
@@ -332,7 +341,7 @@ func (c *client) Extend(ctx context.Context, workflowID string) (err error) {
 
 // Update updates the workflow state.
 func (c *client) Update(ctx context.Context, workflowID string, state rpc.StepState) (err error) {
-	retry := c.newBackOff()
+	retry := c.newBackOff("update")
 	req := new(proto.UpdateRequest)
 	req.Id = workflowID
 	req.State = new(proto.StepState)
@@ -342,6 +351,8 @@ func (c *client) Update(ctx context.Context, workflowID string, state rpc.StepSt
 	req.State.Exited = state.Exited
 	req.State.ExitCode = int32(state.ExitCode)
 	req.State.Error = state.Error
+	req.State.Retry = state.Retry
+	req.State.LogLines = int32(state.LogLines)
 	for {
 		_, err = c.client.Update(ctx, req)
 		if err == nil {
@@ -440,7 +451,7 @@ func (c *client) processLogs(ctx context.Context) {
 
 func (c *client) sendLogs(ctx context.Context, entries []*proto.LogEntry) error {
 	req := &proto.LogRequest{LogEntries: entries}
-	retry := c.newBackOff()
+	retry := c.newBackOff("log")
 
 	for {
 		_, err := c.client.Log(ctx, req)
@@ -499,7 +510,7 @@ func (c *client) UnregisterAgent(ctx context.Context) error {
 }
 
 func (c *client) ReportHealth(ctx context.Context) (err error) {
-	retry := c.newBackOff()
+	retry := c.newBackOff("report_health")
 	req := new(proto.ReportHealthRequest)
 	req.Status = "I am alive!"
 
@@ -537,3 +548,54 @@ func (c *client) ReportHealth(ctx context.Context) (err error) {
 		}
 	}
 }
+
+// UploadArtifact streams the content of an artifact to the server in chunks.
+// Uploads are not retried as the content can only be read once.
+func (c *client) UploadArtifact(ctx context.Context, workflowID string, artifact rpc.Artifact, content io.Reader) error {
+	// canceling the context aborts the upload if reading the content fails
+	ctx, cancel := context.WithCancel(ctx)
+	defer cancel()
+
+	stream, err := c.client.UploadArtifact(ctx)
+	if err != nil {
+		log.Error().Err(err).Msgf("grpc error: upload_artifact(): code: %v", status.Code(err))
+		return err
+	}
+
+	req := &proto.UploadArtifactRequest{
+		Id:       workflowID,
+		StepUuid: artifact.StepUUID,
+		Name:     artifact.Name,
+		Size:     artifact.Size,
+	}
+	for {
+		data := make([]byte, artifactChunkSize)
+		n, readErr := io.ReadFull(content, data)
+		if readErr != nil && !errors.Is(readErr, io.EOF) && !errors.Is(readErr, io.ErrUnexpectedEOF) {
+			return readErr
+		}
+
+		// the first message is sent even for empty artifacts as it contains the metadata
+		if n > 0 || req.Name != "" {
+			req.Data = data[:n]
+			if err := stream.Send(req); err != nil {
+				// the actual error is returned by CloseAndRecv
+				if errors.Is(err, io.EOF) {
+					break
+				}
+				return err
+			}
+			req = new(proto.UploadArtifactRequest)
+		}
+
+		if readErr != nil {
+			break
+		}
+	}
+
+	if _, err := stream.CloseAndRecv(); err != nil {
+		log.Error().Err(err).Msgf("grpc error: upload_artifact(): code: %v", status.Code(err))
+		return err
+	}
+	return nil
+}
@@ -0,0 +1,71 @@
+// Copyright 2025 Woodpecker Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package rpc
+
+import (
+	"context"
+	"path"
+	"time"
+
+	"github.com/cenkalti/backoff/v5"
+	"github.com/prometheus/client_golang/prometheus"
+	prometheus_auto "github.com/prometheus/client_golang/prometheus/promauto"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/status"
+)
+
+var (
+	rpcErrors = prometheus_auto.NewCounterVec(prometheus.CounterOpts{
+		Namespace: "woodpecker",
+		Subsystem: "agent",
+		Name:      "rpc_errors_total",
+		Help:      "Total number of failed RPC calls to the server.",
+	}, []string{"method", "code"})
+	rpcRetries = prometheus_auto.NewCounterVec(prometheus.CounterOpts{
+		Namespace: "woodpecker",
+		Subsystem: "agent",
+		Name:      "rpc_retries_total",
+		Help:      "Total number of retried RPC calls to the server.",
+	}, []string{"method"})
+)
+
+// MetricsUnaryInterceptor returns a client interceptor which counts failed unary RPC.
+func MetricsUnaryInterceptor() grpc.UnaryClientInterceptor {
+	return func(
+		ctx context.Context,
+		method string,
+		req, reply any,
+		cc *grpc.ClientConn,
+		invoker grpc.UnaryInvoker,
+		opts ...grpc.CallOption,
+	) error {
+		err := invoker(ctx, method, req, reply, cc, opts...)
+		if err != nil {
+			rpcErrors.WithLabelValues(path.Base(method), status.Code(err).String()).Inc()
+		}
+		return err
+	}
+}
+
+// countingBackOff counts the retries of a RPC call.
+type countingBackOff struct {
+	backoff.BackOff
+	retries prometheus.Counter
+}
+
+func (b *countingBackOff) NextBackOff() time.Duration {
+	b.retries.Inc()
+	return b.BackOff.NextBackOff()
+}
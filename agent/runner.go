@@ -23,12 +23,16 @@ import (
 	"time"
 
 	"github.com/rs/zerolog/log"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/trace"
 	"google.golang.org/grpc/metadata"
 
 	"go.woodpecker-ci.org/woodpecker/v3/pipeline"
 	backend "go.woodpecker-ci.org/woodpecker/v3/pipeline/backend/types"
+	"go.woodpecker-ci.org/woodpecker/v3/pipeline/cache"
 	"go.woodpecker-ci.org/woodpecker/v3/pipeline/rpc"
 	"go.woodpecker-ci.org/woodpecker/v3/shared/constant"
+	"go.woodpecker-ci.org/woodpecker/v3/shared/tracing"
 	"go.woodpecker-ci.org/woodpecker/v3/shared/utils"
 )
 
@@ -38,15 +42,17 @@ type Runner struct {
 	hostname string
 	counter  *State
 	backend  *backend.Backend
+	cache    cache.Store
 }
 
-func NewRunner(workEngine rpc.Peer, f rpc.Filter, h string, state *State, backend *backend.Backend) Runner {
+func NewRunner(workEngine rpc.Peer, f rpc.Filter, h string, state *State, backend *backend.Backend, cache cache.Store) Runner {
 	return Runner{
 		client:   workEngine,
 		filter:   f,
 		hostname: h,
 		counter:  state,
 		backend:  backend,
+		cache:    cache,
 	}
 }
 
@@ -81,6 +87,10 @@ func (r *Runner) Run(runnerCtx, shutdownCtx context.Context) error { //nolint:co
 	)
 	defer r.counter.Done(workflow.ID)
 
+	backendName := (*r.backend).Name()
+	workflowsRunning.WithLabelValues(backendName).Inc()
+	defer workflowsRunning.WithLabelValues(backendName).Dec()
+
 	logger := log.With().
 		Str("repo", repoName).
 		Str("pipeline", pipelineNumber).
@@ -89,6 +99,15 @@ func (r *Runner) Run(runnerCtx, shutdownCtx context.Context) error { //nolint:co
 
 	logger.Debug().Msg("received execution")
 
+	// continue the trace the server started for the pipeline
+	runnerCtx, span := tracing.Tracer.Start(tracing.Extract(runnerCtx, workflow.TraceContext), "Runner.Run", trace.WithAttributes(
+		attribute.String("repo", repoName),
+		attribute.String("pipeline", pipelineNumber),
+		attribute.String("workflow_id", workflow.ID),
+	))
+	defer span.End()
+	ctxMeta = trace.ContextWithSpan(ctxMeta, span)
+
 	workflowCtx, cancel := context.WithTimeout(ctxMeta, timeout)
 	defer cancel()
 
@@ -138,13 +157,16 @@ func (r *Runner) Run(runnerCtx, shutdownCtx context.Context) error { //nolint:co
 	}
 
 	var uploads sync.WaitGroup
+	logStreams := newLogStreams()
 	//nolint:contextcheck
 	err = pipeline.New(workflow.Config,
 		pipeline.WithContext(workflowCtx),
 		pipeline.WithTaskUUID(fmt.Sprint(workflow.ID)),
-		pipeline.WithLogger(r.createLogger(logger, &uploads, workflow)),
-		pipeline.WithTracer(r.createTracer(ctxMeta, &uploads, logger, workflow)),
+		pipeline.WithLogger(r.createLogger(logger, &uploads, workflow, logStreams)),
+		pipeline.WithTracer(r.createTracer(ctxMeta, &uploads, logger, workflow, logStreams)),
 		pipeline.WithBackend(*r.backend),
+		pipeline.WithCache(r.cache),
+		pipeline.WithArtifactUploader(r.createArtifactUploader(workflowCtx, logger, workflow)),
 		pipeline.WithDescription(map[string]string{
 			"workflow_id":     workflow.ID,
 			"repo":            repoName,
@@ -163,6 +185,16 @@ func (r *Runner) Run(runnerCtx, shutdownCtx context.Context) error { //nolint:co
 	if err != nil {
 		state.Error = err.Error()
 	}
+	tracing.RecordError(span, err)
+
+	switch {
+	case canceled:
+		workflowsExecuted.WithLabelValues(backendName, workflowStatusCanceled).Inc()
+	case err != nil:
+		workflowsExecuted.WithLabelValues(backendName, workflowStatusFailure).Inc()
+	default:
+		workflowsExecuted.WithLabelValues(backendName, workflowStatusSuccess).Inc()
+	}
 
 	logger.Debug().
 		Str("error", state.Error).
@@ -179,7 +211,7 @@ func (r *Runner) Run(runnerCtx, shutdownCtx context.Context) error { //nolint:co
 
 	doneCtx := runnerCtx
 	if doneCtx.Err() != nil {
-		doneCtx = shutdownCtx
+		doneCtx = trace.ContextWithSpan(shutdownCtx, span)
 	}
 	if err := r.client.Done(doneCtx, workflow.ID, state); err != nil {
 		logger.Error().Err(err).Msg("updating workflow status failed")
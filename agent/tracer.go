@@ -27,10 +27,24 @@ import (
 	"go.woodpecker-ci.org/woodpecker/v3/pipeline/rpc"
 )
 
-func (r *Runner) createTracer(ctxMeta context.Context, uploads *sync.WaitGroup, logger zerolog.Logger, workflow *rpc.Workflow) pipeline.TraceFunc {
+func (r *Runner) createTracer(ctxMeta context.Context, uploads *sync.WaitGroup, logger zerolog.Logger, workflow *rpc.Workflow, streams *logStreams) pipeline.TraceFunc {
+	// keep the start of the steps to observe their duration
+	var stepsStartedMu sync.Mutex
+	stepsStarted := make(map[string]time.Time)
+	stepDuration := stepDuration.WithLabelValues((*r.backend).Name())
+
 	return func(state *pipeline.State) error {
 		uploads.Add(1)
 
+		stepsStartedMu.Lock()
+		if started, ok := stepsStarted[state.Pipeline.Step.UUID]; !ok {
+			stepsStarted[state.Pipeline.Step.UUID] = time.Now()
+		} else if state.Process.Exited {
+			stepDuration.Observe(time.Since(started).Seconds())
+			delete(stepsStarted, state.Pipeline.Step.UUID)
+		}
+		stepsStartedMu.Unlock()
+
 		stepLogger := logger.With().
 			Str("image", state.Pipeline.Step.Image).
 			Str("workflow_id", workflow.ID).
@@ -45,10 +59,15 @@ func (r *Runner) createTracer(ctxMeta context.Context, uploads *sync.WaitGroup,
 			ExitCode: state.Process.ExitCode,
 			Started:  time.Now().Unix(), // TODO: do not do this
 			Finished: time.Now().Unix(),
+			Retry:    state.Pipeline.Retry,
 		}
 		if state.Process.Error != nil {
 			stepState.Error = state.Process.Error.Error()
 		}
+		if state.Pipeline.Retry {
+			// the logs of the next attempt start after the lines logged so far
+			stepState.LogLines = streams.lines(state.Pipeline.Step.UUID)
+		}
 
 		defer func() {
 			stepLogger.Debug().Msg("update step status")
@@ -17,6 +17,7 @@ package admin
 import (
 	"github.com/urfave/cli/v3"
 
+	"go.woodpecker-ci.org/woodpecker/v3/cli/admin/audit"
 	"go.woodpecker-ci.org/woodpecker/v3/cli/admin/loglevel"
 	"go.woodpecker-ci.org/woodpecker/v3/cli/admin/registry"
 	"go.woodpecker-ci.org/woodpecker/v3/cli/admin/secret"
@@ -28,6 +29,7 @@ var Command = &cli.Command{
 	Name:  "admin",
 	Usage: "manage server settings",
 	Commands: []*cli.Command{
+		audit.Command,
 		loglevel.Command,
 		registry.Command,
 		secret.Command,
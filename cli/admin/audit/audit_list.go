@@ -0,0 +1,151 @@
+// Copyright 2025 Woodpecker Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package audit
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"os"
+	"text/template"
+	"time"
+
+	"github.com/urfave/cli/v3"
+
+	"go.woodpecker-ci.org/woodpecker/v3/cli/common"
+	"go.woodpecker-ci.org/woodpecker/v3/cli/internal"
+	"go.woodpecker-ci.org/woodpecker/v3/cli/output"
+	shared_utils "go.woodpecker-ci.org/woodpecker/v3/shared/utils"
+	"go.woodpecker-ci.org/woodpecker/v3/woodpecker-go/woodpecker"
+)
+
+//nolint:mnd
+var auditListCmd = &cli.Command{
+	Name:   "ls",
+	Usage:  "list audit events, newest first",
+	Action: auditList,
+	Flags: append(common.OutputFlags("table"), []cli.Flag{
+		&cli.StringFlag{
+			Name:  "user",
+			Usage: "only list actions of the user with this login",
+		},
+		&cli.StringFlag{
+			Name:  "action",
+			Usage: "action filter [create, update, delete, approve, decline, chown, reset_token]",
+		},
+		&cli.StringFlag{
+			Name:  "target-type",
+			Usage: "filter by the type of the changed resource [secret, registry, repo, pipeline, agent, user, forge, webhook]",
+		},
+		&cli.IntFlag{
+			Name:  "repo-id",
+			Usage: "only list actions on resources of this repository",
+		},
+		&cli.IntFlag{
+			Name:  "org-id",
+			Usage: "only list actions on resources of this organization",
+		},
+		&cli.IntFlag{
+			Name:  "limit",
+			Usage: "limit the list size",
+			Value: 50,
+		},
+		&cli.TimestampFlag{
+			Name:  "before",
+			Usage: "only list events before this date (RFC3339)",
+			Config: cli.TimestampConfig{
+				Layouts: []string{
+					time.RFC3339,
+				},
+			},
+		},
+		&cli.TimestampFlag{
+			Name:  "after",
+			Usage: "only list events after this date (RFC3339)",
+			Config: cli.TimestampConfig{
+				Layouts: []string{
+					time.RFC3339,
+				},
+			},
+		},
+	}...),
+}
+
+func auditList(ctx context.Context, c *cli.Command) error {
+	client, err := internal.NewClient(ctx, c)
+	if err != nil {
+		return err
+	}
+
+	opt := woodpecker.AuditEventListOptions{
+		User:       c.String("user"),
+		Action:     c.String("action"),
+		TargetType: c.String("target-type"),
+		RepoID:     c.Int("repo-id"),
+		OrgID:      c.Int("org-id"),
+		Before:     c.Timestamp("before"),
+		After:      c.Timestamp("after"),
+	}
+	events, err := shared_utils.Paginate(func(page int) ([]*woodpecker.AuditEvent, error) {
+		pageOpt := opt
+		pageOpt.Page = page
+		return client.AuditEventList(pageOpt)
+	}, int(c.Int("limit")))
+	if err != nil {
+		return err
+	}
+
+	return auditOutput(c, events, os.Stdout)
+}
+
+func auditOutput(c *cli.Command, events []*woodpecker.AuditEvent, out io.Writer) error {
+	outFmt, outOpt := output.ParseOutputOptions(c.String("output"))
+	noHeader := c.Bool("output-no-headers")
+
+	switch outFmt {
+	case "go-template":
+		if len(outOpt) < 1 {
+			return fmt.Errorf("%w: missing template", output.ErrOutputOptionRequired)
+		}
+
+		tmpl, err := template.New("_").Parse(outOpt[0] + "\n")
+		if err != nil {
+			return err
+		}
+		return tmpl.Execute(out, events)
+	case "table":
+		fallthrough
+	default:
+		table := output.NewTable(out)
+		table.AddFieldFn("created", func(obj any) string {
+			event, _ := obj.(*woodpecker.AuditEvent)
+			return time.Unix(event.Created, 0).UTC().Format(time.RFC3339)
+		})
+		cols := []string{"Created", "User_Login", "Action", "Target_Type", "Target_Name", "Repo_ID", "Details"}
+
+		if len(outOpt) > 0 {
+			cols = outOpt
+		}
+		if !noHeader {
+			table.WriteHeader(cols)
+		}
+		for _, resource := range events {
+			if err := table.Write(cols, resource); err != nil {
+				return err
+			}
+		}
+		return table.Flush()
+	}
+}
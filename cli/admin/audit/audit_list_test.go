@@ -0,0 +1,55 @@
+// Copyright 2025 Woodpecker Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package audit
+
+import (
+	"bytes"
+	"context"
+	"io"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/urfave/cli/v3"
+
+	"go.woodpecker-ci.org/woodpecker/v3/cli/common"
+	"go.woodpecker-ci.org/woodpecker/v3/woodpecker-go/woodpecker"
+)
+
+func TestAuditOutput(t *testing.T) {
+	events := []*woodpecker.AuditEvent{
+		{
+			Created:    1735689600,
+			UserLogin:  "alice",
+			Action:     "update",
+			TargetType: "repo",
+			TargetName: "org/repo",
+			RepoID:     5,
+			Details:    "timeout: 60 -> 120",
+		},
+	}
+
+	var buf bytes.Buffer
+	command := &cli.Command{
+		Writer: io.Discard,
+		Name:   "output",
+		Flags:  common.OutputFlags("table"),
+		Action: func(_ context.Context, c *cli.Command) error {
+			return auditOutput(c, events, &buf)
+		},
+	}
+	assert.NoError(t, command.Run(context.Background(), []string{"output"}))
+	assert.Equal(t, "CREATED               USER LOGIN  ACTION  TARGET TYPE  TARGET NAME  REPO ID  DETAILS\n"+
+		"2025-01-01T00:00:00Z  alice       update  repo         org/repo     5        timeout: 60 -> 120\n", buf.String())
+}
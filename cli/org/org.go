@@ -26,6 +26,7 @@ var Command = &cli.Command{
 	Name:  "org",
 	Usage: "manage organizations",
 	Commands: []*cli.Command{
+		orgUpdateCmd,
 		registry.Command,
 		secret.Command,
 	},
@@ -0,0 +1,79 @@
+// Copyright 2025 Woodpecker Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package org
+
+import (
+	"context"
+	"fmt"
+	"strconv"
+
+	"github.com/urfave/cli/v3"
+
+	"go.woodpecker-ci.org/woodpecker/v3/cli/common"
+	"go.woodpecker-ci.org/woodpecker/v3/cli/internal"
+	"go.woodpecker-ci.org/woodpecker/v3/woodpecker-go/woodpecker"
+)
+
+var orgUpdateCmd = &cli.Command{
+	Name:      "update",
+	Usage:     "update an organization",
+	ArgsUsage: "[org-id|org-full-name]",
+	Action:    orgUpdate,
+	Flags: []cli.Flag{
+		common.OrgFlag,
+		&cli.StringFlag{
+			Name:  "config-extension-endpoint",
+			Usage: "url of a config extension for all repositories of the organization, an empty value removes it",
+		},
+	},
+}
+
+func orgUpdate(ctx context.Context, c *cli.Command) error {
+	orgIDOrName := c.String("organization")
+	if orgIDOrName == "" {
+		orgIDOrName = c.Args().First()
+	}
+	if orgIDOrName == "" {
+		return cli.ShowSubcommandHelp(c)
+	}
+
+	client, err := internal.NewClient(ctx, c)
+	if err != nil {
+		return err
+	}
+
+	orgID, err := strconv.ParseInt(orgIDOrName, 10, 64)
+	if err != nil {
+		org, err := client.OrgLookup(orgIDOrName)
+		if err != nil {
+			return err
+		}
+		orgID = org.ID
+	}
+
+	patch := new(woodpecker.OrgPatch)
+	if c.IsSet("config-extension-endpoint") {
+		endpoint := c.String("config-extension-endpoint")
+		patch.ConfigExtensionEndpoint = &endpoint
+	}
+
+	org, err := client.OrgPatch(orgID, patch)
+	if err != nil {
+		return err
+	}
+
+	fmt.Printf("Successfully updated organization %s\n", org.Name)
+	return nil
+}
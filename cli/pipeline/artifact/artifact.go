@@ -0,0 +1,63 @@
+// Copyright 2025 Woodpecker Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package artifact
+
+import (
+	"context"
+	"fmt"
+	"strconv"
+
+	"github.com/urfave/cli/v3"
+
+	"go.woodpecker-ci.org/woodpecker/v3/cli/internal"
+	"go.woodpecker-ci.org/woodpecker/v3/woodpecker-go/woodpecker"
+)
+
+// Command exports the artifact command set.
+var Command = &cli.Command{
+	Name:  "artifact",
+	Usage: "manage pipeline artifacts",
+	Commands: []*cli.Command{
+		artifactDownloadCmd,
+		artifactListCmd,
+	},
+}
+
+// parseArgs returns the client, repository id and pipeline number of the first two arguments.
+func parseArgs(ctx context.Context, c *cli.Command) (woodpecker.Client, int64, int64, error) {
+	client, err := internal.NewClient(ctx, c)
+	if err != nil {
+		return nil, 0, 0, err
+	}
+	repoIDOrFullName := c.Args().First()
+	if len(repoIDOrFullName) == 0 {
+		return nil, 0, 0, fmt.Errorf("missing required argument repo-id / repo-full-name")
+	}
+	repoID, err := internal.ParseRepo(client, repoIDOrFullName)
+	if err != nil {
+		return nil, 0, 0, fmt.Errorf("invalid repo '%s': %w", repoIDOrFullName, err)
+	}
+
+	pipelineArg := c.Args().Get(1)
+	if len(pipelineArg) == 0 {
+		return nil, 0, 0, fmt.Errorf("missing required argument pipeline")
+	}
+	number, err := strconv.ParseInt(pipelineArg, 10, 64)
+	if err != nil {
+		return nil, 0, 0, fmt.Errorf("invalid pipeline '%s': %w", pipelineArg, err)
+	}
+
+	return client, repoID, number, nil
+}
@@ -0,0 +1,99 @@
+// Copyright 2025 Woodpecker Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package artifact
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"os"
+	"path"
+	"strconv"
+
+	"github.com/urfave/cli/v3"
+
+	"go.woodpecker-ci.org/woodpecker/v3/woodpecker-go/woodpecker"
+)
+
+var artifactDownloadCmd = &cli.Command{
+	Name:      "download",
+	Usage:     "download a pipeline artifact",
+	ArgsUsage: "<repo-id|repo-full-name> <pipeline> <artifact-id|artifact-name>",
+	Action:    artifactDownload,
+	Flags: []cli.Flag{
+		&cli.StringFlag{
+			Name:    "target",
+			Aliases: []string{"t"},
+			Usage:   "file to write the artifact to, use - for stdout (default: the base name of the artifact)",
+		},
+	},
+}
+
+func artifactDownload(ctx context.Context, c *cli.Command) error {
+	client, repoID, number, err := parseArgs(ctx, c)
+	if err != nil {
+		return err
+	}
+
+	artifactArg := c.Args().Get(2) //nolint:mnd
+	if len(artifactArg) == 0 {
+		return fmt.Errorf("missing required argument artifact")
+	}
+	artifact, err := findArtifact(client, repoID, number, artifactArg)
+	if err != nil {
+		return err
+	}
+
+	content, err := client.PipelineArtifact(repoID, number, artifact.ID)
+	if err != nil {
+		return err
+	}
+	defer content.Close()
+
+	target := c.String("target")
+	if target == "-" {
+		_, err = io.Copy(os.Stdout, content)
+		return err
+	}
+	if target == "" {
+		target = path.Base(artifact.Name)
+	}
+
+	f, err := os.Create(target)
+	if err != nil {
+		return err
+	}
+	if _, err := io.Copy(f, content); err != nil {
+		f.Close()
+		return err
+	}
+	return f.Close()
+}
+
+// findArtifact returns the artifact of the pipeline with the given id or name.
+func findArtifact(client woodpecker.Client, repoID, number int64, idOrName string) (*woodpecker.Artifact, error) {
+	artifacts, err := client.PipelineArtifacts(repoID, number)
+	if err != nil {
+		return nil, err
+	}
+
+	id, _ := strconv.ParseInt(idOrName, 10, 64)
+	for _, artifact := range artifacts {
+		if artifact.ID == id || artifact.Name == idOrName {
+			return artifact, nil
+		}
+	}
+	return nil, fmt.Errorf("artifact '%s' not found", idOrName)
+}
@@ -0,0 +1,46 @@
+package artifact
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"go.woodpecker-ci.org/woodpecker/v3/woodpecker-go/woodpecker"
+	"go.woodpecker-ci.org/woodpecker/v3/woodpecker-go/woodpecker/mocks"
+)
+
+func TestFindArtifact(t *testing.T) {
+	artifacts := []*woodpecker.Artifact{
+		{ID: 3, Name: "dist/app"},
+		{ID: 4, Name: "coverage.out"},
+	}
+
+	tests := []struct {
+		name     string
+		idOrName string
+		listErr  error
+		wantID   int64
+		wantErr  string
+	}{
+		{name: "by id", idOrName: "4", wantID: 4},
+		{name: "by name", idOrName: "dist/app", wantID: 3},
+		{name: "not found", idOrName: "missing", wantErr: "artifact 'missing' not found"},
+		{name: "list error", idOrName: "3", listErr: errors.New("server error"), wantErr: "server error"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			client := mocks.NewClient(t)
+			client.On("PipelineArtifacts", int64(1), int64(2)).Return(artifacts, tt.listErr)
+
+			artifact, err := findArtifact(client, 1, 2, tt.idOrName)
+			if tt.wantErr != "" {
+				assert.EqualError(t, err, tt.wantErr)
+				return
+			}
+			assert.NoError(t, err)
+			assert.Equal(t, tt.wantID, artifact.ID)
+		})
+	}
+}
@@ -0,0 +1,65 @@
+// Copyright 2025 Woodpecker Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package artifact
+
+import (
+	"context"
+	"os"
+	"text/template"
+
+	"github.com/urfave/cli/v3"
+
+	"go.woodpecker-ci.org/woodpecker/v3/cli/common"
+)
+
+var artifactListCmd = &cli.Command{
+	Name:      "ls",
+	Usage:     "list pipeline artifacts",
+	ArgsUsage: "<repo-id|repo-full-name> <pipeline>",
+	Action:    artifactList,
+	Flags:     []cli.Flag{common.FormatFlag(tmplArtifactList)},
+}
+
+func artifactList(ctx context.Context, c *cli.Command) error {
+	client, repoID, number, err := parseArgs(ctx, c)
+	if err != nil {
+		return err
+	}
+
+	artifacts, err := client.PipelineArtifacts(repoID, number)
+	if err != nil {
+		return err
+	}
+
+	tmpl, err := template.New("_").Parse(c.String("format") + "\n")
+	if err != nil {
+		return err
+	}
+
+	for _, artifact := range artifacts {
+		if err := tmpl.Execute(os.Stdout, artifact); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// template for artifact list information.
+var tmplArtifactList = "\x1b[33m{{ .Name }}\x1b[0m" + `
+ID: {{ .ID }}
+Step: {{ .StepID }}
+Size: {{ .Size }}
+`
@@ -16,6 +16,7 @@ package pipeline
 
 import (
 	"context"
+	"fmt"
 	"strings"
 
 	"github.com/urfave/cli/v3"
@@ -40,6 +41,10 @@ var pipelineCreateCmd = &cli.Command{
 			Name:  "var",
 			Usage: "key=value",
 		},
+		&cli.StringSliceFlag{
+			Name:  "input",
+			Usage: "name=value of an input declared by the workflows",
+		},
 	}...),
 }
 
@@ -64,9 +69,19 @@ func pipelineCreate(ctx context.Context, c *cli.Command) error {
 		}
 	}
 
+	inputs := make(map[string]any)
+	for _, input := range c.StringSlice("input") {
+		name, value, ok := strings.Cut(input, "=")
+		if !ok || name == "" {
+			return fmt.Errorf("invalid input '%s', expected name=value", input)
+		}
+		inputs[name] = value
+	}
+
 	options := &woodpecker.PipelineOptions{
 		Branch:    branch,
 		Variables: variables,
+		Inputs:    inputs,
 	}
 
 	pipeline, err := client.PipelineCreate(repoID, options)
@@ -23,6 +23,7 @@ import (
 	"github.com/urfave/cli/v3"
 
 	"go.woodpecker-ci.org/woodpecker/v3/cli/output"
+	"go.woodpecker-ci.org/woodpecker/v3/cli/pipeline/artifact"
 	"go.woodpecker-ci.org/woodpecker/v3/cli/pipeline/deploy"
 	"go.woodpecker-ci.org/woodpecker/v3/cli/pipeline/log"
 	"go.woodpecker-ci.org/woodpecker/v3/woodpecker-go/woodpecker"
@@ -34,6 +35,7 @@ var Command = &cli.Command{
 	Usage: "manage pipelines",
 	Commands: []*cli.Command{
 		pipelineApproveCmd,
+		artifact.Command,
 		pipelineCreateCmd,
 		pipelineDeclineCmd,
 		deploy.Command,
@@ -0,0 +1,93 @@
+// Copyright 2025 Woodpecker Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package environment
+
+import (
+	"github.com/urfave/cli/v3"
+
+	"go.woodpecker-ci.org/woodpecker/v3/woodpecker-go/woodpecker"
+)
+
+// Command exports the environment command set.
+var Command = &cli.Command{
+	Name:  "environment",
+	Usage: "manage deployment environments",
+	Commands: []*cli.Command{
+		environmentCreateCmd,
+		environmentDeleteCmd,
+		environmentDeploymentsCmd,
+		environmentListCmd,
+		environmentShowCmd,
+		environmentUpdateCmd,
+	},
+}
+
+var environmentNameFlag = &cli.StringFlag{
+	Name:     "name",
+	Usage:    "environment name",
+	Required: true,
+}
+
+var environmentFlags = []cli.Flag{
+	&cli.StringFlag{
+		Name:  "url",
+		Usage: "url of the deployed environment",
+	},
+	&cli.StringSliceFlag{
+		Name:  "approver",
+		Usage: "login of a user allowed to approve deployments, deployments require an approval if set",
+	},
+	&cli.StringSliceFlag{
+		Name:  "branch",
+		Usage: "pattern of a branch deployments are allowed from",
+	},
+}
+
+// applyFlags sets the fields of the patch which are set by flags.
+func applyFlags(c *cli.Command, patch *woodpecker.EnvironmentPatch) {
+	if c.IsSet("url") {
+		envURL := c.String("url")
+		patch.URL = &envURL
+	}
+	if c.IsSet("approver") {
+		approvers := c.StringSlice("approver")
+		patch.RequiredApprovers = &approvers
+	}
+	if c.IsSet("branch") {
+		branches := c.StringSlice("branch")
+		patch.AllowedBranches = &branches
+	}
+}
+
+// Template for environment information.
+var tmplEnvironmentList = "\x1b[33m{{ .Name }} \x1b[0m" + `
+{{- if .URL }}
+URL: {{ .URL }}
+{{- end }}
+{{- if .RequiredApprovers }}
+Approvers: {{ range $i, $e := .RequiredApprovers }}{{ if $i }}, {{ end }}{{ $e }}{{ end }}
+{{- end }}
+{{- if .AllowedBranches }}
+Branches: {{ range $i, $e := .AllowedBranches }}{{ if $i }}, {{ end }}{{ $e }}{{ end }}
+{{- end }}
+`
+
+// Template for deployment information.
+var tmplDeploymentList = "\x1b[33mPipeline #{{ .Number }} \x1b[0m" + `
+Status: {{ .Status }}
+Branch: {{ .Branch }}
+Commit: {{ .Commit }}
+Author: {{ .Author }}
+`
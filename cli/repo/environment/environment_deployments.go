@@ -0,0 +1,72 @@
+// Copyright 2025 Woodpecker Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package environment
+
+import (
+	"context"
+	"html/template"
+	"os"
+
+	"github.com/urfave/cli/v3"
+
+	"go.woodpecker-ci.org/woodpecker/v3/cli/common"
+	"go.woodpecker-ci.org/woodpecker/v3/cli/internal"
+	"go.woodpecker-ci.org/woodpecker/v3/woodpecker-go/woodpecker"
+)
+
+var environmentDeploymentsCmd = &cli.Command{
+	Name:      "deployments",
+	Usage:     "list the deployments to an environment",
+	ArgsUsage: "[repo-id|repo-full-name]",
+	Action:    environmentDeployments,
+	Flags: []cli.Flag{
+		common.RepoFlag,
+		environmentNameFlag,
+		common.FormatFlag(tmplDeploymentList, true),
+	},
+}
+
+func environmentDeployments(ctx context.Context, c *cli.Command) error {
+	var (
+		name             = c.String("name")
+		repoIDOrFullName = c.String("repository")
+		format           = c.String("format") + "\n"
+	)
+	if repoIDOrFullName == "" {
+		repoIDOrFullName = c.Args().First()
+	}
+	client, err := internal.NewClient(ctx, c)
+	if err != nil {
+		return err
+	}
+	repoID, err := internal.ParseRepo(client, repoIDOrFullName)
+	if err != nil {
+		return err
+	}
+	list, err := client.EnvironmentDeployments(repoID, name, woodpecker.EnvironmentListOptions{})
+	if err != nil {
+		return err
+	}
+	tmpl, err := template.New("_").Parse(format)
+	if err != nil {
+		return err
+	}
+	for _, pipeline := range list {
+		if err := tmpl.Execute(os.Stdout, pipeline); err != nil {
+			return err
+		}
+	}
+	return nil
+}
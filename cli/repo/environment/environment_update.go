@@ -0,0 +1,85 @@
+// Copyright 2025 Woodpecker Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package environment
+
+import (
+	"context"
+	"html/template"
+	"os"
+
+	"github.com/urfave/cli/v3"
+
+	"go.woodpecker-ci.org/woodpecker/v3/cli/common"
+	"go.woodpecker-ci.org/woodpecker/v3/cli/internal"
+	"go.woodpecker-ci.org/woodpecker/v3/woodpecker-go/woodpecker"
+)
+
+var environmentUpdateCmd = &cli.Command{
+	Name:      "update",
+	Usage:     "update an environment",
+	ArgsUsage: "[repo-id|repo-full-name]",
+	Action:    environmentUpdate,
+	Flags: append([]cli.Flag{
+		common.RepoFlag,
+		environmentNameFlag,
+		common.FormatFlag(tmplEnvironmentList, true),
+		&cli.BoolFlag{
+			Name:  "clear-approvers",
+			Usage: "remove all approvers, deployments don't require an approval anymore",
+		},
+		&cli.BoolFlag{
+			Name:  "clear-branches",
+			Usage: "remove all branch patterns, deployments are allowed from all branches",
+		},
+	}, environmentFlags...),
+}
+
+func environmentUpdate(ctx context.Context, c *cli.Command) error {
+	var (
+		name             = c.String("name")
+		repoIDOrFullName = c.String("repository")
+		format           = c.String("format") + "\n"
+	)
+	if repoIDOrFullName == "" {
+		repoIDOrFullName = c.Args().First()
+	}
+	client, err := internal.NewClient(ctx, c)
+	if err != nil {
+		return err
+	}
+	repoID, err := internal.ParseRepo(client, repoIDOrFullName)
+	if err != nil {
+		return err
+	}
+
+	patch := new(woodpecker.EnvironmentPatch)
+	if c.Bool("clear-approvers") {
+		patch.RequiredApprovers = &[]string{}
+	}
+	if c.Bool("clear-branches") {
+		patch.AllowedBranches = &[]string{}
+	}
+	applyFlags(c, patch)
+
+	env, err := client.EnvironmentUpdate(repoID, name, patch)
+	if err != nil {
+		return err
+	}
+	tmpl, err := template.New("_").Parse(format)
+	if err != nil {
+		return err
+	}
+	return tmpl.Execute(os.Stdout, env)
+}
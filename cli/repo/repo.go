@@ -18,8 +18,10 @@ import (
 	"github.com/urfave/cli/v3"
 
 	"go.woodpecker-ci.org/woodpecker/v3/cli/repo/cron"
+	"go.woodpecker-ci.org/woodpecker/v3/cli/repo/environment"
 	"go.woodpecker-ci.org/woodpecker/v3/cli/repo/registry"
 	"go.woodpecker-ci.org/woodpecker/v3/cli/repo/secret"
+	"go.woodpecker-ci.org/woodpecker/v3/cli/repo/webhook"
 )
 
 // Command exports the repository command.
@@ -30,13 +32,16 @@ var Command = &cli.Command{
 		repoAddCmd,
 		repoChownCmd,
 		cron.Command,
+		environment.Command,
 		repoListCmd,
 		registry.Command,
 		repoRemoveCmd,
 		repoRepairCmd,
 		secret.Command,
 		repoShowCmd,
+		repoStatsCmd,
 		repoSyncCmd,
 		repoUpdateCmd,
+		webhook.Command,
 	},
 }
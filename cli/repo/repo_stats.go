@@ -0,0 +1,130 @@
+// Copyright 2025 Woodpecker Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package repo
+
+import (
+	"context"
+	"os"
+	"strconv"
+	"text/template"
+	"time"
+
+	"github.com/urfave/cli/v3"
+
+	"go.woodpecker-ci.org/woodpecker/v3/cli/common"
+	"go.woodpecker-ci.org/woodpecker/v3/cli/internal"
+	"go.woodpecker-ci.org/woodpecker/v3/woodpecker-go/woodpecker"
+)
+
+var repoStatsCmd = &cli.Command{
+	Name:      "stats",
+	Usage:     "show statistics of the finished pipelines of a repository",
+	ArgsUsage: "<repo-id|repo-full-name>",
+	Action:    repoStats,
+	Flags: []cli.Flag{
+		common.FormatFlag(tmplRepoStats),
+		&cli.StringFlag{
+			Name:  "branch",
+			Usage: "only include pipelines of this branch",
+		},
+		&cli.StringFlag{
+			Name:  "interval",
+			Usage: "size of the history buckets [hour, day, week]",
+		},
+		&cli.TimestampFlag{
+			Name:  "before",
+			Usage: "only include pipelines created before this date (RFC3339)",
+			Config: cli.TimestampConfig{
+				Layouts: []string{
+					time.RFC3339,
+				},
+			},
+		},
+		&cli.TimestampFlag{
+			Name:  "after",
+			Usage: "only include pipelines created after this date (RFC3339), defaults to 30 days before the end of the range",
+			Config: cli.TimestampConfig{
+				Layouts: []string{
+					time.RFC3339,
+				},
+			},
+		},
+	},
+}
+
+var repoStatsFuncMap = template.FuncMap{
+	"time": func(unix int64) string {
+		return time.Unix(unix, 0).UTC().Format(time.RFC3339)
+	},
+	"duration": func(seconds int64) string {
+		return (time.Duration(seconds) * time.Second).String()
+	},
+	"percent": func(rate float64) string {
+		return strconv.FormatFloat(rate*100, 'f', 1, 64) + "%" //nolint:mnd
+	},
+}
+
+func repoStats(ctx context.Context, c *cli.Command) error {
+	repoIDOrFullName := c.Args().First()
+	client, err := internal.NewClient(ctx, c)
+	if err != nil {
+		return err
+	}
+	repoID, err := internal.ParseRepo(client, repoIDOrFullName)
+	if err != nil {
+		return err
+	}
+
+	stats, err := client.RepoStats(repoID, woodpecker.RepoStatsOptions{
+		Before:   c.Timestamp("before"),
+		After:    c.Timestamp("after"),
+		Branch:   c.String("branch"),
+		Interval: c.String("interval"),
+	})
+	if err != nil {
+		return err
+	}
+
+	tmpl, err := template.New("_").Funcs(repoStatsFuncMap).Parse(c.String("format"))
+	if err != nil {
+		return err
+	}
+	return tmpl.Execute(os.Stdout, stats)
+}
+
+// Template for repo statistics.
+var tmplRepoStats = `Range: {{ time .Since }} - {{ time .Until }}
+{{- with .Branch }}
+Branch: {{ . }}
+{{- end }}
+Pipelines: {{ .Pipelines }} ({{ .Successful }} successful, {{ .Failed }} failed, {{ .Killed }} killed)
+Success rate: {{ percent .SuccessRate }}
+Duration: p50 {{ duration .Duration.P50 }}, p95 {{ duration .Duration.P95 }}
+Queue wait: p50 {{ duration .QueueWait.P50 }}, p95 {{ duration .QueueWait.P95 }}
+{{- if .History }}
+
+History:
+{{- range .History }}{{ if .Pipelines }}
+  {{ time .Start }}: {{ .Pipelines }} pipelines, {{ percent .SuccessRate }} successful, p50 {{ duration .Duration.P50 }}, p95 {{ duration .Duration.P95 }}
+{{- end }}{{ end }}
+{{- end }}
+{{- if .Steps }}
+
+Most failing steps:
+{{- range .Steps }}{{ if .Failures }}
+  {{ .Workflow }}/{{ .Name }}: {{ .Failures }} of {{ .Total }} failed ({{ percent .FailureRate }})
+{{- end }}{{ end }}
+{{- end }}
+`
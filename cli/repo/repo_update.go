@@ -17,6 +17,7 @@ package repo
 import (
 	"context"
 	"fmt"
+	"slices"
 	"time"
 
 	"github.com/urfave/cli/v3"
@@ -43,6 +44,18 @@ var repoUpdateCmd = &cli.Command{
 			Name:  "require-approval",
 			Usage: "repository requires approval for",
 		},
+		&cli.IntFlag{
+			Name:  "required-approvals",
+			Usage: "number of distinct users who have to approve a pipeline",
+		},
+		&cli.StringSliceFlag{
+			Name:  "approval-team",
+			Usage: "team or organization whose members can approve pipelines, an empty value allows everyone",
+		},
+		&cli.BoolFlag{
+			Name:  "prevent-self-approval",
+			Usage: "forbid the author of a pipeline to approve it",
+		},
 		&cli.DurationFlag{
 			Name:  "timeout",
 			Usage: "repository timeout",
@@ -59,6 +72,10 @@ var repoUpdateCmd = &cli.Command{
 			Name:  "pipeline-counter",
 			Usage: "repository starting pipeline number",
 		},
+		&cli.StringFlag{
+			Name:  "config-extension-endpoint",
+			Usage: "url of a config extension for the repository, an empty value removes it",
+		},
 		&cli.BoolFlag{
 			Name:  "unsafe",
 			Usage: "allow unsafe operations",
@@ -84,6 +101,7 @@ func repoUpdate(ctx context.Context, c *cli.Command) error {
 		trusted         = c.Bool("trusted")
 		requireApproval = c.String("require-approval")
 		pipelineCounter = int(c.Int("pipeline-counter"))
+		configExtension = c.String("config-extension-endpoint")
 		unsafe          = c.Bool("unsafe")
 	)
 
@@ -104,6 +122,23 @@ func repoUpdate(ctx context.Context, c *cli.Command) error {
 			return fmt.Errorf("update approval mode failed: '%s' is no valid mode", mode)
 		}
 	}
+	if c.IsSet("required-approvals") || c.IsSet("approval-team") || c.IsSet("prevent-self-approval") {
+		repo, err := client.Repo(repoID)
+		if err != nil {
+			return err
+		}
+		policy := repo.ApprovalPolicy
+		if c.IsSet("required-approvals") {
+			policy.RequiredApprovals = int(c.Int("required-approvals"))
+		}
+		if c.IsSet("approval-team") {
+			policy.Teams = slices.DeleteFunc(c.StringSlice("approval-team"), func(team string) bool { return team == "" })
+		}
+		if c.IsSet("prevent-self-approval") {
+			policy.PreventSelfApproval = c.Bool("prevent-self-approval")
+		}
+		patch.ApprovalPolicy = &policy
+	}
 	if c.IsSet("timeout") {
 		v := int64(timeout / time.Minute)
 		patch.Timeout = &v
@@ -111,6 +146,9 @@ func repoUpdate(ctx context.Context, c *cli.Command) error {
 	if c.IsSet("config") {
 		patch.Config = &config
 	}
+	if c.IsSet("config-extension-endpoint") {
+		patch.ConfigExtensionEndpoint = &configExtension
+	}
 	if c.IsSet("visibility") {
 		switch visibility {
 		case "public", "private", "internal":
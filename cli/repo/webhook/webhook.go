@@ -0,0 +1,68 @@
+// Copyright 2025 Woodpecker Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package webhook
+
+import (
+	"github.com/urfave/cli/v3"
+
+	"go.woodpecker-ci.org/woodpecker/v3/woodpecker-go/woodpecker"
+)
+
+// Command exports the webhook command set.
+var Command = &cli.Command{
+	Name:  "webhook",
+	Usage: "manage outgoing webhooks",
+	Commands: []*cli.Command{
+		webhookCreateCmd,
+		webhookDeleteCmd,
+		webhookDeliveriesCmd,
+		webhookListCmd,
+		webhookRedeliverCmd,
+		webhookShowCmd,
+		webhookUpdateCmd,
+	},
+}
+
+var webhookIDFlag = &cli.IntFlag{
+	Name:     "id",
+	Usage:    "webhook id",
+	Required: true,
+}
+
+func parseEvents(events []string) []woodpecker.HookEvent {
+	hookEvents := make([]woodpecker.HookEvent, 0, len(events))
+	for _, event := range events {
+		hookEvents = append(hookEvents, woodpecker.HookEvent(event))
+	}
+	return hookEvents
+}
+
+// Template for webhook information.
+var tmplWebhookList = "\x1b[33m{{ .URL }} \x1b[0m" + `
+ID: {{ .ID }}
+Events: {{ range $i, $e := .Events }}{{ if $i }}, {{ end }}{{ $e }}{{ end }}
+Active: {{ .Active }}
+`
+
+// Template for webhook delivery information.
+var tmplWebhookDeliveryList = "\x1b[33m{{ .ID }} \x1b[0m" + `
+Event: {{ .Event }}
+Success: {{ .Success }}
+Attempts: {{ .Attempts }}
+StatusCode: {{ .StatusCode }}
+{{- if .Error }}
+Error: {{ .Error }}
+{{- end }}
+`
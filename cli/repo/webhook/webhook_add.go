@@ -0,0 +1,88 @@
+// Copyright 2025 Woodpecker Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package webhook
+
+import (
+	"context"
+	"html/template"
+	"os"
+
+	"github.com/urfave/cli/v3"
+
+	"go.woodpecker-ci.org/woodpecker/v3/cli/common"
+	"go.woodpecker-ci.org/woodpecker/v3/cli/internal"
+	"go.woodpecker-ci.org/woodpecker/v3/woodpecker-go/woodpecker"
+)
+
+var webhookCreateCmd = &cli.Command{
+	Name:      "add",
+	Usage:     "add a webhook",
+	ArgsUsage: "[repo-id|repo-full-name]",
+	Action:    webhookCreate,
+	Flags: []cli.Flag{
+		common.RepoFlag,
+		&cli.StringFlag{
+			Name:     "url",
+			Usage:    "webhook url",
+			Required: true,
+		},
+		&cli.StringSliceFlag{
+			Name:     "event",
+			Usage:    "subscribed events [pipeline_created, pipeline_started, pipeline_finished, workflow_status, step_status]",
+			Required: true,
+		},
+		&cli.BoolFlag{
+			Name:  "inactive",
+			Usage: "create the webhook without sending payloads",
+		},
+		common.FormatFlag(tmplWebhookList, true),
+	},
+}
+
+func webhookCreate(ctx context.Context, c *cli.Command) error {
+	var (
+		hookURL          = c.String("url")
+		active           = !c.Bool("inactive")
+		repoIDOrFullName = c.String("repository")
+		format           = c.String("format") + "\n"
+	)
+	if repoIDOrFullName == "" {
+		repoIDOrFullName = c.Args().First()
+	}
+
+	client, err := internal.NewClient(ctx, c)
+	if err != nil {
+		return err
+	}
+
+	repoID, err := internal.ParseRepo(client, repoIDOrFullName)
+	if err != nil {
+		return err
+	}
+
+	hook, err := client.WebhookCreate(repoID, &woodpecker.WebhookPatch{
+		URL:    &hookURL,
+		Events: parseEvents(c.StringSlice("event")),
+		Active: &active,
+	})
+	if err != nil {
+		return err
+	}
+	tmpl, err := template.New("_").Parse(format)
+	if err != nil {
+		return err
+	}
+	return tmpl.Execute(os.Stdout, hook)
+}
@@ -0,0 +1,70 @@
+// Copyright 2025 Woodpecker Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package webhook
+
+import (
+	"context"
+	"html/template"
+	"os"
+
+	"github.com/urfave/cli/v3"
+
+	"go.woodpecker-ci.org/woodpecker/v3/cli/common"
+	"go.woodpecker-ci.org/woodpecker/v3/cli/internal"
+	"go.woodpecker-ci.org/woodpecker/v3/woodpecker-go/woodpecker"
+)
+
+var webhookListCmd = &cli.Command{
+	Name:      "ls",
+	Usage:     "list webhooks",
+	ArgsUsage: "[repo-id|repo-full-name]",
+	Action:    webhookList,
+	Flags: []cli.Flag{
+		common.RepoFlag,
+		common.FormatFlag(tmplWebhookList, true),
+	},
+}
+
+func webhookList(ctx context.Context, c *cli.Command) error {
+	var (
+		format           = c.String("format") + "\n"
+		repoIDOrFullName = c.String("repository")
+	)
+	if repoIDOrFullName == "" {
+		repoIDOrFullName = c.Args().First()
+	}
+	client, err := internal.NewClient(ctx, c)
+	if err != nil {
+		return err
+	}
+	repoID, err := internal.ParseRepo(client, repoIDOrFullName)
+	if err != nil {
+		return err
+	}
+	list, err := client.WebhookList(repoID, woodpecker.WebhookListOptions{})
+	if err != nil {
+		return err
+	}
+	tmpl, err := template.New("_").Parse(format)
+	if err != nil {
+		return err
+	}
+	for _, hook := range list {
+		if err := tmpl.Execute(os.Stdout, hook); err != nil {
+			return err
+		}
+	}
+	return nil
+}
@@ -0,0 +1,60 @@
+// Copyright 2025 Woodpecker Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package webhook
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/urfave/cli/v3"
+
+	"go.woodpecker-ci.org/woodpecker/v3/cli/common"
+	"go.woodpecker-ci.org/woodpecker/v3/cli/internal"
+)
+
+var webhookDeleteCmd = &cli.Command{
+	Name:      "rm",
+	Usage:     "remove a webhook",
+	ArgsUsage: "[repo-id|repo-full-name]",
+	Action:    webhookDelete,
+	Flags: []cli.Flag{
+		common.RepoFlag,
+		webhookIDFlag,
+	},
+}
+
+func webhookDelete(ctx context.Context, c *cli.Command) error {
+	var (
+		webhookID        = c.Int("id")
+		repoIDOrFullName = c.String("repository")
+	)
+	if repoIDOrFullName == "" {
+		repoIDOrFullName = c.Args().First()
+	}
+	client, err := internal.NewClient(ctx, c)
+	if err != nil {
+		return err
+	}
+	repoID, err := internal.ParseRepo(client, repoIDOrFullName)
+	if err != nil {
+		return err
+	}
+	if err := client.WebhookDelete(repoID, webhookID); err != nil {
+		return err
+	}
+
+	fmt.Println("Success")
+	return nil
+}
@@ -0,0 +1,41 @@
+// Copyright 2025 Woodpecker Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package token
+
+import (
+	"github.com/urfave/cli/v3"
+)
+
+// Command exports the token command set.
+var Command = &cli.Command{
+	Name:  "token",
+	Usage: "manage personal access tokens",
+	Commands: []*cli.Command{
+		tokenCreateCmd,
+		tokenDeleteCmd,
+		tokenListCmd,
+	},
+}
+
+// Template for token information.
+var tmplTokenList = "\x1b[33m{{ .Name }} \x1b[0m" + `
+ID: {{ .ID }}
+Scopes: {{ range $i, $s := .Scopes }}{{ if $i }}, {{ end }}{{ $s }}{{ end }}
+{{- if .RepoID }}
+RepoID: {{ .RepoID }}
+{{- end }}
+Expires: {{ if .Expires }}{{ .Expires | time }}{{ else }}never{{ end }}
+LastUsed: {{ if .LastUsed }}{{ .LastUsed | time }}{{ else }}never{{ end }}
+`
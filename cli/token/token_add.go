@@ -0,0 +1,80 @@
+// Copyright 2025 Woodpecker Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package token
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/urfave/cli/v3"
+
+	"go.woodpecker-ci.org/woodpecker/v3/cli/internal"
+	"go.woodpecker-ci.org/woodpecker/v3/woodpecker-go/woodpecker"
+)
+
+var tokenCreateCmd = &cli.Command{
+	Name:   "add",
+	Usage:  "create a personal access token",
+	Action: tokenCreate,
+	Flags: []cli.Flag{
+		&cli.StringFlag{
+			Name:     "name",
+			Usage:    "token name",
+			Required: true,
+		},
+		&cli.StringSliceFlag{
+			Name:  "scope",
+			Usage: "token scope (all, read, pipeline:write, secrets:admin)",
+			Value: []string{"read"},
+		},
+		&cli.StringFlag{
+			Name:  "repository",
+			Usage: "restrict the token to a repository (id or full name)",
+		},
+		&cli.DurationFlag{
+			Name:  "expires",
+			Usage: "time until the token expires, e.g. 720h (never expires if unset)",
+		},
+	},
+}
+
+func tokenCreate(ctx context.Context, c *cli.Command) error {
+	client, err := internal.NewClient(ctx, c)
+	if err != nil {
+		return err
+	}
+
+	opt := &woodpecker.PersonalAccessTokenOptions{
+		Name:   c.String("name"),
+		Scopes: c.StringSlice("scope"),
+	}
+	if repo := c.String("repository"); repo != "" {
+		if opt.RepoID, err = internal.ParseRepo(client, repo); err != nil {
+			return err
+		}
+	}
+	if expires := c.Duration("expires"); expires > 0 {
+		opt.Expires = time.Now().Add(expires).Unix()
+	}
+
+	token, err := client.PersonalAccessTokenCreate(opt)
+	if err != nil {
+		return err
+	}
+
+	fmt.Println(token.Token)
+	return nil
+}
@@ -0,0 +1,65 @@
+// Copyright 2025 Woodpecker Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package token
+
+import (
+	"context"
+	"os"
+	"text/template"
+	"time"
+
+	"github.com/urfave/cli/v3"
+
+	"go.woodpecker-ci.org/woodpecker/v3/cli/common"
+	"go.woodpecker-ci.org/woodpecker/v3/cli/internal"
+)
+
+var tokenListCmd = &cli.Command{
+	Name:   "ls",
+	Usage:  "list personal access tokens",
+	Action: tokenList,
+	Flags: []cli.Flag{
+		common.FormatFlag(tmplTokenList, true),
+	},
+}
+
+var tokenFuncMap = template.FuncMap{
+	"time": func(unix int64) string {
+		return time.Unix(unix, 0).UTC().Format(time.RFC3339)
+	},
+}
+
+func tokenList(ctx context.Context, c *cli.Command) error {
+	format := c.String("format") + "\n"
+
+	client, err := internal.NewClient(ctx, c)
+	if err != nil {
+		return err
+	}
+	list, err := client.PersonalAccessTokenList()
+	if err != nil {
+		return err
+	}
+	tmpl, err := template.New("_").Funcs(tokenFuncMap).Parse(format)
+	if err != nil {
+		return err
+	}
+	for _, token := range list {
+		if err := tmpl.Execute(os.Stdout, token); err != nil {
+			return err
+		}
+	}
+	return nil
+}
@@ -44,6 +44,7 @@ import (
 	"go.woodpecker-ci.org/woodpecker/v3/pipeline/backend/types"
 	"go.woodpecker-ci.org/woodpecker/v3/pipeline/rpc"
 	"go.woodpecker-ci.org/woodpecker/v3/shared/logger"
+	"go.woodpecker-ci.org/woodpecker/v3/shared/tracing"
 	"go.woodpecker-ci.org/woodpecker/v3/shared/utils"
 	"go.woodpecker-ci.org/woodpecker/v3/version"
 )
@@ -150,6 +151,7 @@ func run(ctx context.Context, c *cli.Command, backends []types.Backend) error {
 			Timeout: c.Duration("grpc-keepalive-timeout"),
 		}),
 		grpc.WithUnaryInterceptor(authInterceptor.Unary()),
+		grpc.WithChainUnaryInterceptor(agent_rpc.MetricsUnaryInterceptor(), tracing.UnaryClientInterceptor()),
 		grpc.WithStreamInterceptor(authInterceptor.Stream()),
 	)
 	if err != nil {
@@ -198,7 +200,14 @@ func run(ctx context.Context, c *cli.Command, backends []types.Backend) error {
 	}
 	log.Debug().Msgf("loaded %s backend engine", backendEngine.Name())
 
+	cacheStore, err := setupCacheStore(c)
+	if err != nil {
+		log.Error().Err(err).Msg("cannot setup cache store")
+		return err
+	}
+
 	maxWorkflows := int(c.Int("max-workflows"))
+	agent.SetMaxWorkflows(backendEngine.Name(), maxWorkflows)
 
 	customLabels := make(map[string]string)
 	if err := stringSliceAddToMap(c.StringSlice("labels"), customLabels); err != nil {
@@ -281,7 +290,7 @@ func run(ctx context.Context, c *cli.Command, backends []types.Backend) error {
 	for i := 0; i < maxWorkflows; i++ {
 		i := i
 		serviceWaitingGroup.Go(func() error {
-			runner := agent.NewRunner(client, filter, hostname, counter, &backendEngine)
+			runner := agent.NewRunner(client, filter, hostname, counter, &backendEngine, cacheStore)
 			log.Debug().Msgf("created new runner %d", i)
 
 			for {
@@ -311,11 +320,22 @@ func runWithRetry(backendEngines []types.Backend) func(ctx context.Context, c *c
 			return err
 		}
 
+		shutdownTracing, err := tracing.Setup(ctx, c, "woodpecker-agent")
+		if err != nil {
+			return fmt.Errorf("can't setup tracing: %w", err)
+		}
+		defer func() {
+			flushCtx, cancel := context.WithTimeout(context.Background(), shutdownTimeout) //nolint:contextcheck
+			defer cancel()
+			if err := shutdownTracing(flushCtx); err != nil {
+				log.Error().Err(err).Msg("could not flush traces")
+			}
+		}()
+
 		initHealth()
 
 		retryCount := int(c.Int("connect-retry-count"))
 		retryDelay := c.Duration("connect-retry-delay")
-		var err error
 		for i := 0; i < retryCount; i++ {
 			if err = run(ctx, c, backendEngines); status.Code(err) == codes.Unavailable {
 				log.Warn().Err(err).Msg(fmt.Sprintf("cannot connect to server, retrying in %v", retryDelay))
@@ -0,0 +1,44 @@
+// Copyright 2025 Woodpecker Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package core
+
+import (
+	"fmt"
+
+	"github.com/urfave/cli/v3"
+
+	"go.woodpecker-ci.org/woodpecker/v3/pipeline/cache"
+	"go.woodpecker-ci.org/woodpecker/v3/shared/s3"
+)
+
+func setupCacheStore(c *cli.Command) (cache.Store, error) {
+	switch c.String("cache-store") {
+	case "", "none":
+		return nil, nil
+	case "file":
+		return cache.NewLocal(c.String("cache-store-file-path"))
+	case "s3":
+		return cache.NewS3(s3.Config{
+			Endpoint:  c.String("cache-store-s3-endpoint"),
+			Region:    c.String("cache-store-s3-region"),
+			Bucket:    c.String("cache-store-s3-bucket"),
+			AccessKey: c.String("cache-store-s3-access-key"),
+			SecretKey: c.String("cache-store-s3-secret-key"),
+			PathStyle: c.Bool("cache-store-s3-path-style"),
+		}, c.String("cache-store-s3-prefix"))
+	default:
+		return nil, fmt.Errorf("unsupported cache store: %s", c.String("cache-store"))
+	}
+}
@@ -115,4 +115,56 @@ var flags = []cli.Flag{
 		Usage:   "duration to wait before retrying to connect to the server",
 		Value:   time.Second * 2,
 	},
+	&cli.StringFlag{
+		Sources: cli.EnvVars("WOODPECKER_CACHE_STORE"),
+		Name:    "cache-store",
+		Usage:   "store used for workflow caches ('none', 'file' or 's3')",
+		Value:   "none",
+	},
+	&cli.StringFlag{
+		Sources: cli.EnvVars("WOODPECKER_CACHE_STORE_FILE_PATH"),
+		Name:    "cache-store-file-path",
+		Usage:   "directory used for file based cache storage",
+		Value:   "/var/lib/woodpecker/cache",
+	},
+	&cli.StringFlag{
+		Sources: cli.EnvVars("WOODPECKER_CACHE_STORE_S3_ENDPOINT"),
+		Name:    "cache-store-s3-endpoint",
+		Usage:   "endpoint of the S3 compatible object storage used for cache storage",
+	},
+	&cli.StringFlag{
+		Sources: cli.EnvVars("WOODPECKER_CACHE_STORE_S3_REGION"),
+		Name:    "cache-store-s3-region",
+		Usage:   "region of the S3 compatible object storage used for cache storage",
+		Value:   "us-east-1",
+	},
+	&cli.StringFlag{
+		Sources: cli.EnvVars("WOODPECKER_CACHE_STORE_S3_BUCKET"),
+		Name:    "cache-store-s3-bucket",
+		Usage:   "bucket used for S3 based cache storage",
+	},
+	&cli.StringFlag{
+		Sources: cli.EnvVars("WOODPECKER_CACHE_STORE_S3_PREFIX"),
+		Name:    "cache-store-s3-prefix",
+		Usage:   "prefix of the objects used for S3 based cache storage",
+	},
+	&cli.StringFlag{
+		Sources: cli.NewValueSourceChain(
+			cli.File(os.Getenv("WOODPECKER_CACHE_STORE_S3_ACCESS_KEY_FILE")),
+			cli.EnvVar("WOODPECKER_CACHE_STORE_S3_ACCESS_KEY")),
+		Name:  "cache-store-s3-access-key",
+		Usage: "access key for S3 based cache storage",
+	},
+	&cli.StringFlag{
+		Sources: cli.NewValueSourceChain(
+			cli.File(os.Getenv("WOODPECKER_CACHE_STORE_S3_SECRET_KEY_FILE")),
+			cli.EnvVar("WOODPECKER_CACHE_STORE_S3_SECRET_KEY")),
+		Name:  "cache-store-s3-secret-key",
+		Usage: "secret key for S3 based cache storage",
+	},
+	&cli.BoolFlag{
+		Sources: cli.EnvVars("WOODPECKER_CACHE_STORE_S3_PATH_STYLE"),
+		Name:    "cache-store-s3-path-style",
+		Usage:   "use path style bucket addressing for S3 based cache storage, required by most self hosted object storages like MinIO",
+	},
 }
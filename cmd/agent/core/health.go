@@ -21,6 +21,7 @@ import (
 	"net/http"
 	"strings"
 
+	prometheus_http "github.com/prometheus/client_golang/prometheus/promhttp"
 	"github.com/rs/zerolog/log"
 	"github.com/urfave/cli/v3"
 
@@ -36,6 +37,7 @@ func initHealth() {
 	http.HandleFunc("/varz", handleStats)
 	http.HandleFunc("/healthz", handleHeartbeat)
 	http.HandleFunc("/version", handleVersion)
+	http.Handle("/metrics", prometheus_http.Handler())
 }
 
 func handleHeartbeat(w http.ResponseWriter, _ *http.Request) {
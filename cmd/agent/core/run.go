@@ -25,6 +25,7 @@ import (
 
 	backend "go.woodpecker-ci.org/woodpecker/v3/pipeline/backend/types"
 	"go.woodpecker-ci.org/woodpecker/v3/shared/logger"
+	"go.woodpecker-ci.org/woodpecker/v3/shared/tracing"
 	"go.woodpecker-ci.org/woodpecker/v3/shared/utils"
 	"go.woodpecker-ci.org/woodpecker/v3/version"
 )
@@ -42,7 +43,7 @@ func RunAgent(ctx context.Context, backends []backend.Backend) {
 			Action: pinger,
 		},
 	}
-	agentFlags := utils.MergeSlices(flags, logger.GlobalLoggerFlags)
+	agentFlags := utils.MergeSlices(flags, logger.GlobalLoggerFlags, tracing.Flags)
 	for _, b := range backends {
 		agentFlags = utils.MergeSlices(agentFlags, b.Flags())
 	}
@@ -26,6 +26,7 @@ import (
 	"go.woodpecker-ci.org/woodpecker/v3/cli/pipeline"
 	"go.woodpecker-ci.org/woodpecker/v3/cli/repo"
 	"go.woodpecker-ci.org/woodpecker/v3/cli/setup"
+	"go.woodpecker-ci.org/woodpecker/v3/cli/token"
 	"go.woodpecker-ci.org/woodpecker/v3/cli/update"
 	"go.woodpecker-ci.org/woodpecker/v3/version"
 )
@@ -50,6 +51,7 @@ func newApp() *cli.Command {
 		pipeline.Command,
 		repo.Command,
 		setup.Command,
+		token.Command,
 		update.Command,
 	}
 
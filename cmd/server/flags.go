@@ -21,11 +21,14 @@ import (
 
 	"github.com/urfave/cli/v3"
 
+	"go.woodpecker-ci.org/woodpecker/v3/server/metrics"
 	"go.woodpecker-ci.org/woodpecker/v3/shared/constant"
 	"go.woodpecker-ci.org/woodpecker/v3/shared/logger"
+	"go.woodpecker-ci.org/woodpecker/v3/shared/tracing"
+	"go.woodpecker-ci.org/woodpecker/v3/shared/utils"
 )
 
-var flags = append([]cli.Flag{
+var flags = utils.MergeSlices([]cli.Flag{
 	&cli.BoolFlag{
 		Sources: cli.EnvVars("WOODPECKER_DATABASE_LOG", "WOODPECKER_LOG_XORM"),
 		Name:    "db-log",
@@ -166,6 +169,22 @@ var flags = append([]cli.Flag{
 		Usage:   "The maximum time in minutes you can set in the repo settings before a pipeline gets killed",
 		Value:   120,
 	},
+	&cli.IntFlag{
+		Sources: cli.EnvVars("WOODPECKER_RETENTION_KEEP_PIPELINES_PER_BRANCH"),
+		Name:    "retention-keep-pipelines-per-branch",
+		Usage:   "The default number of pipelines kept per branch, older pipelines get deleted (0 keeps all pipelines)",
+	},
+	&cli.IntFlag{
+		Sources: cli.EnvVars("WOODPECKER_RETENTION_LOG_MAX_AGE_DAYS"),
+		Name:    "retention-log-max-age-days",
+		Usage:   "The default number of days the logs of a finished pipeline are kept (0 keeps logs forever)",
+	},
+	&cli.DurationFlag{
+		Sources: cli.EnvVars("WOODPECKER_RETENTION_INTERVAL"),
+		Name:    "retention-interval",
+		Usage:   "How often the retention policies are applied",
+		Value:   time.Hour,
+	},
 	&cli.StringSliceFlag{
 		Sources: cli.EnvVars("WOODPECKER_DEFAULT_WORKFLOW_LABELS"),
 		Name:    "default-workflow-labels",
@@ -229,6 +248,21 @@ var flags = append([]cli.Flag{
 		Name:    "config-service-endpoint",
 		Usage:   "url used for calling configuration service endpoint",
 	},
+	&cli.StringFlag{
+		Sources: cli.EnvVars("WOODPECKER_SECRET_SERVICE_ENDPOINT"),
+		Name:    "secret-service-endpoint",
+		Usage:   "url used for calling secret service endpoint",
+	},
+	&cli.StringFlag{
+		Sources: cli.EnvVars("WOODPECKER_REGISTRY_SERVICE_ENDPOINT"),
+		Name:    "registry-service-endpoint",
+		Usage:   "url used for calling registry service endpoint",
+	},
+	&cli.StringSliceFlag{
+		Sources: cli.EnvVars("WOODPECKER_EXTENSIONS_ALLOWED_HOSTS"),
+		Name:    "extensions-allowed-hosts",
+		Usage:   "hosts, IPs and CIDR ranges in loopback, link-local or private networks which webhooks and config extensions of repos and orgs may send requests to",
+	},
 	&cli.StringFlag{
 		Sources: cli.EnvVars("WOODPECKER_DATABASE_DRIVER"),
 		Name:    "db-driver",
@@ -258,6 +292,12 @@ var flags = append([]cli.Flag{
 			TrimSpace: true,
 		},
 	},
+	&cli.IntFlag{
+		Sources: cli.EnvVars("WOODPECKER_PROMETHEUS_MAX_REPO_LABELS"),
+		Name:    "prometheus-max-repo-labels",
+		Usage:   "max number of distinct repos and orgs used as metric labels, others are reported as \"other\"",
+		Value:   metrics.DefaultMaxRepoLabels,
+	},
 	&cli.StringFlag{
 		Sources: cli.EnvVars("WOODPECKER_STATUS_CONTEXT", "WOODPECKER_GITHUB_CONTEXT", "WOODPECKER_GITEA_CONTEXT"),
 		Name:    "status-context",
@@ -285,10 +325,22 @@ var flags = append([]cli.Flag{
 		Usage:   "Disable version check in admin web ui.",
 		Name:    "skip-version-check",
 	},
+	&cli.StringFlag{
+		Sources: cli.EnvVars("WOODPECKER_QUEUE_BACKEND"),
+		Name:    "queue-backend",
+		Usage:   "queue backend to use ('memory' or 'database'), use 'database' to share the queue between multiple server instances",
+		Value:   "memory",
+	},
+	&cli.StringFlag{
+		Sources: cli.EnvVars("WOODPECKER_PUBSUB_BACKEND"),
+		Name:    "pubsub-backend",
+		Usage:   "pubsub backend used to stream pipeline events and logs to the web ui ('memory' or 'database'), use 'database' if multiple server instances are running",
+		Value:   "memory",
+	},
 	&cli.StringFlag{
 		Sources: cli.EnvVars("WOODPECKER_LOG_STORE"),
 		Name:    "log-store",
-		Usage:   "log store to use ('database' or 'file')",
+		Usage:   "log store to use ('database', 'file' or 's3')",
 		Value:   "database",
 	},
 	&cli.StringFlag{
@@ -296,6 +348,114 @@ var flags = append([]cli.Flag{
 		Name:    "log-store-file-path",
 		Usage:   "directory used for file based log storage",
 	},
+	&cli.StringFlag{
+		Sources: cli.EnvVars("WOODPECKER_LOG_STORE_FILE_COMPRESSION"),
+		Name:    "log-store-file-compression",
+		Usage:   "compression of the logs of finished steps for file based log storage ('none', 'gzip' or 'zstd')",
+		Value:   "none",
+	},
+	&cli.IntFlag{
+		Sources: cli.EnvVars("WOODPECKER_LOG_STORE_FILE_MAX_STEP_SIZE"),
+		Name:    "log-store-file-max-step-size",
+		Usage:   "maximum size in bytes of the log of a single step for file based log storage, longer logs are truncated (0 means unlimited)",
+	},
+	&cli.StringFlag{
+		Sources: cli.EnvVars("WOODPECKER_LOG_STORE_S3_ENDPOINT"),
+		Name:    "log-store-s3-endpoint",
+		Usage:   "endpoint of the S3 compatible object storage used for log storage",
+	},
+	&cli.StringFlag{
+		Sources: cli.EnvVars("WOODPECKER_LOG_STORE_S3_REGION"),
+		Name:    "log-store-s3-region",
+		Usage:   "region of the S3 compatible object storage used for log storage",
+		Value:   "us-east-1",
+	},
+	&cli.StringFlag{
+		Sources: cli.EnvVars("WOODPECKER_LOG_STORE_S3_BUCKET"),
+		Name:    "log-store-s3-bucket",
+		Usage:   "bucket used for S3 based log storage",
+	},
+	&cli.StringFlag{
+		Sources: cli.EnvVars("WOODPECKER_LOG_STORE_S3_PREFIX"),
+		Name:    "log-store-s3-prefix",
+		Usage:   "prefix of the objects used for S3 based log storage",
+	},
+	&cli.StringFlag{
+		Sources: cli.NewValueSourceChain(
+			cli.File(os.Getenv("WOODPECKER_LOG_STORE_S3_ACCESS_KEY_FILE")),
+			cli.EnvVar("WOODPECKER_LOG_STORE_S3_ACCESS_KEY")),
+		Name:  "log-store-s3-access-key",
+		Usage: "access key for S3 based log storage",
+	},
+	&cli.StringFlag{
+		Sources: cli.NewValueSourceChain(
+			cli.File(os.Getenv("WOODPECKER_LOG_STORE_S3_SECRET_KEY_FILE")),
+			cli.EnvVar("WOODPECKER_LOG_STORE_S3_SECRET_KEY")),
+		Name:  "log-store-s3-secret-key",
+		Usage: "secret key for S3 based log storage",
+	},
+	&cli.BoolFlag{
+		Sources: cli.EnvVars("WOODPECKER_LOG_STORE_S3_PATH_STYLE"),
+		Name:    "log-store-s3-path-style",
+		Usage:   "use path style bucket addressing for S3 based log storage, required by most self hosted object storages like MinIO",
+	},
+	&cli.StringFlag{
+		Sources: cli.EnvVars("WOODPECKER_ARTIFACT_STORE"),
+		Name:    "artifact-store",
+		Usage:   "artifact store to use ('none', 'file' or 's3')",
+		Value:   "none",
+	},
+	&cli.IntFlag{
+		Sources: cli.EnvVars("WOODPECKER_ARTIFACT_STORE_MAX_SIZE"),
+		Name:    "artifact-store-max-size",
+		Usage:   "maximum size in bytes of a single artifact, larger artifacts are rejected (0 means unlimited)",
+		Value:   1 << 30,
+	},
+	&cli.StringFlag{
+		Sources: cli.EnvVars("WOODPECKER_ARTIFACT_STORE_FILE_PATH"),
+		Name:    "artifact-store-file-path",
+		Usage:   "directory used for file based artifact storage",
+	},
+	&cli.StringFlag{
+		Sources: cli.EnvVars("WOODPECKER_ARTIFACT_STORE_S3_ENDPOINT"),
+		Name:    "artifact-store-s3-endpoint",
+		Usage:   "endpoint of the S3 compatible object storage used for artifact storage",
+	},
+	&cli.StringFlag{
+		Sources: cli.EnvVars("WOODPECKER_ARTIFACT_STORE_S3_REGION"),
+		Name:    "artifact-store-s3-region",
+		Usage:   "region of the S3 compatible object storage used for artifact storage",
+		Value:   "us-east-1",
+	},
+	&cli.StringFlag{
+		Sources: cli.EnvVars("WOODPECKER_ARTIFACT_STORE_S3_BUCKET"),
+		Name:    "artifact-store-s3-bucket",
+		Usage:   "bucket used for S3 based artifact storage",
+	},
+	&cli.StringFlag{
+		Sources: cli.EnvVars("WOODPECKER_ARTIFACT_STORE_S3_PREFIX"),
+		Name:    "artifact-store-s3-prefix",
+		Usage:   "prefix of the objects used for S3 based artifact storage",
+	},
+	&cli.StringFlag{
+		Sources: cli.NewValueSourceChain(
+			cli.File(os.Getenv("WOODPECKER_ARTIFACT_STORE_S3_ACCESS_KEY_FILE")),
+			cli.EnvVar("WOODPECKER_ARTIFACT_STORE_S3_ACCESS_KEY")),
+		Name:  "artifact-store-s3-access-key",
+		Usage: "access key for S3 based artifact storage",
+	},
+	&cli.StringFlag{
+		Sources: cli.NewValueSourceChain(
+			cli.File(os.Getenv("WOODPECKER_ARTIFACT_STORE_S3_SECRET_KEY_FILE")),
+			cli.EnvVar("WOODPECKER_ARTIFACT_STORE_S3_SECRET_KEY")),
+		Name:  "artifact-store-s3-secret-key",
+		Usage: "secret key for S3 based artifact storage",
+	},
+	&cli.BoolFlag{
+		Sources: cli.EnvVars("WOODPECKER_ARTIFACT_STORE_S3_PATH_STYLE"),
+		Name:    "artifact-store-s3-path-style",
+		Usage:   "use path style bucket addressing for S3 based artifact storage, required by most self hosted object storages like MinIO",
+	},
 	//
 	// backend options for pipeline compiler
 	//
@@ -528,7 +688,7 @@ var flags = append([]cli.Flag{
 		Name:    "encryption-disable-flag",
 		Usage:   "Flag to decrypt all encrypted data and disable encryption on server",
 	},
-}, logger.GlobalLoggerFlags...)
+}, logger.GlobalLoggerFlags, tracing.Flags)
 
 // If woodpecker is running inside a container the default value for
 // the datasource is different from running outside a container.
@@ -28,6 +28,7 @@ import (
 	"go.woodpecker-ci.org/woodpecker/v3/server"
 	woodpeckerGrpcServer "go.woodpecker-ci.org/woodpecker/v3/server/grpc"
 	"go.woodpecker-ci.org/woodpecker/v3/server/store"
+	"go.woodpecker-ci.org/woodpecker/v3/shared/tracing"
 )
 
 func runGrpcServer(ctx context.Context, c *cli.Command, _store store.Store) error {
@@ -42,7 +43,7 @@ func runGrpcServer(ctx context.Context, c *cli.Command, _store store.Store) erro
 	authorizer := woodpeckerGrpcServer.NewAuthorizer(jwtManager)
 	grpcServer := grpc.NewServer(
 		grpc.StreamInterceptor(authorizer.StreamInterceptor),
-		grpc.UnaryInterceptor(authorizer.UnaryInterceptor),
+		grpc.ChainUnaryInterceptor(authorizer.UnaryInterceptor, tracing.UnaryServerInterceptor()),
 		grpc.KeepaliveEnforcementPolicy(keepalive.EnforcementPolicy{
 			MinTime: c.Duration("keepalive-min-time"),
 		}),
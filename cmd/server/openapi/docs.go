@@ -18,6 +18,46 @@ const docTemplate = `{
     "host": "{{.Host}}",
     "basePath": "{{.BasePath}}",
     "paths": {
+        "/.well-known/jwks.json": {
+            "get": {
+                "description": "Returns the JSON web key set to verify the identity tokens issued to workflows.",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "System"
+                ],
+                "summary": "Get the OIDC signing keys",
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "type": "object"
+                        }
+                    }
+                }
+            }
+        },
+        "/.well-known/openid-configuration": {
+            "get": {
+                "description": "Returns the OpenID provider metadata of the identity tokens issued to workflows.",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "System"
+                ],
+                "summary": "Get the OpenID configuration",
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "type": "object"
+                        }
+                    }
+                }
+            }
+        },
         "/agents": {
             "get": {
                 "produces": [
@@ -250,6 +290,95 @@ const docTemplate = `{
                 }
             }
         },
+        "/audit-events": {
+            "get": {
+                "description": "Returns the administrative and security-relevant actions of all users, newest first.",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Audit log"
+                ],
+                "summary": "List audit events",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "default": "Bearer \u003cpersonal access token\u003e",
+                        "description": "Insert your personal access token",
+                        "name": "Authorization",
+                        "in": "header",
+                        "required": true
+                    },
+                    {
+                        "type": "integer",
+                        "default": 1,
+                        "description": "for response pagination, page offset number",
+                        "name": "page",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "default": 50,
+                        "description": "for response pagination, max items per page",
+                        "name": "perPage",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "filter events by the login of the acting user",
+                        "name": "user",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "filter events by action",
+                        "name": "action",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "filter events by the type of the changed resource",
+                        "name": "target_type",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "filter events by repository id",
+                        "name": "repo_id",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "filter events by organization id",
+                        "name": "org_id",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "only return events before this RFC3339 date",
+                        "name": "before",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "only return events after this RFC3339 date",
+                        "name": "after",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "type": "array",
+                            "items": {
+                                "$ref": "#/definitions/AuditEvent"
+                            }
+                        }
+                    }
+                }
+            }
+        },
         "/badges/{repo_id}/cc.xml": {
             "get": {
                 "description": "CCMenu displays the pipeline status of projects on a CI server as an item in the Mac's menu bar.\nMore details on how to install, you can find at http://ccmenu.org/\nThe response format adheres to CCTray v1 Specification, https://cctray.org/v1/",
@@ -1061,6 +1190,49 @@ const docTemplate = `{
                         }
                     }
                 }
+            },
+            "patch": {
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Organization"
+                ],
+                "summary": "Update an organization",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "default": "Bearer \u003cpersonal access token\u003e",
+                        "description": "Insert your personal access token",
+                        "name": "Authorization",
+                        "in": "header",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "the organization's id",
+                        "name": "org_id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "the organization's update parameters",
+                        "name": "orgPatch",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/OrgPatch"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/Org"
+                        }
+                    }
+                }
             }
         },
         "/orgs/{org_id}/agents": {
@@ -1743,15 +1915,15 @@ const docTemplate = `{
                 }
             }
         },
-        "/pipelines": {
+        "/orgs/{org_id}/webhooks": {
             "get": {
                 "produces": [
                     "application/json"
                 ],
                 "tags": [
-                    "Pipeline queues"
+                    "Organization webhooks"
                 ],
-                "summary": "List pipelines in queue",
+                "summary": "List organization webhooks",
                 "parameters": [
                     {
                         "type": "string",
@@ -1760,6 +1932,27 @@ const docTemplate = `{
                         "name": "Authorization",
                         "in": "header",
                         "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "the org's id",
+                        "name": "org_id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "integer",
+                        "default": 1,
+                        "description": "for response pagination, page offset number",
+                        "name": "page",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "default": 50,
+                        "description": "for response pagination, max items per page",
+                        "name": "perPage",
+                        "in": "query"
                     }
                 ],
                 "responses": {
@@ -1768,23 +1961,20 @@ const docTemplate = `{
                         "schema": {
                             "type": "array",
                             "items": {
-                                "$ref": "#/definitions/Feed"
+                                "$ref": "#/definitions/Webhook"
                             }
                         }
                     }
                 }
-            }
-        },
-        "/queue/info": {
-            "get": {
-                "description": "TODO: link the InfoT response object - this is blocked, until the ` + "`" + `swaggo/swag` + "`" + ` tool dependency is v1.18.12 or newer",
+            },
+            "post": {
                 "produces": [
                     "application/json"
                 ],
                 "tags": [
-                    "Pipeline queues"
+                    "Organization webhooks"
                 ],
-                "summary": "Get pipeline queue information",
+                "summary": "Create an organization webhook",
                 "parameters": [
                     {
                         "type": "string",
@@ -1793,30 +1983,43 @@ const docTemplate = `{
                         "name": "Authorization",
                         "in": "header",
                         "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "the org's id",
+                        "name": "org_id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "the new webhook",
+                        "name": "webhook",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/WebhookPatch"
+                        }
                     }
                 ],
                 "responses": {
                     "200": {
                         "description": "OK",
                         "schema": {
-                            "type": "object",
-                            "additionalProperties": {
-                                "type": "string"
-                            }
+                            "$ref": "#/definitions/Webhook"
                         }
                     }
                 }
             }
         },
-        "/queue/norunningpipelines": {
+        "/orgs/{org_id}/webhooks/{webhook_id}": {
             "get": {
                 "produces": [
-                    "text/plain"
+                    "application/json"
                 ],
                 "tags": [
-                    "Pipeline queues"
+                    "Organization webhooks"
                 ],
-                "summary": "Block til pipeline queue has a running item",
+                "summary": "Get an organization webhook",
                 "parameters": [
                     {
                         "type": "string",
@@ -1825,24 +2028,39 @@ const docTemplate = `{
                         "name": "Authorization",
                         "in": "header",
                         "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "the org's id",
+                        "name": "org_id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "integer",
+                        "description": "the webhook id",
+                        "name": "webhook_id",
+                        "in": "path",
+                        "required": true
                     }
                 ],
                 "responses": {
-                    "204": {
-                        "description": "No Content"
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/Webhook"
+                        }
                     }
                 }
-            }
-        },
-        "/queue/pause": {
-            "post": {
+            },
+            "delete": {
                 "produces": [
                     "text/plain"
                 ],
                 "tags": [
-                    "Pipeline queues"
+                    "Organization webhooks"
                 ],
-                "summary": "Pause the pipeline queue",
+                "summary": "Delete an organization webhook",
                 "parameters": [
                     {
                         "type": "string",
@@ -1851,6 +2069,20 @@ const docTemplate = `{
                         "name": "Authorization",
                         "in": "header",
                         "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "the org's id",
+                        "name": "org_id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "integer",
+                        "description": "the webhook id",
+                        "name": "webhook_id",
+                        "in": "path",
+                        "required": true
                     }
                 ],
                 "responses": {
@@ -1858,17 +2090,15 @@ const docTemplate = `{
                         "description": "No Content"
                     }
                 }
-            }
-        },
-        "/queue/resume": {
-            "post": {
+            },
+            "patch": {
                 "produces": [
-                    "text/plain"
+                    "application/json"
                 ],
                 "tags": [
-                    "Pipeline queues"
+                    "Organization webhooks"
                 ],
-                "summary": "Resume the pipeline queue",
+                "summary": "Update an organization webhook",
                 "parameters": [
                     {
                         "type": "string",
@@ -1877,24 +2107,1403 @@ const docTemplate = `{
                         "name": "Authorization",
                         "in": "header",
                         "required": true
-                    }
-                ],
-                "responses": {
-                    "204": {
+                    },
+                    {
+                        "type": "string",
+                        "description": "the org's id",
+                        "name": "org_id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "integer",
+                        "description": "the webhook id",
+                        "name": "webhook_id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "the webhook data",
+                        "name": "webhook",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/WebhookPatch"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/Webhook"
+                        }
+                    }
+                }
+            }
+        },
+        "/orgs/{org_id}/webhooks/{webhook_id}/deliveries": {
+            "get": {
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Organization webhooks"
+                ],
+                "summary": "List the deliveries of an organization webhook",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "default": "Bearer \u003cpersonal access token\u003e",
+                        "description": "Insert your personal access token",
+                        "name": "Authorization",
+                        "in": "header",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "the org's id",
+                        "name": "org_id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "integer",
+                        "description": "the webhook id",
+                        "name": "webhook_id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "integer",
+                        "default": 1,
+                        "description": "for response pagination, page offset number",
+                        "name": "page",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "default": 50,
+                        "description": "for response pagination, max items per page",
+                        "name": "perPage",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "type": "array",
+                            "items": {
+                                "$ref": "#/definitions/WebhookDelivery"
+                            }
+                        }
+                    }
+                }
+            }
+        },
+        "/orgs/{org_id}/webhooks/{webhook_id}/deliveries/{delivery_id}/redeliver": {
+            "post": {
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Organization webhooks"
+                ],
+                "summary": "Send the payload of a delivery of an organization webhook again",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "default": "Bearer \u003cpersonal access token\u003e",
+                        "description": "Insert your personal access token",
+                        "name": "Authorization",
+                        "in": "header",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "the org's id",
+                        "name": "org_id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "integer",
+                        "description": "the webhook id",
+                        "name": "webhook_id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "integer",
+                        "description": "the delivery id",
+                        "name": "delivery_id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/WebhookDelivery"
+                        }
+                    }
+                }
+            }
+        },
+        "/pipelines": {
+            "get": {
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Pipeline queues"
+                ],
+                "summary": "List pipelines in queue",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "default": "Bearer \u003cpersonal access token\u003e",
+                        "description": "Insert your personal access token",
+                        "name": "Authorization",
+                        "in": "header",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "type": "array",
+                            "items": {
+                                "$ref": "#/definitions/Feed"
+                            }
+                        }
+                    }
+                }
+            }
+        },
+        "/queue/info": {
+            "get": {
+                "description": "TODO: link the InfoT response object - this is blocked, until the ` + "`" + `swaggo/swag` + "`" + ` tool dependency is v1.18.12 or newer",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Pipeline queues"
+                ],
+                "summary": "Get pipeline queue information",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "default": "Bearer \u003cpersonal access token\u003e",
+                        "description": "Insert your personal access token",
+                        "name": "Authorization",
+                        "in": "header",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    }
+                }
+            }
+        },
+        "/queue/norunningpipelines": {
+            "get": {
+                "produces": [
+                    "text/plain"
+                ],
+                "tags": [
+                    "Pipeline queues"
+                ],
+                "summary": "Block til pipeline queue has a running item",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "default": "Bearer \u003cpersonal access token\u003e",
+                        "description": "Insert your personal access token",
+                        "name": "Authorization",
+                        "in": "header",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "204": {
+                        "description": "No Content"
+                    }
+                }
+            }
+        },
+        "/queue/pause": {
+            "post": {
+                "produces": [
+                    "text/plain"
+                ],
+                "tags": [
+                    "Pipeline queues"
+                ],
+                "summary": "Pause the pipeline queue",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "default": "Bearer \u003cpersonal access token\u003e",
+                        "description": "Insert your personal access token",
+                        "name": "Authorization",
+                        "in": "header",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "204": {
+                        "description": "No Content"
+                    }
+                }
+            }
+        },
+        "/queue/resume": {
+            "post": {
+                "produces": [
+                    "text/plain"
+                ],
+                "tags": [
+                    "Pipeline queues"
+                ],
+                "summary": "Resume the pipeline queue",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "default": "Bearer \u003cpersonal access token\u003e",
+                        "description": "Insert your personal access token",
+                        "name": "Authorization",
+                        "in": "header",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "204": {
+                        "description": "No Content"
+                    }
+                }
+            }
+        },
+        "/registries": {
+            "get": {
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Registries"
+                ],
+                "summary": "List global registries",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "default": "Bearer \u003cpersonal access token\u003e",
+                        "description": "Insert your personal access token",
+                        "name": "Authorization",
+                        "in": "header",
+                        "required": true
+                    },
+                    {
+                        "type": "integer",
+                        "default": 1,
+                        "description": "for response pagination, page offset number",
+                        "name": "page",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "default": 50,
+                        "description": "for response pagination, max items per page",
+                        "name": "perPage",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "type": "array",
+                            "items": {
+                                "$ref": "#/definitions/Registry"
+                            }
+                        }
+                    }
+                }
+            },
+            "post": {
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Registries"
+                ],
+                "summary": "Create a global registry",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "default": "Bearer \u003cpersonal access token\u003e",
+                        "description": "Insert your personal access token",
+                        "name": "Authorization",
+                        "in": "header",
+                        "required": true
+                    },
+                    {
+                        "description": "the registry object data",
+                        "name": "registry",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/Registry"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/Registry"
+                        }
+                    }
+                }
+            }
+        },
+        "/registries/{registry}": {
+            "get": {
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Registries"
+                ],
+                "summary": "Get a global registry by name",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "default": "Bearer \u003cpersonal access token\u003e",
+                        "description": "Insert your personal access token",
+                        "name": "Authorization",
+                        "in": "header",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "the registry's name",
+                        "name": "registry",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/Registry"
+                        }
+                    }
+                }
+            },
+            "delete": {
+                "produces": [
+                    "text/plain"
+                ],
+                "tags": [
+                    "Registries"
+                ],
+                "summary": "Delete a global registry by name",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "default": "Bearer \u003cpersonal access token\u003e",
+                        "description": "Insert your personal access token",
+                        "name": "Authorization",
+                        "in": "header",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "the registry's name",
+                        "name": "registry",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "204": {
+                        "description": "No Content"
+                    }
+                }
+            },
+            "patch": {
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Registries"
+                ],
+                "summary": "Update a global registry by name",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "default": "Bearer \u003cpersonal access token\u003e",
+                        "description": "Insert your personal access token",
+                        "name": "Authorization",
+                        "in": "header",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "the registry's name",
+                        "name": "registry",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "the registry's data",
+                        "name": "registryData",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/Registry"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/Registry"
+                        }
+                    }
+                }
+            }
+        },
+        "/repos": {
+            "get": {
+                "description": "Returns a list of all repositories. Requires admin rights.",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Repositories"
+                ],
+                "summary": "List all repositories on the server",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "default": "Bearer \u003cpersonal access token\u003e",
+                        "description": "Insert your personal access token",
+                        "name": "Authorization",
+                        "in": "header",
+                        "required": true
+                    },
+                    {
+                        "type": "boolean",
+                        "description": "only list active repos",
+                        "name": "active",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "default": 1,
+                        "description": "for response pagination, page offset number",
+                        "name": "page",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "default": 50,
+                        "description": "for response pagination, max items per page",
+                        "name": "perPage",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "type": "array",
+                            "items": {
+                                "$ref": "#/definitions/Repo"
+                            }
+                        }
+                    }
+                }
+            },
+            "post": {
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Repositories"
+                ],
+                "summary": "Activate a repository",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "default": "Bearer \u003cpersonal access token\u003e",
+                        "description": "Insert your personal access token",
+                        "name": "Authorization",
+                        "in": "header",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "the id of a repository at the forge",
+                        "name": "forge_remote_id",
+                        "in": "query",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/Repo"
+                        }
+                    }
+                }
+            }
+        },
+        "/repos/lookup/{repo_full_name}": {
+            "get": {
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Repositories"
+                ],
+                "summary": "Lookup a repository by full name",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "default": "Bearer \u003cpersonal access token\u003e",
+                        "description": "Insert your personal access token",
+                        "name": "Authorization",
+                        "in": "header",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "the repository full name / slug",
+                        "name": "repo_full_name",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/Repo"
+                        }
+                    }
+                }
+            }
+        },
+        "/repos/repair": {
+            "post": {
+                "description": "Executes a repair process on all repositories. Requires admin rights.",
+                "produces": [
+                    "text/plain"
+                ],
+                "tags": [
+                    "Repositories"
+                ],
+                "summary": "Repair all repositories on the server",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "default": "Bearer \u003cpersonal access token\u003e",
+                        "description": "Insert your personal access token",
+                        "name": "Authorization",
+                        "in": "header",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "204": {
+                        "description": "No Content"
+                    }
+                }
+            }
+        },
+        "/repos/{repo_id}": {
+            "get": {
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Repositories"
+                ],
+                "summary": "Get a repository",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "default": "Bearer \u003cpersonal access token\u003e",
+                        "description": "Insert your personal access token",
+                        "name": "Authorization",
+                        "in": "header",
+                        "required": true
+                    },
+                    {
+                        "type": "integer",
+                        "description": "the repository id",
+                        "name": "repo_id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/Repo"
+                        }
+                    }
+                }
+            },
+            "delete": {
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Repositories"
+                ],
+                "summary": "Delete a repository",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "default": "Bearer \u003cpersonal access token\u003e",
+                        "description": "Insert your personal access token",
+                        "name": "Authorization",
+                        "in": "header",
+                        "required": true
+                    },
+                    {
+                        "type": "integer",
+                        "description": "the repository id",
+                        "name": "repo_id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/Repo"
+                        }
+                    }
+                }
+            },
+            "patch": {
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Repositories"
+                ],
+                "summary": "Update a repository",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "default": "Bearer \u003cpersonal access token\u003e",
+                        "description": "Insert your personal access token",
+                        "name": "Authorization",
+                        "in": "header",
+                        "required": true
+                    },
+                    {
+                        "type": "integer",
+                        "description": "the repository id",
+                        "name": "repo_id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "the repository's information",
+                        "name": "repo",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/RepoPatch"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/Repo"
+                        }
+                    }
+                }
+            }
+        },
+        "/repos/{repo_id}/branches": {
+            "get": {
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Repositories"
+                ],
+                "summary": "Get branches of a repository",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "default": "Bearer \u003cpersonal access token\u003e",
+                        "description": "Insert your personal access token",
+                        "name": "Authorization",
+                        "in": "header",
+                        "required": true
+                    },
+                    {
+                        "type": "integer",
+                        "description": "the repository id",
+                        "name": "repo_id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "integer",
+                        "default": 1,
+                        "description": "for response pagination, page offset number",
+                        "name": "page",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "default": 50,
+                        "description": "for response pagination, max items per page",
+                        "name": "perPage",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "type": "array",
+                            "items": {
+                                "type": "string"
+                            }
+                        }
+                    }
+                }
+            }
+        },
+        "/repos/{repo_id}/chown": {
+            "post": {
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Repositories"
+                ],
+                "summary": "Change a repository's owner to the currently authenticated user",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "default": "Bearer \u003cpersonal access token\u003e",
+                        "description": "Insert your personal access token",
+                        "name": "Authorization",
+                        "in": "header",
+                        "required": true
+                    },
+                    {
+                        "type": "integer",
+                        "description": "the repository id",
+                        "name": "repo_id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/Repo"
+                        }
+                    }
+                }
+            }
+        },
+        "/repos/{repo_id}/cron": {
+            "get": {
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Repository cron jobs"
+                ],
+                "summary": "List cron jobs",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "default": "Bearer \u003cpersonal access token\u003e",
+                        "description": "Insert your personal access token",
+                        "name": "Authorization",
+                        "in": "header",
+                        "required": true
+                    },
+                    {
+                        "type": "integer",
+                        "description": "the repository id",
+                        "name": "repo_id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "integer",
+                        "default": 1,
+                        "description": "for response pagination, page offset number",
+                        "name": "page",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "default": 50,
+                        "description": "for response pagination, max items per page",
+                        "name": "perPage",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "type": "array",
+                            "items": {
+                                "$ref": "#/definitions/Cron"
+                            }
+                        }
+                    }
+                }
+            },
+            "post": {
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Repository cron jobs"
+                ],
+                "summary": "Create a cron job",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "default": "Bearer \u003cpersonal access token\u003e",
+                        "description": "Insert your personal access token",
+                        "name": "Authorization",
+                        "in": "header",
+                        "required": true
+                    },
+                    {
+                        "type": "integer",
+                        "description": "the repository id",
+                        "name": "repo_id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "the new cron job",
+                        "name": "cronJob",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/Cron"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/Cron"
+                        }
+                    }
+                }
+            }
+        },
+        "/repos/{repo_id}/cron/{cron}": {
+            "get": {
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Repository cron jobs"
+                ],
+                "summary": "Get a cron job",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "default": "Bearer \u003cpersonal access token\u003e",
+                        "description": "Insert your personal access token",
+                        "name": "Authorization",
+                        "in": "header",
+                        "required": true
+                    },
+                    {
+                        "type": "integer",
+                        "description": "the repository id",
+                        "name": "repo_id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "the cron job id",
+                        "name": "cron",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/Cron"
+                        }
+                    }
+                }
+            },
+            "post": {
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Repository cron jobs"
+                ],
+                "summary": "Start a cron job now",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "default": "Bearer \u003cpersonal access token\u003e",
+                        "description": "Insert your personal access token",
+                        "name": "Authorization",
+                        "in": "header",
+                        "required": true
+                    },
+                    {
+                        "type": "integer",
+                        "description": "the repository id",
+                        "name": "repo_id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "the cron job id",
+                        "name": "cron",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/Pipeline"
+                        }
+                    }
+                }
+            },
+            "delete": {
+                "produces": [
+                    "text/plain"
+                ],
+                "tags": [
+                    "Repository cron jobs"
+                ],
+                "summary": "Delete a cron job",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "default": "Bearer \u003cpersonal access token\u003e",
+                        "description": "Insert your personal access token",
+                        "name": "Authorization",
+                        "in": "header",
+                        "required": true
+                    },
+                    {
+                        "type": "integer",
+                        "description": "the repository id",
+                        "name": "repo_id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "the cron job id",
+                        "name": "cron",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "204": {
+                        "description": "No Content"
+                    }
+                }
+            },
+            "patch": {
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Repository cron jobs"
+                ],
+                "summary": "Update a cron job",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "default": "Bearer \u003cpersonal access token\u003e",
+                        "description": "Insert your personal access token",
+                        "name": "Authorization",
+                        "in": "header",
+                        "required": true
+                    },
+                    {
+                        "type": "integer",
+                        "description": "the repository id",
+                        "name": "repo_id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "the cron job id",
+                        "name": "cron",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "the cron job data",
+                        "name": "cronJob",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/Cron"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/Cron"
+                        }
+                    }
+                }
+            }
+        },
+        "/repos/{repo_id}/environments": {
+            "get": {
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Repository environments"
+                ],
+                "summary": "List repository environments",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "default": "Bearer \u003cpersonal access token\u003e",
+                        "description": "Insert your personal access token",
+                        "name": "Authorization",
+                        "in": "header",
+                        "required": true
+                    },
+                    {
+                        "type": "integer",
+                        "description": "the repository id",
+                        "name": "repo_id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "integer",
+                        "default": 1,
+                        "description": "for response pagination, page offset number",
+                        "name": "page",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "default": 50,
+                        "description": "for response pagination, max items per page",
+                        "name": "perPage",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "type": "array",
+                            "items": {
+                                "$ref": "#/definitions/Environment"
+                            }
+                        }
+                    }
+                }
+            },
+            "post": {
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Repository environments"
+                ],
+                "summary": "Create a repository environment",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "default": "Bearer \u003cpersonal access token\u003e",
+                        "description": "Insert your personal access token",
+                        "name": "Authorization",
+                        "in": "header",
+                        "required": true
+                    },
+                    {
+                        "type": "integer",
+                        "description": "the repository id",
+                        "name": "repo_id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "the new environment",
+                        "name": "environment",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/EnvironmentPatch"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/Environment"
+                        }
+                    }
+                }
+            }
+        },
+        "/repos/{repo_id}/environments/{environment}": {
+            "get": {
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Repository environments"
+                ],
+                "summary": "Get a repository environment by name",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "default": "Bearer \u003cpersonal access token\u003e",
+                        "description": "Insert your personal access token",
+                        "name": "Authorization",
+                        "in": "header",
+                        "required": true
+                    },
+                    {
+                        "type": "integer",
+                        "description": "the repository id",
+                        "name": "repo_id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "the environment name",
+                        "name": "environment",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/Environment"
+                        }
+                    }
+                }
+            },
+            "delete": {
+                "produces": [
+                    "text/plain"
+                ],
+                "tags": [
+                    "Repository environments"
+                ],
+                "summary": "Delete a repository environment by name",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "default": "Bearer \u003cpersonal access token\u003e",
+                        "description": "Insert your personal access token",
+                        "name": "Authorization",
+                        "in": "header",
+                        "required": true
+                    },
+                    {
+                        "type": "integer",
+                        "description": "the repository id",
+                        "name": "repo_id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "the environment name",
+                        "name": "environment",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "204": {
                         "description": "No Content"
                     }
                 }
+            },
+            "patch": {
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Repository environments"
+                ],
+                "summary": "Update a repository environment by name",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "default": "Bearer \u003cpersonal access token\u003e",
+                        "description": "Insert your personal access token",
+                        "name": "Authorization",
+                        "in": "header",
+                        "required": true
+                    },
+                    {
+                        "type": "integer",
+                        "description": "the repository id",
+                        "name": "repo_id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "the environment name",
+                        "name": "environment",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "the environment data",
+                        "name": "patch",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/EnvironmentPatch"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/Environment"
+                        }
+                    }
+                }
+            }
+        },
+        "/repos/{repo_id}/environments/{environment}/deployments": {
+            "get": {
+                "description": "Get the deployment pipelines to the environment, the most recent first.",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Repository environments"
+                ],
+                "summary": "List the deployments to a repository environment",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "default": "Bearer \u003cpersonal access token\u003e",
+                        "description": "Insert your personal access token",
+                        "name": "Authorization",
+                        "in": "header",
+                        "required": true
+                    },
+                    {
+                        "type": "integer",
+                        "description": "the repository id",
+                        "name": "repo_id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "the environment name",
+                        "name": "environment",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "integer",
+                        "default": 1,
+                        "description": "for response pagination, page offset number",
+                        "name": "page",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "default": 50,
+                        "description": "for response pagination, max items per page",
+                        "name": "perPage",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "type": "array",
+                            "items": {
+                                "$ref": "#/definitions/Pipeline"
+                            }
+                        }
+                    }
+                }
             }
         },
-        "/registries": {
+        "/repos/{repo_id}/environments/{environment}/secrets": {
             "get": {
                 "produces": [
                     "application/json"
                 ],
                 "tags": [
-                    "Registries"
+                    "Repository environments"
                 ],
-                "summary": "List global registries",
+                "summary": "List the secrets of a repository environment",
                 "parameters": [
                     {
                         "type": "string",
@@ -1904,6 +3513,20 @@ const docTemplate = `{
                         "in": "header",
                         "required": true
                     },
+                    {
+                        "type": "integer",
+                        "description": "the repository id",
+                        "name": "repo_id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "the environment name",
+                        "name": "environment",
+                        "in": "path",
+                        "required": true
+                    },
                     {
                         "type": "integer",
                         "default": 1,
@@ -1925,20 +3548,21 @@ const docTemplate = `{
                         "schema": {
                             "type": "array",
                             "items": {
-                                "$ref": "#/definitions/Registry"
+                                "$ref": "#/definitions/Secret"
                             }
                         }
                     }
                 }
             },
             "post": {
+                "description": "Environment secrets are only available to deployments to the environment and take precedence over other secrets with the same name.",
                 "produces": [
                     "application/json"
                 ],
                 "tags": [
-                    "Registries"
+                    "Repository environments"
                 ],
-                "summary": "Create a global registry",
+                "summary": "Create a secret of a repository environment",
                 "parameters": [
                     {
                         "type": "string",
@@ -1949,12 +3573,26 @@ const docTemplate = `{
                         "required": true
                     },
                     {
-                        "description": "the registry object data",
-                        "name": "registry",
+                        "type": "integer",
+                        "description": "the repository id",
+                        "name": "repo_id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "the environment name",
+                        "name": "environment",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "the new secret",
+                        "name": "secret",
                         "in": "body",
                         "required": true,
                         "schema": {
-                            "$ref": "#/definitions/Registry"
+                            "$ref": "#/definitions/Secret"
                         }
                     }
                 ],
@@ -1962,21 +3600,21 @@ const docTemplate = `{
                     "200": {
                         "description": "OK",
                         "schema": {
-                            "$ref": "#/definitions/Registry"
+                            "$ref": "#/definitions/Secret"
                         }
                     }
                 }
             }
         },
-        "/registries/{registry}": {
+        "/repos/{repo_id}/environments/{environment}/secrets/{secretName}": {
             "get": {
                 "produces": [
                     "application/json"
                 ],
                 "tags": [
-                    "Registries"
+                    "Repository environments"
                 ],
-                "summary": "Get a global registry by name",
+                "summary": "Get a secret of a repository environment by name",
                 "parameters": [
                     {
                         "type": "string",
@@ -1986,10 +3624,24 @@ const docTemplate = `{
                         "in": "header",
                         "required": true
                     },
+                    {
+                        "type": "integer",
+                        "description": "the repository id",
+                        "name": "repo_id",
+                        "in": "path",
+                        "required": true
+                    },
                     {
                         "type": "string",
-                        "description": "the registry's name",
-                        "name": "registry",
+                        "description": "the environment name",
+                        "name": "environment",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "the secret name",
+                        "name": "secretName",
                         "in": "path",
                         "required": true
                     }
@@ -1998,7 +3650,7 @@ const docTemplate = `{
                     "200": {
                         "description": "OK",
                         "schema": {
-                            "$ref": "#/definitions/Registry"
+                            "$ref": "#/definitions/Secret"
                         }
                     }
                 }
@@ -2008,9 +3660,9 @@ const docTemplate = `{
                     "text/plain"
                 ],
                 "tags": [
-                    "Registries"
+                    "Repository environments"
                 ],
-                "summary": "Delete a global registry by name",
+                "summary": "Delete a secret of a repository environment by name",
                 "parameters": [
                     {
                         "type": "string",
@@ -2020,10 +3672,24 @@ const docTemplate = `{
                         "in": "header",
                         "required": true
                     },
+                    {
+                        "type": "integer",
+                        "description": "the repository id",
+                        "name": "repo_id",
+                        "in": "path",
+                        "required": true
+                    },
                     {
                         "type": "string",
-                        "description": "the registry's name",
-                        "name": "registry",
+                        "description": "the environment name",
+                        "name": "environment",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "the secret name",
+                        "name": "secretName",
                         "in": "path",
                         "required": true
                     }
@@ -2039,9 +3705,9 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "Registries"
+                    "Repository environments"
                 ],
-                "summary": "Update a global registry by name",
+                "summary": "Update a secret of a repository environment by name",
                 "parameters": [
                     {
                         "type": "string",
@@ -2051,20 +3717,34 @@ const docTemplate = `{
                         "in": "header",
                         "required": true
                     },
+                    {
+                        "type": "integer",
+                        "description": "the repository id",
+                        "name": "repo_id",
+                        "in": "path",
+                        "required": true
+                    },
                     {
                         "type": "string",
-                        "description": "the registry's name",
-                        "name": "registry",
+                        "description": "the environment name",
+                        "name": "environment",
                         "in": "path",
                         "required": true
                     },
                     {
-                        "description": "the registry's data",
-                        "name": "registryData",
+                        "type": "string",
+                        "description": "the secret name",
+                        "name": "secretName",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "the secret itself",
+                        "name": "secret",
                         "in": "body",
                         "required": true,
                         "schema": {
-                            "$ref": "#/definitions/Registry"
+                            "$ref": "#/definitions/Secret"
                         }
                     }
                 ],
@@ -2072,22 +3752,22 @@ const docTemplate = `{
                     "200": {
                         "description": "OK",
                         "schema": {
-                            "$ref": "#/definitions/Registry"
+                            "$ref": "#/definitions/Secret"
                         }
                     }
                 }
             }
         },
-        "/repos": {
+        "/repos/{repo_id}/flaky_steps": {
             "get": {
-                "description": "Returns a list of all repositories. Requires admin rights.",
+                "description": "List the steps of a repository which passed after they failed on the same commit, the most flaky step first.",
                 "produces": [
                     "application/json"
                 ],
                 "tags": [
                     "Repositories"
                 ],
-                "summary": "List all repositories on the server",
+                "summary": "List flaky steps",
                 "parameters": [
                     {
                         "type": "string",
@@ -2098,10 +3778,11 @@ const docTemplate = `{
                         "required": true
                     },
                     {
-                        "type": "boolean",
-                        "description": "only list active repos",
-                        "name": "active",
-                        "in": "query"
+                        "type": "integer",
+                        "description": "the repository id",
+                        "name": "repo_id",
+                        "in": "path",
+                        "required": true
                     },
                     {
                         "type": "integer",
@@ -2124,20 +3805,22 @@ const docTemplate = `{
                         "schema": {
                             "type": "array",
                             "items": {
-                                "$ref": "#/definitions/Repo"
+                                "$ref": "#/definitions/StepFlakiness"
                             }
                         }
                     }
                 }
-            },
-            "post": {
+            }
+        },
+        "/repos/{repo_id}/logs/{number}": {
+            "delete": {
                 "produces": [
-                    "application/json"
+                    "text/plain"
                 ],
                 "tags": [
-                    "Repositories"
+                    "Pipeline logs"
                 ],
-                "summary": "Activate a repository",
+                "summary": "Deletes all logs of a pipeline",
                 "parameters": [
                     {
                         "type": "string",
@@ -2148,32 +3831,36 @@ const docTemplate = `{
                         "required": true
                     },
                     {
-                        "type": "string",
-                        "description": "the id of a repository at the forge",
-                        "name": "forge_remote_id",
-                        "in": "query",
+                        "type": "integer",
+                        "description": "the repository id",
+                        "name": "repo_id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "integer",
+                        "description": "the number of the pipeline",
+                        "name": "number",
+                        "in": "path",
                         "required": true
                     }
                 ],
                 "responses": {
-                    "200": {
-                        "description": "OK",
-                        "schema": {
-                            "$ref": "#/definitions/Repo"
-                        }
+                    "204": {
+                        "description": "No Content"
                     }
                 }
             }
         },
-        "/repos/lookup/{repo_full_name}": {
+        "/repos/{repo_id}/logs/{number}/{stepID}": {
             "get": {
                 "produces": [
                     "application/json"
                 ],
                 "tags": [
-                    "Repositories"
+                    "Pipeline logs"
                 ],
-                "summary": "Lookup a repository by full name",
+                "summary": "Get logs for a pipeline step",
                 "parameters": [
                     {
                         "type": "string",
@@ -2184,59 +3871,55 @@ const docTemplate = `{
                         "required": true
                     },
                     {
-                        "type": "string",
-                        "description": "the repository full name / slug",
-                        "name": "repo_full_name",
+                        "type": "integer",
+                        "description": "the repository id",
+                        "name": "repo_id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "integer",
+                        "description": "the number of the pipeline",
+                        "name": "number",
                         "in": "path",
                         "required": true
+                    },
+                    {
+                        "type": "integer",
+                        "description": "the step id",
+                        "name": "stepID",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "integer",
+                        "description": "only the logs of this attempt of a restarted step, starting at 1",
+                        "name": "attempt",
+                        "in": "query"
                     }
                 ],
                 "responses": {
                     "200": {
                         "description": "OK",
                         "schema": {
-                            "$ref": "#/definitions/Repo"
+                            "type": "array",
+                            "items": {
+                                "$ref": "#/definitions/LogEntry"
+                            }
                         }
                     }
                 }
             }
         },
-        "/repos/repair": {
-            "post": {
-                "description": "Executes a repair process on all repositories. Requires admin rights.",
+        "/repos/{repo_id}/logs/{number}/{stepId}": {
+            "delete": {
                 "produces": [
                     "text/plain"
                 ],
                 "tags": [
-                    "Repositories"
-                ],
-                "summary": "Repair all repositories on the server",
-                "parameters": [
-                    {
-                        "type": "string",
-                        "default": "Bearer \u003cpersonal access token\u003e",
-                        "description": "Insert your personal access token",
-                        "name": "Authorization",
-                        "in": "header",
-                        "required": true
-                    }
-                ],
-                "responses": {
-                    "204": {
-                        "description": "No Content"
-                    }
-                }
-            }
-        },
-        "/repos/{repo_id}": {
-            "get": {
-                "produces": [
-                    "application/json"
-                ],
-                "tags": [
-                    "Repositories"
+                    "Pipeline logs"
                 ],
-                "summary": "Get a repository",
+                "summary": "Delete step logs of a pipeline",
                 "parameters": [
                     {
                         "type": "string",
@@ -2252,25 +3935,38 @@ const docTemplate = `{
                         "name": "repo_id",
                         "in": "path",
                         "required": true
+                    },
+                    {
+                        "type": "integer",
+                        "description": "the number of the pipeline",
+                        "name": "number",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "integer",
+                        "description": "the step id",
+                        "name": "stepId",
+                        "in": "path",
+                        "required": true
                     }
                 ],
                 "responses": {
-                    "200": {
-                        "description": "OK",
-                        "schema": {
-                            "$ref": "#/definitions/Repo"
-                        }
+                    "204": {
+                        "description": "No Content"
                     }
                 }
-            },
-            "delete": {
+            }
+        },
+        "/repos/{repo_id}/move": {
+            "post": {
                 "produces": [
-                    "application/json"
+                    "text/plain"
                 ],
                 "tags": [
                     "Repositories"
                 ],
-                "summary": "Delete a repository",
+                "summary": "Move a repository to a new owner",
                 "parameters": [
                     {
                         "type": "string",
@@ -2286,25 +3982,32 @@ const docTemplate = `{
                         "name": "repo_id",
                         "in": "path",
                         "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "the username to move the repository to",
+                        "name": "to",
+                        "in": "query",
+                        "required": true
                     }
                 ],
                 "responses": {
-                    "200": {
-                        "description": "OK",
-                        "schema": {
-                            "$ref": "#/definitions/Repo"
-                        }
+                    "204": {
+                        "description": "No Content"
                     }
                 }
-            },
-            "patch": {
+            }
+        },
+        "/repos/{repo_id}/permissions": {
+            "get": {
+                "description": "The repository permission, according to the used access token.",
                 "produces": [
                     "application/json"
                 ],
                 "tags": [
                     "Repositories"
                 ],
-                "summary": "Update a repository",
+                "summary": "Check current authenticated users access to the repository",
                 "parameters": [
                     {
                         "type": "string",
@@ -2320,36 +4023,28 @@ const docTemplate = `{
                         "name": "repo_id",
                         "in": "path",
                         "required": true
-                    },
-                    {
-                        "description": "the repository's information",
-                        "name": "repo",
-                        "in": "body",
-                        "required": true,
-                        "schema": {
-                            "$ref": "#/definitions/RepoPatch"
-                        }
                     }
                 ],
                 "responses": {
                     "200": {
                         "description": "OK",
                         "schema": {
-                            "$ref": "#/definitions/Repo"
+                            "$ref": "#/definitions/Perm"
                         }
                     }
                 }
             }
         },
-        "/repos/{repo_id}/branches": {
+        "/repos/{repo_id}/pipelines": {
             "get": {
+                "description": "Get a list of pipelines for a repository.",
                 "produces": [
                     "application/json"
                 ],
                 "tags": [
-                    "Repositories"
+                    "Pipelines"
                 ],
-                "summary": "Get branches of a repository",
+                "summary": "List repository pipelines",
                 "parameters": [
                     {
                         "type": "string",
@@ -2379,6 +4074,42 @@ const docTemplate = `{
                         "description": "for response pagination, max items per page",
                         "name": "perPage",
                         "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "only return pipelines before this RFC3339 date",
+                        "name": "before",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "only return pipelines after this RFC3339 date",
+                        "name": "after",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "filter pipelines by branch",
+                        "name": "branch",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "filter pipelines by webhook events (comma separated)",
+                        "name": "event",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "filter pipelines by strings contained in ref",
+                        "name": "ref",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "filter pipelines by status",
+                        "name": "status",
+                        "in": "query"
                     }
                 ],
                 "responses": {
@@ -2387,22 +4118,20 @@ const docTemplate = `{
                         "schema": {
                             "type": "array",
                             "items": {
-                                "type": "string"
+                                "$ref": "#/definitions/Pipeline"
                             }
                         }
                     }
                 }
-            }
-        },
-        "/repos/{repo_id}/chown": {
+            },
             "post": {
                 "produces": [
                     "application/json"
                 ],
                 "tags": [
-                    "Repositories"
+                    "Pipelines"
                 ],
-                "summary": "Change a repository's owner to the currently authenticated user",
+                "summary": "Trigger a manual pipeline",
                 "parameters": [
                     {
                         "type": "string",
@@ -2418,27 +4147,36 @@ const docTemplate = `{
                         "name": "repo_id",
                         "in": "path",
                         "required": true
+                    },
+                    {
+                        "description": "the options for the pipeline to run",
+                        "name": "options",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/PipelineOptions"
+                        }
                     }
                 ],
                 "responses": {
                     "200": {
                         "description": "OK",
                         "schema": {
-                            "$ref": "#/definitions/Repo"
+                            "$ref": "#/definitions/Pipeline"
                         }
                     }
                 }
             }
         },
-        "/repos/{repo_id}/cron": {
+        "/repos/{repo_id}/pipelines/{number}": {
             "get": {
                 "produces": [
                     "application/json"
                 ],
                 "tags": [
-                    "Repository cron jobs"
+                    "Pipelines"
                 ],
-                "summary": "List cron jobs",
+                "summary": "Get a repositories pipeline",
                 "parameters": [
                     {
                         "type": "string",
@@ -2457,39 +4195,30 @@ const docTemplate = `{
                     },
                     {
                         "type": "integer",
-                        "default": 1,
-                        "description": "for response pagination, page offset number",
-                        "name": "page",
-                        "in": "query"
-                    },
-                    {
-                        "type": "integer",
-                        "default": 50,
-                        "description": "for response pagination, max items per page",
-                        "name": "perPage",
-                        "in": "query"
+                        "description": "the number of the pipeline, OR 'latest'",
+                        "name": "number",
+                        "in": "path",
+                        "required": true
                     }
                 ],
                 "responses": {
                     "200": {
                         "description": "OK",
                         "schema": {
-                            "type": "array",
-                            "items": {
-                                "$ref": "#/definitions/Cron"
-                            }
+                            "$ref": "#/definitions/Pipeline"
                         }
                     }
                 }
             },
             "post": {
+                "description": "Restarts a pipeline optional with altered event, deploy or environment",
                 "produces": [
                     "application/json"
                 ],
                 "tags": [
-                    "Repository cron jobs"
+                    "Pipelines"
                 ],
-                "summary": "Create a cron job",
+                "summary": "Restart a pipeline",
                 "parameters": [
                     {
                         "type": "string",
@@ -2507,34 +4236,42 @@ const docTemplate = `{
                         "required": true
                     },
                     {
-                        "description": "the new cron job",
-                        "name": "cronJob",
-                        "in": "body",
-                        "required": true,
-                        "schema": {
-                            "$ref": "#/definitions/Cron"
-                        }
+                        "type": "integer",
+                        "description": "the number of the pipeline",
+                        "name": "number",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "override the event type",
+                        "name": "event",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "override the target deploy value",
+                        "name": "deploy_to",
+                        "in": "query"
                     }
                 ],
                 "responses": {
                     "200": {
                         "description": "OK",
                         "schema": {
-                            "$ref": "#/definitions/Cron"
+                            "$ref": "#/definitions/Pipeline"
                         }
                     }
                 }
-            }
-        },
-        "/repos/{repo_id}/cron/{cron}": {
-            "get": {
+            },
+            "delete": {
                 "produces": [
-                    "application/json"
+                    "text/plain"
                 ],
                 "tags": [
-                    "Repository cron jobs"
+                    "Pipelines"
                 ],
-                "summary": "Get a cron job",
+                "summary": "Delete a pipeline",
                 "parameters": [
                     {
                         "type": "string",
@@ -2552,30 +4289,30 @@ const docTemplate = `{
                         "required": true
                     },
                     {
-                        "type": "string",
-                        "description": "the cron job id",
-                        "name": "cron",
+                        "type": "integer",
+                        "description": "the number of the pipeline",
+                        "name": "number",
                         "in": "path",
                         "required": true
                     }
                 ],
                 "responses": {
-                    "200": {
-                        "description": "OK",
-                        "schema": {
-                            "$ref": "#/definitions/Cron"
-                        }
+                    "204": {
+                        "description": "No Content"
                     }
                 }
-            },
+            }
+        },
+        "/repos/{repo_id}/pipelines/{number}/approve": {
             "post": {
+                "description": "The pipeline starts once it has the approvals required by the approval policy of the repository.",
                 "produces": [
                     "application/json"
                 ],
                 "tags": [
-                    "Repository cron jobs"
+                    "Pipelines"
                 ],
-                "summary": "Start a cron job now",
+                "summary": "Approve and start a pipeline",
                 "parameters": [
                     {
                         "type": "string",
@@ -2593,9 +4330,9 @@ const docTemplate = `{
                         "required": true
                     },
                     {
-                        "type": "string",
-                        "description": "the cron job id",
-                        "name": "cron",
+                        "type": "integer",
+                        "description": "the number of the pipeline",
+                        "name": "number",
                         "in": "path",
                         "required": true
                     }
@@ -2608,15 +4345,17 @@ const docTemplate = `{
                         }
                     }
                 }
-            },
-            "delete": {
+            }
+        },
+        "/repos/{repo_id}/pipelines/{number}/artifacts": {
+            "get": {
                 "produces": [
-                    "text/plain"
+                    "application/json"
                 ],
                 "tags": [
-                    "Repository cron jobs"
+                    "Pipeline artifacts"
                 ],
-                "summary": "Delete a cron job",
+                "summary": "List the artifacts of a pipeline",
                 "parameters": [
                     {
                         "type": "string",
@@ -2634,27 +4373,35 @@ const docTemplate = `{
                         "required": true
                     },
                     {
-                        "type": "string",
-                        "description": "the cron job id",
-                        "name": "cron",
+                        "type": "integer",
+                        "description": "the number of the pipeline",
+                        "name": "number",
                         "in": "path",
                         "required": true
                     }
                 ],
                 "responses": {
-                    "204": {
-                        "description": "No Content"
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "type": "array",
+                            "items": {
+                                "$ref": "#/definitions/Artifact"
+                            }
+                        }
                     }
                 }
-            },
-            "patch": {
+            }
+        },
+        "/repos/{repo_id}/pipelines/{number}/artifacts/{artifact_id}": {
+            "get": {
                 "produces": [
-                    "application/json"
+                    "application/octet-stream"
                 ],
                 "tags": [
-                    "Repository cron jobs"
+                    "Pipeline artifacts"
                 ],
-                "summary": "Update a cron job",
+                "summary": "Download an artifact of a pipeline",
                 "parameters": [
                     {
                         "type": "string",
@@ -2672,41 +4419,36 @@ const docTemplate = `{
                         "required": true
                     },
                     {
-                        "type": "string",
-                        "description": "the cron job id",
-                        "name": "cron",
+                        "type": "integer",
+                        "description": "the number of the pipeline",
+                        "name": "number",
                         "in": "path",
                         "required": true
                     },
                     {
-                        "description": "the cron job data",
-                        "name": "cronJob",
-                        "in": "body",
-                        "required": true,
-                        "schema": {
-                            "$ref": "#/definitions/Cron"
-                        }
+                        "type": "integer",
+                        "description": "the artifact id",
+                        "name": "artifact_id",
+                        "in": "path",
+                        "required": true
                     }
                 ],
                 "responses": {
                     "200": {
-                        "description": "OK",
-                        "schema": {
-                            "$ref": "#/definitions/Cron"
-                        }
+                        "description": "OK"
                     }
                 }
             }
         },
-        "/repos/{repo_id}/logs/{number}": {
-            "delete": {
+        "/repos/{repo_id}/pipelines/{number}/cancel": {
+            "post": {
                 "produces": [
                     "text/plain"
                 ],
                 "tags": [
-                    "Pipeline logs"
+                    "Pipelines"
                 ],
-                "summary": "Deletes all logs of a pipeline",
+                "summary": "Cancel a pipeline",
                 "parameters": [
                     {
                         "type": "string",
@@ -2732,21 +4474,21 @@ const docTemplate = `{
                     }
                 ],
                 "responses": {
-                    "204": {
-                        "description": "No Content"
+                    "200": {
+                        "description": "OK"
                     }
                 }
             }
         },
-        "/repos/{repo_id}/logs/{number}/{stepID}": {
+        "/repos/{repo_id}/pipelines/{number}/config": {
             "get": {
                 "produces": [
                     "application/json"
                 ],
                 "tags": [
-                    "Pipeline logs"
+                    "Pipelines"
                 ],
-                "summary": "Get logs for a pipeline step",
+                "summary": "Get configuration files for a pipeline",
                 "parameters": [
                     {
                         "type": "string",
@@ -2769,13 +4511,6 @@ const docTemplate = `{
                         "name": "number",
                         "in": "path",
                         "required": true
-                    },
-                    {
-                        "type": "integer",
-                        "description": "the step id",
-                        "name": "stepID",
-                        "in": "path",
-                        "required": true
                     }
                 ],
                 "responses": {
@@ -2784,22 +4519,22 @@ const docTemplate = `{
                         "schema": {
                             "type": "array",
                             "items": {
-                                "$ref": "#/definitions/LogEntry"
+                                "$ref": "#/definitions/Config"
                             }
                         }
                     }
                 }
             }
         },
-        "/repos/{repo_id}/logs/{number}/{stepId}": {
-            "delete": {
+        "/repos/{repo_id}/pipelines/{number}/decline": {
+            "post": {
                 "produces": [
-                    "text/plain"
+                    "application/json"
                 ],
                 "tags": [
-                    "Pipeline logs"
+                    "Pipelines"
                 ],
-                "summary": "Delete step logs of a pipeline",
+                "summary": "Decline a pipeline",
                 "parameters": [
                     {
                         "type": "string",
@@ -2822,31 +4557,27 @@ const docTemplate = `{
                         "name": "number",
                         "in": "path",
                         "required": true
-                    },
-                    {
-                        "type": "integer",
-                        "description": "the step id",
-                        "name": "stepId",
-                        "in": "path",
-                        "required": true
                     }
                 ],
                 "responses": {
-                    "204": {
-                        "description": "No Content"
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/Pipeline"
+                        }
                     }
                 }
             }
         },
-        "/repos/{repo_id}/move": {
-            "post": {
+        "/repos/{repo_id}/pipelines/{number}/metadata": {
+            "get": {
                 "produces": [
-                    "text/plain"
+                    "application/json"
                 ],
                 "tags": [
-                    "Repositories"
+                    "Pipelines"
                 ],
-                "summary": "Move a repository to a new owner",
+                "summary": "Get metadata for a pipeline or a specific workflow, including previous pipeline info",
                 "parameters": [
                     {
                         "type": "string",
@@ -2864,30 +4595,32 @@ const docTemplate = `{
                         "required": true
                     },
                     {
-                        "type": "string",
-                        "description": "the username to move the repository to",
-                        "name": "to",
-                        "in": "query",
+                        "type": "integer",
+                        "description": "the number of the pipeline",
+                        "name": "number",
+                        "in": "path",
                         "required": true
                     }
                 ],
                 "responses": {
-                    "204": {
-                        "description": "No Content"
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/metadata.Metadata"
+                        }
                     }
                 }
             }
         },
-        "/repos/{repo_id}/permissions": {
+        "/repos/{repo_id}/pull_requests": {
             "get": {
-                "description": "The repository permission, according to the used access token.",
                 "produces": [
                     "application/json"
                 ],
                 "tags": [
                     "Repositories"
                 ],
-                "summary": "Check current authenticated users access to the repository",
+                "summary": "List active pull requests of a repository",
                 "parameters": [
                     {
                         "type": "string",
@@ -2903,28 +4636,44 @@ const docTemplate = `{
                         "name": "repo_id",
                         "in": "path",
                         "required": true
+                    },
+                    {
+                        "type": "integer",
+                        "default": 1,
+                        "description": "for response pagination, page offset number",
+                        "name": "page",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "default": 50,
+                        "description": "for response pagination, max items per page",
+                        "name": "perPage",
+                        "in": "query"
                     }
                 ],
                 "responses": {
                     "200": {
                         "description": "OK",
                         "schema": {
-                            "$ref": "#/definitions/Perm"
+                            "type": "array",
+                            "items": {
+                                "$ref": "#/definitions/PullRequest"
+                            }
                         }
                     }
                 }
             }
         },
-        "/repos/{repo_id}/pipelines": {
+        "/repos/{repo_id}/registries": {
             "get": {
-                "description": "Get a list of pipelines for a repository.",
                 "produces": [
                     "application/json"
                 ],
                 "tags": [
-                    "Pipelines"
+                    "Repository registries"
                 ],
-                "summary": "List repository pipelines",
+                "summary": "List registries",
                 "parameters": [
                     {
                         "type": "string",
@@ -2954,42 +4703,6 @@ const docTemplate = `{
                         "description": "for response pagination, max items per page",
                         "name": "perPage",
                         "in": "query"
-                    },
-                    {
-                        "type": "string",
-                        "description": "only return pipelines before this RFC3339 date",
-                        "name": "before",
-                        "in": "query"
-                    },
-                    {
-                        "type": "string",
-                        "description": "only return pipelines after this RFC3339 date",
-                        "name": "after",
-                        "in": "query"
-                    },
-                    {
-                        "type": "string",
-                        "description": "filter pipelines by branch",
-                        "name": "branch",
-                        "in": "query"
-                    },
-                    {
-                        "type": "string",
-                        "description": "filter pipelines by webhook events (comma separated)",
-                        "name": "event",
-                        "in": "query"
-                    },
-                    {
-                        "type": "string",
-                        "description": "filter pipelines by strings contained in ref",
-                        "name": "ref",
-                        "in": "query"
-                    },
-                    {
-                        "type": "string",
-                        "description": "filter pipelines by status",
-                        "name": "status",
-                        "in": "query"
                     }
                 ],
                 "responses": {
@@ -2998,7 +4711,7 @@ const docTemplate = `{
                         "schema": {
                             "type": "array",
                             "items": {
-                                "$ref": "#/definitions/Pipeline"
+                                "$ref": "#/definitions/Registry"
                             }
                         }
                     }
@@ -3009,9 +4722,9 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "Pipelines"
+                    "Repository registries"
                 ],
-                "summary": "Trigger a manual pipeline",
+                "summary": "Create a registry",
                 "parameters": [
                     {
                         "type": "string",
@@ -3029,12 +4742,12 @@ const docTemplate = `{
                         "required": true
                     },
                     {
-                        "description": "the options for the pipeline to run",
-                        "name": "options",
+                        "description": "the new registry data",
+                        "name": "registry",
                         "in": "body",
                         "required": true,
                         "schema": {
-                            "$ref": "#/definitions/PipelineOptions"
+                            "$ref": "#/definitions/Registry"
                         }
                     }
                 ],
@@ -3042,21 +4755,21 @@ const docTemplate = `{
                     "200": {
                         "description": "OK",
                         "schema": {
-                            "$ref": "#/definitions/Pipeline"
+                            "$ref": "#/definitions/Registry"
                         }
                     }
                 }
             }
         },
-        "/repos/{repo_id}/pipelines/{number}": {
+        "/repos/{repo_id}/registries/{registry}": {
             "get": {
                 "produces": [
                     "application/json"
                 ],
                 "tags": [
-                    "Pipelines"
+                    "Repository registries"
                 ],
-                "summary": "Get a repositories pipeline",
+                "summary": "Get a registry by name",
                 "parameters": [
                     {
                         "type": "string",
@@ -3073,73 +4786,19 @@ const docTemplate = `{
                         "in": "path",
                         "required": true
                     },
-                    {
-                        "type": "integer",
-                        "description": "the number of the pipeline, OR 'latest'",
-                        "name": "number",
-                        "in": "path",
-                        "required": true
-                    }
-                ],
-                "responses": {
-                    "200": {
-                        "description": "OK",
-                        "schema": {
-                            "$ref": "#/definitions/Pipeline"
-                        }
-                    }
-                }
-            },
-            "post": {
-                "description": "Restarts a pipeline optional with altered event, deploy or environment",
-                "produces": [
-                    "application/json"
-                ],
-                "tags": [
-                    "Pipelines"
-                ],
-                "summary": "Restart a pipeline",
-                "parameters": [
                     {
                         "type": "string",
-                        "default": "Bearer \u003cpersonal access token\u003e",
-                        "description": "Insert your personal access token",
-                        "name": "Authorization",
-                        "in": "header",
-                        "required": true
-                    },
-                    {
-                        "type": "integer",
-                        "description": "the repository id",
-                        "name": "repo_id",
-                        "in": "path",
-                        "required": true
-                    },
-                    {
-                        "type": "integer",
-                        "description": "the number of the pipeline",
-                        "name": "number",
+                        "description": "the registry name",
+                        "name": "registry",
                         "in": "path",
                         "required": true
-                    },
-                    {
-                        "type": "string",
-                        "description": "override the event type",
-                        "name": "event",
-                        "in": "query"
-                    },
-                    {
-                        "type": "string",
-                        "description": "override the target deploy value",
-                        "name": "deploy_to",
-                        "in": "query"
                     }
                 ],
                 "responses": {
                     "200": {
                         "description": "OK",
                         "schema": {
-                            "$ref": "#/definitions/Pipeline"
+                            "$ref": "#/definitions/Registry"
                         }
                     }
                 }
@@ -3149,9 +4808,9 @@ const docTemplate = `{
                     "text/plain"
                 ],
                 "tags": [
-                    "Pipelines"
+                    "Repository registries"
                 ],
-                "summary": "Delete a pipeline",
+                "summary": "Delete a registry by name",
                 "parameters": [
                     {
                         "type": "string",
@@ -3169,9 +4828,9 @@ const docTemplate = `{
                         "required": true
                     },
                     {
-                        "type": "integer",
-                        "description": "the number of the pipeline",
-                        "name": "number",
+                        "type": "string",
+                        "description": "the registry name",
+                        "name": "registry",
                         "in": "path",
                         "required": true
                     }
@@ -3181,17 +4840,15 @@ const docTemplate = `{
                         "description": "No Content"
                     }
                 }
-            }
-        },
-        "/repos/{repo_id}/pipelines/{number}/approve": {
-            "post": {
+            },
+            "patch": {
                 "produces": [
                     "application/json"
                 ],
                 "tags": [
-                    "Pipelines"
+                    "Repository registries"
                 ],
-                "summary": "Approve and start a pipeline",
+                "summary": "Update a registry by name",
                 "parameters": [
                     {
                         "type": "string",
@@ -3209,32 +4866,41 @@ const docTemplate = `{
                         "required": true
                     },
                     {
-                        "type": "integer",
-                        "description": "the number of the pipeline",
-                        "name": "number",
+                        "type": "string",
+                        "description": "the registry name",
+                        "name": "registry",
                         "in": "path",
                         "required": true
+                    },
+                    {
+                        "description": "the attributes for the registry",
+                        "name": "registryData",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/Registry"
+                        }
                     }
                 ],
                 "responses": {
                     "200": {
                         "description": "OK",
                         "schema": {
-                            "$ref": "#/definitions/Pipeline"
+                            "$ref": "#/definitions/Registry"
                         }
                     }
                 }
             }
         },
-        "/repos/{repo_id}/pipelines/{number}/cancel": {
+        "/repos/{repo_id}/repair": {
             "post": {
                 "produces": [
                     "text/plain"
                 ],
                 "tags": [
-                    "Pipelines"
+                    "Repositories"
                 ],
-                "summary": "Cancel a pipeline",
+                "summary": "Repair a repository",
                 "parameters": [
                     {
                         "type": "string",
@@ -3250,31 +4916,25 @@ const docTemplate = `{
                         "name": "repo_id",
                         "in": "path",
                         "required": true
-                    },
-                    {
-                        "type": "integer",
-                        "description": "the number of the pipeline",
-                        "name": "number",
-                        "in": "path",
-                        "required": true
                     }
                 ],
                 "responses": {
-                    "200": {
-                        "description": "OK"
+                    "204": {
+                        "description": "No Content"
                     }
                 }
             }
         },
-        "/repos/{repo_id}/pipelines/{number}/config": {
+        "/repos/{repo_id}/retention/dry-run": {
             "get": {
+                "description": "Reports the pipelines and logs the retention policy would remove, without removing anything.",
                 "produces": [
                     "application/json"
                 ],
                 "tags": [
-                    "Pipelines"
+                    "Repositories"
                 ],
-                "summary": "Get configuration files for a pipeline",
+                "summary": "Get what the retention policy removes from a repository",
                 "parameters": [
                     {
                         "type": "string",
@@ -3290,37 +4950,27 @@ const docTemplate = `{
                         "name": "repo_id",
                         "in": "path",
                         "required": true
-                    },
-                    {
-                        "type": "integer",
-                        "description": "the number of the pipeline",
-                        "name": "number",
-                        "in": "path",
-                        "required": true
                     }
                 ],
                 "responses": {
                     "200": {
                         "description": "OK",
                         "schema": {
-                            "type": "array",
-                            "items": {
-                                "$ref": "#/definitions/Config"
-                            }
+                            "$ref": "#/definitions/RetentionReport"
                         }
                     }
                 }
             }
         },
-        "/repos/{repo_id}/pipelines/{number}/decline": {
-            "post": {
+        "/repos/{repo_id}/secrets": {
+            "get": {
                 "produces": [
                     "application/json"
                 ],
                 "tags": [
-                    "Pipelines"
+                    "Repository secrets"
                 ],
-                "summary": "Decline a pipeline",
+                "summary": "List repository secrets",
                 "parameters": [
                     {
                         "type": "string",
@@ -3339,31 +4989,39 @@ const docTemplate = `{
                     },
                     {
                         "type": "integer",
-                        "description": "the number of the pipeline",
-                        "name": "number",
-                        "in": "path",
-                        "required": true
+                        "default": 1,
+                        "description": "for response pagination, page offset number",
+                        "name": "page",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "default": 50,
+                        "description": "for response pagination, max items per page",
+                        "name": "perPage",
+                        "in": "query"
                     }
                 ],
                 "responses": {
                     "200": {
                         "description": "OK",
                         "schema": {
-                            "$ref": "#/definitions/Pipeline"
+                            "type": "array",
+                            "items": {
+                                "$ref": "#/definitions/Secret"
+                            }
                         }
                     }
                 }
-            }
-        },
-        "/repos/{repo_id}/pipelines/{number}/metadata": {
-            "get": {
+            },
+            "post": {
                 "produces": [
                     "application/json"
                 ],
                 "tags": [
-                    "Pipelines"
+                    "Repository secrets"
                 ],
-                "summary": "Get metadata for a pipeline or a specific workflow, including previous pipeline info",
+                "summary": "Create a repository secret",
                 "parameters": [
                     {
                         "type": "string",
@@ -3381,32 +5039,34 @@ const docTemplate = `{
                         "required": true
                     },
                     {
-                        "type": "integer",
-                        "description": "the number of the pipeline",
-                        "name": "number",
-                        "in": "path",
-                        "required": true
+                        "description": "the new secret",
+                        "name": "secret",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/Secret"
+                        }
                     }
                 ],
                 "responses": {
                     "200": {
                         "description": "OK",
                         "schema": {
-                            "$ref": "#/definitions/metadata.Metadata"
+                            "$ref": "#/definitions/Secret"
                         }
                     }
                 }
             }
         },
-        "/repos/{repo_id}/pull_requests": {
+        "/repos/{repo_id}/secrets/{secretName}": {
             "get": {
                 "produces": [
                     "application/json"
                 ],
                 "tags": [
-                    "Repositories"
+                    "Repository secrets"
                 ],
-                "summary": "List active pull requests of a repository",
+                "summary": "Get a repository secret by name",
                 "parameters": [
                     {
                         "type": "string",
@@ -3424,42 +5084,30 @@ const docTemplate = `{
                         "required": true
                     },
                     {
-                        "type": "integer",
-                        "default": 1,
-                        "description": "for response pagination, page offset number",
-                        "name": "page",
-                        "in": "query"
-                    },
-                    {
-                        "type": "integer",
-                        "default": 50,
-                        "description": "for response pagination, max items per page",
-                        "name": "perPage",
-                        "in": "query"
+                        "type": "string",
+                        "description": "the secret name",
+                        "name": "secretName",
+                        "in": "path",
+                        "required": true
                     }
                 ],
                 "responses": {
                     "200": {
                         "description": "OK",
                         "schema": {
-                            "type": "array",
-                            "items": {
-                                "$ref": "#/definitions/PullRequest"
-                            }
+                            "$ref": "#/definitions/Secret"
                         }
                     }
                 }
-            }
-        },
-        "/repos/{repo_id}/registries": {
-            "get": {
+            },
+            "delete": {
                 "produces": [
-                    "application/json"
+                    "text/plain"
                 ],
                 "tags": [
-                    "Repository registries"
+                    "Repository secrets"
                 ],
-                "summary": "List registries",
+                "summary": "Delete a repository secret by name",
                 "parameters": [
                     {
                         "type": "string",
@@ -3477,40 +5125,27 @@ const docTemplate = `{
                         "required": true
                     },
                     {
-                        "type": "integer",
-                        "default": 1,
-                        "description": "for response pagination, page offset number",
-                        "name": "page",
-                        "in": "query"
-                    },
-                    {
-                        "type": "integer",
-                        "default": 50,
-                        "description": "for response pagination, max items per page",
-                        "name": "perPage",
-                        "in": "query"
+                        "type": "string",
+                        "description": "the secret name",
+                        "name": "secretName",
+                        "in": "path",
+                        "required": true
                     }
                 ],
                 "responses": {
-                    "200": {
-                        "description": "OK",
-                        "schema": {
-                            "type": "array",
-                            "items": {
-                                "$ref": "#/definitions/Registry"
-                            }
-                        }
+                    "204": {
+                        "description": "No Content"
                     }
                 }
             },
-            "post": {
+            "patch": {
                 "produces": [
                     "application/json"
                 ],
                 "tags": [
-                    "Repository registries"
+                    "Repository secrets"
                 ],
-                "summary": "Create a registry",
+                "summary": "Update a repository secret by name",
                 "parameters": [
                     {
                         "type": "string",
@@ -3528,12 +5163,19 @@ const docTemplate = `{
                         "required": true
                     },
                     {
-                        "description": "the new registry data",
-                        "name": "registry",
+                        "type": "string",
+                        "description": "the secret name",
+                        "name": "secretName",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "the secret itself",
+                        "name": "secret",
                         "in": "body",
                         "required": true,
                         "schema": {
-                            "$ref": "#/definitions/Registry"
+                            "$ref": "#/definitions/Secret"
                         }
                     }
                 ],
@@ -3541,21 +5183,22 @@ const docTemplate = `{
                     "200": {
                         "description": "OK",
                         "schema": {
-                            "$ref": "#/definitions/Registry"
+                            "$ref": "#/definitions/Secret"
                         }
                     }
                 }
             }
         },
-        "/repos/{repo_id}/registries/{registry}": {
+        "/repos/{repo_id}/stats": {
             "get": {
+                "description": "Get the success rate, durations, queue wait time and step failure rates of the finished pipelines of a repository.",
                 "produces": [
                     "application/json"
                 ],
                 "tags": [
-                    "Repository registries"
+                    "Repositories"
                 ],
-                "summary": "Get a registry by name",
+                "summary": "Get repository statistics",
                 "parameters": [
                     {
                         "type": "string",
@@ -3574,29 +5217,49 @@ const docTemplate = `{
                     },
                     {
                         "type": "string",
-                        "description": "the registry name",
-                        "name": "registry",
-                        "in": "path",
-                        "required": true
+                        "description": "only include pipelines before this RFC3339 date, defaults to now",
+                        "name": "before",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "only include pipelines after this RFC3339 date, defaults to 30 days ago",
+                        "name": "after",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "only include pipelines of this branch",
+                        "name": "branch",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "default": "day",
+                        "description": "interval of the history (hour, day or week)",
+                        "name": "interval",
+                        "in": "query"
                     }
                 ],
                 "responses": {
                     "200": {
                         "description": "OK",
                         "schema": {
-                            "$ref": "#/definitions/Registry"
+                            "$ref": "#/definitions/RepoStats"
                         }
                     }
                 }
-            },
-            "delete": {
+            }
+        },
+        "/repos/{repo_id}/webhooks": {
+            "get": {
                 "produces": [
-                    "text/plain"
+                    "application/json"
                 ],
                 "tags": [
-                    "Repository registries"
+                    "Repository webhooks"
                 ],
-                "summary": "Delete a registry by name",
+                "summary": "List repository webhooks",
                 "parameters": [
                     {
                         "type": "string",
@@ -3614,27 +5277,40 @@ const docTemplate = `{
                         "required": true
                     },
                     {
-                        "type": "string",
-                        "description": "the registry name",
-                        "name": "registry",
-                        "in": "path",
-                        "required": true
+                        "type": "integer",
+                        "default": 1,
+                        "description": "for response pagination, page offset number",
+                        "name": "page",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "default": 50,
+                        "description": "for response pagination, max items per page",
+                        "name": "perPage",
+                        "in": "query"
                     }
                 ],
                 "responses": {
-                    "204": {
-                        "description": "No Content"
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "type": "array",
+                            "items": {
+                                "$ref": "#/definitions/Webhook"
+                            }
+                        }
                     }
                 }
             },
-            "patch": {
+            "post": {
                 "produces": [
                     "application/json"
                 ],
                 "tags": [
-                    "Repository registries"
+                    "Repository webhooks"
                 ],
-                "summary": "Update a registry by name",
+                "summary": "Create a repository webhook",
                 "parameters": [
                     {
                         "type": "string",
@@ -3652,19 +5328,12 @@ const docTemplate = `{
                         "required": true
                     },
                     {
-                        "type": "string",
-                        "description": "the registry name",
-                        "name": "registry",
-                        "in": "path",
-                        "required": true
-                    },
-                    {
-                        "description": "the attributes for the registry",
-                        "name": "registryData",
+                        "description": "the new webhook",
+                        "name": "webhook",
                         "in": "body",
                         "required": true,
                         "schema": {
-                            "$ref": "#/definitions/Registry"
+                            "$ref": "#/definitions/WebhookPatch"
                         }
                     }
                 ],
@@ -3672,21 +5341,21 @@ const docTemplate = `{
                     "200": {
                         "description": "OK",
                         "schema": {
-                            "$ref": "#/definitions/Registry"
+                            "$ref": "#/definitions/Webhook"
                         }
                     }
                 }
             }
         },
-        "/repos/{repo_id}/repair": {
-            "post": {
+        "/repos/{repo_id}/webhooks/{webhook_id}": {
+            "get": {
                 "produces": [
-                    "text/plain"
+                    "application/json"
                 ],
                 "tags": [
-                    "Repositories"
+                    "Repository webhooks"
                 ],
-                "summary": "Repair a repository",
+                "summary": "Get a repository webhook",
                 "parameters": [
                     {
                         "type": "string",
@@ -3698,28 +5367,36 @@ const docTemplate = `{
                     },
                     {
                         "type": "integer",
-                        "description": "the repository id",
-                        "name": "repo_id",
+                        "description": "the repository id",
+                        "name": "repo_id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "integer",
+                        "description": "the webhook id",
+                        "name": "webhook_id",
                         "in": "path",
                         "required": true
                     }
                 ],
                 "responses": {
-                    "204": {
-                        "description": "No Content"
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/Webhook"
+                        }
                     }
                 }
-            }
-        },
-        "/repos/{repo_id}/secrets": {
-            "get": {
+            },
+            "delete": {
                 "produces": [
-                    "application/json"
+                    "text/plain"
                 ],
                 "tags": [
-                    "Repository secrets"
+                    "Repository webhooks"
                 ],
-                "summary": "List repository secrets",
+                "summary": "Delete a repository webhook",
                 "parameters": [
                     {
                         "type": "string",
@@ -3738,39 +5415,26 @@ const docTemplate = `{
                     },
                     {
                         "type": "integer",
-                        "default": 1,
-                        "description": "for response pagination, page offset number",
-                        "name": "page",
-                        "in": "query"
-                    },
-                    {
-                        "type": "integer",
-                        "default": 50,
-                        "description": "for response pagination, max items per page",
-                        "name": "perPage",
-                        "in": "query"
+                        "description": "the webhook id",
+                        "name": "webhook_id",
+                        "in": "path",
+                        "required": true
                     }
                 ],
                 "responses": {
-                    "200": {
-                        "description": "OK",
-                        "schema": {
-                            "type": "array",
-                            "items": {
-                                "$ref": "#/definitions/Secret"
-                            }
-                        }
+                    "204": {
+                        "description": "No Content"
                     }
                 }
             },
-            "post": {
+            "patch": {
                 "produces": [
                     "application/json"
                 ],
                 "tags": [
-                    "Repository secrets"
+                    "Repository webhooks"
                 ],
-                "summary": "Create a repository secret",
+                "summary": "Update a repository webhook",
                 "parameters": [
                     {
                         "type": "string",
@@ -3788,12 +5452,19 @@ const docTemplate = `{
                         "required": true
                     },
                     {
-                        "description": "the new secret",
-                        "name": "secret",
+                        "type": "integer",
+                        "description": "the webhook id",
+                        "name": "webhook_id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "the webhook data",
+                        "name": "webhook",
                         "in": "body",
                         "required": true,
                         "schema": {
-                            "$ref": "#/definitions/Secret"
+                            "$ref": "#/definitions/WebhookPatch"
                         }
                     }
                 ],
@@ -3801,21 +5472,21 @@ const docTemplate = `{
                     "200": {
                         "description": "OK",
                         "schema": {
-                            "$ref": "#/definitions/Secret"
+                            "$ref": "#/definitions/Webhook"
                         }
                     }
                 }
             }
         },
-        "/repos/{repo_id}/secrets/{secretName}": {
+        "/repos/{repo_id}/webhooks/{webhook_id}/deliveries": {
             "get": {
                 "produces": [
                     "application/json"
                 ],
                 "tags": [
-                    "Repository secrets"
+                    "Repository webhooks"
                 ],
-                "summary": "Get a repository secret by name",
+                "summary": "List the deliveries of a repository webhook",
                 "parameters": [
                     {
                         "type": "string",
@@ -3833,30 +5504,49 @@ const docTemplate = `{
                         "required": true
                     },
                     {
-                        "type": "string",
-                        "description": "the secret name",
-                        "name": "secretName",
+                        "type": "integer",
+                        "description": "the webhook id",
+                        "name": "webhook_id",
                         "in": "path",
                         "required": true
+                    },
+                    {
+                        "type": "integer",
+                        "default": 1,
+                        "description": "for response pagination, page offset number",
+                        "name": "page",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "default": 50,
+                        "description": "for response pagination, max items per page",
+                        "name": "perPage",
+                        "in": "query"
                     }
                 ],
                 "responses": {
                     "200": {
                         "description": "OK",
                         "schema": {
-                            "$ref": "#/definitions/Secret"
+                            "type": "array",
+                            "items": {
+                                "$ref": "#/definitions/WebhookDelivery"
+                            }
                         }
                     }
                 }
-            },
-            "delete": {
+            }
+        },
+        "/repos/{repo_id}/webhooks/{webhook_id}/deliveries/{delivery_id}/redeliver": {
+            "post": {
                 "produces": [
-                    "text/plain"
+                    "application/json"
                 ],
                 "tags": [
-                    "Repository secrets"
+                    "Repository webhooks"
                 ],
-                "summary": "Delete a repository secret by name",
+                "summary": "Send the payload of a delivery of a repository webhook again",
                 "parameters": [
                     {
                         "type": "string",
@@ -3874,27 +5564,40 @@ const docTemplate = `{
                         "required": true
                     },
                     {
-                        "type": "string",
-                        "description": "the secret name",
-                        "name": "secretName",
+                        "type": "integer",
+                        "description": "the webhook id",
+                        "name": "webhook_id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "integer",
+                        "description": "the delivery id",
+                        "name": "delivery_id",
                         "in": "path",
                         "required": true
                     }
                 ],
                 "responses": {
-                    "204": {
-                        "description": "No Content"
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/WebhookDelivery"
+                        }
                     }
                 }
-            },
-            "patch": {
+            }
+        },
+        "/retention/dry-run": {
+            "get": {
+                "description": "Reports the pipelines and logs the retention policies would remove from all active repositories, without removing anything. Requires admin rights.",
                 "produces": [
                     "application/json"
                 ],
                 "tags": [
-                    "Repository secrets"
+                    "Retention"
                 ],
-                "summary": "Update a repository secret by name",
+                "summary": "Get what the retention policies remove",
                 "parameters": [
                     {
                         "type": "string",
@@ -3903,36 +5606,16 @@ const docTemplate = `{
                         "name": "Authorization",
                         "in": "header",
                         "required": true
-                    },
-                    {
-                        "type": "integer",
-                        "description": "the repository id",
-                        "name": "repo_id",
-                        "in": "path",
-                        "required": true
-                    },
-                    {
-                        "type": "string",
-                        "description": "the secret name",
-                        "name": "secretName",
-                        "in": "path",
-                        "required": true
-                    },
-                    {
-                        "description": "the secret itself",
-                        "name": "secret",
-                        "in": "body",
-                        "required": true,
-                        "schema": {
-                            "$ref": "#/definitions/Secret"
-                        }
                     }
                 ],
                 "responses": {
                     "200": {
                         "description": "OK",
                         "schema": {
-                            "$ref": "#/definitions/Secret"
+                            "type": "array",
+                            "items": {
+                                "$ref": "#/definitions/RetentionReport"
+                            }
                         }
                     }
                 }
@@ -4156,6 +5839,33 @@ const docTemplate = `{
                 }
             }
         },
+        "/signature/user-public-key": {
+            "get": {
+                "description": "The key signs the requests to webhooks and to config extensions of repositories and organizations.",
+                "produces": [
+                    "text/plain"
+                ],
+                "tags": [
+                    "System"
+                ],
+                "summary": "Get server's signature public key for endpoints configured by users",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "default": "Bearer \u003cpersonal access token\u003e",
+                        "description": "Insert your personal access token",
+                        "name": "Authorization",
+                        "in": "header",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK"
+                    }
+                }
+            }
+        },
         "/stream/events": {
             "get": {
                 "description": "With quic and http2 support",
@@ -4294,9 +6004,106 @@ const docTemplate = `{
                         "required": true
                     },
                     {
-                        "type": "boolean",
-                        "description": "query all repos, including inactive ones",
-                        "name": "all",
+                        "type": "boolean",
+                        "description": "query all repos, including inactive ones",
+                        "name": "all",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "type": "array",
+                            "items": {
+                                "$ref": "#/definitions/RepoLastPipeline"
+                            }
+                        }
+                    }
+                }
+            }
+        },
+        "/user/token": {
+            "post": {
+                "produces": [
+                    "text/plain"
+                ],
+                "tags": [
+                    "User"
+                ],
+                "summary": "Return the token of the current user as string",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "default": "Bearer \u003cpersonal access token\u003e",
+                        "description": "Insert your personal access token",
+                        "name": "Authorization",
+                        "in": "header",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK"
+                    }
+                }
+            },
+            "delete": {
+                "description": "Reset's the current personal access token of the user and returns a new one.",
+                "produces": [
+                    "text/plain"
+                ],
+                "tags": [
+                    "User"
+                ],
+                "summary": "Reset a token",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "default": "Bearer \u003cpersonal access token\u003e",
+                        "description": "Insert your personal access token",
+                        "name": "Authorization",
+                        "in": "header",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK"
+                    }
+                }
+            }
+        },
+        "/user/tokens": {
+            "get": {
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "User"
+                ],
+                "summary": "List the personal access tokens of the current user",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "default": "Bearer \u003cpersonal access token\u003e",
+                        "description": "Insert your personal access token",
+                        "name": "Authorization",
+                        "in": "header",
+                        "required": true
+                    },
+                    {
+                        "type": "integer",
+                        "default": 1,
+                        "description": "for response pagination, page offset number",
+                        "name": "page",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "default": 50,
+                        "description": "for response pagination, max items per page",
+                        "name": "perPage",
                         "in": "query"
                     }
                 ],
@@ -4306,22 +6113,21 @@ const docTemplate = `{
                         "schema": {
                             "type": "array",
                             "items": {
-                                "$ref": "#/definitions/RepoLastPipeline"
+                                "$ref": "#/definitions/PersonalAccessToken"
                             }
                         }
                     }
                 }
-            }
-        },
-        "/user/token": {
+            },
             "post": {
+                "description": "Creates a named token with the given scopes, optionally restricted to a repository and with an expiration date (unix timestamp). The token value is only returned once.",
                 "produces": [
-                    "text/plain"
+                    "application/json"
                 ],
                 "tags": [
                     "User"
                 ],
-                "summary": "Return the token of the current user as string",
+                "summary": "Create a personal access token",
                 "parameters": [
                     {
                         "type": "string",
@@ -4330,23 +6136,36 @@ const docTemplate = `{
                         "name": "Authorization",
                         "in": "header",
                         "required": true
+                    },
+                    {
+                        "description": "the token options",
+                        "name": "token",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/PersonalAccessTokenOptions"
+                        }
                     }
                 ],
                 "responses": {
                     "200": {
-                        "description": "OK"
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/PersonalAccessToken"
+                        }
                     }
                 }
-            },
+            }
+        },
+        "/user/tokens/{token_id}": {
             "delete": {
-                "description": "Reset's the current personal access token of the user and returns a new one.",
                 "produces": [
                     "text/plain"
                 ],
                 "tags": [
                     "User"
                 ],
-                "summary": "Reset a token",
+                "summary": "Revoke a personal access token",
                 "parameters": [
                     {
                         "type": "string",
@@ -4355,11 +6174,18 @@ const docTemplate = `{
                         "name": "Authorization",
                         "in": "header",
                         "required": true
+                    },
+                    {
+                        "type": "integer",
+                        "description": "the token id",
+                        "name": "token_id",
+                        "in": "path",
+                        "required": true
                     }
                 ],
                 "responses": {
-                    "200": {
-                        "description": "OK"
+                    "204": {
+                        "description": "No Content"
                     }
                 }
             }
@@ -4649,6 +6475,156 @@ const docTemplate = `{
                 }
             }
         },
+        "Approval": {
+            "type": "object",
+            "properties": {
+                "created": {
+                    "type": "integer"
+                },
+                "id": {
+                    "type": "integer"
+                },
+                "login": {
+                    "type": "string"
+                },
+                "pipeline_id": {
+                    "type": "integer"
+                },
+                "user_id": {
+                    "type": "integer"
+                }
+            }
+        },
+        "ApprovalPolicy": {
+            "type": "object",
+            "properties": {
+                "prevent_self_approval": {
+                    "description": "PreventSelfApproval forbids the author of a pipeline to approve it.",
+                    "type": "boolean"
+                },
+                "required_approvals": {
+                    "description": "RequiredApprovals is the number of distinct users who have to approve a pipeline, at least one.",
+                    "type": "integer"
+                },
+                "teams": {
+                    "description": "Teams limits approvers to members of these teams or organizations of the forge.",
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    }
+                }
+            }
+        },
+        "Artifact": {
+            "type": "object",
+            "properties": {
+                "created": {
+                    "type": "integer"
+                },
+                "id": {
+                    "type": "integer"
+                },
+                "name": {
+                    "type": "string"
+                },
+                "pipeline_id": {
+                    "type": "integer"
+                },
+                "size": {
+                    "type": "integer"
+                },
+                "step_id": {
+                    "type": "integer"
+                }
+            }
+        },
+        "AuditAction": {
+            "type": "string",
+            "enum": [
+                "create",
+                "update",
+                "delete",
+                "approve",
+                "decline",
+                "chown",
+                "reset_token"
+            ],
+            "x-enum-varnames": [
+                "AuditActionCreate",
+                "AuditActionUpdate",
+                "AuditActionDelete",
+                "AuditActionApprove",
+                "AuditActionDecline",
+                "AuditActionChown",
+                "AuditActionResetToken"
+            ]
+        },
+        "AuditEvent": {
+            "type": "object",
+            "properties": {
+                "action": {
+                    "$ref": "#/definitions/AuditAction"
+                },
+                "created": {
+                    "type": "integer"
+                },
+                "details": {
+                    "type": "string"
+                },
+                "id": {
+                    "type": "integer"
+                },
+                "org_id": {
+                    "type": "integer"
+                },
+                "repo_id": {
+                    "description": "RepoID and OrgID are set if the target belongs to a repository or an organization.",
+                    "type": "integer"
+                },
+                "target_id": {
+                    "type": "integer"
+                },
+                "target_name": {
+                    "type": "string"
+                },
+                "target_type": {
+                    "$ref": "#/definitions/AuditTarget"
+                },
+                "user_id": {
+                    "type": "integer"
+                },
+                "user_login": {
+                    "type": "string"
+                }
+            }
+        },
+        "AuditTarget": {
+            "type": "string",
+            "enum": [
+                "secret",
+                "registry",
+                "repo",
+                "pipeline",
+                "agent",
+                "user",
+                "forge",
+                "webhook",
+                "token",
+                "environment"
+            ],
+            "x-enum-varnames": [
+                "AuditTargetSecret",
+                "AuditTargetRegistry",
+                "AuditTargetRepo",
+                "AuditTargetPipeline",
+                "AuditTargetAgent",
+                "AuditTargetUser",
+                "AuditTargetForge",
+                "AuditTargetWebhook",
+                "AuditTargetToken",
+                "AuditTargetEnvironment"
+            ]
+        },
         "Config": {
             "type": "object",
             "properties": {
@@ -4696,6 +6672,77 @@ const docTemplate = `{
                 }
             }
         },
+        "DurationStats": {
+            "type": "object",
+            "properties": {
+                "p50": {
+                    "type": "integer"
+                },
+                "p95": {
+                    "type": "integer"
+                }
+            }
+        },
+        "Environment": {
+            "type": "object",
+            "properties": {
+                "allowed_branches": {
+                    "description": "AllowedBranches are patterns of the branches deployments are allowed from, all branches if empty.",
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    }
+                },
+                "created": {
+                    "type": "integer"
+                },
+                "id": {
+                    "type": "integer"
+                },
+                "name": {
+                    "type": "string"
+                },
+                "repo_id": {
+                    "type": "integer"
+                },
+                "required_approvers": {
+                    "description": "RequiredApprovers are the logins of the users allowed to approve deployments,\nif set every deployment to the environment waits for the approval of one of them.",
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    }
+                },
+                "updated": {
+                    "type": "integer"
+                },
+                "url": {
+                    "type": "string"
+                }
+            }
+        },
+        "EnvironmentPatch": {
+            "type": "object",
+            "properties": {
+                "allowed_branches": {
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    }
+                },
+                "name": {
+                    "type": "string"
+                },
+                "required_approvers": {
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    }
+                },
+                "url": {
+                    "type": "string"
+                }
+            }
+        },
         "Feed": {
             "type": "object",
             "properties": {
@@ -4780,6 +6827,23 @@ const docTemplate = `{
                 }
             }
         },
+        "HookEvent": {
+            "type": "string",
+            "enum": [
+                "pipeline_created",
+                "pipeline_started",
+                "pipeline_finished",
+                "workflow_status",
+                "step_status"
+            ],
+            "x-enum-varnames": [
+                "HookEventPipelineCreated",
+                "HookEventPipelineStarted",
+                "HookEventPipelineFinished",
+                "HookEventWorkflowStatus",
+                "HookEventStepStatus"
+            ]
+        },
         "LogEntry": {
             "type": "object",
             "properties": {
@@ -4826,6 +6890,10 @@ const docTemplate = `{
         "Org": {
             "type": "object",
             "properties": {
+                "config_extension_endpoint": {
+                    "description": "ConfigExtensionEndpoint is used for all repos of the org without an own endpoint.",
+                    "type": "string"
+                },
                 "forge_id": {
                     "type": "integer"
                 },
@@ -4837,6 +6905,20 @@ const docTemplate = `{
                 },
                 "name": {
                     "type": "string"
+                },
+                "retention": {
+                    "$ref": "#/definitions/RetentionPolicy"
+                }
+            }
+        },
+        "OrgPatch": {
+            "type": "object",
+            "properties": {
+                "config_extension_endpoint": {
+                    "type": "string"
+                },
+                "retention": {
+                    "$ref": "#/definitions/RetentionPolicy"
                 }
             }
         },
@@ -4874,9 +6956,72 @@ const docTemplate = `{
                 }
             }
         },
+        "PersonalAccessToken": {
+            "type": "object",
+            "properties": {
+                "created": {
+                    "type": "integer"
+                },
+                "expires": {
+                    "type": "integer"
+                },
+                "id": {
+                    "type": "integer"
+                },
+                "last_used": {
+                    "type": "integer"
+                },
+                "name": {
+                    "type": "string"
+                },
+                "repo_id": {
+                    "description": "RepoID restricts the token to a single repository if set.",
+                    "type": "integer"
+                },
+                "scopes": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/TokenScope"
+                    }
+                },
+                "token": {
+                    "description": "Token is only returned once after creating the token.",
+                    "type": "string"
+                },
+                "user_id": {
+                    "type": "integer"
+                }
+            }
+        },
+        "PersonalAccessTokenOptions": {
+            "type": "object",
+            "properties": {
+                "expires": {
+                    "type": "integer"
+                },
+                "name": {
+                    "type": "string"
+                },
+                "repo_id": {
+                    "type": "integer"
+                },
+                "scopes": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/TokenScope"
+                    }
+                }
+            }
+        },
         "Pipeline": {
             "type": "object",
             "properties": {
+                "approvals": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/Approval"
+                    }
+                },
                 "author": {
                     "type": "string"
                 },
@@ -4928,9 +7073,16 @@ const docTemplate = `{
                 "id": {
                     "type": "integer"
                 },
+                "inputs": {
+                    "type": "object",
+                    "additionalProperties": {}
+                },
                 "is_prerelease": {
                     "type": "boolean"
                 },
+                "logs_deleted": {
+                    "type": "boolean"
+                },
                 "message": {
                     "type": "string"
                 },
@@ -4997,6 +7149,10 @@ const docTemplate = `{
                 "branch": {
                     "type": "string"
                 },
+                "inputs": {
+                    "type": "object",
+                    "additionalProperties": {}
+                },
                 "variables": {
                     "type": "object",
                     "additionalProperties": {
@@ -5060,6 +7216,9 @@ const docTemplate = `{
                         "type": "string"
                     }
                 },
+                "approval_policy": {
+                    "$ref": "#/definitions/ApprovalPolicy"
+                },
                 "avatar_url": {
                     "type": "string"
                 },
@@ -5075,6 +7234,9 @@ const docTemplate = `{
                 "clone_url_ssh": {
                     "type": "string"
                 },
+                "config_extension_endpoint": {
+                    "type": "string"
+                },
                 "config_file": {
                     "type": "string"
                 },
@@ -5097,6 +7259,9 @@ const docTemplate = `{
                 "id": {
                     "type": "integer"
                 },
+                "max_concurrent_pipelines": {
+                    "type": "integer"
+                },
                 "name": {
                     "type": "string"
                 },
@@ -5121,6 +7286,9 @@ const docTemplate = `{
                 "require_approval": {
                     "$ref": "#/definitions/model.ApprovalMode"
                 },
+                "retention": {
+                    "$ref": "#/definitions/RetentionPolicy"
+                },
                 "timeout": {
                     "type": "integer"
                 },
@@ -5150,6 +7318,9 @@ const docTemplate = `{
                         "type": "string"
                     }
                 },
+                "approval_policy": {
+                    "$ref": "#/definitions/ApprovalPolicy"
+                },
                 "avatar_url": {
                     "type": "string"
                 },
@@ -5165,6 +7336,9 @@ const docTemplate = `{
                 "clone_url_ssh": {
                     "type": "string"
                 },
+                "config_extension_endpoint": {
+                    "type": "string"
+                },
                 "config_file": {
                     "type": "string"
                 },
@@ -5190,6 +7364,9 @@ const docTemplate = `{
                 "last_pipeline": {
                     "$ref": "#/definitions/Pipeline"
                 },
+                "max_concurrent_pipelines": {
+                    "type": "integer"
+                },
                 "name": {
                     "type": "string"
                 },
@@ -5214,6 +7391,9 @@ const docTemplate = `{
                 "require_approval": {
                     "$ref": "#/definitions/model.ApprovalMode"
                 },
+                "retention": {
+                    "$ref": "#/definitions/RetentionPolicy"
+                },
                 "timeout": {
                     "type": "integer"
                 },
@@ -5240,15 +7420,24 @@ const docTemplate = `{
                         "type": "string"
                     }
                 },
+                "approval_policy": {
+                    "$ref": "#/definitions/ApprovalPolicy"
+                },
                 "cancel_previous_pipeline_events": {
                     "type": "array",
                     "items": {
                         "$ref": "#/definitions/WebhookEvent"
                     }
                 },
+                "config_extension_endpoint": {
+                    "type": "string"
+                },
                 "config_file": {
                     "type": "string"
                 },
+                "max_concurrent_pipelines": {
+                    "type": "integer"
+                },
                 "netrc_trusted": {
                     "type": "array",
                     "items": {
@@ -5258,6 +7447,9 @@ const docTemplate = `{
                 "require_approval": {
                     "type": "string"
                 },
+                "retention": {
+                    "$ref": "#/definitions/RetentionPolicy"
+                },
                 "timeout": {
                     "type": "integer"
                 },
@@ -5269,6 +7461,68 @@ const docTemplate = `{
                 }
             }
         },
+        "RepoStats": {
+            "type": "object",
+            "properties": {
+                "branch": {
+                    "type": "string"
+                },
+                "duration": {
+                    "description": "Duration of pipelines with status success or failure in seconds, pipelines which ended\nwith an error are ignored. Like QueueWait it is computed from the newest pipelines of the time window only.",
+                    "allOf": [
+                        {
+                            "$ref": "#/definitions/DurationStats"
+                        }
+                    ]
+                },
+                "failed": {
+                    "description": "Failed counts pipelines with status failure or error.",
+                    "type": "integer"
+                },
+                "history": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/StatsBucket"
+                    }
+                },
+                "killed": {
+                    "type": "integer"
+                },
+                "pipelines": {
+                    "description": "Pipelines is the number of finished pipelines, including killed ones.",
+                    "type": "integer"
+                },
+                "queue_wait": {
+                    "description": "QueueWait is the time in seconds pipelines waited after their creation or approval until they started.",
+                    "allOf": [
+                        {
+                            "$ref": "#/definitions/DurationStats"
+                        }
+                    ]
+                },
+                "since": {
+                    "description": "Since and Until are the unix timestamps the time window starts and ends at.",
+                    "type": "integer"
+                },
+                "steps": {
+                    "description": "Steps are sorted by their number of failures, the most failing step first.",
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/StepStats"
+                    }
+                },
+                "success_rate": {
+                    "description": "SuccessRate is the share of successful pipelines of all successful and failed ones.",
+                    "type": "number"
+                },
+                "successful": {
+                    "type": "integer"
+                },
+                "until": {
+                    "type": "integer"
+                }
+            }
+        },
         "RepoVisibility": {
             "type": "string",
             "enum": [
@@ -5282,9 +7536,55 @@ const docTemplate = `{
                 "VisibilityInternal"
             ]
         },
+        "RetentionPolicy": {
+            "type": "object",
+            "properties": {
+                "keep_pipelines_per_branch": {
+                    "description": "KeepPipelinesPerBranch is the number of the latest pipelines kept per branch.",
+                    "type": "integer"
+                },
+                "log_max_age_days": {
+                    "description": "LogMaxAgeDays is the number of days the logs of a finished pipeline are kept.",
+                    "type": "integer"
+                }
+            }
+        },
+        "RetentionReport": {
+            "type": "object",
+            "properties": {
+                "logs": {
+                    "description": "Logs are the numbers of the pipelines whose logs are deleted.",
+                    "type": "array",
+                    "items": {
+                        "type": "integer"
+                    }
+                },
+                "pipelines": {
+                    "description": "Pipelines are the numbers of the pipelines which are deleted.",
+                    "type": "array",
+                    "items": {
+                        "type": "integer"
+                    }
+                },
+                "policy": {
+                    "description": "Policy is the effective policy of the repository.",
+                    "allOf": [
+                        {
+                            "$ref": "#/definitions/RetentionPolicy"
+                        }
+                    ]
+                },
+                "repo_id": {
+                    "type": "integer"
+                }
+            }
+        },
         "Secret": {
             "type": "object",
             "properties": {
+                "environment_id": {
+                    "type": "integer"
+                },
                 "events": {
                     "type": "array",
                     "items": {
@@ -5314,6 +7614,23 @@ const docTemplate = `{
                 }
             }
         },
+        "StatsBucket": {
+            "type": "object",
+            "properties": {
+                "duration": {
+                    "$ref": "#/definitions/DurationStats"
+                },
+                "pipelines": {
+                    "type": "integer"
+                },
+                "start": {
+                    "type": "integer"
+                },
+                "success_rate": {
+                    "type": "number"
+                }
+            }
+        },
         "StatusValue": {
             "type": "string",
             "enum": [
@@ -5356,6 +7673,12 @@ const docTemplate = `{
         "Step": {
             "type": "object",
             "properties": {
+                "attempts": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/StepAttempt"
+                    }
+                },
                 "error": {
                     "type": "string"
                 },
@@ -5365,6 +7688,9 @@ const docTemplate = `{
                 "finished": {
                     "type": "integer"
                 },
+                "flakiness": {
+                    "type": "number"
+                },
                 "id": {
                     "type": "integer"
                 },
@@ -5394,6 +7720,73 @@ const docTemplate = `{
                 }
             }
         },
+        "StepAttempt": {
+            "type": "object",
+            "properties": {
+                "error": {
+                    "type": "string"
+                },
+                "exit_code": {
+                    "type": "integer"
+                },
+                "finished": {
+                    "type": "integer"
+                },
+                "log_lines": {
+                    "description": "LogLines is the number of log lines of the step at the end of the attempt,\nthe logs of the next attempt start at this line.",
+                    "type": "integer"
+                }
+            }
+        },
+        "StepFlakiness": {
+            "type": "object",
+            "properties": {
+                "flakes": {
+                    "type": "integer"
+                },
+                "id": {
+                    "type": "integer"
+                },
+                "last_flake": {
+                    "type": "integer"
+                },
+                "name": {
+                    "type": "string"
+                },
+                "repo_id": {
+                    "type": "integer"
+                },
+                "runs": {
+                    "type": "integer"
+                },
+                "score": {
+                    "type": "number"
+                },
+                "workflow": {
+                    "type": "string"
+                }
+            }
+        },
+        "StepStats": {
+            "type": "object",
+            "properties": {
+                "failure_rate": {
+                    "type": "number"
+                },
+                "failures": {
+                    "type": "integer"
+                },
+                "name": {
+                    "type": "string"
+                },
+                "total": {
+                    "type": "integer"
+                },
+                "workflow": {
+                    "type": "string"
+                }
+            }
+        },
         "StepType": {
             "type": "string",
             "enum": [
@@ -5417,6 +7810,9 @@ const docTemplate = `{
                 "agent_id": {
                     "type": "integer"
                 },
+                "concurrency_group": {
+                    "type": "string"
+                },
                 "dep_status": {
                     "type": "object",
                     "additionalProperties": {
@@ -5438,6 +7834,16 @@ const docTemplate = `{
                         "type": "string"
                     }
                 },
+                "max_concurrent_pipelines": {
+                    "type": "integer"
+                },
+                "pipeline_id": {
+                    "type": "integer"
+                },
+                "repo_id": {
+                    "description": "RepoID, PipelineID, MaxConcurrentPipelines and ConcurrencyGroup are used to limit\nhow many tasks of a repository run at the same time.",
+                    "type": "integer"
+                },
                 "run_on": {
                     "type": "array",
                     "items": {
@@ -5446,6 +7852,21 @@ const docTemplate = `{
                 }
             }
         },
+        "TokenScope": {
+            "type": "string",
+            "enum": [
+                "all",
+                "read",
+                "pipeline:write",
+                "secrets:admin"
+            ],
+            "x-enum-varnames": [
+                "TokenScopeAll",
+                "TokenScopeRead",
+                "TokenScopePipelineWrite",
+                "TokenScopeSecretsAdmin"
+            ]
+        },
         "User": {
             "type": "object",
             "properties": {
@@ -5478,6 +7899,94 @@ const docTemplate = `{
                 }
             }
         },
+        "Webhook": {
+            "type": "object",
+            "properties": {
+                "active": {
+                    "type": "boolean"
+                },
+                "created": {
+                    "type": "integer"
+                },
+                "events": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/HookEvent"
+                    }
+                },
+                "id": {
+                    "type": "integer"
+                },
+                "org_id": {
+                    "type": "integer"
+                },
+                "repo_id": {
+                    "type": "integer"
+                },
+                "updated": {
+                    "type": "integer"
+                },
+                "url": {
+                    "type": "string"
+                }
+            }
+        },
+        "WebhookDelivery": {
+            "type": "object",
+            "properties": {
+                "attempts": {
+                    "type": "integer"
+                },
+                "created": {
+                    "type": "integer"
+                },
+                "duration": {
+                    "description": "of the last attempt in milliseconds",
+                    "type": "integer"
+                },
+                "error": {
+                    "description": "Error is only the class of the error, neither the response nor the error message is stored\nas they could disclose information about the network of the server.",
+                    "allOf": [
+                        {
+                            "$ref": "#/definitions/WebhookDeliveryError"
+                        }
+                    ]
+                },
+                "event": {
+                    "$ref": "#/definitions/HookEvent"
+                },
+                "id": {
+                    "type": "integer"
+                },
+                "payload": {
+                    "type": "string"
+                },
+                "status_code": {
+                    "type": "integer"
+                },
+                "success": {
+                    "type": "boolean"
+                },
+                "webhook_id": {
+                    "type": "integer"
+                }
+            }
+        },
+        "WebhookDeliveryError": {
+            "type": "string",
+            "enum": [
+                "forbidden_address",
+                "timeout",
+                "connection_failed",
+                "unexpected_status"
+            ],
+            "x-enum-varnames": [
+                "WebhookDeliveryErrorForbiddenAddress",
+                "WebhookDeliveryErrorTimeout",
+                "WebhookDeliveryErrorConnection",
+                "WebhookDeliveryErrorStatus"
+            ]
+        },
         "WebhookEvent": {
             "type": "string",
             "enum": [
@@ -5501,6 +8010,23 @@ const docTemplate = `{
                 "EventManual"
             ]
         },
+        "WebhookPatch": {
+            "type": "object",
+            "properties": {
+                "active": {
+                    "type": "boolean"
+                },
+                "events": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/HookEvent"
+                    }
+                },
+                "url": {
+                    "type": "string"
+                }
+            }
+        },
         "metadata.Author": {
             "type": "object",
             "properties": {
@@ -5530,6 +8056,9 @@ const docTemplate = `{
                         "type": "string"
                     }
                 },
+                "from_fork": {
+                    "type": "boolean"
+                },
                 "is_prerelease": {
                     "type": "boolean"
                 },
@@ -5614,6 +8143,10 @@ const docTemplate = `{
                 "forge_url": {
                     "type": "string"
                 },
+                "inputs": {
+                    "type": "object",
+                    "additionalProperties": {}
+                },
                 "number": {
                     "type": "integer"
                 },
@@ -34,10 +34,12 @@ import (
 
 	"go.woodpecker-ci.org/woodpecker/v3/server"
 	"go.woodpecker-ci.org/woodpecker/v3/server/cron"
+	"go.woodpecker-ci.org/woodpecker/v3/server/retention"
 	"go.woodpecker-ci.org/woodpecker/v3/server/router"
 	"go.woodpecker-ci.org/woodpecker/v3/server/router/middleware"
 	"go.woodpecker-ci.org/woodpecker/v3/server/web"
 	"go.woodpecker-ci.org/woodpecker/v3/shared/logger"
+	"go.woodpecker-ci.org/woodpecker/v3/shared/tracing"
 	"go.woodpecker-ci.org/woodpecker/v3/version"
 )
 
@@ -68,6 +70,16 @@ func run(ctx context.Context, c *cli.Command) error {
 	defer stopServerFunc(nil)
 	defer shutdownCancelFunc()
 
+	shutdownTracing, err := tracing.Setup(ctx, c, "woodpecker-server")
+	if err != nil {
+		return fmt.Errorf("can't setup tracing: %w", err)
+	}
+	defer func() {
+		if err := shutdownTracing(shutdownCtx); err != nil { //nolint:contextcheck
+			log.Error().Err(err).Msg("could not flush traces")
+		}
+	}()
+
 	// set gin mode based on log level
 	if zerolog.GlobalLevel() > zerolog.DebugLevel {
 		gin.SetMode(gin.ReleaseMode)
@@ -121,6 +133,17 @@ func run(ctx context.Context, c *cli.Command) error {
 		return nil
 	})
 
+	serviceWaitingGroup.Go(func() error {
+		log.Info().Msg("starting retention service ...")
+		janitor := retention.New(_store, server.Config.Services.LogStore, server.Config.Services.ArtifactStore, server.Config.Retention.Default)
+		if err := janitor.Run(ctx, server.Config.Retention.Interval); err != nil {
+			go stopServerFunc(err)
+			return err
+		}
+		log.Info().Msg("retention service stopped")
+		return nil
+	})
+
 	// start the grpc server
 	serviceWaitingGroup.Go(func() error {
 		log.Info().Msg("starting grpc server ...")
@@ -33,16 +33,23 @@ import (
 	"go.woodpecker-ci.org/woodpecker/v3/server/cache"
 	"go.woodpecker-ci.org/woodpecker/v3/server/forge/setup"
 	"go.woodpecker-ci.org/woodpecker/v3/server/logging"
+	"go.woodpecker-ci.org/woodpecker/v3/server/metrics"
 	"go.woodpecker-ci.org/woodpecker/v3/server/model"
 	"go.woodpecker-ci.org/woodpecker/v3/server/pubsub"
 	"go.woodpecker-ci.org/woodpecker/v3/server/queue"
 	"go.woodpecker-ci.org/woodpecker/v3/server/services"
+	"go.woodpecker-ci.org/woodpecker/v3/server/services/artifact"
+	artifact_file "go.woodpecker-ci.org/woodpecker/v3/server/services/artifact/file"
+	artifact_s3 "go.woodpecker-ci.org/woodpecker/v3/server/services/artifact/s3"
 	logService "go.woodpecker-ci.org/woodpecker/v3/server/services/log"
 	"go.woodpecker-ci.org/woodpecker/v3/server/services/log/file"
+	"go.woodpecker-ci.org/woodpecker/v3/server/services/log/s3"
+	"go.woodpecker-ci.org/woodpecker/v3/server/services/oidc"
 	"go.woodpecker-ci.org/woodpecker/v3/server/services/permissions"
 	"go.woodpecker-ci.org/woodpecker/v3/server/store"
 	"go.woodpecker-ci.org/woodpecker/v3/server/store/datastore"
 	"go.woodpecker-ci.org/woodpecker/v3/server/store/types"
+	s3client "go.woodpecker-ci.org/woodpecker/v3/shared/s3"
 )
 
 const (
@@ -106,13 +113,24 @@ func checkSqliteFileExist(path string) error {
 	return err
 }
 
-func setupQueue(ctx context.Context, s store.Store) (queue.Queue, error) {
+func setupQueue(ctx context.Context, c *cli.Command, s store.Store) (queue.Queue, error) {
 	return queue.New(ctx, queue.Config{
-		Backend: queue.TypeMemory,
+		Backend: queue.Type(c.String("queue-backend")),
 		Store:   s,
 	})
 }
 
+func setupPubsub(ctx context.Context, c *cli.Command, s store.Store) (logging.Log, pubsub.Publisher, error) {
+	switch c.String("pubsub-backend") {
+	case "memory":
+		return logging.New(), pubsub.New(), nil
+	case "database":
+		return logging.NewPubsub(ctx, pubsub.NewDatabase(ctx, s, "logs")), pubsub.NewDatabase(ctx, s, "events"), nil
+	default:
+		return nil, nil, fmt.Errorf("unsupported pubsub backend: %s", c.String("pubsub-backend"))
+	}
+}
+
 func setupMembershipService(_ context.Context, _store store.Store) cache.MembershipService {
 	return cache.NewMembershipService(_store)
 }
@@ -120,12 +138,45 @@ func setupMembershipService(_ context.Context, _store store.Store) cache.Members
 func setupLogStore(c *cli.Command, s store.Store) (logService.Service, error) {
 	switch c.String("log-store") {
 	case "file":
-		return file.NewLogStore(c.String("log-store-file-path"))
+		return file.NewLogStore(
+			c.String("log-store-file-path"),
+			file.Compression(c.String("log-store-file-compression")),
+			c.Int("log-store-file-max-step-size"),
+		)
+	case "s3":
+		return s3.NewLogStore(s3client.Config{
+			Endpoint:  c.String("log-store-s3-endpoint"),
+			Region:    c.String("log-store-s3-region"),
+			Bucket:    c.String("log-store-s3-bucket"),
+			AccessKey: c.String("log-store-s3-access-key"),
+			SecretKey: c.String("log-store-s3-secret-key"),
+			PathStyle: c.Bool("log-store-s3-path-style"),
+		}, c.String("log-store-s3-prefix"))
 	default:
 		return s, nil
 	}
 }
 
+func setupArtifactStore(c *cli.Command) (artifact.Service, error) {
+	switch c.String("artifact-store") {
+	case "", "none":
+		return nil, nil
+	case "file":
+		return artifact_file.NewArtifactStore(c.String("artifact-store-file-path"))
+	case "s3":
+		return artifact_s3.NewArtifactStore(s3client.Config{
+			Endpoint:  c.String("artifact-store-s3-endpoint"),
+			Region:    c.String("artifact-store-s3-region"),
+			Bucket:    c.String("artifact-store-s3-bucket"),
+			AccessKey: c.String("artifact-store-s3-access-key"),
+			SecretKey: c.String("artifact-store-s3-secret-key"),
+			PathStyle: c.Bool("artifact-store-s3-path-style"),
+		}, c.String("artifact-store-s3-prefix"))
+	default:
+		return nil, fmt.Errorf("unsupported artifact store: %s", c.String("artifact-store"))
+	}
+}
+
 const jwtSecretID = "jwt-secret"
 
 func setupJWTSecret(_store store.Store) (string, error) {
@@ -151,10 +202,12 @@ func setupJWTSecret(_store store.Store) (string, error) {
 
 func setupEvilGlobals(ctx context.Context, c *cli.Command, s store.Store) (err error) {
 	// services
-	server.Config.Services.Logs = logging.New()
-	server.Config.Services.Pubsub = pubsub.New()
+	server.Config.Services.Logs, server.Config.Services.Pubsub, err = setupPubsub(ctx, c, s)
+	if err != nil {
+		return fmt.Errorf("could not setup pubsub: %w", err)
+	}
 	server.Config.Services.Membership = setupMembershipService(ctx, s)
-	server.Config.Services.Queue, err = setupQueue(ctx, s)
+	server.Config.Services.Queue, err = setupQueue(ctx, c, s)
 	if err != nil {
 		return fmt.Errorf("could not setup queue: %w", err)
 	}
@@ -166,6 +219,10 @@ func setupEvilGlobals(ctx context.Context, c *cli.Command, s store.Store) (err e
 	if err != nil {
 		return fmt.Errorf("could not setup log store: %w", err)
 	}
+	server.Config.Services.ArtifactStore, err = setupArtifactStore(c)
+	if err != nil {
+		return fmt.Errorf("could not setup artifact store: %w", err)
+	}
 
 	// agents
 	server.Config.Agent.DisableUserRegisteredAgentRegistration = c.Bool("disable-user-agent-registration")
@@ -187,6 +244,14 @@ func setupEvilGlobals(ctx context.Context, c *cli.Command, s store.Store) (err e
 	server.Config.Pipeline.DefaultCancelPreviousPipelineEvents = events
 	server.Config.Pipeline.DefaultTimeout = c.Int("default-pipeline-timeout")
 	server.Config.Pipeline.MaxTimeout = c.Int("max-pipeline-timeout")
+	server.Config.Pipeline.MaxArtifactSize = c.Int("artifact-store-max-size")
+
+	// Retention
+	server.Config.Retention.Default = model.RetentionPolicy{
+		KeepPipelinesPerBranch: c.Int("retention-keep-pipelines-per-branch"),
+		LogMaxAgeDays:          c.Int("retention-log-max-age-days"),
+	}
+	server.Config.Retention.Interval = c.Duration("retention-interval")
 
 	_labels := c.StringSlice("default-workflow-labels")
 	labels := make(map[string]string, len(_labels))
@@ -219,6 +284,11 @@ func setupEvilGlobals(ctx context.Context, c *cli.Command, s store.Store) (err e
 	} else {
 		server.Config.Server.WebhookHost = serverHost
 	}
+	oidcKey, err := oidc.LoadOrCreateKey(s)
+	if err != nil {
+		return fmt.Errorf("could not setup oidc key: %w", err)
+	}
+	server.Config.Services.OIDC = oidc.New(serverHost, oidcKey)
 	server.Config.Server.OAuthHost = serverHost
 	server.Config.Server.Port = c.String("server-addr")
 	server.Config.Server.PortTLS = c.String("server-addr-tls")
@@ -241,6 +311,7 @@ func setupEvilGlobals(ctx context.Context, c *cli.Command, s store.Store) (err e
 
 	// prometheus
 	server.Config.Prometheus.AuthToken = c.String("prometheus-auth-token")
+	metrics.SetMaxRepoLabels(int(c.Int("prometheus-max-repo-labels")))
 
 	// permissions
 	server.Config.Permissions.Open = c.Bool("open")
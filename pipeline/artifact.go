@@ -0,0 +1,93 @@
+// Copyright 2025 Woodpecker Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package pipeline
+
+import (
+	"archive/tar"
+	"bytes"
+	"errors"
+	"fmt"
+	"io"
+	"strings"
+
+	backend "go.woodpecker-ci.org/woodpecker/v3/pipeline/backend/types"
+)
+
+// ArtifactUploader uploads the content of a file of the workspace as artifact of a step.
+type ArtifactUploader func(step *backend.Step, name string, size int64, content io.Reader) error
+
+// uploadArtifacts uploads the files of the artifact paths of a step which exited.
+// Failed uploads are written to the step log but do not fail the step.
+func (r *Runtime) uploadArtifacts(step *backend.Step) {
+	if len(step.Artifacts) == 0 {
+		return
+	}
+
+	var out bytes.Buffer
+	r.runUploadArtifacts(step, &out)
+
+	if r.logger != nil {
+		if err := r.logger(step, io.NopCloser(&out)); err != nil {
+			logger := r.MakeLogger()
+			logger.Error().Err(err).Msg("artifact logging failed")
+		}
+	}
+}
+
+func (r *Runtime) runUploadArtifacts(step *backend.Step, out io.Writer) {
+	if r.artifactUploader == nil {
+		fmt.Fprintln(out, "artifacts are not supported by this agent, skip uploading artifacts")
+		return
+	}
+	engine, ok := r.engine.(backend.WorkspaceBackend)
+	if !ok {
+		fmt.Fprintf(out, "backend %s does not support artifacts, skip uploading artifacts\n", r.engine.Name())
+		return
+	}
+
+	archive, err := engine.ArchiveWorkspace(r.ctx, step, r.taskUUID, step.Artifacts)
+	if err != nil {
+		fmt.Fprintf(out, "could not read artifacts: %s\n", err)
+		return
+	}
+	defer archive.Close()
+
+	uploaded := 0
+	tr := tar.NewReader(archive)
+	for {
+		header, err := tr.Next()
+		if errors.Is(err, io.EOF) {
+			break
+		}
+		if err != nil {
+			fmt.Fprintf(out, "could not read artifacts: %s\n", err)
+			return
+		}
+		if header.Typeflag != tar.TypeReg {
+			continue
+		}
+
+		if err := r.artifactUploader(step, header.Name, header.Size, tr); err != nil {
+			fmt.Fprintf(out, "could not upload artifact '%s': %s\n", header.Name, err)
+			continue
+		}
+		fmt.Fprintf(out, "uploaded artifact '%s' (%d bytes)\n", header.Name, header.Size)
+		uploaded++
+	}
+
+	if uploaded == 0 {
+		fmt.Fprintf(out, "no artifacts found for %s\n", strings.Join(step.Artifacts, ", "))
+	}
+}
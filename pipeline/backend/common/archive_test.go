@@ -0,0 +1,68 @@
+// Copyright 2025 Woodpecker Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package common
+
+import (
+	"archive/tar"
+	"bytes"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestArchiveRoundTrip(t *testing.T) {
+	src := t.TempDir()
+	require.NoError(t, os.MkdirAll(filepath.Join(src, "node_modules", "pkg"), 0o755))
+	require.NoError(t, os.WriteFile(filepath.Join(src, "node_modules", "pkg", "index.js"), []byte("module.exports = 1"), 0o644))
+	require.NoError(t, os.WriteFile(filepath.Join(src, "other.txt"), []byte("not cached"), 0o644))
+
+	var buf bytes.Buffer
+	require.NoError(t, ArchivePaths(&buf, src, []string{"node_modules", "missing"}))
+
+	dst := t.TempDir()
+	require.NoError(t, ExtractArchive(&buf, dst))
+
+	data, err := os.ReadFile(filepath.Join(dst, "node_modules", "pkg", "index.js"))
+	assert.NoError(t, err)
+	assert.Equal(t, "module.exports = 1", string(data))
+	assert.NoFileExists(t, filepath.Join(dst, "other.txt"))
+}
+
+func TestArchivePathsOutsideDir(t *testing.T) {
+	assert.Error(t, ArchivePaths(&bytes.Buffer{}, t.TempDir(), []string{"../etc"}))
+	assert.Error(t, ArchivePaths(&bytes.Buffer{}, t.TempDir(), []string{"/etc"}))
+}
+
+func TestExtractArchiveOutsideDir(t *testing.T) {
+	archive := func(headers ...*tar.Header) *bytes.Buffer {
+		var buf bytes.Buffer
+		tw := tar.NewWriter(&buf)
+		for _, header := range headers {
+			require.NoError(t, tw.WriteHeader(header))
+		}
+		require.NoError(t, tw.Close())
+		return &buf
+	}
+
+	dir := t.TempDir()
+	assert.Error(t, ExtractArchive(archive(&tar.Header{Name: "../evil", Typeflag: tar.TypeReg, Mode: 0o644}), dir))
+	assert.Error(t, ExtractArchive(archive(
+		&tar.Header{Name: "link", Typeflag: tar.TypeSymlink, Linkname: "/tmp"},
+		&tar.Header{Name: "link/evil", Typeflag: tar.TypeReg, Mode: 0o644},
+	), dir))
+}
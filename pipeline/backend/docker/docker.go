@@ -21,6 +21,7 @@ import (
 	"os"
 	"path/filepath"
 	"strings"
+	"time"
 
 	"github.com/docker/docker/api/types/container"
 	"github.com/docker/docker/api/types/image"
@@ -164,13 +165,42 @@ func (e *docker) SetupWorkflow(ctx context.Context, conf *backend.Config, taskUU
 }
 
 func (e *docker) StartStep(ctx context.Context, step *backend.Step, taskUUID string) error {
+	log.Trace().Str("taskUUID", taskUUID).Msgf("start step %s", step.Name)
+
+	containerName := toContainerName(step)
+	if err := e.createContainer(ctx, step); err != nil {
+		return err
+	}
+
+	if len(step.NetworkMode) == 0 {
+		for _, net := range step.Networks {
+			err := e.client.NetworkConnect(ctx, net.Name, containerName, &network.EndpointSettings{
+				Aliases: net.Aliases,
+			})
+			if err != nil {
+				return err
+			}
+		}
+
+		// join the container to an existing network
+		if e.config.network != "" {
+			err := e.client.NetworkConnect(ctx, e.config.network, containerName, &network.EndpointSettings{})
+			if err != nil {
+				return err
+			}
+		}
+	}
+
+	return e.client.ContainerStart(ctx, containerName, container.StartOptions{})
+}
+
+// createContainer creates the container of a step and pulls its image if required.
+func (e *docker) createContainer(ctx context.Context, step *backend.Step) error {
 	options, err := parseBackendOptions(step)
 	if err != nil {
 		log.Error().Err(err).Msg("could not parse backend options")
 	}
 
-	log.Trace().Str("taskUUID", taskUUID).Msgf("start step %s", step.Name)
-
 	config := e.toConfig(step, options)
 	hostConfig := toHostConfig(step, &e.config)
 	containerName := toContainerName(step)
@@ -184,6 +214,7 @@ func (e *docker) StartStep(ctx context.Context, step *backend.Step, taskUUID str
 	// automatically pull the latest version of the image if requested
 	// by the process configuration.
 	if step.Pull {
+		pullStarted := time.Now()
 		responseBody, pErr := e.client.ImagePull(ctx, config.Image, pullOpts)
 		if pErr == nil {
 			// TODO(1936): show image pull progress in web-ui
@@ -192,6 +223,7 @@ func (e *docker) StartStep(ctx context.Context, step *backend.Step, taskUUID str
 				log.Error().Err(err).Msg("DisplayJSONMessagesStream")
 			}
 			responseBody.Close()
+			imagePullDuration.Observe(time.Since(pullStarted).Seconds())
 		}
 		// Fix "Show warning when fail to auth to docker registry"
 		// (https://web.archive.org/web/20201023145804/https://github.com/drone/drone/issues/1917)
@@ -207,6 +239,7 @@ func (e *docker) StartStep(ctx context.Context, step *backend.Step, taskUUID str
 	if client.IsErrNotFound(err) {
 		// automatically pull and try to re-create the image if the
 		// failure is caused because the image does not exist.
+		pullStarted := time.Now()
 		responseBody, pErr := e.client.ImagePull(ctx, config.Image, pullOpts)
 		if pErr != nil {
 			return pErr
@@ -217,33 +250,11 @@ func (e *docker) StartStep(ctx context.Context, step *backend.Step, taskUUID str
 			log.Error().Err(err).Msg("DisplayJSONMessagesStream")
 		}
 		responseBody.Close()
+		imagePullDuration.Observe(time.Since(pullStarted).Seconds())
 
 		_, err = e.client.ContainerCreate(ctx, config, hostConfig, nil, nil, containerName)
 	}
-	if err != nil {
-		return err
-	}
-
-	if len(step.NetworkMode) == 0 {
-		for _, net := range step.Networks {
-			err = e.client.NetworkConnect(ctx, net.Name, containerName, &network.EndpointSettings{
-				Aliases: net.Aliases,
-			})
-			if err != nil {
-				return err
-			}
-		}
-
-		// join the container to an existing network
-		if e.config.network != "" {
-			err = e.client.NetworkConnect(ctx, e.config.network, containerName, &network.EndpointSettings{})
-			if err != nil {
-				return err
-			}
-		}
-	}
-
-	return e.client.ContainerStart(ctx, containerName, container.StartOptions{})
+	return err
 }
 
 func (e *docker) WaitStep(ctx context.Context, step *backend.Step, taskUUID string) (*backend.State, error) {
@@ -0,0 +1,29 @@
+// Copyright 2025 Woodpecker Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package docker
+
+import (
+	"github.com/prometheus/client_golang/prometheus"
+	prometheus_auto "github.com/prometheus/client_golang/prometheus/promauto"
+)
+
+var imagePullDuration = prometheus_auto.NewHistogram(prometheus.HistogramOpts{
+	Namespace:   "woodpecker",
+	Subsystem:   "agent",
+	Name:        "image_pull_duration_seconds",
+	Help:        "Duration of image pulls.",
+	ConstLabels: prometheus.Labels{"backend": EngineName},
+	Buckets:     prometheus.ExponentialBuckets(0.5, 2, 12), //nolint:mnd
+})
@@ -0,0 +1,125 @@
+// Copyright 2025 Woodpecker Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package docker
+
+import (
+	"archive/tar"
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"path"
+	"strings"
+
+	"github.com/docker/docker/api/types/container"
+	"github.com/moby/moby/client"
+	"github.com/rs/zerolog/log"
+
+	backend "go.woodpecker-ci.org/woodpecker/v3/pipeline/backend/types"
+)
+
+// ArchiveWorkspace returns a tar archive of the given paths of the workspace volume.
+// The files are copied out of the (never started) container of the step.
+func (e *docker) ArchiveWorkspace(ctx context.Context, step *backend.Step, taskUUID string, paths []string) (io.ReadCloser, error) {
+	log.Trace().Str("taskUUID", taskUUID).Msgf("archive workspace for step %s", step.Name)
+
+	for _, p := range paths {
+		if !isLocalPath(p) {
+			return nil, fmt.Errorf("path '%s' is not relative to the workspace", p)
+		}
+	}
+
+	if err := e.createContainer(ctx, step); err != nil {
+		return nil, err
+	}
+
+	rc, wc := io.Pipe()
+	go func() {
+		err := e.archivePaths(ctx, toContainerName(step), step.WorkingDir, paths, wc)
+		if rmErr := e.DestroyStep(ctx, step, taskUUID); rmErr != nil {
+			log.Error().Err(rmErr).Msgf("could not remove container of step %s", step.Name)
+		}
+		_ = wc.CloseWithError(err)
+	}()
+	return rc, nil
+}
+
+// ExtractWorkspace extracts a tar archive into the workspace volume using the (never started) container of the step.
+func (e *docker) ExtractWorkspace(ctx context.Context, step *backend.Step, taskUUID string, archive io.Reader) error {
+	log.Trace().Str("taskUUID", taskUUID).Msgf("extract workspace for step %s", step.Name)
+
+	if err := e.createContainer(ctx, step); err != nil {
+		return err
+	}
+	defer func() {
+		if err := e.DestroyStep(ctx, step, taskUUID); err != nil {
+			log.Error().Err(err).Msgf("could not remove container of step %s", step.Name)
+		}
+	}()
+
+	return e.client.CopyToContainer(ctx, toContainerName(step), step.WorkingDir, archive, container.CopyToContainerOptions{})
+}
+
+// archivePaths combines the archives docker returns for every path into one archive with names relative to dir.
+func (e *docker) archivePaths(ctx context.Context, containerName, dir string, paths []string, w io.Writer) error {
+	tw := tar.NewWriter(w)
+	for _, p := range paths {
+		p = path.Clean(p)
+		content, _, err := e.client.CopyFromContainer(ctx, containerName, path.Join(dir, p))
+		if client.IsErrNotFound(err) {
+			continue
+		}
+		if err != nil {
+			return err
+		}
+
+		// docker names the entries relative to the parent of the copied path
+		err = rebaseArchive(tw, content, path.Dir(p))
+		content.Close()
+		if err != nil {
+			return err
+		}
+	}
+	return tw.Close()
+}
+
+func rebaseArchive(tw *tar.Writer, r io.Reader, base string) error {
+	tr := tar.NewReader(r)
+	for {
+		header, err := tr.Next()
+		if errors.Is(err, io.EOF) {
+			return nil
+		}
+		if err != nil {
+			return err
+		}
+
+		header.Name = path.Join(base, header.Name)
+		if header.Typeflag == tar.TypeDir {
+			header.Name += "/"
+		}
+		if err := tw.WriteHeader(header); err != nil {
+			return err
+		}
+		if _, err := io.Copy(tw, tr); err != nil {
+			return err
+		}
+	}
+}
+
+func isLocalPath(p string) bool {
+	p = path.Clean(p)
+	return p != ".." && !path.IsAbs(p) && !strings.HasPrefix(p, "../")
+}
@@ -141,7 +141,11 @@ func (e *dummy) WaitStep(ctx context.Context, step *backend.Step, taskUUID strin
 			err = fmt.Errorf("WaitStep fail to parse sleep duration: %w", err)
 			return &backend.State{Error: err}, err
 		}
-		time.Sleep(toSleep)
+		select {
+		case <-time.After(toSleep):
+		case <-ctx.Done():
+			return nil, ctx.Err()
+		}
 	} else {
 		if step.Type == backend.StepTypeService {
 			select {
@@ -0,0 +1,47 @@
+// Copyright 2025 Woodpecker Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package local
+
+import (
+	"context"
+	"io"
+
+	"go.woodpecker-ci.org/woodpecker/v3/pipeline/backend/common"
+	"go.woodpecker-ci.org/woodpecker/v3/pipeline/backend/types"
+)
+
+// ArchiveWorkspace returns a tar archive of the given paths of the workspace directory.
+func (e *local) ArchiveWorkspace(_ context.Context, _ *types.Step, taskUUID string, paths []string) (io.ReadCloser, error) {
+	state, err := e.getState(taskUUID)
+	if err != nil {
+		return nil, err
+	}
+
+	rc, wc := io.Pipe()
+	go func() {
+		_ = wc.CloseWithError(common.ArchivePaths(wc, state.workspaceDir, paths))
+	}()
+	return rc, nil
+}
+
+// ExtractWorkspace extracts a tar archive into the workspace directory.
+func (e *local) ExtractWorkspace(_ context.Context, _ *types.Step, taskUUID string, archive io.Reader) error {
+	state, err := e.getState(taskUUID)
+	if err != nil {
+		return err
+	}
+
+	return common.ExtractArchive(archive, state.workspaceDir)
+}
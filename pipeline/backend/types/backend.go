@@ -56,6 +56,17 @@ type Backend interface {
 	DestroyWorkflow(ctx context.Context, conf *Config, taskUUID string) error
 }
 
+// WorkspaceBackend is implemented by backends which give access to the files
+// of the workflow workspace, it is required to restore and save caches and to upload artifacts.
+type WorkspaceBackend interface {
+	// ArchiveWorkspace returns a tar archive of the given paths relative to the
+	// workspace of the step. Paths which do not exist are skipped.
+	ArchiveWorkspace(ctx context.Context, step *Step, taskUUID string, paths []string) (io.ReadCloser, error)
+
+	// ExtractWorkspace extracts a tar archive into the workspace of the step.
+	ExtractWorkspace(ctx context.Context, step *Step, taskUUID string, archive io.Reader) error
+}
+
 // BackendInfo represents the reported information of a loaded backend.
 type BackendInfo struct {
 	Platform string
@@ -14,6 +14,8 @@
 
 package types
 
+import "time"
+
 // Step defines a container process.
 type Step struct {
 	Name           string            `json:"name"`
@@ -38,12 +40,45 @@ type Step struct {
 	OnFailure      bool              `json:"on_failure,omitempty"`
 	OnSuccess      bool              `json:"on_success,omitempty"`
 	Failure        string            `json:"failure,omitempty"`
+	Retry          *Retry            `json:"retry,omitempty"`
+	Cache          *Cache            `json:"cache,omitempty"`
+	Artifacts      []string          `json:"artifacts,omitempty"`
 	AuthConfig     Auth              `json:"auth_config,omitempty"`
 	NetworkMode    string            `json:"network_mode,omitempty"`
 	Ports          []Port            `json:"ports,omitempty"`
 	BackendOptions map[string]any    `json:"backend_options,omitempty"`
 }
 
+// Retry defines how often a failed step gets restarted.
+type Retry struct {
+	Count int `json:"count"`
+	// Backoff is the time to wait before the first restart, it is doubled for every further restart.
+	Backoff time.Duration `json:"backoff,omitempty"`
+	// ExitCodes limits the restarts to these exit codes, all non zero exit codes are retried if empty.
+	ExitCodes []int `json:"exit_codes,omitempty"`
+}
+
+// Cache defines the caches a step of type cache restores or saves.
+type Cache struct {
+	// Save is set if the caches get saved, otherwise they are restored.
+	Save bool `json:"save,omitempty"`
+	// Scope separates the caches of different repositories and branches, caches are saved to it.
+	Scope string `json:"scope"`
+	// RestoreScopes are only restored from if there is no cache in the scope, e.g. the default branch.
+	RestoreScopes []string     `json:"restore_scopes,omitempty"`
+	Entries       []CacheEntry `json:"entries"`
+}
+
+// CacheEntry is a set of paths which is cached under a key.
+type CacheEntry struct {
+	// Key is a template which gets evaluated against the workspace, e.g. to hash lock files.
+	Key string `json:"key"`
+	// RestoreKeys are key prefixes used to restore the most recent cache if there is none for the key.
+	RestoreKeys []string `json:"restore_keys,omitempty"`
+	// Paths are relative to the workspace.
+	Paths []string `json:"paths"`
+}
+
 // StepType identifies the type of step.
 type StepType string
 
@@ -0,0 +1,244 @@
+// Copyright 2025 Woodpecker Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package cache restores and saves the caches of workflows.
+package cache
+
+import (
+	"archive/tar"
+	"compress/gzip"
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"io"
+	"runtime"
+	"strings"
+	"text/template"
+
+	backend "go.woodpecker-ci.org/woodpecker/v3/pipeline/backend/types"
+)
+
+// ErrNotFound is returned by a store if there is no cache for a key.
+var ErrNotFound = errors.New("cache not found")
+
+const maxKeyLength = 512
+
+// Store persists cache archives.
+type Store interface {
+	// Get returns the archive saved for key or ErrNotFound.
+	Get(ctx context.Context, key string) (io.ReadCloser, error)
+	// Put saves the archive for key.
+	Put(ctx context.Context, key string, archive io.Reader) error
+	// List returns all keys starting with prefix, the most recently saved first.
+	List(ctx context.Context, prefix string) ([]string, error)
+}
+
+// Workspace gives access to the files of a workflow workspace.
+type Workspace interface {
+	// Archive returns a tar archive of the given paths.
+	Archive(ctx context.Context, paths []string) (io.ReadCloser, error)
+	// Extract extracts a tar archive into the workspace.
+	Extract(ctx context.Context, archive io.Reader) error
+}
+
+// Restore restores the cache of entry into the workspace. It falls back to the most recent cache
+// matching one of the restore keys if there is none for the key. The scopes are checked in order,
+// the next scope is only used if there is no matching cache in the previous one. A cache miss is not an error.
+func Restore(ctx context.Context, store Store, workspace Workspace, scopes []string, entry backend.CacheEntry, out io.Writer) error {
+	key, err := ResolveKey(ctx, workspace, entry.Key)
+	if err != nil {
+		return err
+	}
+
+	var archive io.ReadCloser
+	restored := key
+	err = ErrNotFound
+	for _, scope := range scopes {
+		archive, err = store.Get(ctx, scopedKey(scope, key))
+		if errors.Is(err, ErrNotFound) {
+			archive, restored, err = findByPrefix(ctx, store, workspace, scope, entry.RestoreKeys)
+		}
+		if !errors.Is(err, ErrNotFound) {
+			break
+		}
+	}
+	if errors.Is(err, ErrNotFound) {
+		fmt.Fprintf(out, "cache miss for key '%s'\n", key)
+		return nil
+	}
+	if err != nil {
+		return err
+	}
+	defer archive.Close()
+
+	gz, err := gzip.NewReader(archive)
+	if err != nil {
+		return err
+	}
+	defer gz.Close()
+
+	if err := workspace.Extract(ctx, gz); err != nil {
+		return err
+	}
+	fmt.Fprintf(out, "restored cache for key '%s'\n", restored)
+	return nil
+}
+
+// Save saves the paths of entry from the workspace. Caches are immutable,
+// so nothing is saved if there already is a cache for the key.
+func Save(ctx context.Context, store Store, workspace Workspace, scope string, entry backend.CacheEntry, out io.Writer) error {
+	key, err := ResolveKey(ctx, workspace, entry.Key)
+	if err != nil {
+		return err
+	}
+
+	keys, err := store.List(ctx, scopedKey(scope, key))
+	if err != nil {
+		return err
+	}
+	for _, k := range keys {
+		if k == scopedKey(scope, key) {
+			fmt.Fprintf(out, "cache for key '%s' already exists, skip saving\n", key)
+			return nil
+		}
+	}
+
+	archive, err := workspace.Archive(ctx, entry.Paths)
+	if err != nil {
+		return err
+	}
+	defer archive.Close()
+
+	r, w := io.Pipe()
+	go func() {
+		gz := gzip.NewWriter(w)
+		_, err := io.Copy(gz, archive)
+		if err == nil {
+			err = gz.Close()
+		}
+		_ = w.CloseWithError(err)
+	}()
+
+	if err := store.Put(ctx, scopedKey(scope, key), r); err != nil {
+		_ = r.CloseWithError(err)
+		return err
+	}
+	fmt.Fprintf(out, "saved cache for key '%s'\n", key)
+	return nil
+}
+
+// ResolveKey evaluates a key template. Besides the functions of text/template it supports:
+//   - checksum: the sha256 hash of the content of the given files, e.g. {{ checksum "go.sum" }}
+//   - os and arch: the platform of the agent, e.g. {{ os }}-{{ arch }}
+func ResolveKey(ctx context.Context, workspace Workspace, key string) (string, error) {
+	tmpl, err := template.New("key").Funcs(template.FuncMap{
+		"checksum": func(files ...string) (string, error) {
+			return checksum(ctx, workspace, files)
+		},
+		"os":   func() string { return runtime.GOOS },
+		"arch": func() string { return runtime.GOARCH },
+	}).Parse(key)
+	if err != nil {
+		return "", fmt.Errorf("invalid cache key '%s': %w", key, err)
+	}
+
+	var sb strings.Builder
+	if err := tmpl.Execute(&sb, nil); err != nil {
+		return "", fmt.Errorf("invalid cache key '%s': %w", key, err)
+	}
+	return sb.String(), validateKey(sb.String())
+}
+
+func validateKey(key string) error {
+	if key == "" {
+		return errors.New("cache key is empty")
+	}
+	if len(key) > maxKeyLength {
+		return fmt.Errorf("cache key is longer than %d characters", maxKeyLength)
+	}
+	if strings.ContainsFunc(key, func(r rune) bool { return r < ' ' }) {
+		return fmt.Errorf("cache key '%s' contains control characters", key)
+	}
+	for _, segment := range strings.Split(key, "/") {
+		if segment == "." || segment == ".." {
+			return fmt.Errorf("cache key '%s' contains relative path segments", key)
+		}
+	}
+	return nil
+}
+
+func checksum(ctx context.Context, workspace Workspace, files []string) (string, error) {
+	archive, err := workspace.Archive(ctx, files)
+	if err != nil {
+		return "", err
+	}
+	defer archive.Close()
+
+	found := false
+	hash := sha256.New()
+	tr := tar.NewReader(archive)
+	for {
+		header, err := tr.Next()
+		if errors.Is(err, io.EOF) {
+			break
+		}
+		if err != nil {
+			return "", err
+		}
+		if header.Typeflag != tar.TypeReg {
+			continue
+		}
+		found = true
+		fmt.Fprintf(hash, "%s\x00", header.Name)
+		if _, err := io.Copy(hash, tr); err != nil {
+			return "", err
+		}
+	}
+	if !found {
+		return "", fmt.Errorf("checksum: none of the files %s exists", strings.Join(files, ", "))
+	}
+
+	return hex.EncodeToString(hash.Sum(nil)), nil
+}
+
+// findByPrefix returns the most recent cache matching one of the prefixes, the prefixes are checked in order.
+func findByPrefix(ctx context.Context, store Store, workspace Workspace, scope string, prefixes []string) (io.ReadCloser, string, error) {
+	for _, prefix := range prefixes {
+		prefix, err := ResolveKey(ctx, workspace, prefix)
+		if err != nil {
+			return nil, "", err
+		}
+
+		keys, err := store.List(ctx, scopedKey(scope, prefix))
+		if err != nil {
+			return nil, "", err
+		}
+		if len(keys) == 0 {
+			continue
+		}
+
+		archive, err := store.Get(ctx, keys[0])
+		if err != nil {
+			return nil, "", err
+		}
+		return archive, strings.TrimPrefix(keys[0], scopedKey(scope, "")), nil
+	}
+	return nil, "", ErrNotFound
+}
+
+func scopedKey(scope, key string) string {
+	return scope + "/" + key
+}
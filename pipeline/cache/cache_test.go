@@ -0,0 +1,142 @@
+// Copyright 2025 Woodpecker Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package cache
+
+import (
+	"bytes"
+	"context"
+	"io"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"go.woodpecker-ci.org/woodpecker/v3/pipeline/backend/common"
+	backend "go.woodpecker-ci.org/woodpecker/v3/pipeline/backend/types"
+	"go.woodpecker-ci.org/woodpecker/v3/shared/s3"
+	"go.woodpecker-ci.org/woodpecker/v3/shared/s3/fixtures"
+)
+
+type dirWorkspace string
+
+func (d dirWorkspace) Archive(_ context.Context, paths []string) (io.ReadCloser, error) {
+	var buf bytes.Buffer
+	err := common.ArchivePaths(&buf, string(d), paths)
+	return io.NopCloser(&buf), err
+}
+
+func (d dirWorkspace) Extract(_ context.Context, archive io.Reader) error {
+	return common.ExtractArchive(archive, string(d))
+}
+
+func writeFile(t *testing.T, dir, name, content string) {
+	require.NoError(t, os.MkdirAll(filepath.Join(dir, filepath.Dir(name)), 0o755))
+	require.NoError(t, os.WriteFile(filepath.Join(dir, name), []byte(content), 0o644))
+}
+
+func TestResolveKey(t *testing.T) {
+	ctx := context.Background()
+	ws := dirWorkspace(t.TempDir())
+	writeFile(t, string(ws), "go.sum", "v1")
+
+	key, err := ResolveKey(ctx, ws, `go-{{ checksum "go.sum" }}`)
+	assert.NoError(t, err)
+	assert.Len(t, key, len("go-")+64)
+
+	writeFile(t, string(ws), "go.sum", "v2")
+	changed, err := ResolveKey(ctx, ws, `go-{{ checksum "go.sum" }}`)
+	assert.NoError(t, err)
+	assert.NotEqual(t, key, changed)
+
+	_, err = ResolveKey(ctx, ws, `go-{{ checksum "missing.sum" }}`)
+	assert.Error(t, err)
+	_, err = ResolveKey(ctx, ws, `go-{{ unknown }}`)
+	assert.Error(t, err)
+	_, err = ResolveKey(ctx, ws, `../other-repo/key`)
+	assert.Error(t, err)
+	_, err = ResolveKey(ctx, ws, ``)
+	assert.Error(t, err)
+}
+
+func testStore(t *testing.T, store Store) {
+	ctx := context.Background()
+	var out bytes.Buffer
+
+	src := dirWorkspace(t.TempDir())
+	writeFile(t, string(src), "package-lock.json", "lock")
+	writeFile(t, string(src), "node_modules/pkg/index.js", "cached")
+	entry := backend.CacheEntry{
+		Key:         `npm-{{ checksum "package-lock.json" }}`,
+		RestoreKeys: []string{"npm-"},
+		Paths:       []string{"node_modules"},
+	}
+
+	// nothing to restore yet
+	assert.NoError(t, Restore(ctx, store, src, []string{"1"}, entry, &out))
+	assert.Contains(t, out.String(), "cache miss")
+
+	assert.NoError(t, Save(ctx, store, src, "1", entry, &out))
+	assert.Contains(t, out.String(), "saved cache")
+	assert.NoError(t, Save(ctx, store, src, "1", entry, &out))
+	assert.Contains(t, out.String(), "already exists")
+
+	// exact match
+	dst := dirWorkspace(t.TempDir())
+	writeFile(t, string(dst), "package-lock.json", "lock")
+	assert.NoError(t, Restore(ctx, store, dst, []string{"1"}, entry, &out))
+	data, err := os.ReadFile(filepath.Join(string(dst), "node_modules/pkg/index.js"))
+	assert.NoError(t, err)
+	assert.Equal(t, "cached", string(data))
+
+	// fallback to restore key
+	dst = dirWorkspace(t.TempDir())
+	writeFile(t, string(dst), "package-lock.json", "changed")
+	assert.NoError(t, Restore(ctx, store, dst, []string{"1"}, entry, &out))
+	assert.FileExists(t, filepath.Join(string(dst), "node_modules/pkg/index.js"))
+
+	// caches of other scopes are not restored
+	dst = dirWorkspace(t.TempDir())
+	writeFile(t, string(dst), "package-lock.json", "lock")
+	assert.NoError(t, Restore(ctx, store, dst, []string{"2"}, entry, &out))
+	assert.NoFileExists(t, filepath.Join(string(dst), "node_modules/pkg/index.js"))
+
+	// but the ones of restore scopes
+	assert.NoError(t, Restore(ctx, store, dst, []string{"2", "1"}, entry, &out))
+	assert.FileExists(t, filepath.Join(string(dst), "node_modules/pkg/index.js"))
+}
+
+func TestLocalStore(t *testing.T) {
+	store, err := NewLocal(t.TempDir())
+	require.NoError(t, err)
+	testStore(t, store)
+}
+
+func TestS3Store(t *testing.T) {
+	server := httptest.NewServer(fixtures.Handler())
+	defer server.Close()
+
+	store, err := NewS3(s3.Config{
+		Endpoint:  server.URL,
+		Bucket:    "woodpecker",
+		AccessKey: "access",
+		SecretKey: "secret",
+		PathStyle: true,
+	}, "cache")
+	require.NoError(t, err)
+	testStore(t, store)
+}
@@ -0,0 +1,110 @@
+// Copyright 2025 Woodpecker Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package cache
+
+import (
+	"context"
+	"errors"
+	"io"
+	"io/fs"
+	"net/url"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"time"
+)
+
+const archiveExtension = ".tar.gz"
+
+type local struct {
+	dir string
+}
+
+// NewLocal returns a store which keeps the caches as files in a local directory.
+func NewLocal(dir string) (Store, error) {
+	if err := os.MkdirAll(dir, 0o700); err != nil {
+		return nil, err
+	}
+	return &local{dir: dir}, nil
+}
+
+func (l *local) Get(_ context.Context, key string) (io.ReadCloser, error) {
+	f, err := os.Open(l.path(key))
+	if errors.Is(err, fs.ErrNotExist) {
+		return nil, ErrNotFound
+	}
+	if err != nil {
+		return nil, err
+	}
+	return f, nil
+}
+
+func (l *local) Put(_ context.Context, key string, archive io.Reader) error {
+	tmp, err := os.CreateTemp(l.dir, ".upload-*")
+	if err != nil {
+		return err
+	}
+	defer os.Remove(tmp.Name())
+
+	if _, err := io.Copy(tmp, archive); err != nil {
+		tmp.Close()
+		return err
+	}
+	if err := tmp.Close(); err != nil {
+		return err
+	}
+	return os.Rename(tmp.Name(), l.path(key))
+}
+
+func (l *local) List(_ context.Context, prefix string) ([]string, error) {
+	entries, err := os.ReadDir(l.dir)
+	if err != nil {
+		return nil, err
+	}
+
+	type file struct {
+		key      string
+		modified time.Time
+	}
+	var files []file
+	for _, entry := range entries {
+		name, ok := strings.CutSuffix(entry.Name(), archiveExtension)
+		if !ok || entry.IsDir() {
+			continue
+		}
+		key, err := url.PathUnescape(name)
+		if err != nil || !strings.HasPrefix(key, prefix) {
+			continue
+		}
+		info, err := entry.Info()
+		if err != nil {
+			continue
+		}
+		files = append(files, file{key: key, modified: info.ModTime()})
+	}
+	sort.SliceStable(files, func(i, j int) bool { return files[i].modified.After(files[j].modified) })
+
+	keys := make([]string, 0, len(files))
+	for _, f := range files {
+		keys = append(keys, f.key)
+	}
+	return keys, nil
+}
+
+func (l *local) path(key string) string {
+	// keys contain slashes, escape them to keep one flat directory
+	return filepath.Join(l.dir, url.PathEscape(key)+archiveExtension)
+}
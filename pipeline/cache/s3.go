@@ -0,0 +1,98 @@
+// Copyright 2025 Woodpecker Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package cache
+
+import (
+	"context"
+	"errors"
+	"io"
+	"os"
+	"sort"
+	"strings"
+
+	"go.woodpecker-ci.org/woodpecker/v3/shared/s3"
+)
+
+type s3Store struct {
+	client *s3.Client
+	prefix string
+}
+
+// NewS3 returns a store which keeps the caches in an S3 compatible object storage below prefix.
+func NewS3(config s3.Config, prefix string) (Store, error) {
+	client, err := s3.New(config)
+	if err != nil {
+		return nil, err
+	}
+	return &s3Store{client: client, prefix: strings.Trim(prefix, "/")}, nil
+}
+
+func (s *s3Store) Get(ctx context.Context, key string) (io.ReadCloser, error) {
+	archive, err := s.client.Get(ctx, s.objectKey(key))
+	if errors.Is(err, s3.ErrNotFound) {
+		return nil, ErrNotFound
+	}
+	return archive, err
+}
+
+func (s *s3Store) Put(ctx context.Context, key string, archive io.Reader) error {
+	// the size has to be known before the upload, so spool the archive to disk
+	tmp, err := os.CreateTemp("", "woodpecker-cache-*")
+	if err != nil {
+		return err
+	}
+	defer os.Remove(tmp.Name())
+	defer tmp.Close()
+
+	size, err := io.Copy(tmp, archive)
+	if err != nil {
+		return err
+	}
+	if _, err := tmp.Seek(0, io.SeekStart); err != nil {
+		return err
+	}
+
+	return s.client.PutStream(ctx, s.objectKey(key), tmp, size, "application/gzip")
+}
+
+func (s *s3Store) List(ctx context.Context, prefix string) ([]string, error) {
+	objects, err := s.client.List(ctx, s.object(prefix))
+	if err != nil {
+		return nil, err
+	}
+	sort.SliceStable(objects, func(i, j int) bool { return objects[i].LastModified.After(objects[j].LastModified) })
+
+	keys := make([]string, 0, len(objects))
+	for _, object := range objects {
+		key, ok := strings.CutSuffix(object.Key, archiveExtension)
+		if !ok {
+			continue
+		}
+		keys = append(keys, strings.TrimPrefix(key, s.object("")))
+	}
+	return keys, nil
+}
+
+func (s *s3Store) objectKey(key string) string {
+	return s.object(key) + archiveExtension
+}
+
+// object prepends the prefix, keys are not cleaned as that could move them out of their scope.
+func (s *s3Store) object(name string) string {
+	if s.prefix == "" {
+		return name
+	}
+	return s.prefix + "/" + name
+}
@@ -0,0 +1,90 @@
+// Copyright 2025 Woodpecker Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package pipeline
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"io"
+
+	backend "go.woodpecker-ci.org/woodpecker/v3/pipeline/backend/types"
+	"go.woodpecker-ci.org/woodpecker/v3/pipeline/cache"
+)
+
+// execCache restores or saves the caches of a step of type cache on the agent itself.
+// Cache errors are written to the step log but do not fail the step,
+// as a missing cache only slows the workflow down.
+func (r *Runtime) execCache(step *backend.Step) (*backend.State, error) {
+	var out bytes.Buffer
+	r.runCache(step, &out)
+
+	if r.ctx.Err() != nil {
+		return nil, ErrCancel
+	}
+
+	if r.logger != nil {
+		if err := r.logger(step, io.NopCloser(&out)); err != nil {
+			logger := r.MakeLogger()
+			logger.Error().Err(err).Msg("cache logging failed")
+		}
+	}
+
+	return &backend.State{Exited: true}, nil
+}
+
+func (r *Runtime) runCache(step *backend.Step, out io.Writer) {
+	if step.Cache == nil {
+		return
+	}
+	if r.cache == nil {
+		fmt.Fprintln(out, "no cache store is configured for this agent, skip caching")
+		return
+	}
+	engine, ok := r.engine.(backend.WorkspaceBackend)
+	if !ok {
+		fmt.Fprintf(out, "backend %s does not support caching, skip caching\n", r.engine.Name())
+		return
+	}
+
+	workspace := &stepWorkspace{engine: engine, step: step, taskUUID: r.taskUUID}
+	for _, entry := range step.Cache.Entries {
+		if step.Cache.Save {
+			if err := cache.Save(r.ctx, r.cache, workspace, step.Cache.Scope, entry, out); err != nil {
+				fmt.Fprintf(out, "could not save cache '%s': %s\n", entry.Key, err)
+			}
+		} else {
+			scopes := append([]string{step.Cache.Scope}, step.Cache.RestoreScopes...)
+			if err := cache.Restore(r.ctx, r.cache, workspace, scopes, entry, out); err != nil {
+				fmt.Fprintf(out, "could not restore cache '%s': %s\n", entry.Key, err)
+			}
+		}
+	}
+}
+
+// stepWorkspace gives the cache access to the workspace of a step.
+type stepWorkspace struct {
+	engine   backend.WorkspaceBackend
+	step     *backend.Step
+	taskUUID string
+}
+
+func (w *stepWorkspace) Archive(ctx context.Context, paths []string) (io.ReadCloser, error) {
+	return w.engine.ArchiveWorkspace(ctx, w.step, w.taskUUID, paths)
+}
+
+func (w *stepWorkspace) Extract(ctx context.Context, archive io.Reader) error {
+	return w.engine.ExtractWorkspace(ctx, w.step, w.taskUUID, archive)
+}
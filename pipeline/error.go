@@ -27,6 +27,10 @@ var (
 	// ErrCancel is used as a return value when the container execution receives
 	// a cancellation signal from the context.
 	ErrCancel = errors.New("Canceled")
+
+	// errCanceledByStep is used as cancellation cause if a step with failure
+	// mode cancel failed and stops all other steps of the workflow.
+	errCanceledByStep = errors.New("Canceled by failed step")
 )
 
 // An ExitError reports an unsuccessful exit.
@@ -16,8 +16,11 @@ package pipeline
 
 import (
 	"testing"
+	"time"
 
 	"github.com/stretchr/testify/assert"
+
+	backend "go.woodpecker-ci.org/woodpecker/v3/pipeline/backend/types"
 )
 
 func TestExitError(t *testing.T) {
@@ -34,3 +37,30 @@ func TestOomError(t *testing.T) {
 	}
 	assert.Equal(t, "uuid=14534321: received oom kill", err.Error())
 }
+
+func TestShouldRetry(t *testing.T) {
+	step := &backend.Step{UUID: "14534321", Retry: &backend.Retry{Count: 2}}
+
+	assert.True(t, shouldRetry(step, 1, &ExitError{Code: 1}))
+	assert.True(t, shouldRetry(step, 2, &OomError{Code: 137}))
+	assert.False(t, shouldRetry(step, 3, &ExitError{Code: 1}), "retry count exceeded")
+	assert.False(t, shouldRetry(step, 1, nil), "step succeeded")
+	assert.False(t, shouldRetry(step, 1, ErrCancel), "pipeline canceled")
+	assert.False(t, shouldRetry(&backend.Step{}, 1, &ExitError{Code: 1}), "no retry configured")
+
+	step.Retry.ExitCodes = []int{137}
+	assert.False(t, shouldRetry(step, 1, &ExitError{Code: 1}))
+	assert.True(t, shouldRetry(step, 1, &ExitError{Code: 137}))
+}
+
+func TestRetryBackoff(t *testing.T) {
+	step := &backend.Step{Retry: &backend.Retry{Count: 1000, Backoff: 10 * time.Second}}
+
+	assert.Equal(t, 10*time.Second, retryBackoff(step, 1))
+	assert.Equal(t, 40*time.Second, retryBackoff(step, 3))
+	assert.Equal(t, maxRetryBackoff, retryBackoff(step, 100))
+	assert.Equal(t, maxRetryBackoff, retryBackoff(step, 1000))
+
+	step.Retry.Backoff = 24 * time.Hour
+	assert.Equal(t, maxRetryBackoff, retryBackoff(step, 1))
+}
@@ -28,9 +28,8 @@ const (
 
 // Different ways to handle failure states.
 const (
-	FailureIgnore = "ignore"
-	FailureFail   = "fail"
-	//nolint:godot
-	// TODO: Not implemented yet.
-	// FailureCancel = "cancel"
+	FailureIgnore         = "ignore"
+	FailureFail           = "fail"
+	FailureCancel         = "cancel"
+	FailureCancelPipeline = "cancel_pipeline"
 )
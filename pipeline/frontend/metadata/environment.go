@@ -74,6 +74,10 @@ func (m *Metadata) Environ() map[string]string {
 	setNonEmptyEnvVar(params, "CI_PIPELINE_DEPLOY_TASK", pipeline.DeployTask)
 	setNonEmptyEnvVar(params, "CI_PIPELINE_CREATED", strconv.FormatInt(pipeline.Created, 10))
 	setNonEmptyEnvVar(params, "CI_PIPELINE_STARTED", strconv.FormatInt(pipeline.Started, 10))
+	for name, value := range pipeline.Inputs {
+		// inputs are set even if empty, as an empty string can be a valid value
+		params["CI_INPUT_"+strings.ToUpper(name)] = inputEnvValue(value)
+	}
 
 	workflow := m.Workflow
 	setNonEmptyEnvVar(params, "CI_WORKFLOW_NAME", workflow.Name)
@@ -176,6 +180,19 @@ func getSourceTargetBranches(refspec string) (string, string) {
 	return sourceBranch, targetBranch
 }
 
+func inputEnvValue(value any) string {
+	switch v := value.(type) {
+	case string:
+		return v
+	case bool:
+		return strconv.FormatBool(v)
+	case float64:
+		return strconv.FormatFloat(v, 'f', -1, 64)
+	default:
+		return fmt.Sprint(v)
+	}
+}
+
 func setNonEmptyEnvVar(env map[string]string, key, value string) {
 	if len(value) > 0 {
 		env[key] = value
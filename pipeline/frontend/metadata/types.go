@@ -43,18 +43,19 @@ type (
 
 	// Pipeline defines runtime metadata for a pipeline.
 	Pipeline struct {
-		Number     int64  `json:"number,omitempty"`
-		Created    int64  `json:"created,omitempty"`
-		Started    int64  `json:"started,omitempty"`
-		Finished   int64  `json:"finished,omitempty"`
-		Status     string `json:"status,omitempty"`
-		Event      string `json:"event,omitempty"`
-		ForgeURL   string `json:"forge_url,omitempty"`
-		DeployTo   string `json:"target,omitempty"`
-		DeployTask string `json:"task,omitempty"`
-		Commit     Commit `json:"commit,omitempty"`
-		Parent     int64  `json:"parent,omitempty"`
-		Cron       string `json:"cron,omitempty"`
+		Number     int64          `json:"number,omitempty"`
+		Created    int64          `json:"created,omitempty"`
+		Started    int64          `json:"started,omitempty"`
+		Finished   int64          `json:"finished,omitempty"`
+		Status     string         `json:"status,omitempty"`
+		Event      string         `json:"event,omitempty"`
+		ForgeURL   string         `json:"forge_url,omitempty"`
+		DeployTo   string         `json:"target,omitempty"`
+		DeployTask string         `json:"task,omitempty"`
+		Commit     Commit         `json:"commit,omitempty"`
+		Parent     int64          `json:"parent,omitempty"`
+		Cron       string         `json:"cron,omitempty"`
+		Inputs     map[string]any `json:"inputs,omitempty"`
 	}
 
 	// Commit defines runtime metadata for a commit.
@@ -67,6 +68,7 @@ type (
 		Author            Author   `json:"author,omitempty"`
 		ChangedFiles      []string `json:"changed_files,omitempty"`
 		PullRequestLabels []string `json:"labels,omitempty"`
+		FromFork          bool     `json:"from_fork,omitempty"`
 		IsPrerelease      bool     `json:"is_prerelease,omitempty"`
 	}
 
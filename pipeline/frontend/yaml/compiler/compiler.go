@@ -16,7 +16,10 @@ package compiler
 
 import (
 	"fmt"
+	"net/url"
 	"path"
+	"slices"
+	"strconv"
 
 	backend_types "go.woodpecker-ci.org/woodpecker/v3/pipeline/backend/types"
 	"go.woodpecker-ci.org/woodpecker/v3/pipeline/frontend/metadata"
@@ -26,7 +29,9 @@ import (
 )
 
 const (
-	defaultCloneName = "clone"
+	defaultCloneName        = "clone"
+	defaultRestoreCacheName = "restore-cache"
+	defaultSaveCacheName    = "save-cache"
 )
 
 // Registry represents registry credentials.
@@ -98,6 +103,8 @@ type Compiler struct {
 	defaultClonePlugin      string
 	trustedClonePlugins     []string
 	securityTrustedPipeline bool
+	oidcTokenIssuer         OIDCTokenIssuer
+	oidcTokens              map[string]string
 }
 
 // New creates a new Compiler with options.
@@ -106,6 +113,7 @@ func New(opts ...Option) *Compiler {
 		env:                 map[string]string{},
 		cloneEnv:            map[string]string{},
 		secrets:             map[string]Secret{},
+		oidcTokens:          map[string]string{},
 		defaultClonePlugin:  constant.DefaultClonePlugin,
 		trustedClonePlugins: constant.TrustedClonePlugins,
 	}
@@ -207,6 +215,15 @@ func (c *Compiler) Compile(conf *yaml_types.Workflow) (*backend_types.Config, er
 		}
 	}
 
+	// add cache restore step
+	if len(conf.Cache) != 0 {
+		step, err := c.createCacheStep(defaultRestoreCacheName, conf.Cache, false)
+		if err != nil {
+			return nil, err
+		}
+		config.Stages = append(config.Stages, &backend_types.Stage{Steps: []*backend_types.Step{step}})
+	}
+
 	// add services steps
 	if len(conf.Services.ContainerList) != 0 {
 		stage := new(backend_types.Stage)
@@ -274,5 +291,109 @@ func (c *Compiler) Compile(conf *yaml_types.Workflow) (*backend_types.Config, er
 
 	config.Stages = append(config.Stages, stepStages...)
 
+	// add cache save step, pull requests of forks control their own config and must not write caches
+	if len(conf.Cache) != 0 && !c.metadata.Curr.Commit.FromFork {
+		step, err := c.createCacheStep(defaultSaveCacheName, conf.Cache, true)
+		if err != nil {
+			return nil, err
+		}
+		config.Stages = append(config.Stages, &backend_types.Stage{Steps: []*backend_types.Step{step}})
+	}
+
+	// mask the oidc tokens like secrets
+	for _, token := range c.oidcTokens {
+		config.Secrets = append(config.Secrets, &backend_types.Secret{
+			Name:  oidcTokenEnv,
+			Value: token,
+		})
+	}
+
 	return config, nil
 }
+
+// oidcToken returns the OIDC identity token of the workflow for the audience,
+// every audience gets one token per workflow.
+func (c *Compiler) oidcToken(audience string) (string, error) {
+	if token, ok := c.oidcTokens[audience]; ok {
+		return token, nil
+	}
+	if c.oidcTokenIssuer == nil {
+		return "", fmt.Errorf("oidc tokens are not available")
+	}
+
+	token, err := c.oidcTokenIssuer(audience)
+	if err != nil {
+		return "", fmt.Errorf("could not issue oidc token: %w", err)
+	}
+	c.oidcTokens[audience] = token
+	return token, nil
+}
+
+// createCacheStep creates a step restoring or saving the caches. It is executed by the agent itself,
+// the image is only used by backends which need a container to access the workspace.
+func (c *Compiler) createCacheStep(name string, caches []yaml_types.Cache, save bool) (*backend_types.Step, error) {
+	image := c.defaultClonePlugin
+	if image == "" {
+		image = constant.DefaultClonePlugin
+	}
+
+	step, err := c.createProcess(&yaml_types.Container{Name: name, Image: image}, backend_types.StepTypeCache)
+	if err != nil {
+		return nil, err
+	}
+
+	scope, restoreScopes := c.cacheScopes()
+	step.Cache = &backend_types.Cache{Save: save, Scope: scope}
+	if !save {
+		step.Cache.RestoreScopes = restoreScopes
+	}
+	for _, cache := range caches {
+		step.Cache.Entries = append(step.Cache.Entries, backend_types.CacheEntry{
+			Key:         cache.Key,
+			RestoreKeys: cache.RestoreKeys,
+			Paths:       cache.Paths,
+		})
+	}
+	return step, nil
+}
+
+// cacheScopes returns the scope caches are saved to and the scopes they are restored from otherwise.
+// Caches are separated by branch, so pipelines of pull requests can not overwrite the caches of
+// other branches, but fall back to the caches of their target and the default branch.
+func (c *Compiler) cacheScopes() (string, []string) {
+	repo := path.Join(c.metadata.Repo.Owner, c.metadata.Repo.Name)
+	if c.metadata.Repo.ID != 0 {
+		repo = strconv.FormatInt(c.metadata.Repo.ID, 10)
+	}
+	branchScope := func(branch string) string {
+		if branch == "" {
+			return ""
+		}
+		return repo + "/branch/" + url.PathEscape(branch)
+	}
+
+	commit := c.metadata.Curr.Commit
+	var scope string
+	var candidates []string
+	switch {
+	case c.metadata.Curr.Event == metadata.EventPull || c.metadata.Curr.Event == metadata.EventPullClosed:
+		// the branch of pull requests is their target branch
+		scope = repo + "/ref/" + url.PathEscape(commit.Ref)
+		candidates = append(candidates, branchScope(commit.Branch))
+	case commit.Branch == "" && commit.Ref != "":
+		scope = repo + "/ref/" + url.PathEscape(commit.Ref)
+	case commit.Branch != "":
+		scope = branchScope(commit.Branch)
+	default:
+		scope = repo
+	}
+	candidates = append(candidates, branchScope(c.metadata.Repo.Branch))
+
+	var restoreScopes []string
+	for _, candidate := range candidates {
+		if candidate != "" && candidate != scope && !slices.Contains(restoreScopes, candidate) {
+			restoreScopes = append(restoreScopes, candidate)
+		}
+	}
+	return scope, restoreScopes
+}
@@ -18,6 +18,7 @@ import (
 	"testing"
 
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
 
 	backend_types "go.woodpecker-ci.org/woodpecker/v3/pipeline/backend/types"
 	"go.woodpecker-ci.org/woodpecker/v3/pipeline/frontend/metadata"
@@ -278,6 +279,83 @@ func TestCompilerCompile(t *testing.T) {
 				}},
 			},
 		},
+		{
+			name: "workflow with cache",
+			fronConf: &yaml_types.Workflow{
+				Steps: yaml_types.ContainerList{ContainerList: []*yaml_types.Container{{
+					Name:     "build",
+					Image:    "golang",
+					Commands: []string{"go build"},
+				}}},
+				Cache: []yaml_types.Cache{{
+					Key:         `go-{{ checksum "go.sum" }}`,
+					RestoreKeys: []string{"go-"},
+					Paths:       []string{".go"},
+				}},
+			},
+			backConf: &backend_types.Config{
+				Network: defaultNetwork,
+				Volume:  defaultVolume,
+				Stages: []*backend_types.Stage{defaultCloneStage, {
+					Steps: []*backend_types.Step{{
+						Name:          "restore-cache",
+						Type:          backend_types.StepTypeCache,
+						Image:         constant.DefaultClonePlugin,
+						OnSuccess:     true,
+						Failure:       "fail",
+						Volumes:       []string{defaultVolume.Name + ":/woodpecker"},
+						WorkingDir:    "/woodpecker/src/github.com/octocat/hello-world",
+						WorkspaceBase: "/woodpecker",
+						Networks:      []backend_types.Conn{{Name: "test_default", Aliases: []string{"restore-cache"}}},
+						ExtraHosts:    []backend_types.HostAlias{},
+						Cache: &backend_types.Cache{
+							Scope: "octacat/hello-world",
+							Entries: []backend_types.CacheEntry{{
+								Key:         `go-{{ checksum "go.sum" }}`,
+								RestoreKeys: []string{"go-"},
+								Paths:       []string{".go"},
+							}},
+						},
+					}},
+				}, {
+					Steps: []*backend_types.Step{{
+						Name:          "build",
+						Type:          backend_types.StepTypeCommands,
+						Image:         "golang",
+						Commands:      []string{"go build"},
+						OnSuccess:     true,
+						Failure:       "fail",
+						Volumes:       []string{defaultVolume.Name + ":/test"},
+						WorkingDir:    "/test/src/github.com/octocat/hello-world",
+						WorkspaceBase: "/test",
+						Networks:      []backend_types.Conn{{Name: "test_default", Aliases: []string{"build"}}},
+						ExtraHosts:    []backend_types.HostAlias{},
+					}},
+				}, {
+					Steps: []*backend_types.Step{{
+						Name:          "save-cache",
+						Type:          backend_types.StepTypeCache,
+						Image:         constant.DefaultClonePlugin,
+						OnSuccess:     true,
+						Failure:       "fail",
+						Volumes:       []string{defaultVolume.Name + ":/woodpecker"},
+						WorkingDir:    "/woodpecker/src/github.com/octocat/hello-world",
+						WorkspaceBase: "/woodpecker",
+						Networks:      []backend_types.Conn{{Name: "test_default", Aliases: []string{"save-cache"}}},
+						ExtraHosts:    []backend_types.HostAlias{},
+						Cache: &backend_types.Cache{
+							Save:  true,
+							Scope: "octacat/hello-world",
+							Entries: []backend_types.CacheEntry{{
+								Key:         `go-{{ checksum "go.sum" }}`,
+								RestoreKeys: []string{"go-"},
+								Paths:       []string{".go"},
+							}},
+						},
+					}},
+				}},
+			},
+		},
 		{
 			name: "workflow with missing secret",
 			fronConf: &yaml_types.Workflow{Steps: yaml_types.ContainerList{ContainerList: []*yaml_types.Container{{
@@ -402,3 +480,135 @@ func TestCompilerCompilePrivileged(t *testing.T) {
 	assert.False(t, backConf.Stages[0].Steps[1].Privileged)
 	assert.False(t, backConf.Stages[0].Steps[2].Privileged)
 }
+
+func TestCompilerCompileOIDCToken(t *testing.T) {
+	fronConf := &yaml_types.Workflow{
+		SkipClone: true,
+		Steps: yaml_types.ContainerList{
+			ContainerList: []*yaml_types.Container{
+				{
+					Name:      "aws",
+					Image:     "amazon/aws-cli",
+					Commands:  []string{"aws sts get-caller-identity"},
+					OIDCToken: &yaml_types.OIDCToken{Audience: "sts.amazonaws.com"},
+				},
+				{
+					Name:      "aws-again",
+					Image:     "amazon/aws-cli",
+					Commands:  []string{"aws sts get-caller-identity"},
+					OIDCToken: &yaml_types.OIDCToken{Audience: "sts.amazonaws.com"},
+				},
+				{
+					Name:     "build",
+					Image:    "golang",
+					Commands: []string{"go build"},
+				},
+			},
+		},
+	}
+
+	issued := 0
+	compiler := New(WithOIDCTokenIssuer(func(audience string) (string, error) {
+		issued++
+		return "token-for-" + audience, nil
+	}))
+
+	backConf, err := compiler.Compile(fronConf)
+	assert.NoError(t, err)
+
+	assert.Equal(t, 1, issued)
+	var steps []*backend_types.Step
+	for _, stage := range backConf.Stages {
+		steps = append(steps, stage.Steps...)
+	}
+	assert.Len(t, steps, 3)
+	assert.Equal(t, "token-for-sts.amazonaws.com", steps[0].Environment["WOODPECKER_OIDC_TOKEN"])
+	assert.Equal(t, "token-for-sts.amazonaws.com", steps[1].Environment["WOODPECKER_OIDC_TOKEN"])
+	assert.NotContains(t, steps[2].Environment, "WOODPECKER_OIDC_TOKEN")
+	assert.Contains(t, backConf.Secrets, &backend_types.Secret{Name: "WOODPECKER_OIDC_TOKEN", Value: "token-for-sts.amazonaws.com"})
+
+	_, err = New().Compile(fronConf)
+	assert.ErrorContains(t, err, "oidc tokens are not available")
+}
+
+func TestCompilerCompileCacheScopes(t *testing.T) {
+	fronConf := &yaml_types.Workflow{
+		Steps: yaml_types.ContainerList{ContainerList: []*yaml_types.Container{{
+			Name:     "build",
+			Image:    "golang",
+			Commands: []string{"go build"},
+		}}},
+		Cache: []yaml_types.Cache{{Key: "go", Paths: []string{".go"}}},
+	}
+	repo := metadata.Repo{ID: 1, Owner: "octocat", Name: "hello-world", Branch: "main"}
+
+	tests := []struct {
+		name          string
+		curr          metadata.Pipeline
+		scope         string
+		restoreScopes []string
+		save          bool
+	}{
+		{
+			name:  "default branch",
+			curr:  metadata.Pipeline{Event: metadata.EventPush, Commit: metadata.Commit{Branch: "main", Ref: "refs/heads/main"}},
+			scope: "1/branch/main",
+			save:  true,
+		},
+		{
+			name:          "other branch",
+			curr:          metadata.Pipeline{Event: metadata.EventPush, Commit: metadata.Commit{Branch: "feature/cache", Ref: "refs/heads/feature/cache"}},
+			scope:         "1/branch/feature%2Fcache",
+			restoreScopes: []string{"1/branch/main"},
+			save:          true,
+		},
+		{
+			name:          "tag",
+			curr:          metadata.Pipeline{Event: metadata.EventTag, Commit: metadata.Commit{Ref: "refs/tags/v1.0.0"}},
+			scope:         "1/ref/refs%2Ftags%2Fv1.0.0",
+			restoreScopes: []string{"1/branch/main"},
+			save:          true,
+		},
+		{
+			name:          "pull request",
+			curr:          metadata.Pipeline{Event: metadata.EventPull, Commit: metadata.Commit{Branch: "release", Ref: "refs/pull/7/head"}},
+			scope:         "1/ref/refs%2Fpull%2F7%2Fhead",
+			restoreScopes: []string{"1/branch/release", "1/branch/main"},
+			save:          true,
+		},
+		{
+			name:          "pull request of fork",
+			curr:          metadata.Pipeline{Event: metadata.EventPull, Commit: metadata.Commit{Branch: "main", Ref: "refs/pull/8/head", FromFork: true}},
+			scope:         "1/ref/refs%2Fpull%2F8%2Fhead",
+			restoreScopes: []string{"1/branch/main"},
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			backConf, err := New(WithMetadata(metadata.Metadata{Repo: repo, Curr: test.curr})).Compile(fronConf)
+			require.NoError(t, err)
+
+			var caches []*backend_types.Cache
+			for _, stage := range backConf.Stages {
+				for _, step := range stage.Steps {
+					if step.Cache != nil {
+						caches = append(caches, step.Cache)
+					}
+				}
+			}
+
+			require.NotEmpty(t, caches)
+			assert.Equal(t, test.scope, caches[0].Scope)
+			assert.Equal(t, test.restoreScopes, caches[0].RestoreScopes)
+			if test.save {
+				require.Len(t, caches, 2)
+				assert.True(t, caches[1].Save)
+				assert.Equal(t, test.scope, caches[1].Scope)
+				assert.Empty(t, caches[1].RestoreScopes)
+			} else {
+				assert.Len(t, caches, 1)
+			}
+		})
+	}
+}
@@ -20,6 +20,7 @@ import (
 	"path"
 	"strconv"
 	"strings"
+	"time"
 
 	"github.com/oklog/ulid/v2"
 
@@ -35,6 +36,10 @@ const (
 	pluginWorkspaceBase = "/woodpecker"
 	// DefaultWorkspaceBase is set if not altered by the user.
 	DefaultWorkspaceBase = pluginWorkspaceBase
+	// defaultRetryBackoff is the time to wait before the first restart of a failed step if not set by the user.
+	defaultRetryBackoff = 10 * time.Second
+	// oidcTokenEnv is the environment variable containing the OIDC identity token of steps requesting one.
+	oidcTokenEnv = "WOODPECKER_OIDC_TOKEN"
 )
 
 func (c *Compiler) createProcess(container *yaml_types.Container, stepType backend_types.StepType) (*backend_types.Step, error) {
@@ -122,6 +127,14 @@ func (c *Compiler) createProcess(container *yaml_types.Container, stepType backe
 		return nil, err
 	}
 
+	if container.OIDCToken != nil {
+		token, err := c.oidcToken(container.OIDCToken.Audience)
+		if err != nil {
+			return nil, err
+		}
+		environment[oidcTokenEnv] = token
+	}
+
 	if utils.MatchImageDynamic(container.Image, c.escalated...) && container.IsPlugin() {
 		privileged = true
 	}
@@ -154,6 +167,25 @@ func (c *Compiler) createProcess(container *yaml_types.Container, stepType backe
 		failure = metadata.FailureFail
 	}
 
+	var retry *backend_types.Retry
+	// services and detached steps do not exit, so they cannot be restarted on failure
+	if container.Retry != nil && container.Retry.Count > 0 && !detached && stepType != backend_types.StepTypeService {
+		retry = &backend_types.Retry{
+			Count:     container.Retry.Count,
+			Backoff:   container.Retry.Backoff,
+			ExitCodes: container.Retry.ExitCodes,
+		}
+		if retry.Backoff == 0 {
+			retry.Backoff = defaultRetryBackoff
+		}
+	}
+
+	var artifacts []string
+	// artifacts are uploaded once a step exited, which services and detached steps never do
+	if !detached && stepType != backend_types.StepTypeService {
+		artifacts = container.Artifacts
+	}
+
 	return &backend_types.Step{
 		Name:           container.Name,
 		UUID:           uuid.String(),
@@ -178,6 +210,8 @@ func (c *Compiler) createProcess(container *yaml_types.Container, stepType backe
 		OnSuccess:      onSuccess,
 		OnFailure:      onFailure,
 		Failure:        failure,
+		Retry:          retry,
+		Artifacts:      artifacts,
 		NetworkMode:    networkMode,
 		Ports:          ports,
 		BackendOptions: container.BackendOptions,
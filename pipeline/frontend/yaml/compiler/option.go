@@ -169,6 +169,17 @@ func WithTrustedClonePlugins(images []string) Option {
 	}
 }
 
+// OIDCTokenIssuer returns an OIDC identity token of the workflow for the audience.
+type OIDCTokenIssuer func(audience string) (string, error)
+
+// WithOIDCTokenIssuer configures the compiler with the issuer of the
+// OIDC identity tokens requested by steps.
+func WithOIDCTokenIssuer(issuer OIDCTokenIssuer) Option {
+	return func(compiler *Compiler) {
+		compiler.oidcTokenIssuer = issuer
+	}
+}
+
 // WithTrustedSecurity configures the compiler with the trusted repo option.
 func WithTrustedSecurity(trusted bool) Option {
 	return func(compiler *Compiler) {
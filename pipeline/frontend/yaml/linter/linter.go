@@ -16,6 +16,9 @@ package linter
 
 import (
 	"fmt"
+	"path"
+	"regexp"
+	"strings"
 
 	"codeberg.org/6543/xyaml"
 	"go.uber.org/multierr"
@@ -28,6 +31,12 @@ import (
 	"go.woodpecker-ci.org/woodpecker/v3/shared/constant"
 )
 
+// input names are used for environment variables.
+var validInputName = regexp.MustCompile(`^[a-zA-Z_][a-zA-Z0-9_]*$`)
+
+// maxRetryCount is the maximum number of restarts of a failed step, it matches the schema.
+const maxRetryCount = 10
+
 // A Linter lints a pipeline configuration.
 type Linter struct {
 	trusted             TrustedConfiguration
@@ -85,6 +94,13 @@ func (l *Linter) lintFile(config *WorkflowConfig) error {
 		linterErr = multierr.Append(linterErr, err)
 	}
 
+	if err := l.lintInputs(config); err != nil {
+		linterErr = multierr.Append(linterErr, err)
+	}
+	if err := l.lintCache(config); err != nil {
+		linterErr = multierr.Append(linterErr, err)
+	}
+
 	if err := l.lintContainers(config, "clone"); err != nil {
 		linterErr = multierr.Append(linterErr, err)
 	}
@@ -131,6 +147,38 @@ func (l *Linter) lintCloneSteps(config *WorkflowConfig) error {
 	return linterErr
 }
 
+func (l *Linter) lintInputs(config *WorkflowConfig) error {
+	var linterErr error
+	for name, input := range config.Workflow.Inputs {
+		if !validInputName.MatchString(name) {
+			linterErr = multierr.Append(linterErr,
+				newLinterError(fmt.Sprintf("Input names have to match '%s'", validInputName.String()), config.File, "inputs."+name, false),
+			)
+		}
+		if input == nil {
+			continue
+		}
+		if err := input.Validate(); err != nil {
+			linterErr = multierr.Append(linterErr, newLinterError(err.Error(), config.File, "inputs."+name, false))
+		}
+	}
+	return linterErr
+}
+
+func (l *Linter) lintCache(config *WorkflowConfig) error {
+	var linterErr error
+	for i, cache := range config.Workflow.Cache {
+		for _, p := range cache.Paths {
+			if p = path.Clean(p); path.IsAbs(p) || p == ".." || strings.HasPrefix(p, "../") {
+				linterErr = multierr.Append(linterErr,
+					newLinterError("Cache paths have to be relative to the workspace", config.File, fmt.Sprintf("cache[%d].paths", i), false),
+				)
+			}
+		}
+	}
+	return linterErr
+}
+
 func (l *Linter) lintContainers(config *WorkflowConfig, area string) error {
 	var linterErr error
 
@@ -164,6 +212,15 @@ func (l *Linter) lintContainers(config *WorkflowConfig, area string) error {
 		if err := l.lintDependsOn(config, container, area); err != nil {
 			linterErr = multierr.Append(linterErr, err)
 		}
+		if err := l.lintArtifacts(config, container, area); err != nil {
+			linterErr = multierr.Append(linterErr, err)
+		}
+		if err := l.lintOIDCToken(config, container, area); err != nil {
+			linterErr = multierr.Append(linterErr, err)
+		}
+		if err := l.lintRetry(config, container, area); err != nil {
+			linterErr = multierr.Append(linterErr, err)
+		}
 	}
 
 	return linterErr
@@ -192,6 +249,44 @@ check:
 	return linterErr
 }
 
+func (l *Linter) lintArtifacts(config *WorkflowConfig, c *types.Container, area string) error {
+	var linterErr error
+	for _, p := range c.Artifacts {
+		if p = path.Clean(p); path.IsAbs(p) || p == ".." || strings.HasPrefix(p, "../") {
+			linterErr = multierr.Append(linterErr,
+				newLinterError("Artifact paths have to be relative to the working directory of the step", config.File, fmt.Sprintf("%s.%s.artifacts", area, c.Name), false),
+			)
+		}
+	}
+	return linterErr
+}
+
+func (l *Linter) lintOIDCToken(config *WorkflowConfig, c *types.Container, area string) error {
+	if c.OIDCToken != nil && c.OIDCToken.Audience == "" {
+		return newLinterError("OIDC tokens require an audience", config.File, fmt.Sprintf("%s.%s.oidc_token", area, c.Name), false)
+	}
+	return nil
+}
+
+func (l *Linter) lintRetry(config *WorkflowConfig, c *types.Container, area string) error {
+	if c.Retry == nil {
+		return nil
+	}
+
+	var linterErr error
+	if c.Retry.Count > maxRetryCount {
+		linterErr = multierr.Append(linterErr,
+			newLinterError(fmt.Sprintf("Steps can be restarted at most %d times", maxRetryCount), config.File, fmt.Sprintf("%s.%s.retry.count", area, c.Name), false),
+		)
+	}
+	if c.Retry.Backoff < 0 {
+		linterErr = multierr.Append(linterErr,
+			newLinterError("The retry backoff must not be negative", config.File, fmt.Sprintf("%s.%s.retry.backoff", area, c.Name), false),
+		)
+	}
+	return linterErr
+}
+
 func (l *Linter) lintImage(config *WorkflowConfig, c *types.Container, area string) error {
 	if len(c.Image) == 0 {
 		return newLinterError("Invalid or missing image", config.File, fmt.Sprintf("%s.%s", area, c.Name), false)
@@ -189,6 +189,38 @@ func TestLintErrors(t *testing.T) {
 			from: "steps: { build: { image: golang }, publish: { image: golang, depends_on: [ binary ] } }",
 			want: "One or more of the specified dependencies do not exist",
 		},
+		{
+			from: "{ steps: { build: { image: golang } }, cache: [ { key: go, paths: [ /root/go ] } ] }",
+			want: "Cache paths have to be relative to the workspace",
+		},
+		{
+			from: "{ steps: { build: { image: golang } }, cache: [ { key: go, paths: [ ../go ] } ] }",
+			want: "Cache paths have to be relative to the workspace",
+		},
+		{
+			from: "{ steps: { build: { image: golang } }, inputs: { version: { type: list } } }",
+			want: "unknown input type 'list'",
+		},
+		{
+			from: "{ steps: { build: { image: golang } }, inputs: { my-version: { type: string } } }",
+			want: "Input names have to match '^[a-zA-Z_][a-zA-Z0-9_]*$'",
+		},
+		{
+			from: "{ steps: { build: { image: golang, artifacts: [ /tmp/app ] } } }",
+			want: "Artifact paths have to be relative to the working directory of the step",
+		},
+		{
+			from: "{ steps: { deploy: { image: amazon/aws-cli, oidc_token: {} } } }",
+			want: "OIDC tokens require an audience",
+		},
+		{
+			from: "{ steps: { test: { image: golang, retry: { count: 1000 } } } }",
+			want: "Steps can be restarted at most 10 times",
+		},
+		{
+			from: "{ steps: { test: { image: golang, retry: { count: 2, backoff: -1s } } } }",
+			want: "The retry backoff must not be negative",
+		},
 	}
 
 	for _, test := range testdata {
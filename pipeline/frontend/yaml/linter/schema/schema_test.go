@@ -56,6 +56,30 @@ func TestSchema(t *testing.T) {
 			name:     "Run on",
 			testFile: ".woodpecker/test-run-on.yaml",
 		},
+		{
+			name:     "Concurrency",
+			testFile: ".woodpecker/test-concurrency.yaml",
+		},
+		{
+			name:     "Retry",
+			testFile: ".woodpecker/test-retry.yaml",
+		},
+		{
+			name:     "Cache",
+			testFile: ".woodpecker/test-cache.yaml",
+		},
+		{
+			name:     "Inputs",
+			testFile: ".woodpecker/test-inputs.yaml",
+		},
+		{
+			name:     "Artifacts",
+			testFile: ".woodpecker/test-artifacts.yaml",
+		},
+		{
+			name:     "OIDC token",
+			testFile: ".woodpecker/test-oidc-token.yaml",
+		},
 		{
 			name:     "Service",
 			testFile: ".woodpecker/test-service.yaml",
@@ -16,6 +16,7 @@ package types
 
 import (
 	"fmt"
+	"time"
 
 	"gopkg.in/yaml.v3"
 
@@ -44,9 +45,13 @@ type (
 		DependsOn base.StringOrSlice `yaml:"depends_on,omitempty"`
 		When      constraint.When    `yaml:"when,omitempty"`
 		Failure   string             `yaml:"failure,omitempty"`
+		Retry     *Retry             `yaml:"retry,omitempty"`
 		Detached  bool               `yaml:"detach,omitempty"`
 		// state
-		Volumes Volumes `yaml:"volumes,omitempty"`
+		Volumes   Volumes            `yaml:"volumes,omitempty"`
+		Artifacts base.StringOrSlice `yaml:"artifacts,omitempty"`
+		// identity
+		OIDCToken *OIDCToken `yaml:"oidc_token,omitempty"`
 		// network
 		Ports     []string           `yaml:"ports,omitempty"`
 		DNS       base.StringOrSlice `yaml:"dns,omitempty"`
@@ -71,6 +76,18 @@ type (
 		NetworkMode string   `yaml:"network_mode,omitempty"`
 		Tmpfs       []string `yaml:"tmpfs,omitempty"`
 	}
+
+	// OIDCToken requests an OIDC identity token for the step.
+	OIDCToken struct {
+		Audience string `yaml:"audience"`
+	}
+
+	// Retry defines how often a failed step gets restarted.
+	Retry struct {
+		Count     int           `yaml:"count"`
+		Backoff   time.Duration `yaml:"backoff,omitempty"`
+		ExitCodes []int         `yaml:"exit_codes,omitempty"`
+	}
 )
 
 // UnmarshalYAML implements the Unmarshaler interface.
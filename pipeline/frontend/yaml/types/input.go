@@ -0,0 +1,121 @@
+// Copyright 2025 Woodpecker Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package types
+
+import (
+	"errors"
+	"fmt"
+	"slices"
+	"strconv"
+)
+
+// InputType is the type of the value of an input.
+type InputType string
+
+const (
+	InputTypeString InputType = "string"
+	InputTypeBool   InputType = "bool"
+	InputTypeNumber InputType = "number"
+	InputTypeChoice InputType = "choice"
+)
+
+var ErrInvalidInputValue = errors.New("invalid input value")
+
+// Input defines a typed input which can be set when a pipeline is started manually.
+type Input struct {
+	Type        InputType `yaml:"type,omitempty"`
+	Description string    `yaml:"description,omitempty"`
+	Default     any       `yaml:"default,omitempty"`
+	Required    bool      `yaml:"required,omitempty"`
+	Options     []string  `yaml:"options,omitempty"`
+}
+
+// Validate ensures the input has a known type and a valid default value.
+func (i *Input) Validate() error {
+	switch i.Type {
+	case "", InputTypeString, InputTypeBool, InputTypeNumber:
+		if len(i.Options) != 0 {
+			return fmt.Errorf("options are only supported by inputs of type %s", InputTypeChoice)
+		}
+	case InputTypeChoice:
+		if len(i.Options) == 0 {
+			return fmt.Errorf("inputs of type %s require options", InputTypeChoice)
+		}
+	default:
+		return fmt.Errorf("unknown input type '%s'", i.Type)
+	}
+
+	if i.Default != nil {
+		if _, err := i.Value(i.Default); err != nil {
+			return fmt.Errorf("invalid default: %w", err)
+		}
+	}
+	return nil
+}
+
+// Value converts a value to the type of the input. Strings are parsed, so
+// values set as text like on the command line can be used for all types.
+// The result is a string, a bool or a float64.
+func (i *Input) Value(value any) (any, error) {
+	switch i.Type {
+	case InputTypeBool:
+		switch v := value.(type) {
+		case bool:
+			return v, nil
+		case string:
+			b, err := strconv.ParseBool(v)
+			if err != nil {
+				return nil, fmt.Errorf("%w: '%s' is no bool", ErrInvalidInputValue, v)
+			}
+			return b, nil
+		}
+	case InputTypeNumber:
+		switch v := value.(type) {
+		case int:
+			return float64(v), nil
+		case int64:
+			return float64(v), nil
+		case uint64:
+			return float64(v), nil
+		case float64:
+			return v, nil
+		case string:
+			f, err := strconv.ParseFloat(v, 64)
+			if err != nil {
+				return nil, fmt.Errorf("%w: '%s' is no number", ErrInvalidInputValue, v)
+			}
+			return f, nil
+		}
+	case InputTypeChoice:
+		s := fmt.Sprint(value)
+		if !slices.Contains(i.Options, s) {
+			return nil, fmt.Errorf("%w: '%s' is none of the options %v", ErrInvalidInputValue, s, i.Options)
+		}
+		return s, nil
+	default:
+		switch value.(type) {
+		case string, bool, int, int64, uint64, float64:
+			return fmt.Sprint(value), nil
+		}
+	}
+	return nil, fmt.Errorf("%w: unsupported value %v for type %s", ErrInvalidInputValue, value, i.typeName())
+}
+
+func (i *Input) typeName() InputType {
+	if i.Type == "" {
+		return InputTypeString
+	}
+	return i.Type
+}
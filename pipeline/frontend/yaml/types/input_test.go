@@ -0,0 +1,94 @@
+// Copyright 2025 Woodpecker Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package types
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"gopkg.in/yaml.v3"
+)
+
+func TestUnmarshalInputs(t *testing.T) {
+	var workflow Workflow
+	err := yaml.Unmarshal([]byte(`
+inputs:
+  version:
+    description: the version to release
+    required: true
+  dry_run:
+    type: bool
+    default: true
+  replicas:
+    type: number
+    default: 3
+  target:
+    type: choice
+    options: [staging, production]
+    default: staging
+`), &workflow)
+	assert.NoError(t, err)
+	if assert.Len(t, workflow.Inputs, 4) {
+		for _, input := range workflow.Inputs {
+			assert.NoError(t, input.Validate())
+		}
+		assert.True(t, workflow.Inputs["version"].Required)
+		assert.Equal(t, true, workflow.Inputs["dry_run"].Default)
+		assert.Equal(t, []string{"staging", "production"}, workflow.Inputs["target"].Options)
+	}
+}
+
+func TestInputValue(t *testing.T) {
+	testCases := []struct {
+		input    Input
+		value    any
+		expected any
+	}{
+		{input: Input{}, value: "v1.0.0", expected: "v1.0.0"},
+		{input: Input{Type: InputTypeString}, value: 1, expected: "1"},
+		{input: Input{Type: InputTypeBool}, value: "true", expected: true},
+		{input: Input{Type: InputTypeBool}, value: false, expected: false},
+		{input: Input{Type: InputTypeNumber}, value: "1.5", expected: 1.5},
+		{input: Input{Type: InputTypeNumber}, value: 3, expected: float64(3)},
+		{input: Input{Type: InputTypeChoice, Options: []string{"a", "b"}}, value: "b", expected: "b"},
+	}
+	for _, tc := range testCases {
+		value, err := tc.input.Value(tc.value)
+		assert.NoError(t, err)
+		assert.Equal(t, tc.expected, value)
+	}
+
+	for _, tc := range []struct {
+		input Input
+		value any
+	}{
+		{input: Input{Type: InputTypeBool}, value: "maybe"},
+		{input: Input{Type: InputTypeNumber}, value: "many"},
+		{input: Input{Type: InputTypeNumber}, value: true},
+		{input: Input{Type: InputTypeChoice, Options: []string{"a", "b"}}, value: "c"},
+		{input: Input{}, value: []string{"a"}},
+	} {
+		_, err := tc.input.Value(tc.value)
+		assert.ErrorIs(t, err, ErrInvalidInputValue)
+	}
+}
+
+func TestInputValidate(t *testing.T) {
+	assert.NoError(t, (&Input{}).Validate())
+	assert.Error(t, (&Input{Type: "list"}).Validate())
+	assert.Error(t, (&Input{Type: InputTypeChoice}).Validate())
+	assert.Error(t, (&Input{Type: InputTypeString, Options: []string{"a"}}).Validate())
+	assert.Error(t, (&Input{Type: InputTypeBool, Default: "maybe"}).Validate())
+}
@@ -16,20 +16,31 @@ package types
 
 import (
 	"go.woodpecker-ci.org/woodpecker/v3/pipeline/frontend/yaml/constraint"
+	"go.woodpecker-ci.org/woodpecker/v3/pipeline/frontend/yaml/types/base"
 )
 
 type (
 	// Workflow defines a workflow configuration.
 	Workflow struct {
-		When      constraint.When   `yaml:"when,omitempty"`
-		Workspace Workspace         `yaml:"workspace,omitempty"`
-		Clone     ContainerList     `yaml:"clone,omitempty"`
-		Steps     ContainerList     `yaml:"steps,omitempty"`
-		Services  ContainerList     `yaml:"services,omitempty"`
-		Labels    map[string]string `yaml:"labels,omitempty"`
-		DependsOn []string          `yaml:"depends_on,omitempty"`
-		RunsOn    []string          `yaml:"runs_on,omitempty"`
-		SkipClone bool              `yaml:"skip_clone"`
+		When        constraint.When   `yaml:"when,omitempty"`
+		Workspace   Workspace         `yaml:"workspace,omitempty"`
+		Clone       ContainerList     `yaml:"clone,omitempty"`
+		Steps       ContainerList     `yaml:"steps,omitempty"`
+		Services    ContainerList     `yaml:"services,omitempty"`
+		Labels      map[string]string `yaml:"labels,omitempty"`
+		DependsOn   []string          `yaml:"depends_on,omitempty"`
+		RunsOn      []string          `yaml:"runs_on,omitempty"`
+		SkipClone   bool              `yaml:"skip_clone"`
+		Concurrency string            `yaml:"concurrency,omitempty"`
+		Cache       []Cache           `yaml:"cache,omitempty"`
+		Inputs      map[string]*Input `yaml:"inputs,omitempty"`
+	}
+
+	// Cache defines paths which are restored before and saved after the steps of a workflow.
+	Cache struct {
+		Key         string             `yaml:"key"`
+		RestoreKeys base.StringOrSlice `yaml:"restore_keys,omitempty"`
+		Paths       base.StringOrSlice `yaml:"paths"`
 	}
 
 	// Workspace defines a pipeline workspace.
@@ -18,6 +18,7 @@ import (
 	"context"
 
 	backend "go.woodpecker-ci.org/woodpecker/v3/pipeline/backend/types"
+	"go.woodpecker-ci.org/woodpecker/v3/pipeline/cache"
 )
 
 // Option configures a runtime option.
@@ -44,6 +45,20 @@ func WithTracer(tracer Tracer) Option {
 	}
 }
 
+// WithCache returns an option configured with a store for the caches of the workflow.
+func WithCache(store cache.Store) Option {
+	return func(r *Runtime) {
+		r.cache = store
+	}
+}
+
+// WithArtifactUploader returns an option configured with an uploader for the artifacts of the steps.
+func WithArtifactUploader(uploader ArtifactUploader) Option {
+	return func(r *Runtime) {
+		r.artifactUploader = uploader
+	}
+}
+
 // WithContext returns an option configured with a context.
 func WithContext(ctx context.Context) Option {
 	return func(r *Runtime) {
@@ -17,6 +17,9 @@ package pipeline
 import (
 	"context"
 	"errors"
+	"fmt"
+	"io"
+	"slices"
 	"strings"
 	"sync"
 	"time"
@@ -24,14 +27,21 @@ import (
 	"github.com/oklog/ulid/v2"
 	"github.com/rs/zerolog"
 	"github.com/rs/zerolog/log"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/trace"
 	"golang.org/x/sync/errgroup"
 
 	backend "go.woodpecker-ci.org/woodpecker/v3/pipeline/backend/types"
+	"go.woodpecker-ci.org/woodpecker/v3/pipeline/cache"
 	"go.woodpecker-ci.org/woodpecker/v3/pipeline/frontend/metadata"
+	"go.woodpecker-ci.org/woodpecker/v3/shared/tracing"
 )
 
 // TODO: move runtime into "runtime" subpackage
 
+// maxRetryBackoff is the maximum time to wait before restarting a failed step.
+const maxRetryBackoff = time.Hour
+
 type (
 	// State defines the pipeline and process state.
 	State struct {
@@ -41,6 +51,8 @@ type (
 			Started int64 `json:"time"`
 			// Current pipeline step
 			Step *backend.Step `json:"step"`
+			// Current step exited but gets restarted
+			Retry bool `json:"retry"`
 			// Current pipeline error state
 			Error error `json:"error"`
 		}
@@ -57,9 +69,12 @@ type Runtime struct {
 	engine  backend.Backend
 	started int64
 
-	ctx    context.Context
-	tracer Tracer
-	logger Logger
+	ctx              context.Context
+	cancel           context.CancelCauseFunc
+	tracer           Tracer
+	logger           Logger
+	cache            cache.Store
+	artifactUploader ArtifactUploader
 
 	taskUUID string
 
@@ -115,19 +130,33 @@ func (r *Runtime) Run(runnerCtx context.Context) error {
 	}()
 
 	r.started = time.Now().Unix()
-	if err := r.engine.SetupWorkflow(runnerCtx, r.spec, r.taskUUID); err != nil {
+	setupCtx, span := tracing.Tracer.Start(runnerCtx, "SetupWorkflow")
+	err := r.engine.SetupWorkflow(setupCtx, r.spec, r.taskUUID)
+	tracing.RecordError(span, err)
+	span.End()
+	if err != nil {
 		return err
 	}
 
+	// steps with failure mode cancel stop the workflow using this context
+	parentCtx := r.ctx
+	r.ctx, r.cancel = context.WithCancelCause(parentCtx)
+	defer r.cancel(nil)
+
 	for _, stage := range r.spec.Stages {
 		select {
-		case <-r.ctx.Done():
+		case <-parentCtx.Done():
 			return ErrCancel
 		case err := <-r.execAll(stage.Steps):
 			if err != nil {
 				r.err = err
 			}
 		}
+
+		if r.canceledByStep() {
+			logger.Debug().Err(r.err).Msg("workflow canceled by failed step")
+			break
+		}
 	}
 
 	return r.err
@@ -135,6 +164,10 @@ func (r *Runtime) Run(runnerCtx context.Context) error {
 
 // Updates the current status of a step.
 func (r *Runtime) traceStep(processState *backend.State, err error, step *backend.Step) error {
+	return r.trace(processState, err, step, false)
+}
+
+func (r *Runtime) trace(processState *backend.State, err error, step *backend.Step, retry bool) error {
 	if r.tracer == nil {
 		// no tracer nothing to trace :)
 		return nil
@@ -153,6 +186,7 @@ func (r *Runtime) traceStep(processState *backend.State, err error, step *backen
 	state := new(State)
 	state.Pipeline.Started = r.started
 	state.Pipeline.Step = step
+	state.Pipeline.Retry = retry
 	state.Process = processState // empty
 	state.Pipeline.Error = r.err
 
@@ -207,6 +241,31 @@ func (r *Runtime) execAll(steps []*backend.Step) <-chan error {
 				Msg("executing")
 
 			processState, err := r.exec(step)
+			for attempt := 1; shouldRetry(step, attempt, err); attempt++ {
+				backoff := retryBackoff(step, attempt)
+				logger.Debug().
+					Str("step", step.Name).
+					Int("attempt", attempt).
+					Msgf("failed, restarting in %s", backoff)
+
+				// log before tracing, so the line is part of the logs of the failed attempt
+				r.logRetry(step, attempt, processState.ExitCode, backoff)
+				if err := r.trace(processState, err, step, true); err != nil {
+					return err
+				}
+
+				if !r.waitBackoff(backoff) {
+					break
+				}
+				processState, err = r.exec(step)
+			}
+
+			// steps stopped because another step failed are reported as killed
+			if errors.Is(err, ErrCancel) && r.canceledByStep() {
+				processState, err = &backend.State{Exited: true, ExitCode: ExitCodeKilled}, nil
+			} else if processState != nil && processState.Exited && r.ctx.Err() == nil {
+				r.uploadArtifacts(step)
+			}
 
 			logger.Debug().
 				Str("step", step.Name).
@@ -217,6 +276,12 @@ func (r *Runtime) execAll(steps []*backend.Step) <-chan error {
 			if err != nil && step.Failure == metadata.FailureIgnore {
 				return nil
 			}
+			if err != nil && (step.Failure == metadata.FailureCancel || step.Failure == metadata.FailureCancelPipeline) {
+				logger.Debug().
+					Str("step", step.Name).
+					Msg("failed, canceling workflow")
+				r.cancel(errCanceledByStep)
+			}
 			return err
 		})
 	}
@@ -229,8 +294,25 @@ func (r *Runtime) execAll(steps []*backend.Step) <-chan error {
 }
 
 // Executes the step and returns the state and error.
-func (r *Runtime) exec(step *backend.Step) (*backend.State, error) {
-	if err := r.engine.StartStep(r.ctx, step, r.taskUUID); err != nil {
+func (r *Runtime) exec(step *backend.Step) (state *backend.State, err error) {
+	ctx, span := tracing.Tracer.Start(r.ctx, "step "+step.Name, trace.WithAttributes(
+		attribute.String("step", step.Name),
+		attribute.String("image", step.Image),
+	))
+	defer func() {
+		tracing.RecordError(span, err)
+		span.End()
+	}()
+
+	if step.Type == backend.StepTypeCache {
+		return r.execCache(step)
+	}
+
+	startCtx, startSpan := tracing.Tracer.Start(ctx, "StartStep")
+	err = r.engine.StartStep(startCtx, step, r.taskUUID)
+	tracing.RecordError(startSpan, err)
+	startSpan.End()
+	if err != nil {
 		return nil, err
 	}
 
@@ -261,7 +343,10 @@ func (r *Runtime) exec(step *backend.Step) (*backend.State, error) {
 	// We wait until all data was logged. (Needed for some backends like local as WaitStep kills the log stream)
 	wg.Wait()
 
-	waitState, err := r.engine.WaitStep(r.ctx, step, r.taskUUID)
+	waitCtx, waitSpan := tracing.Tracer.Start(ctx, "WaitStep")
+	waitState, err := r.engine.WaitStep(waitCtx, step, r.taskUUID)
+	tracing.RecordError(waitSpan, err)
+	waitSpan.End()
 	if err != nil {
 		if errors.Is(err, context.Canceled) {
 			return waitState, ErrCancel
@@ -287,3 +372,62 @@ func (r *Runtime) exec(step *backend.Step) (*backend.State, error) {
 
 	return waitState, nil
 }
+
+// canceledByStep returns true if the workflow got canceled because a step with failure mode cancel failed.
+func (r *Runtime) canceledByStep() bool {
+	return r.cancel != nil && errors.Is(context.Cause(r.ctx), errCanceledByStep)
+}
+
+// retryBackoff returns the time to wait before the restart after the attempt, it doubles for
+// every attempt and is capped at maxRetryBackoff.
+func retryBackoff(step *backend.Step, attempt int) time.Duration {
+	backoff := step.Retry.Backoff
+	for i := 1; i < attempt && backoff < maxRetryBackoff; i++ {
+		backoff *= 2
+	}
+	return max(min(backoff, maxRetryBackoff), 0)
+}
+
+// shouldRetry returns true if the step failed with an exit code it gets restarted for.
+func shouldRetry(step *backend.Step, attempt int, err error) bool {
+	if err == nil || step.Retry == nil || attempt > step.Retry.Count {
+		return false
+	}
+
+	var exitCode int
+	var exitErr *ExitError
+	var oomErr *OomError
+	switch {
+	case errors.As(err, &exitErr):
+		exitCode = exitErr.Code
+	case errors.As(err, &oomErr):
+		exitCode = oomErr.Code
+	default:
+		return false
+	}
+
+	return len(step.Retry.ExitCodes) == 0 || slices.Contains(step.Retry.ExitCodes, exitCode)
+}
+
+// waitBackoff waits before a step gets restarted, it returns false if the pipeline got canceled meanwhile.
+func (r *Runtime) waitBackoff(backoff time.Duration) bool {
+	select {
+	case <-r.ctx.Done():
+		return false
+	case <-time.After(backoff):
+		return true
+	}
+}
+
+// logRetry adds a line to the step logs, so the logs of every attempt can be told apart.
+func (r *Runtime) logRetry(step *backend.Step, attempt, exitCode int, backoff time.Duration) {
+	if r.logger == nil {
+		return
+	}
+
+	line := fmt.Sprintf("step failed with exit code %d (attempt %d of %d), restarting in %s\n", exitCode, attempt, step.Retry.Count+1, backoff)
+	if err := r.logger(step, io.NopCloser(strings.NewReader(line))); err != nil {
+		logger := r.MakeLogger()
+		logger.Error().Err(err).Msg("logging retry failed")
+	}
+}
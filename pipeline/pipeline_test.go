@@ -0,0 +1,136 @@
+// Copyright 2025 Woodpecker Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+//go:build test
+// +build test
+
+package pipeline
+
+import (
+	"archive/tar"
+	"bytes"
+	"context"
+	"io"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+
+	"go.woodpecker-ci.org/woodpecker/v3/pipeline/backend/dummy"
+	backend "go.woodpecker-ci.org/woodpecker/v3/pipeline/backend/types"
+	"go.woodpecker-ci.org/woodpecker/v3/pipeline/frontend/metadata"
+)
+
+func TestRunFailureCancel(t *testing.T) {
+	spec := &backend.Config{
+		Stages: []*backend.Stage{{
+			Steps: []*backend.Step{{
+				Name:        "lint",
+				UUID:        "lint",
+				Failure:     metadata.FailureCancel,
+				OnSuccess:   true,
+				Environment: map[string]string{dummy.EnvKeyStepExitCode: "1"},
+			}, {
+				Name:        "test",
+				UUID:        "test",
+				Failure:     metadata.FailureFail,
+				OnSuccess:   true,
+				Environment: map[string]string{dummy.EnvKeyStepSleep: "1m"},
+			}},
+		}, {
+			Steps: []*backend.Step{{
+				Name:      "notify",
+				UUID:      "notify",
+				Failure:   metadata.FailureFail,
+				OnSuccess: true,
+				OnFailure: true,
+			}},
+		}},
+	}
+
+	var mu sync.Mutex
+	exited := map[string]int{}
+	tracer := TraceFunc(func(state *State) error {
+		mu.Lock()
+		defer mu.Unlock()
+		if state.Process.Exited {
+			exited[state.Pipeline.Step.Name] = state.Process.ExitCode
+		}
+		return nil
+	})
+
+	start := time.Now()
+	err := New(spec, WithBackend(dummy.New()), WithTracer(tracer)).Run(context.Background())
+
+	assert.EqualError(t, err, "uuid=lint: exit code 1")
+	assert.Less(t, time.Since(start), 30*time.Second)
+	assert.Equal(t, map[string]int{"lint": 1, "test": ExitCodeKilled}, exited)
+}
+
+type workspaceBackend struct {
+	backend.Backend
+	files map[string]string
+}
+
+func (b *workspaceBackend) ArchiveWorkspace(_ context.Context, _ *backend.Step, _ string, _ []string) (io.ReadCloser, error) {
+	var buf bytes.Buffer
+	tw := tar.NewWriter(&buf)
+	for name, content := range b.files {
+		if err := tw.WriteHeader(&tar.Header{Name: name, Typeflag: tar.TypeReg, Mode: 0o644, Size: int64(len(content))}); err != nil {
+			return nil, err
+		}
+		if _, err := tw.Write([]byte(content)); err != nil {
+			return nil, err
+		}
+	}
+	return io.NopCloser(&buf), tw.Close()
+}
+
+func (b *workspaceBackend) ExtractWorkspace(context.Context, *backend.Step, string, io.Reader) error {
+	return nil
+}
+
+func TestRunUploadArtifacts(t *testing.T) {
+	spec := &backend.Config{
+		Stages: []*backend.Stage{{
+			Steps: []*backend.Step{{
+				Name:        "build",
+				UUID:        "build",
+				Failure:     metadata.FailureFail,
+				OnSuccess:   true,
+				Environment: map[string]string{},
+				Artifacts:   []string{"dist"},
+			}},
+		}},
+	}
+
+	engine := &workspaceBackend{Backend: dummy.New(), files: map[string]string{"dist/app": "binary"}}
+	uploaded := map[string]string{}
+	uploader := func(step *backend.Step, name string, size int64, content io.Reader) error {
+		data, err := io.ReadAll(content)
+		if err != nil {
+			return err
+		}
+		assert.Equal(t, "build", step.Name)
+		assert.EqualValues(t, len(data), size)
+		uploaded[name] = string(data)
+		return nil
+	}
+
+	err := New(spec, WithBackend(engine), WithArtifactUploader(uploader)).Run(context.Background())
+
+	assert.NoError(t, err)
+	assert.Equal(t, map[string]string{"dist/app": "binary"}, uploaded)
+}
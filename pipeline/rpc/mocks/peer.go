@@ -8,6 +8,8 @@ package mocks
 import (
 	context "context"
 
+	io "io"
+
 	mock "github.com/stretchr/testify/mock"
 	rpc "go.woodpecker-ci.org/woodpecker/v3/pipeline/rpc"
 )
@@ -188,6 +190,24 @@ func (_m *Peer) Update(c context.Context, workflowID string, state rpc.StepState
 	return r0
 }
 
+// UploadArtifact provides a mock function with given fields: c, workflowID, artifact, content
+func (_m *Peer) UploadArtifact(c context.Context, workflowID string, artifact rpc.Artifact, content io.Reader) error {
+	ret := _m.Called(c, workflowID, artifact, content)
+
+	if len(ret) == 0 {
+		panic("no return value specified for UploadArtifact")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, string, rpc.Artifact, io.Reader) error); ok {
+		r0 = rf(c, workflowID, artifact, content)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
 // Version provides a mock function with given fields: c
 func (_m *Peer) Version(c context.Context) (*rpc.Version, error) {
 	ret := _m.Called(c)
@@ -17,6 +17,7 @@ package rpc
 
 import (
 	"context"
+	"io"
 
 	backend "go.woodpecker-ci.org/woodpecker/v3/pipeline/backend/types"
 )
@@ -35,6 +36,10 @@ type (
 		Exited   bool   `json:"exited"`
 		ExitCode int    `json:"exit_code"`
 		Error    string `json:"error"`
+		// Retry is set if the step exited but gets retried.
+		Retry bool `json:"retry"`
+		// LogLines is the number of log lines of the step when it exited, set if it gets retried.
+		LogLines int `json:"log_lines"`
 	}
 
 	// WorkflowState defines the workflow state.
@@ -49,6 +54,15 @@ type (
 		ID      string          `json:"id"`
 		Config  *backend.Config `json:"config"`
 		Timeout int64           `json:"timeout"`
+		// TraceContext links the execution of the workflow to the trace of its pipeline.
+		TraceContext map[string]string `json:"trace_context,omitempty"`
+	}
+
+	// Artifact defines the metadata of a file uploaded as artifact of a step.
+	Artifact struct {
+		StepUUID string `json:"step_uuid"`
+		Name     string `json:"name"`
+		Size     int64  `json:"size"`
 	}
 
 	Version struct {
@@ -102,4 +116,7 @@ type Peer interface {
 
 	// ReportHealth reports health status of the agent to the server
 	ReportHealth(c context.Context) error
+
+	// UploadArtifact uploads the content of a file as artifact of a step
+	UploadArtifact(c context.Context, workflowID string, artifact Artifact, content io.Reader) error
 }
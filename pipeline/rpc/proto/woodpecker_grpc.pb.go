@@ -45,6 +45,7 @@ const (
 	Woodpecker_RegisterAgent_FullMethodName   = "/proto.Woodpecker/RegisterAgent"
 	Woodpecker_UnregisterAgent_FullMethodName = "/proto.Woodpecker/UnregisterAgent"
 	Woodpecker_ReportHealth_FullMethodName    = "/proto.Woodpecker/ReportHealth"
+	Woodpecker_UploadArtifact_FullMethodName  = "/proto.Woodpecker/UploadArtifact"
 )
 
 // WoodpeckerClient is the client API for Woodpecker service.
@@ -64,6 +65,7 @@ type WoodpeckerClient interface {
 	RegisterAgent(ctx context.Context, in *RegisterAgentRequest, opts ...grpc.CallOption) (*RegisterAgentResponse, error)
 	UnregisterAgent(ctx context.Context, in *Empty, opts ...grpc.CallOption) (*Empty, error)
 	ReportHealth(ctx context.Context, in *ReportHealthRequest, opts ...grpc.CallOption) (*Empty, error)
+	UploadArtifact(ctx context.Context, opts ...grpc.CallOption) (grpc.ClientStreamingClient[UploadArtifactRequest, Empty], error)
 }
 
 type woodpeckerClient struct {
@@ -184,6 +186,19 @@ func (c *woodpeckerClient) ReportHealth(ctx context.Context, in *ReportHealthReq
 	return out, nil
 }
 
+func (c *woodpeckerClient) UploadArtifact(ctx context.Context, opts ...grpc.CallOption) (grpc.ClientStreamingClient[UploadArtifactRequest, Empty], error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	stream, err := c.cc.NewStream(ctx, &Woodpecker_ServiceDesc.Streams[0], Woodpecker_UploadArtifact_FullMethodName, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	x := &grpc.GenericClientStream[UploadArtifactRequest, Empty]{ClientStream: stream}
+	return x, nil
+}
+
+// This type alias is provided for backwards compatibility with existing code that references the prior non-generic stream type by name.
+type Woodpecker_UploadArtifactClient = grpc.ClientStreamingClient[UploadArtifactRequest, Empty]
+
 // WoodpeckerServer is the server API for Woodpecker service.
 // All implementations must embed UnimplementedWoodpeckerServer
 // for forward compatibility.
@@ -201,6 +216,7 @@ type WoodpeckerServer interface {
 	RegisterAgent(context.Context, *RegisterAgentRequest) (*RegisterAgentResponse, error)
 	UnregisterAgent(context.Context, *Empty) (*Empty, error)
 	ReportHealth(context.Context, *ReportHealthRequest) (*Empty, error)
+	UploadArtifact(grpc.ClientStreamingServer[UploadArtifactRequest, Empty]) error
 	mustEmbedUnimplementedWoodpeckerServer()
 }
 
@@ -244,6 +260,9 @@ func (UnimplementedWoodpeckerServer) UnregisterAgent(context.Context, *Empty) (*
 func (UnimplementedWoodpeckerServer) ReportHealth(context.Context, *ReportHealthRequest) (*Empty, error) {
 	return nil, status.Errorf(codes.Unimplemented, "method ReportHealth not implemented")
 }
+func (UnimplementedWoodpeckerServer) UploadArtifact(grpc.ClientStreamingServer[UploadArtifactRequest, Empty]) error {
+	return status.Errorf(codes.Unimplemented, "method UploadArtifact not implemented")
+}
 func (UnimplementedWoodpeckerServer) mustEmbedUnimplementedWoodpeckerServer() {}
 func (UnimplementedWoodpeckerServer) testEmbeddedByValue()                    {}
 
@@ -463,6 +482,13 @@ func _Woodpecker_ReportHealth_Handler(srv interface{}, ctx context.Context, dec
 	return interceptor(ctx, in, info, handler)
 }
 
+func _Woodpecker_UploadArtifact_Handler(srv interface{}, stream grpc.ServerStream) error {
+	return srv.(WoodpeckerServer).UploadArtifact(&grpc.GenericServerStream[UploadArtifactRequest, Empty]{ServerStream: stream})
+}
+
+// This type alias is provided for backwards compatibility with existing code that references the prior non-generic stream type by name.
+type Woodpecker_UploadArtifactServer = grpc.ClientStreamingServer[UploadArtifactRequest, Empty]
+
 // Woodpecker_ServiceDesc is the grpc.ServiceDesc for Woodpecker service.
 // It's only intended for direct use with grpc.RegisterService,
 // and not to be introspected or modified (even as a copy)
@@ -515,7 +541,13 @@ var Woodpecker_ServiceDesc = grpc.ServiceDesc{
 			Handler:    _Woodpecker_ReportHealth_Handler,
 		},
 	},
-	Streams:  []grpc.StreamDesc{},
+	Streams: []grpc.StreamDesc{
+		{
+			StreamName:    "UploadArtifact",
+			Handler:       _Woodpecker_UploadArtifact_Handler,
+			ClientStreams: true,
+		},
+	},
 	Metadata: "woodpecker.proto",
 }
 
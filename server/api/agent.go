@@ -151,6 +151,7 @@ func PatchAgent(c *gin.Context) {
 		return
 	}
 
+	recordAudit(c, &model.AuditEvent{Action: model.AuditActionUpdate, TargetType: model.AuditTargetAgent, TargetID: agent.ID, TargetName: agent.Name})
 	c.JSON(http.StatusOK, agent)
 }
 
@@ -185,6 +186,7 @@ func PostAgent(c *gin.Context) {
 		c.String(http.StatusInternalServerError, err.Error())
 		return
 	}
+	recordAudit(c, &model.AuditEvent{Action: model.AuditActionCreate, TargetType: model.AuditTargetAgent, TargetID: agent.ID, TargetName: agent.Name})
 	c.JSON(http.StatusOK, agent)
 }
 
@@ -228,6 +230,7 @@ func DeleteAgent(c *gin.Context) {
 		c.String(http.StatusInternalServerError, "Error deleting user. %s", err)
 		return
 	}
+	recordAudit(c, &model.AuditEvent{Action: model.AuditActionDelete, TargetType: model.AuditTargetAgent, TargetID: agent.ID, TargetName: agent.Name})
 	c.Status(http.StatusNoContent)
 }
 
@@ -276,6 +279,7 @@ func PostOrgAgent(c *gin.Context) {
 		return
 	}
 
+	recordAudit(c, &model.AuditEvent{Action: model.AuditActionCreate, TargetType: model.AuditTargetAgent, TargetID: agent.ID, TargetName: agent.Name, OrgID: agent.OrgID})
 	c.JSON(http.StatusOK, agent)
 }
 
@@ -353,6 +357,7 @@ func PatchOrgAgent(c *gin.Context) {
 		return
 	}
 
+	recordAudit(c, &model.AuditEvent{Action: model.AuditActionUpdate, TargetType: model.AuditTargetAgent, TargetID: agent.ID, TargetName: agent.Name, OrgID: org.ID})
 	c.JSON(http.StatusOK, agent)
 }
 
@@ -404,5 +409,6 @@ func DeleteOrgAgent(c *gin.Context) {
 		return
 	}
 
+	recordAudit(c, &model.AuditEvent{Action: model.AuditActionDelete, TargetType: model.AuditTargetAgent, TargetID: agent.ID, TargetName: agent.Name, OrgID: org.ID})
 	c.Status(http.StatusNoContent)
 }
@@ -128,6 +128,7 @@ func TestPatchAgent(t *testing.T) {
 		mockStore := store_mocks.NewStore(t)
 		mockStore.On("AgentFind", int64(1)).Return(fakeAgent, nil)
 		mockStore.On("AgentUpdate", mock.AnythingOfType("*model.Agent")).Return(nil)
+		mockStore.On("AuditEventCreate", mock.AnythingOfType("*model.AuditEvent")).Return(nil)
 
 		mockManager := mocks_manager.NewManager(t)
 		server.Config.Services.Manager = mockManager
@@ -164,6 +165,7 @@ func TestPostAgent(t *testing.T) {
 
 		mockStore := store_mocks.NewStore(t)
 		mockStore.On("AgentCreate", mock.AnythingOfType("*model.Agent")).Return(nil)
+		mockStore.On("AuditEventCreate", mock.AnythingOfType("*model.AuditEvent")).Return(nil)
 
 		w := httptest.NewRecorder()
 		c, _ := gin.CreateTestContext(w)
@@ -193,6 +195,7 @@ func TestDeleteAgent(t *testing.T) {
 		mockStore := store_mocks.NewStore(t)
 		mockStore.On("AgentFind", int64(1)).Return(fakeAgent, nil)
 		mockStore.On("AgentDelete", mock.AnythingOfType("*model.Agent")).Return(nil)
+		mockStore.On("AuditEventCreate", mock.AnythingOfType("*model.AuditEvent")).Return(nil)
 
 		mockManager := mocks_manager.NewManager(t)
 		server.Config.Services.Manager = mockManager
@@ -212,6 +215,9 @@ func TestDeleteAgent(t *testing.T) {
 
 		mockStore.AssertCalled(t, "AgentFind", int64(1))
 		mockStore.AssertCalled(t, "AgentDelete", mock.AnythingOfType("*model.Agent"))
+		mockStore.AssertCalled(t, "AuditEventCreate", mock.MatchedBy(func(event *model.AuditEvent) bool {
+			return event.Action == model.AuditActionDelete && event.TargetType == model.AuditTargetAgent && event.TargetID == 1
+		}))
 		mockQueue.AssertCalled(t, "KickAgentWorkers", int64(1))
 		assert.Equal(t, http.StatusNoContent, w.Code)
 	})
@@ -249,6 +255,7 @@ func TestPostOrgAgent(t *testing.T) {
 	t.Run("create org agent should succeed", func(t *testing.T) {
 		mockStore := store_mocks.NewStore(t)
 		mockStore.On("AgentCreate", mock.AnythingOfType("*model.Agent")).Return(nil)
+		mockStore.On("AuditEventCreate", mock.AnythingOfType("*model.AuditEvent")).Return(nil)
 
 		w := httptest.NewRecorder()
 		c, _ := gin.CreateTestContext(w)
@@ -0,0 +1,126 @@
+// Copyright 2025 Woodpecker Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package api
+
+import (
+	"errors"
+	"mime"
+	"net/http"
+	"path"
+	"strconv"
+
+	"github.com/gin-gonic/gin"
+
+	"go.woodpecker-ci.org/woodpecker/v3/server"
+	"go.woodpecker-ci.org/woodpecker/v3/server/router/middleware/session"
+	"go.woodpecker-ci.org/woodpecker/v3/server/services/artifact"
+	"go.woodpecker-ci.org/woodpecker/v3/server/store"
+)
+
+// GetPipelineArtifacts
+//
+//	@Summary	List the artifacts of a pipeline
+//	@Router		/repos/{repo_id}/pipelines/{number}/artifacts [get]
+//	@Produce	json
+//	@Success	200	{array}	Artifact
+//	@Tags		Pipeline artifacts
+//	@Param		Authorization	header	string	true	"Insert your personal access token"	default(Bearer <personal access token>)
+//	@Param		repo_id			path	int		true	"the repository id"
+//	@Param		number			path	int		true	"the number of the pipeline"
+func GetPipelineArtifacts(c *gin.Context) {
+	_store := store.FromContext(c)
+	repo := session.Repo(c)
+
+	num, err := strconv.ParseInt(c.Param("number"), 10, 64)
+	if err != nil {
+		_ = c.AbortWithError(http.StatusBadRequest, err)
+		return
+	}
+
+	pl, err := _store.GetPipelineNumber(repo, num)
+	if err != nil {
+		handleDBError(c, err)
+		return
+	}
+
+	artifacts, err := _store.ArtifactList(pl)
+	if err != nil {
+		handleDBError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, artifacts)
+}
+
+// GetPipelineArtifact
+//
+//	@Summary	Download an artifact of a pipeline
+//	@Router		/repos/{repo_id}/pipelines/{number}/artifacts/{artifact_id} [get]
+//	@Produce	octet-stream
+//	@Success	200
+//	@Tags		Pipeline artifacts
+//	@Param		Authorization	header	string	true	"Insert your personal access token"	default(Bearer <personal access token>)
+//	@Param		repo_id			path	int		true	"the repository id"
+//	@Param		number			path	int		true	"the number of the pipeline"
+//	@Param		artifact_id		path	int		true	"the artifact id"
+func GetPipelineArtifact(c *gin.Context) {
+	_store := store.FromContext(c)
+	repo := session.Repo(c)
+
+	num, err := strconv.ParseInt(c.Param("number"), 10, 64)
+	if err != nil {
+		_ = c.AbortWithError(http.StatusBadRequest, err)
+		return
+	}
+
+	artifactID, err := strconv.ParseInt(c.Param("artifact_id"), 10, 64)
+	if err != nil {
+		_ = c.AbortWithError(http.StatusBadRequest, err)
+		return
+	}
+
+	pl, err := _store.GetPipelineNumber(repo, num)
+	if err != nil {
+		handleDBError(c, err)
+		return
+	}
+
+	a, err := _store.ArtifactFind(pl, artifactID)
+	if err != nil {
+		handleDBError(c, err)
+		return
+	}
+
+	artifactStore := server.Config.Services.ArtifactStore
+	if artifactStore == nil {
+		c.String(http.StatusNotFound, "No artifact store is configured")
+		return
+	}
+
+	content, err := artifactStore.ArtifactGet(a)
+	if errors.Is(err, artifact.ErrNotFound) {
+		c.AbortWithStatus(http.StatusNotFound)
+		return
+	}
+	if err != nil {
+		_ = c.AbortWithError(http.StatusInternalServerError, err)
+		return
+	}
+	defer content.Close()
+
+	c.DataFromReader(http.StatusOK, a.Size, "application/octet-stream", content, map[string]string{
+		"Content-Disposition": mime.FormatMediaType("attachment", map[string]string{"filename": path.Base(a.Name)}),
+	})
+}
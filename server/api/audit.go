@@ -0,0 +1,150 @@
+// Copyright 2025 Woodpecker Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package api
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"maps"
+	"net/http"
+	"slices"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/rs/zerolog/log"
+
+	"go.woodpecker-ci.org/woodpecker/v3/server/model"
+	"go.woodpecker-ci.org/woodpecker/v3/server/router/middleware/session"
+	"go.woodpecker-ci.org/woodpecker/v3/server/store"
+)
+
+// GetAuditEvents
+//
+//	@Summary		List audit events
+//	@Description	Returns the administrative and security-relevant actions of all users, newest first.
+//	@Router			/audit-events [get]
+//	@Produce		json
+//	@Success		200	{array}	AuditEvent
+//	@Tags			Audit log
+//	@Param			Authorization	header	string	true	"Insert your personal access token"	default(Bearer <personal access token>)
+//	@Param			page			query	int		false	"for response pagination, page offset number"	default(1)
+//	@Param			perPage			query	int		false	"for response pagination, max items per page"	default(50)
+//	@Param			user			query	string	false	"filter events by the login of the acting user"
+//	@Param			action			query	string	false	"filter events by action"
+//	@Param			target_type		query	string	false	"filter events by the type of the changed resource"
+//	@Param			repo_id			query	int		false	"filter events by repository id"
+//	@Param			org_id			query	int		false	"filter events by organization id"
+//	@Param			before			query	string	false	"only return events before this RFC3339 date"
+//	@Param			after			query	string	false	"only return events after this RFC3339 date"
+func GetAuditEvents(c *gin.Context) {
+	filter := &model.AuditEventFilter{
+		UserLogin:  c.Query("user"),
+		Action:     model.AuditAction(c.Query("action")),
+		TargetType: model.AuditTarget(c.Query("target_type")),
+	}
+
+	for param, id := range map[string]*int64{"repo_id": &filter.RepoID, "org_id": &filter.OrgID} {
+		if value := c.Query(param); value != "" {
+			var err error
+			if *id, err = strconv.ParseInt(value, 10, 64); err != nil {
+				_ = c.AbortWithError(http.StatusBadRequest, err)
+				return
+			}
+		}
+	}
+
+	if before := c.Query("before"); before != "" {
+		beforeDt, err := time.Parse(time.RFC3339, before)
+		if err != nil {
+			_ = c.AbortWithError(http.StatusBadRequest, err)
+			return
+		}
+		filter.Before = beforeDt.Unix()
+	}
+
+	if after := c.Query("after"); after != "" {
+		afterDt, err := time.Parse(time.RFC3339, after)
+		if err != nil {
+			_ = c.AbortWithError(http.StatusBadRequest, err)
+			return
+		}
+		filter.After = afterDt.Unix()
+	}
+
+	events, err := store.FromContext(c).AuditEventList(filter, session.Pagination(c))
+	if err != nil {
+		c.String(http.StatusInternalServerError, "Error getting audit events. %s", err)
+		return
+	}
+	c.JSON(http.StatusOK, events)
+}
+
+// recordAudit saves an audit event for an action of the current user. Errors are only
+// logged as the action itself already succeeded.
+func recordAudit(c *gin.Context, event *model.AuditEvent) {
+	if user := session.User(c); user != nil {
+		event.UserID = user.ID
+		event.UserLogin = user.Login
+	}
+
+	if err := store.FromContext(c).AuditEventCreate(event); err != nil {
+		log.Error().Err(err).Msgf("could not save audit event: %s %s %q", event.Action, event.TargetType, event.TargetName)
+	}
+}
+
+// auditChanges describes the JSON fields which differ between before and after.
+func auditChanges(before, after any) string {
+	var old, updated map[string]json.RawMessage
+	if err := unmarshalAsMap(before, &old); err != nil {
+		return ""
+	}
+	if err := unmarshalAsMap(after, &updated); err != nil {
+		return ""
+	}
+
+	keys := slices.Collect(maps.Keys(old))
+	for key := range updated {
+		if _, ok := old[key]; !ok {
+			keys = append(keys, key)
+		}
+	}
+	slices.Sort(keys)
+
+	var changes []string
+	for _, key := range keys {
+		if !bytes.Equal(old[key], updated[key]) {
+			changes = append(changes, fmt.Sprintf("%s: %s -> %s", key, jsonOrNull(old[key]), jsonOrNull(updated[key])))
+		}
+	}
+	return strings.Join(changes, ", ")
+}
+
+func jsonOrNull(value json.RawMessage) json.RawMessage {
+	if value == nil {
+		return json.RawMessage("null")
+	}
+	return value
+}
+
+func unmarshalAsMap(v any, m *map[string]json.RawMessage) error {
+	data, err := json.Marshal(v)
+	if err != nil {
+		return err
+	}
+	return json.Unmarshal(data, m)
+}
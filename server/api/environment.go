@@ -0,0 +1,379 @@
+// Copyright 2025 Woodpecker Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package api
+
+import (
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+
+	"go.woodpecker-ci.org/woodpecker/v3/server/model"
+	"go.woodpecker-ci.org/woodpecker/v3/server/router/middleware/session"
+	"go.woodpecker-ci.org/woodpecker/v3/server/store"
+)
+
+// GetEnvironmentList
+//
+//	@Summary	List repository environments
+//	@Router		/repos/{repo_id}/environments [get]
+//	@Produce	json
+//	@Success	200	{array}	Environment
+//	@Tags		Repository environments
+//	@Param		Authorization	header	string	true	"Insert your personal access token"	default(Bearer <personal access token>)
+//	@Param		repo_id			path	int		true	"the repository id"
+//	@Param		page			query	int		false	"for response pagination, page offset number"	default(1)
+//	@Param		perPage			query	int		false	"for response pagination, max items per page"	default(50)
+func GetEnvironmentList(c *gin.Context) {
+	repo := session.Repo(c)
+	list, err := store.FromContext(c).EnvironmentList(repo, session.Pagination(c))
+	if err != nil {
+		c.String(http.StatusInternalServerError, "Error getting environment list. %s", err)
+		return
+	}
+	c.JSON(http.StatusOK, list)
+}
+
+// GetEnvironment
+//
+//	@Summary	Get a repository environment by name
+//	@Router		/repos/{repo_id}/environments/{environment} [get]
+//	@Produce	json
+//	@Success	200	{object}	Environment
+//	@Tags		Repository environments
+//	@Param		Authorization	header	string	true	"Insert your personal access token"	default(Bearer <personal access token>)
+//	@Param		repo_id			path	int		true	"the repository id"
+//	@Param		environment		path	string	true	"the environment name"
+func GetEnvironment(c *gin.Context) {
+	env := findEnvironment(c)
+	if env == nil {
+		return
+	}
+	c.JSON(http.StatusOK, env)
+}
+
+// PostEnvironment
+//
+//	@Summary	Create a repository environment
+//	@Router		/repos/{repo_id}/environments [post]
+//	@Produce	json
+//	@Success	200	{object}	Environment
+//	@Tags		Repository environments
+//	@Param		Authorization	header	string				true	"Insert your personal access token"	default(Bearer <personal access token>)
+//	@Param		repo_id			path	int					true	"the repository id"
+//	@Param		environment		body	EnvironmentPatch	true	"the new environment"
+func PostEnvironment(c *gin.Context) {
+	repo := session.Repo(c)
+
+	in := new(model.EnvironmentPatch)
+	if err := c.Bind(in); err != nil {
+		c.String(http.StatusBadRequest, "Error parsing request. %s", err)
+		return
+	}
+	env := &model.Environment{RepoID: repo.ID}
+	in.Apply(env)
+
+	if err := env.Validate(); err != nil {
+		c.String(http.StatusUnprocessableEntity, "Error inserting environment. %s", err)
+		return
+	}
+	if err := store.FromContext(c).EnvironmentCreate(env); err != nil {
+		c.String(http.StatusInternalServerError, "Error inserting environment %q. %s", env.Name, err)
+		return
+	}
+	recordAudit(c, &model.AuditEvent{Action: model.AuditActionCreate, TargetType: model.AuditTargetEnvironment, TargetID: env.ID, TargetName: env.Name, RepoID: repo.ID})
+	c.JSON(http.StatusOK, env)
+}
+
+// PatchEnvironment
+//
+//	@Summary	Update a repository environment by name
+//	@Router		/repos/{repo_id}/environments/{environment} [patch]
+//	@Produce	json
+//	@Success	200	{object}	Environment
+//	@Tags		Repository environments
+//	@Param		Authorization	header	string				true	"Insert your personal access token"	default(Bearer <personal access token>)
+//	@Param		repo_id			path	int					true	"the repository id"
+//	@Param		environment		path	string				true	"the environment name"
+//	@Param		patch			body	EnvironmentPatch	true	"the environment data"
+func PatchEnvironment(c *gin.Context) {
+	env := findEnvironment(c)
+	if env == nil {
+		return
+	}
+
+	in := new(model.EnvironmentPatch)
+	if err := c.Bind(in); err != nil {
+		c.String(http.StatusBadRequest, "Error parsing request. %s", err)
+		return
+	}
+	before := *env
+	in.Apply(env)
+
+	if err := env.Validate(); err != nil {
+		c.String(http.StatusUnprocessableEntity, "Error updating environment. %s", err)
+		return
+	}
+	if err := store.FromContext(c).EnvironmentUpdate(env); err != nil {
+		c.String(http.StatusInternalServerError, "Error updating environment %q. %s", env.Name, err)
+		return
+	}
+	recordAudit(c, &model.AuditEvent{Action: model.AuditActionUpdate, TargetType: model.AuditTargetEnvironment, TargetID: env.ID, TargetName: env.Name, RepoID: env.RepoID, Details: auditChanges(before, env)})
+	c.JSON(http.StatusOK, env)
+}
+
+// DeleteEnvironment
+//
+//	@Summary	Delete a repository environment by name
+//	@Router		/repos/{repo_id}/environments/{environment} [delete]
+//	@Produce	plain
+//	@Success	204
+//	@Tags		Repository environments
+//	@Param		Authorization	header	string	true	"Insert your personal access token"	default(Bearer <personal access token>)
+//	@Param		repo_id			path	int		true	"the repository id"
+//	@Param		environment		path	string	true	"the environment name"
+func DeleteEnvironment(c *gin.Context) {
+	env := findEnvironment(c)
+	if env == nil {
+		return
+	}
+
+	if err := store.FromContext(c).EnvironmentDelete(env); err != nil {
+		handleDBError(c, err)
+		return
+	}
+	recordAudit(c, &model.AuditEvent{Action: model.AuditActionDelete, TargetType: model.AuditTargetEnvironment, TargetID: env.ID, TargetName: env.Name, RepoID: env.RepoID})
+	c.Status(http.StatusNoContent)
+}
+
+// GetEnvironmentDeployments
+//
+//	@Summary		List the deployments to a repository environment
+//	@Description	Get the deployment pipelines to the environment, the most recent first.
+//	@Router			/repos/{repo_id}/environments/{environment}/deployments [get]
+//	@Produce		json
+//	@Success		200	{array}	Pipeline
+//	@Tags			Repository environments
+//	@Param			Authorization	header	string	true	"Insert your personal access token"	default(Bearer <personal access token>)
+//	@Param			repo_id			path	int		true	"the repository id"
+//	@Param			environment		path	string	true	"the environment name"
+//	@Param			page			query	int		false	"for response pagination, page offset number"	default(1)
+//	@Param			perPage			query	int		false	"for response pagination, max items per page"	default(50)
+func GetEnvironmentDeployments(c *gin.Context) {
+	env := findEnvironment(c)
+	if env == nil {
+		return
+	}
+
+	filter := &model.PipelineFilter{
+		Events:   []model.WebhookEvent{model.EventDeploy},
+		DeployTo: env.Name,
+	}
+	pipelines, err := store.FromContext(c).GetPipelineList(session.Repo(c), session.Pagination(c), filter)
+	if err != nil {
+		c.String(http.StatusInternalServerError, "Error getting deployments. %s", err)
+		return
+	}
+	c.JSON(http.StatusOK, pipelines)
+}
+
+// GetEnvironmentSecretList
+//
+//	@Summary	List the secrets of a repository environment
+//	@Router		/repos/{repo_id}/environments/{environment}/secrets [get]
+//	@Produce	json
+//	@Success	200	{array}	Secret
+//	@Tags		Repository environments
+//	@Param		Authorization	header	string	true	"Insert your personal access token"	default(Bearer <personal access token>)
+//	@Param		repo_id			path	int		true	"the repository id"
+//	@Param		environment		path	string	true	"the environment name"
+//	@Param		page			query	int		false	"for response pagination, page offset number"	default(1)
+//	@Param		perPage			query	int		false	"for response pagination, max items per page"	default(50)
+func GetEnvironmentSecretList(c *gin.Context) {
+	env := findEnvironment(c)
+	if env == nil {
+		return
+	}
+
+	list, err := store.FromContext(c).EnvironmentSecretList(env, session.Pagination(c))
+	if err != nil {
+		c.String(http.StatusInternalServerError, "Error getting secret list. %s", err)
+		return
+	}
+	// copy the secret detail to remove the sensitive
+	// password and token fields.
+	for i, secret := range list {
+		list[i] = secret.Copy()
+	}
+	c.JSON(http.StatusOK, list)
+}
+
+// GetEnvironmentSecret
+//
+//	@Summary	Get a secret of a repository environment by name
+//	@Router		/repos/{repo_id}/environments/{environment}/secrets/{secretName} [get]
+//	@Produce	json
+//	@Success	200	{object}	Secret
+//	@Tags		Repository environments
+//	@Param		Authorization	header	string	true	"Insert your personal access token"	default(Bearer <personal access token>)
+//	@Param		repo_id			path	int		true	"the repository id"
+//	@Param		environment		path	string	true	"the environment name"
+//	@Param		secretName		path	string	true	"the secret name"
+func GetEnvironmentSecret(c *gin.Context) {
+	env := findEnvironment(c)
+	if env == nil {
+		return
+	}
+
+	secret, err := store.FromContext(c).EnvironmentSecretFind(env, c.Param("secret"))
+	if err != nil {
+		handleDBError(c, err)
+		return
+	}
+	c.JSON(http.StatusOK, secret.Copy())
+}
+
+// PostEnvironmentSecret
+//
+//	@Summary		Create a secret of a repository environment
+//	@Description	Environment secrets are only available to deployments to the environment and take precedence over other secrets with the same name.
+//	@Router			/repos/{repo_id}/environments/{environment}/secrets [post]
+//	@Produce		json
+//	@Success		200	{object}	Secret
+//	@Tags			Repository environments
+//	@Param			Authorization	header	string	true	"Insert your personal access token"	default(Bearer <personal access token>)
+//	@Param			repo_id			path	int		true	"the repository id"
+//	@Param			environment		path	string	true	"the environment name"
+//	@Param			secret			body	Secret	true	"the new secret"
+func PostEnvironmentSecret(c *gin.Context) {
+	env := findEnvironment(c)
+	if env == nil {
+		return
+	}
+
+	in := new(model.Secret)
+	if err := c.Bind(in); err != nil {
+		c.String(http.StatusBadRequest, "Error parsing secret. %s", err)
+		return
+	}
+	secret := &model.Secret{
+		RepoID:        env.RepoID,
+		EnvironmentID: env.ID,
+		Name:          in.Name,
+		Value:         in.Value,
+		Events:        []model.WebhookEvent{model.EventDeploy},
+		Images:        in.Images,
+	}
+	if err := secret.Validate(); err != nil {
+		c.String(http.StatusUnprocessableEntity, "Error inserting secret. %s", err)
+		return
+	}
+
+	if err := store.FromContext(c).SecretCreate(secret); err != nil {
+		c.String(http.StatusInternalServerError, "Error inserting secret %q. %s", in.Name, err)
+		return
+	}
+	recordAudit(c, &model.AuditEvent{Action: model.AuditActionCreate, TargetType: model.AuditTargetSecret, TargetID: secret.ID, TargetName: env.Name + "/" + secret.Name, RepoID: env.RepoID})
+	c.JSON(http.StatusOK, secret.Copy())
+}
+
+// PatchEnvironmentSecret
+//
+//	@Summary	Update a secret of a repository environment by name
+//	@Router		/repos/{repo_id}/environments/{environment}/secrets/{secretName} [patch]
+//	@Produce	json
+//	@Success	200	{object}	Secret
+//	@Tags		Repository environments
+//	@Param		Authorization	header	string	true	"Insert your personal access token"	default(Bearer <personal access token>)
+//	@Param		repo_id			path	int		true	"the repository id"
+//	@Param		environment		path	string	true	"the environment name"
+//	@Param		secretName		path	string	true	"the secret name"
+//	@Param		secret			body	Secret	true	"the secret itself"
+func PatchEnvironmentSecret(c *gin.Context) {
+	env := findEnvironment(c)
+	if env == nil {
+		return
+	}
+
+	in := new(model.Secret)
+	if err := c.Bind(in); err != nil {
+		c.String(http.StatusBadRequest, "Error parsing secret. %s", err)
+		return
+	}
+
+	_store := store.FromContext(c)
+	secret, err := _store.EnvironmentSecretFind(env, c.Param("secret"))
+	if err != nil {
+		handleDBError(c, err)
+		return
+	}
+	if in.Value != "" {
+		secret.Value = in.Value
+	}
+	if in.Images != nil {
+		secret.Images = in.Images
+	}
+
+	if err := secret.Validate(); err != nil {
+		c.String(http.StatusUnprocessableEntity, "Error updating secret. %s", err)
+		return
+	}
+	if err := _store.SecretUpdate(secret); err != nil {
+		c.String(http.StatusInternalServerError, "Error updating secret %q. %s", secret.Name, err)
+		return
+	}
+	recordAudit(c, &model.AuditEvent{Action: model.AuditActionUpdate, TargetType: model.AuditTargetSecret, TargetID: secret.ID, TargetName: env.Name + "/" + secret.Name, RepoID: env.RepoID})
+	c.JSON(http.StatusOK, secret.Copy())
+}
+
+// DeleteEnvironmentSecret
+//
+//	@Summary	Delete a secret of a repository environment by name
+//	@Router		/repos/{repo_id}/environments/{environment}/secrets/{secretName} [delete]
+//	@Produce	plain
+//	@Success	204
+//	@Tags		Repository environments
+//	@Param		Authorization	header	string	true	"Insert your personal access token"	default(Bearer <personal access token>)
+//	@Param		repo_id			path	int		true	"the repository id"
+//	@Param		environment		path	string	true	"the environment name"
+//	@Param		secretName		path	string	true	"the secret name"
+func DeleteEnvironmentSecret(c *gin.Context) {
+	env := findEnvironment(c)
+	if env == nil {
+		return
+	}
+
+	_store := store.FromContext(c)
+	secret, err := _store.EnvironmentSecretFind(env, c.Param("secret"))
+	if err != nil {
+		handleDBError(c, err)
+		return
+	}
+	if err := _store.SecretDelete(secret); err != nil {
+		handleDBError(c, err)
+		return
+	}
+	recordAudit(c, &model.AuditEvent{Action: model.AuditActionDelete, TargetType: model.AuditTargetSecret, TargetID: secret.ID, TargetName: env.Name + "/" + secret.Name, RepoID: env.RepoID})
+	c.Status(http.StatusNoContent)
+}
+
+// findEnvironment returns the environment of the url parameter or writes an error response and returns nil.
+func findEnvironment(c *gin.Context) *model.Environment {
+	env, err := store.FromContext(c).EnvironmentFind(session.Repo(c), c.Param("environment"))
+	if err != nil {
+		handleDBError(c, err)
+		return nil
+	}
+	return env
+}
@@ -139,6 +139,7 @@ func PatchForge(c *gin.Context) {
 		return
 	}
 
+	recordAudit(c, &model.AuditEvent{Action: model.AuditActionUpdate, TargetType: model.AuditTargetForge, TargetID: forge.ID, TargetName: forge.URL})
 	c.JSON(http.StatusOK, forge)
 }
 
@@ -173,6 +174,7 @@ func PostForge(c *gin.Context) {
 		c.String(http.StatusInternalServerError, err.Error())
 		return
 	}
+	recordAudit(c, &model.AuditEvent{Action: model.AuditActionCreate, TargetType: model.AuditTargetForge, TargetID: forge.ID, TargetName: forge.URL})
 	c.JSON(http.StatusOK, forge)
 }
 
@@ -204,5 +206,6 @@ func DeleteForge(c *gin.Context) {
 		c.String(http.StatusInternalServerError, "Error deleting user. %s", err)
 		return
 	}
+	recordAudit(c, &model.AuditEvent{Action: model.AuditActionDelete, TargetType: model.AuditTargetForge, TargetID: forge.ID, TargetName: forge.URL})
 	c.Status(http.StatusNoContent)
 }
@@ -99,6 +99,7 @@ func PostGlobalRegistry(c *gin.Context) {
 		c.String(http.StatusInternalServerError, "Error inserting global registry %q. %s", in.Address, err)
 		return
 	}
+	recordAudit(c, &model.AuditEvent{Action: model.AuditActionCreate, TargetType: model.AuditTargetRegistry, TargetID: registry.ID, TargetName: registry.Address})
 	c.JSON(http.StatusOK, registry.Copy())
 }
 
@@ -147,6 +148,7 @@ func PatchGlobalRegistry(c *gin.Context) {
 		c.String(http.StatusInternalServerError, "Error updating global registry %q. %s", in.Address, err)
 		return
 	}
+	recordAudit(c, &model.AuditEvent{Action: model.AuditActionUpdate, TargetType: model.AuditTargetRegistry, TargetID: registry.ID, TargetName: registry.Address})
 	c.JSON(http.StatusOK, registry.Copy())
 }
 
@@ -166,5 +168,6 @@ func DeleteGlobalRegistry(c *gin.Context) {
 		handleDBError(c, err)
 		return
 	}
+	recordAudit(c, &model.AuditEvent{Action: model.AuditActionDelete, TargetType: model.AuditTargetRegistry, TargetName: addr})
 	c.Status(http.StatusNoContent)
 }
@@ -100,6 +100,7 @@ func PostGlobalSecret(c *gin.Context) {
 		c.String(http.StatusInternalServerError, "Error inserting global secret %q. %s", in.Name, err)
 		return
 	}
+	recordAudit(c, &model.AuditEvent{Action: model.AuditActionCreate, TargetType: model.AuditTargetSecret, TargetID: secret.ID, TargetName: secret.Name})
 	c.JSON(http.StatusOK, secret.Copy())
 }
 
@@ -148,6 +149,7 @@ func PatchGlobalSecret(c *gin.Context) {
 		c.String(http.StatusInternalServerError, "Error updating global secret %q. %s", in.Name, err)
 		return
 	}
+	recordAudit(c, &model.AuditEvent{Action: model.AuditActionUpdate, TargetType: model.AuditTargetSecret, TargetID: secret.ID, TargetName: secret.Name})
 	c.JSON(http.StatusOK, secret.Copy())
 }
 
@@ -167,5 +169,6 @@ func DeleteGlobalSecret(c *gin.Context) {
 		handleDBError(c, err)
 		return
 	}
+	recordAudit(c, &model.AuditEvent{Action: model.AuditActionDelete, TargetType: model.AuditTargetSecret, TargetName: name})
 	c.Status(http.StatusNoContent)
 }
@@ -35,6 +35,8 @@ func handlePipelineErr(c *gin.Context, err error) {
 		c.String(http.StatusNotFound, "%s", err)
 	case errors.Is(err, &pipeline.ErrBadRequest{}):
 		c.String(http.StatusBadRequest, "%s", err)
+	case errors.Is(err, &pipeline.ErrForbidden{}):
+		c.String(http.StatusForbidden, "%s", err)
 	case errors.Is(err, pipeline.ErrFiltered):
 		// for debugging purpose we add a header
 		c.Writer.Header().Add("Pipeline-Filtered", "true")
@@ -28,6 +28,10 @@ func TestHandlePipelineError(t *testing.T) {
 			err:  &pipeline.ErrBadRequest{Msg: "bad request error"},
 			code: http.StatusBadRequest,
 		},
+		{
+			err:  &pipeline.ErrForbidden{Msg: "forbidden error"},
+			code: http.StatusForbidden,
+		},
 	}
 
 	for _, tt := range tests {
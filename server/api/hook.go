@@ -24,14 +24,18 @@ import (
 
 	"github.com/gin-gonic/gin"
 	"github.com/rs/zerolog/log"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/trace"
 
 	"go.woodpecker-ci.org/woodpecker/v3/server"
 	"go.woodpecker-ci.org/woodpecker/v3/server/forge"
 	"go.woodpecker-ci.org/woodpecker/v3/server/forge/types"
+	"go.woodpecker-ci.org/woodpecker/v3/server/metrics"
 	"go.woodpecker-ci.org/woodpecker/v3/server/model"
 	"go.woodpecker-ci.org/woodpecker/v3/server/pipeline"
 	"go.woodpecker-ci.org/woodpecker/v3/server/store"
 	"go.woodpecker-ci.org/woodpecker/v3/shared/token"
+	"go.woodpecker-ci.org/woodpecker/v3/shared/tracing"
 )
 
 // GetQueueInfo
@@ -122,6 +126,7 @@ func PostHook(c *gin.Context) {
 	if err != nil {
 		msg := "failure to parse token from hook"
 		log.Error().Err(err).Msg(msg)
+		metrics.WebhookError("token")
 		c.String(http.StatusBadRequest, msg)
 		return
 	}
@@ -129,6 +134,7 @@ func PostHook(c *gin.Context) {
 	if repo == nil {
 		msg := "failure to get repo from token"
 		log.Error().Msg(msg)
+		metrics.WebhookError("token")
 		c.String(http.StatusBadRequest, msg)
 		return
 	}
@@ -136,6 +142,7 @@ func PostHook(c *gin.Context) {
 	_forge, err := server.Config.Services.Manager.ForgeFromRepo(repo)
 	if err != nil {
 		log.Error().Err(err).Int64("repo-id", repo.ID).Msgf("Cannot get forge with id: %d", repo.ForgeID)
+		metrics.WebhookError("forge")
 		c.AbortWithStatus(http.StatusInternalServerError)
 		return
 	}
@@ -155,6 +162,7 @@ func PostHook(c *gin.Context) {
 
 		msg := "failure to parse hook"
 		log.Debug().Err(err).Msg(msg)
+		metrics.WebhookError("parse")
 		c.String(http.StatusBadRequest, msg)
 		return
 	}
@@ -168,6 +176,7 @@ func PostHook(c *gin.Context) {
 	if repoFromForge == nil {
 		msg := "failure to ascertain repo from hook"
 		log.Debug().Msg(msg)
+		metrics.WebhookError("parse")
 		c.String(http.StatusBadRequest, msg)
 		return
 	}
@@ -178,6 +187,7 @@ func PostHook(c *gin.Context) {
 
 	if repo.ForgeRemoteID != repoFromForge.ForgeRemoteID {
 		log.Warn().Msgf("ignoring hook: repo %s does not match the repo from the token", repo.FullName)
+		metrics.WebhookError("repo_mismatch")
 		c.String(http.StatusBadRequest, "failure to parse token from hook")
 		return
 	}
@@ -200,6 +210,7 @@ func PostHook(c *gin.Context) {
 
 	user, err := _store.GetUser(repo.UserID)
 	if err != nil {
+		metrics.WebhookError("store")
 		handleDBError(c, err)
 		return
 	}
@@ -213,6 +224,7 @@ func PostHook(c *gin.Context) {
 		// create a redirection
 		err = _store.CreateRedirection(&model.Redirection{RepoID: repo.ID, FullName: repo.FullName})
 		if err != nil {
+			metrics.WebhookError("store")
 			_ = c.AbortWithError(http.StatusInternalServerError, err)
 			return
 		}
@@ -221,6 +233,7 @@ func PostHook(c *gin.Context) {
 	repo.Update(repoFromForge)
 	err = _store.UpdateRepo(repo)
 	if err != nil {
+		metrics.WebhookError("store")
 		c.String(http.StatusInternalServerError, err.Error())
 		return
 	}
@@ -239,8 +252,18 @@ func PostHook(c *gin.Context) {
 	// 6. Finally create a pipeline
 	//
 
-	pl, err := pipeline.Create(c, _store, repo, pipelineFromForge)
+	ctx, span := tracing.Tracer.Start(c, "PostHook", trace.WithAttributes(
+		attribute.String("repo", repo.FullName),
+		attribute.String("event", string(pipelineFromForge.Event)),
+	))
+	defer span.End()
+
+	pl, err := pipeline.Create(ctx, _store, repo, pipelineFromForge)
 	if err != nil {
+		tracing.RecordError(span, err)
+		if !errors.Is(err, pipeline.ErrFiltered) {
+			metrics.WebhookError("pipeline")
+		}
 		handlePipelineErr(c, err)
 	} else {
 		c.JSON(http.StatusOK, pl)
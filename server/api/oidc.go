@@ -0,0 +1,47 @@
+// Copyright 2025 Woodpecker Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package api
+
+import (
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+
+	"go.woodpecker-ci.org/woodpecker/v3/server"
+)
+
+// GetOIDCConfiguration
+//
+//	@Summary		Get the OpenID configuration
+//	@Description	Returns the OpenID provider metadata of the identity tokens issued to workflows.
+//	@Router			/.well-known/openid-configuration [get]
+//	@Produce		json
+//	@Success		200	{object}	object
+//	@Tags			System
+func GetOIDCConfiguration(c *gin.Context) {
+	c.JSON(http.StatusOK, server.Config.Services.OIDC.Discovery())
+}
+
+// GetOIDCKeys
+//
+//	@Summary		Get the OIDC signing keys
+//	@Description	Returns the JSON web key set to verify the identity tokens issued to workflows.
+//	@Router			/.well-known/jwks.json [get]
+//	@Produce		json
+//	@Success		200	{object}	object
+//	@Tags			System
+func GetOIDCKeys(c *gin.Context) {
+	c.JSON(http.StatusOK, server.Config.Services.OIDC.Keys())
+}
@@ -162,6 +162,51 @@ func LookupOrg(c *gin.Context) {
 	c.JSON(http.StatusOK, org)
 }
 
+// PatchOrg
+//
+//	@Summary	Update an organization
+//	@Router		/orgs/{org_id} [patch]
+//	@Produce	json
+//	@Success	200	{object}	Org
+//	@Tags		Organization
+//	@Param		Authorization	header	string		true	"Insert your personal access token"	default(Bearer <personal access token>)
+//	@Param		org_id			path	string		true	"the organization's id"
+//	@Param		orgPatch		body	OrgPatch	true	"the organization's update parameters"
+func PatchOrg(c *gin.Context) {
+	_store := store.FromContext(c)
+	org := session.Org(c)
+
+	in := new(model.OrgPatch)
+	if err := c.Bind(in); err != nil {
+		_ = c.AbortWithError(http.StatusBadRequest, err)
+		return
+	}
+
+	if in.Retention != nil {
+		org.Retention = *in.Retention
+	}
+	if in.ConfigExtensionEndpoint != nil {
+		if err := model.ValidateConfigExtensionEndpoint(*in.ConfigExtensionEndpoint); err != nil {
+			c.String(http.StatusBadRequest, err.Error())
+			return
+		}
+		if *in.ConfigExtensionEndpoint != "" {
+			if err := server.Config.Services.Manager.ValidateEndpoint(c, *in.ConfigExtensionEndpoint); err != nil {
+				c.String(http.StatusBadRequest, err.Error())
+				return
+			}
+		}
+		org.ConfigExtensionEndpoint = *in.ConfigExtensionEndpoint
+	}
+
+	if err := _store.OrgUpdate(org); err != nil {
+		_ = c.AbortWithError(http.StatusInternalServerError, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, org)
+}
+
 // DeleteOrg
 //
 //	@Summary		Delete an organization
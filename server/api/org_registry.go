@@ -109,6 +109,7 @@ func PostOrgRegistry(c *gin.Context) {
 		c.String(http.StatusInternalServerError, "Error inserting org %q registry %q. %s", org.ID, in.Address, err)
 		return
 	}
+	recordAudit(c, &model.AuditEvent{Action: model.AuditActionCreate, TargetType: model.AuditTargetRegistry, TargetID: registry.ID, TargetName: registry.Address, OrgID: org.ID})
 	c.JSON(http.StatusOK, registry.Copy())
 }
 
@@ -158,6 +159,7 @@ func PatchOrgRegistry(c *gin.Context) {
 		c.String(http.StatusInternalServerError, "Error updating org %q registry %q. %s", org.ID, in.Address, err)
 		return
 	}
+	recordAudit(c, &model.AuditEvent{Action: model.AuditActionUpdate, TargetType: model.AuditTargetRegistry, TargetID: registry.ID, TargetName: registry.Address, OrgID: org.ID})
 	c.JSON(http.StatusOK, registry.Copy())
 }
 
@@ -180,5 +182,6 @@ func DeleteOrgRegistry(c *gin.Context) {
 		handleDBError(c, err)
 		return
 	}
+	recordAudit(c, &model.AuditEvent{Action: model.AuditActionDelete, TargetType: model.AuditTargetRegistry, TargetName: addr, OrgID: org.ID})
 	c.Status(http.StatusNoContent)
 }
@@ -110,6 +110,7 @@ func PostOrgSecret(c *gin.Context) {
 		c.String(http.StatusInternalServerError, "Error inserting org %q secret %q. %s", org.ID, in.Name, err)
 		return
 	}
+	recordAudit(c, &model.AuditEvent{Action: model.AuditActionCreate, TargetType: model.AuditTargetSecret, TargetID: secret.ID, TargetName: secret.Name, OrgID: org.ID})
 	c.JSON(http.StatusOK, secret.Copy())
 }
 
@@ -159,6 +160,7 @@ func PatchOrgSecret(c *gin.Context) {
 		c.String(http.StatusInternalServerError, "Error updating org %q secret %q. %s", org.ID, in.Name, err)
 		return
 	}
+	recordAudit(c, &model.AuditEvent{Action: model.AuditActionUpdate, TargetType: model.AuditTargetSecret, TargetID: secret.ID, TargetName: secret.Name, OrgID: org.ID})
 	c.JSON(http.StatusOK, secret.Copy())
 }
 
@@ -181,5 +183,6 @@ func DeleteOrgSecret(c *gin.Context) {
 		handleDBError(c, err)
 		return
 	}
+	recordAudit(c, &model.AuditEvent{Action: model.AuditActionDelete, TargetType: model.AuditTargetSecret, TargetName: name, OrgID: org.ID})
 	c.Status(http.StatusNoContent)
 }
@@ -0,0 +1,146 @@
+// Copyright 2025 Woodpecker Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package api
+
+import (
+	"encoding/base32"
+	"net/http"
+	"strconv"
+
+	"github.com/gin-gonic/gin"
+	"github.com/gorilla/securecookie"
+
+	"go.woodpecker-ci.org/woodpecker/v3/server/model"
+	"go.woodpecker-ci.org/woodpecker/v3/server/router/middleware/session"
+	"go.woodpecker-ci.org/woodpecker/v3/server/store"
+	"go.woodpecker-ci.org/woodpecker/v3/shared/token"
+)
+
+// GetPersonalAccessTokens
+//
+//	@Summary	List the personal access tokens of the current user
+//	@Router		/user/tokens [get]
+//	@Produce	json
+//	@Success	200	{array}	PersonalAccessToken
+//	@Tags		User
+//	@Param		Authorization	header	string	true	"Insert your personal access token"	default(Bearer <personal access token>)
+//	@Param		page			query	int		false	"for response pagination, page offset number"	default(1)
+//	@Param		perPage			query	int		false	"for response pagination, max items per page"	default(50)
+func GetPersonalAccessTokens(c *gin.Context) {
+	user := session.User(c)
+	tokens, err := store.FromContext(c).PersonalAccessTokenList(user, session.Pagination(c))
+	if err != nil {
+		c.String(http.StatusInternalServerError, "Error getting personal access tokens. %s", err)
+		return
+	}
+	c.JSON(http.StatusOK, tokens)
+}
+
+// PostPersonalAccessToken
+//
+//	@Summary		Create a personal access token
+//	@Description	Creates a named token with the given scopes, optionally restricted to a repository and with an expiration date (unix timestamp). The token value is only returned once.
+//	@Router			/user/tokens [post]
+//	@Produce		json
+//	@Success		200	{object}	PersonalAccessToken
+//	@Tags			User
+//	@Param			Authorization	header	string						true	"Insert your personal access token"	default(Bearer <personal access token>)
+//	@Param			token			body	PersonalAccessTokenOptions	true	"the token options"
+func PostPersonalAccessToken(c *gin.Context) {
+	_store := store.FromContext(c)
+	user := session.User(c)
+
+	in := new(model.PersonalAccessTokenOptions)
+	if err := c.Bind(in); err != nil {
+		c.String(http.StatusBadRequest, "Error parsing request. %s", err)
+		return
+	}
+
+	pat := &model.PersonalAccessToken{
+		UserID:  user.ID,
+		Name:    in.Name,
+		Scopes:  in.Scopes,
+		RepoID:  in.RepoID,
+		Expires: in.Expires,
+		Secret: base32.StdEncoding.EncodeToString(
+			securecookie.GenerateRandomKey(32),
+		),
+	}
+	if err := pat.Validate(); err != nil {
+		c.String(http.StatusUnprocessableEntity, "Error inserting personal access token. %s", err)
+		return
+	}
+	if pat.RepoID != 0 {
+		if _, err := _store.GetRepo(pat.RepoID); err != nil {
+			handleDBError(c, err)
+			return
+		}
+	}
+
+	if err := _store.PersonalAccessTokenCreate(pat); err != nil {
+		c.String(http.StatusInternalServerError, "Error inserting personal access token. %s", err)
+		return
+	}
+
+	t := token.New(token.PersonalToken)
+	t.Set("user-id", strconv.FormatInt(user.ID, 10))
+	t.Set("token-id", strconv.FormatInt(pat.ID, 10))
+	tokenString, err := t.SignExpires(user.Hash+pat.Secret, pat.Expires)
+	if err != nil {
+		_ = c.AbortWithError(http.StatusInternalServerError, err)
+		return
+	}
+	pat.Token = tokenString
+
+	recordAudit(c, &model.AuditEvent{Action: model.AuditActionCreate, TargetType: model.AuditTargetToken, TargetID: pat.ID, TargetName: pat.Name, RepoID: pat.RepoID})
+	c.JSON(http.StatusOK, pat)
+}
+
+// DeletePersonalAccessToken
+//
+//	@Summary	Revoke a personal access token
+//	@Router		/user/tokens/{token_id} [delete]
+//	@Produce	plain
+//	@Success	204
+//	@Tags		User
+//	@Param		Authorization	header	string	true	"Insert your personal access token"	default(Bearer <personal access token>)
+//	@Param		token_id		path	int		true	"the token id"
+func DeletePersonalAccessToken(c *gin.Context) {
+	_store := store.FromContext(c)
+	user := session.User(c)
+
+	id, err := strconv.ParseInt(c.Param("token_id"), 10, 64)
+	if err != nil {
+		c.String(http.StatusBadRequest, "Error parsing token id. %s", err)
+		return
+	}
+
+	pat, err := _store.PersonalAccessTokenFind(id)
+	if err != nil {
+		handleDBError(c, err)
+		return
+	}
+	if pat.UserID != user.ID {
+		c.String(http.StatusNotFound, "Personal access token not found")
+		return
+	}
+
+	if err := _store.PersonalAccessTokenDelete(pat); err != nil {
+		handleDBError(c, err)
+		return
+	}
+	recordAudit(c, &model.AuditEvent{Action: model.AuditActionDelete, TargetType: model.AuditTargetToken, TargetID: pat.ID, TargetName: pat.Name, RepoID: pat.RepoID})
+	c.Status(http.StatusNoContent)
+}
@@ -21,20 +21,25 @@ import (
 	"errors"
 	"fmt"
 	"net/http"
+	"slices"
 	"strconv"
 	"strings"
 	"time"
 
 	"github.com/gin-gonic/gin"
 	"github.com/rs/zerolog/log"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/trace"
 
 	"go.woodpecker-ci.org/woodpecker/v3/server"
 	"go.woodpecker-ci.org/woodpecker/v3/server/model"
 	"go.woodpecker-ci.org/woodpecker/v3/server/pipeline"
 	"go.woodpecker-ci.org/woodpecker/v3/server/pipeline/stepbuilder"
 	"go.woodpecker-ci.org/woodpecker/v3/server/router/middleware/session"
+	"go.woodpecker-ci.org/woodpecker/v3/server/services/artifact"
 	"go.woodpecker-ci.org/woodpecker/v3/server/store"
 	"go.woodpecker-ci.org/woodpecker/v3/server/store/types"
+	"go.woodpecker-ci.org/woodpecker/v3/shared/tracing"
 )
 
 // CreatePipeline
@@ -75,8 +80,15 @@ func CreatePipeline(c *gin.Context) {
 
 	tmpPipeline := createTmpPipeline(model.EventManual, lastCommit, user, &opts)
 
-	pl, err := pipeline.Create(c, _store, repo, tmpPipeline)
+	ctx, span := tracing.Tracer.Start(c, "CreatePipeline", trace.WithAttributes(
+		attribute.String("repo", repo.FullName),
+		attribute.String("event", string(tmpPipeline.Event)),
+	))
+	defer span.End()
+
+	pl, err := pipeline.Create(ctx, _store, repo, tmpPipeline)
 	if err != nil {
+		tracing.RecordError(span, err)
 		handlePipelineErr(c, err)
 	} else {
 		c.JSON(http.StatusOK, pl)
@@ -95,6 +107,7 @@ func createTmpPipeline(event model.WebhookEvent, commit *model.Commit, user *mod
 
 		Ref:                 opts.Branch,
 		AdditionalVariables: opts.Variables,
+		Inputs:              opts.Inputs,
 
 		Author: user.Login,
 		Email:  user.Email,
@@ -209,6 +222,11 @@ func DeletePipeline(c *gin.Context) {
 		return
 	}
 
+	if err := artifact.DeletePipelineArtifacts(server.Config.Services.ArtifactStore, _store, pl); err != nil {
+		c.String(http.StatusInternalServerError, "Error deleting pipeline artifacts. %s", err)
+		return
+	}
+
 	err = store.FromContext(c).DeletePipeline(pl)
 	if err != nil {
 		c.String(http.StatusInternalServerError, "Error deleting pipeline. %s", err)
@@ -251,6 +269,14 @@ func GetPipeline(c *gin.Context) {
 		_ = c.AbortWithError(http.StatusInternalServerError, err)
 		return
 	}
+	if pl.Approvals, err = _store.ApprovalList(pl); err != nil {
+		_ = c.AbortWithError(http.StatusInternalServerError, err)
+		return
+	}
+	if err := pipeline.SetStepFlakiness(_store, repo, pl.Workflows); err != nil {
+		_ = c.AbortWithError(http.StatusInternalServerError, err)
+		return
+	}
 
 	c.JSON(http.StatusOK, pl)
 }
@@ -270,6 +296,10 @@ func GetPipelineLast(c *gin.Context) {
 		_ = c.AbortWithError(http.StatusInternalServerError, err)
 		return
 	}
+	if err := pipeline.SetStepFlakiness(_store, repo, pl.Workflows); err != nil {
+		_ = c.AbortWithError(http.StatusInternalServerError, err)
+		return
+	}
 	c.JSON(http.StatusOK, pl)
 }
 
@@ -284,6 +314,7 @@ func GetPipelineLast(c *gin.Context) {
 //	@Param		repo_id			path	int		true	"the repository id"
 //	@Param		number			path	int		true	"the number of the pipeline"
 //	@Param		stepID			path	int		true	"the step id"
+//	@Param		attempt			query	int		false	"only the logs of this attempt of a restarted step, starting at 1"
 func GetStepLogs(c *gin.Context) {
 	_store := store.FromContext(c)
 	repo := session.Repo(c)
@@ -320,12 +351,32 @@ func GetStepLogs(c *gin.Context) {
 		return
 	}
 
+	first, last := 0, -1
+	if attempt := c.Query("attempt"); attempt != "" {
+		n, err := strconv.Atoi(attempt)
+		if err != nil {
+			_ = c.AbortWithError(http.StatusBadRequest, err)
+			return
+		}
+		var ok bool
+		if first, last, ok = step.AttemptLogLines(n); !ok {
+			c.String(http.StatusNotFound, "Step %d has no attempt %d", step.ID, n)
+			return
+		}
+	}
+
 	logs, err := server.Config.Services.LogStore.LogFind(step)
 	if err != nil {
 		handleDBError(c, err)
 		return
 	}
 
+	if first > 0 || last >= 0 {
+		logs = slices.DeleteFunc(logs, func(entry *model.LogEntry) bool {
+			return entry.Line < first || (last >= 0 && entry.Line >= last)
+		})
+	}
+
 	c.JSON(http.StatusOK, logs)
 }
 
@@ -502,14 +553,15 @@ func CancelPipeline(c *gin.Context) {
 
 // PostApproval
 //
-//	@Summary	Approve and start a pipeline
-//	@Router		/repos/{repo_id}/pipelines/{number}/approve [post]
-//	@Produce	json
-//	@Success	200	{object}	Pipeline
-//	@Tags		Pipelines
-//	@Param		Authorization	header	string	true	"Insert your personal access token"	default(Bearer <personal access token>)
-//	@Param		repo_id			path	int		true	"the repository id"
-//	@Param		number			path	int		true	"the number of the pipeline"
+//	@Summary		Approve and start a pipeline
+//	@Description	The pipeline starts once it has the approvals required by the approval policy of the repository.
+//	@Router			/repos/{repo_id}/pipelines/{number}/approve [post]
+//	@Produce		json
+//	@Success		200	{object}	Pipeline
+//	@Tags			Pipelines
+//	@Param			Authorization	header	string	true	"Insert your personal access token"	default(Bearer <personal access token>)
+//	@Param			repo_id			path	int		true	"the repository id"
+//	@Param			number			path	int		true	"the number of the pipeline"
 func PostApproval(c *gin.Context) {
 	var (
 		_store = store.FromContext(c)
@@ -528,6 +580,7 @@ func PostApproval(c *gin.Context) {
 	if err != nil {
 		handlePipelineErr(c, err)
 	} else {
+		recordAudit(c, &model.AuditEvent{Action: model.AuditActionApprove, TargetType: model.AuditTargetPipeline, TargetID: pl.ID, TargetName: fmt.Sprintf("#%d", pl.Number), RepoID: repo.ID})
 		c.JSON(http.StatusOK, newPipeline)
 	}
 }
@@ -560,6 +613,7 @@ func PostDecline(c *gin.Context) {
 	if err != nil {
 		handlePipelineErr(c, err)
 	} else {
+		recordAudit(c, &model.AuditEvent{Action: model.AuditActionDecline, TargetType: model.AuditTargetPipeline, TargetID: pl.ID, TargetName: fmt.Sprintf("#%d", pl.Number), RepoID: repo.ID})
 		c.JSON(http.StatusOK, pl)
 	}
 }
@@ -706,6 +760,17 @@ func DeletePipelineLogs(c *gin.Context) {
 		c.String(http.StatusInternalServerError, "Error deleting pipeline logs. %s", err)
 		return
 	}
+	// artifacts follow the retention of the logs
+	if err := artifact.DeletePipelineArtifacts(server.Config.Services.ArtifactStore, _store, pl); err != nil {
+		c.String(http.StatusInternalServerError, "Error deleting pipeline artifacts. %s", err)
+		return
+	}
+
+	pl.LogsDeleted = true
+	if err := _store.UpdatePipeline(pl); err != nil {
+		_ = c.AbortWithError(http.StatusInternalServerError, err)
+		return
+	}
 
 	c.Status(http.StatusNoContent)
 }
@@ -28,6 +28,7 @@ import (
 	"go.woodpecker-ci.org/woodpecker/v3/server"
 	forge_mocks "go.woodpecker-ci.org/woodpecker/v3/server/forge/mocks"
 	"go.woodpecker-ci.org/woodpecker/v3/server/model"
+	"go.woodpecker-ci.org/woodpecker/v3/server/services/log/file"
 	mocks_manager "go.woodpecker-ci.org/woodpecker/v3/server/services/mocks"
 	store_mocks "go.woodpecker-ci.org/woodpecker/v3/server/store/mocks"
 	"go.woodpecker-ci.org/woodpecker/v3/server/store/types"
@@ -122,6 +123,7 @@ func TestDeletePipeline(t *testing.T) {
 	t.Run("should delete pipeline", func(t *testing.T) {
 		mockStore := store_mocks.NewStore(t)
 		mockStore.On("GetPipelineNumber", mock.Anything, mock.Anything).Return(fakePipeline, nil)
+		mockStore.On("ArtifactList", mock.Anything).Return([]*model.Artifact{}, nil)
 		mockStore.On("DeletePipeline", mock.Anything).Return(nil)
 
 		c, _ := gin.CreateTestContext(httptest.NewRecorder())
@@ -162,6 +164,67 @@ func TestDeletePipeline(t *testing.T) {
 	})
 }
 
+func TestGetStepLogs(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+
+	logStore, err := file.NewLogStore(t.TempDir(), file.CompressionNone, 0)
+	if !assert.NoError(t, err) {
+		return
+	}
+	server.Config.Services.LogStore = logStore
+	defer func() { server.Config.Services.LogStore = nil }()
+
+	step := &model.Step{ID: 3, PipelineID: fakePipeline.ID, Attempts: []model.StepAttempt{{ExitCode: 1, LogLines: 2}}}
+	var entries []*model.LogEntry
+	for line := range 5 {
+		entries = append(entries, &model.LogEntry{StepID: step.ID, Line: line})
+	}
+	assert.NoError(t, logStore.LogAppend(step, entries))
+
+	getLogs := func(query string) (int, []*model.LogEntry) {
+		mockStore := store_mocks.NewStore(t)
+		mockStore.On("GetPipelineNumber", mock.Anything, int64(2)).Return(fakePipeline, nil)
+		mockStore.On("StepLoad", int64(3)).Return(step, nil)
+
+		w := httptest.NewRecorder()
+		c, _ := gin.CreateTestContext(w)
+		c.Request = httptest.NewRequest(http.MethodGet, "/?"+query, nil)
+		c.Set("store", mockStore)
+		c.Set("repo", &model.Repo{ID: 1})
+		c.Params = gin.Params{{Key: "number", Value: "2"}, {Key: "stepId", Value: "3"}}
+
+		GetStepLogs(c)
+
+		var logs []*model.LogEntry
+		if w.Code == http.StatusOK {
+			assert.NoError(t, json.Unmarshal(w.Body.Bytes(), &logs))
+		}
+		return w.Code, logs
+	}
+
+	lines := func(logs []*model.LogEntry) (lines []int) {
+		for _, entry := range logs {
+			lines = append(lines, entry.Line)
+		}
+		return lines
+	}
+
+	code, logs := getLogs("")
+	assert.Equal(t, http.StatusOK, code)
+	assert.Equal(t, []int{0, 1, 2, 3, 4}, lines(logs))
+
+	code, logs = getLogs("attempt=1")
+	assert.Equal(t, http.StatusOK, code)
+	assert.Equal(t, []int{0, 1}, lines(logs))
+
+	code, logs = getLogs("attempt=2")
+	assert.Equal(t, http.StatusOK, code)
+	assert.Equal(t, []int{2, 3, 4}, lines(logs))
+
+	code, _ = getLogs("attempt=3")
+	assert.Equal(t, http.StatusNotFound, code)
+}
+
 func TestGetPipelineMetadata(t *testing.T) {
 	gin.SetMode(gin.TestMode)
 
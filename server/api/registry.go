@@ -81,6 +81,7 @@ func PostRegistry(c *gin.Context) {
 		c.String(http.StatusInternalServerError, "Error inserting registry %q. %s", in.Address, err)
 		return
 	}
+	recordAudit(c, &model.AuditEvent{Action: model.AuditActionCreate, TargetType: model.AuditTargetRegistry, TargetID: registry.ID, TargetName: registry.Address, RepoID: repo.ID})
 	c.JSON(http.StatusOK, in.Copy())
 }
 
@@ -127,6 +128,7 @@ func PatchRegistry(c *gin.Context) {
 		c.String(http.StatusInternalServerError, "Error updating registry %q. %s", in.Address, err)
 		return
 	}
+	recordAudit(c, &model.AuditEvent{Action: model.AuditActionUpdate, TargetType: model.AuditTargetRegistry, TargetID: registry.ID, TargetName: registry.Address, RepoID: repo.ID})
 	c.JSON(http.StatusOK, in.Copy())
 }
 
@@ -177,5 +179,6 @@ func DeleteRegistry(c *gin.Context) {
 		handleDBError(c, err)
 		return
 	}
+	recordAudit(c, &model.AuditEvent{Action: model.AuditActionDelete, TargetType: model.AuditTargetRegistry, TargetName: addr, RepoID: repo.ID})
 	c.Status(http.StatusNoContent)
 }
@@ -31,6 +31,7 @@ import (
 	"go.woodpecker-ci.org/woodpecker/v3/server/forge"
 	"go.woodpecker-ci.org/woodpecker/v3/server/model"
 	"go.woodpecker-ci.org/woodpecker/v3/server/router/middleware/session"
+	"go.woodpecker-ci.org/woodpecker/v3/server/services/artifact"
 	"go.woodpecker-ci.org/woodpecker/v3/server/store"
 	"go.woodpecker-ci.org/woodpecker/v3/server/store/types"
 	"go.woodpecker-ci.org/woodpecker/v3/shared/token"
@@ -214,6 +215,7 @@ func PatchRepo(c *gin.Context) {
 	_store := store.FromContext(c)
 	repo := session.Repo(c)
 	user := session.User(c)
+	before := *repo
 
 	in := new(model.RepoPatch)
 	if err := c.Bind(in); err != nil {
@@ -262,6 +264,13 @@ func PatchRepo(c *gin.Context) {
 	if in.ApprovalAllowedUsers != nil {
 		repo.ApprovalAllowedUsers = *in.ApprovalAllowedUsers
 	}
+	if in.ApprovalPolicy != nil {
+		if in.ApprovalPolicy.RequiredApprovals < 0 {
+			c.String(http.StatusBadRequest, "Required approvals must not be negative")
+			return
+		}
+		repo.ApprovalPolicy = *in.ApprovalPolicy
+	}
 	if in.Timeout != nil {
 		repo.Timeout = *in.Timeout
 	}
@@ -274,6 +283,29 @@ func PatchRepo(c *gin.Context) {
 	if in.NetrcTrusted != nil {
 		repo.NetrcTrustedPlugins = *in.NetrcTrusted
 	}
+	if in.Retention != nil {
+		repo.Retention = *in.Retention
+	}
+	if in.MaxConcurrentPipelines != nil {
+		if *in.MaxConcurrentPipelines < 0 {
+			c.String(http.StatusBadRequest, "Max concurrent pipelines must not be negative")
+			return
+		}
+		repo.MaxConcurrentPipelines = *in.MaxConcurrentPipelines
+	}
+	if in.ConfigExtensionEndpoint != nil {
+		if err := model.ValidateConfigExtensionEndpoint(*in.ConfigExtensionEndpoint); err != nil {
+			c.String(http.StatusBadRequest, err.Error())
+			return
+		}
+		if *in.ConfigExtensionEndpoint != "" {
+			if err := server.Config.Services.Manager.ValidateEndpoint(c, *in.ConfigExtensionEndpoint); err != nil {
+				c.String(http.StatusBadRequest, err.Error())
+				return
+			}
+		}
+		repo.ConfigExtensionEndpoint = *in.ConfigExtensionEndpoint
+	}
 	if in.Visibility != nil {
 		switch *in.Visibility {
 		case string(model.VisibilityInternal), string(model.VisibilityPrivate), string(model.VisibilityPublic):
@@ -289,6 +321,7 @@ func PatchRepo(c *gin.Context) {
 		_ = c.AbortWithError(http.StatusInternalServerError, err)
 		return
 	}
+	recordAudit(c, &model.AuditEvent{Action: model.AuditActionUpdate, TargetType: model.AuditTargetRepo, TargetID: repo.ID, TargetName: repo.FullName, RepoID: repo.ID, Details: auditChanges(before, repo)})
 
 	c.JSON(http.StatusOK, repo)
 }
@@ -313,6 +346,7 @@ func ChownRepo(c *gin.Context) {
 		_ = c.AbortWithError(http.StatusInternalServerError, err)
 		return
 	}
+	recordAudit(c, &model.AuditEvent{Action: model.AuditActionChown, TargetType: model.AuditTargetRepo, TargetID: repo.ID, TargetName: repo.FullName, RepoID: repo.ID})
 	c.JSON(http.StatusOK, repo)
 }
 
@@ -461,6 +495,11 @@ func DeleteRepo(c *gin.Context) {
 	}
 
 	if remove {
+		// the database only holds the metadata of the artifacts
+		if err := artifact.DeleteRepoArtifacts(server.Config.Services.ArtifactStore, _store, repo); err != nil {
+			_ = c.AbortWithError(http.StatusInternalServerError, err)
+			return
+		}
 		if err := _store.DeleteRepo(repo); err != nil {
 			handleDBError(c, err)
 			return
@@ -474,6 +513,7 @@ func DeleteRepo(c *gin.Context) {
 			return
 		}
 	}
+	recordAudit(c, &model.AuditEvent{Action: model.AuditActionDelete, TargetType: model.AuditTargetRepo, TargetID: repo.ID, TargetName: repo.FullName, RepoID: repo.ID})
 
 	c.JSON(http.StatusOK, repo)
 }
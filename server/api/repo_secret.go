@@ -82,6 +82,7 @@ func PostSecret(c *gin.Context) {
 		c.String(http.StatusInternalServerError, "Error inserting secret %q. %s", in.Name, err)
 		return
 	}
+	recordAudit(c, &model.AuditEvent{Action: model.AuditActionCreate, TargetType: model.AuditTargetSecret, TargetID: secret.ID, TargetName: secret.Name, RepoID: repo.ID})
 	c.JSON(http.StatusOK, secret.Copy())
 }
 
@@ -133,6 +134,7 @@ func PatchSecret(c *gin.Context) {
 		c.String(http.StatusInternalServerError, "Error updating secret %q. %s", in.Name, err)
 		return
 	}
+	recordAudit(c, &model.AuditEvent{Action: model.AuditActionUpdate, TargetType: model.AuditTargetSecret, TargetID: secret.ID, TargetName: secret.Name, RepoID: repo.ID})
 	c.JSON(http.StatusOK, secret.Copy())
 }
 
@@ -182,5 +184,6 @@ func DeleteSecret(c *gin.Context) {
 		handleDBError(c, err)
 		return
 	}
+	recordAudit(c, &model.AuditEvent{Action: model.AuditActionDelete, TargetType: model.AuditTargetSecret, TargetName: name, RepoID: repo.ID})
 	c.Status(http.StatusNoContent)
 }
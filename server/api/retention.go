@@ -0,0 +1,71 @@
+// Copyright 2025 Woodpecker Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package api
+
+import (
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+
+	"go.woodpecker-ci.org/woodpecker/v3/server"
+	"go.woodpecker-ci.org/woodpecker/v3/server/retention"
+	"go.woodpecker-ci.org/woodpecker/v3/server/router/middleware/session"
+	"go.woodpecker-ci.org/woodpecker/v3/server/store"
+)
+
+// GetRepoRetentionDryRun
+//
+//	@Summary		Get what the retention policy removes from a repository
+//	@Description	Reports the pipelines and logs the retention policy would remove, without removing anything.
+//	@Router			/repos/{repo_id}/retention/dry-run [get]
+//	@Produce		json
+//	@Success		200	{object}	RetentionReport
+//	@Tags			Repositories
+//	@Param			Authorization	header	string	true	"Insert your personal access token"	default(Bearer <personal access token>)
+//	@Param			repo_id			path	int		true	"the repository id"
+func GetRepoRetentionDryRun(c *gin.Context) {
+	repo := session.Repo(c)
+
+	report, err := newRetentionJanitor(c).DryRun(repo)
+	if err != nil {
+		c.String(http.StatusInternalServerError, "Error checking retention policy. %s", err)
+		return
+	}
+
+	c.JSON(http.StatusOK, report)
+}
+
+// GetRetentionDryRun
+//
+//	@Summary		Get what the retention policies remove
+//	@Description	Reports the pipelines and logs the retention policies would remove from all active repositories, without removing anything. Requires admin rights.
+//	@Router			/retention/dry-run [get]
+//	@Produce		json
+//	@Success		200	{array}	RetentionReport
+//	@Tags			Retention
+//	@Param			Authorization	header	string	true	"Insert your personal access token"	default(Bearer <personal access token>)
+func GetRetentionDryRun(c *gin.Context) {
+	reports, err := newRetentionJanitor(c).DryRunAll()
+	if err != nil {
+		c.String(http.StatusInternalServerError, "Error checking retention policies. %s", err)
+		return
+	}
+
+	c.JSON(http.StatusOK, reports)
+}
+
+func newRetentionJanitor(c *gin.Context) *retention.Janitor {
+	return retention.New(store.FromContext(c), server.Config.Services.LogStore, server.Config.Services.ArtifactStore, server.Config.Retention.Default)
+}
@@ -15,6 +15,7 @@
 package api
 
 import (
+	"crypto"
 	"crypto/x509"
 	"encoding/pem"
 	"net/http"
@@ -34,7 +35,24 @@ import (
 //	@Tags		System
 //	@Param		Authorization	header	string	true	"Insert your personal access token"	default(Bearer <personal access token>)
 func GetSignaturePublicKey(c *gin.Context) {
-	b, err := x509.MarshalPKIXPublicKey(server.Config.Services.Manager.SignaturePublicKey())
+	writePublicKey(c, server.Config.Services.Manager.SignaturePublicKey())
+}
+
+// GetUserSignaturePublicKey
+//
+//	@Summary		Get server's signature public key for endpoints configured by users
+//	@Description	The key signs the requests to webhooks and to config extensions of repositories and organizations.
+//	@Router			/signature/user-public-key [get]
+//	@Produce		plain
+//	@Success		200
+//	@Tags			System
+//	@Param			Authorization	header	string	true	"Insert your personal access token"	default(Bearer <personal access token>)
+func GetUserSignaturePublicKey(c *gin.Context) {
+	writePublicKey(c, server.Config.Services.Manager.UserSignaturePublicKey())
+}
+
+func writePublicKey(c *gin.Context, publicKey crypto.PublicKey) {
+	b, err := x509.MarshalPKIXPublicKey(publicKey)
 	if err != nil {
 		log.Error().Err(err).Msg("can't marshal public key")
 		c.AbortWithStatus(http.StatusInternalServerError)
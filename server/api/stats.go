@@ -0,0 +1,144 @@
+// Copyright 2025 Woodpecker Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package api
+
+import (
+	"fmt"
+	"net/http"
+	"time"
+
+	"github.com/gin-gonic/gin"
+
+	"go.woodpecker-ci.org/woodpecker/v3/server/model"
+	"go.woodpecker-ci.org/woodpecker/v3/server/router/middleware/session"
+	"go.woodpecker-ci.org/woodpecker/v3/server/store"
+)
+
+const (
+	defaultStatsWindow = 30 * 24 * time.Hour
+	maxStatsBuckets    = 366
+	// maxStatsSamples limits the pipelines loaded to compute the duration percentiles.
+	maxStatsSamples = 10000
+)
+
+var statsIntervals = map[string]time.Duration{
+	"hour": time.Hour,
+	"day":  24 * time.Hour,     //nolint:mnd
+	"week": 7 * 24 * time.Hour, //nolint:mnd
+}
+
+// GetRepoStats
+//
+//	@Summary		Get repository statistics
+//	@Description	Get the success rate, durations, queue wait time and step failure rates of the finished pipelines of a repository.
+//	@Router			/repos/{repo_id}/stats [get]
+//	@Produce		json
+//	@Success		200	{object}	RepoStats
+//	@Tags			Repositories
+//	@Param			Authorization	header	string	true	"Insert your personal access token"	default(Bearer <personal access token>)
+//	@Param			repo_id			path	int		true	"the repository id"
+//	@Param			before			query	string	false	"only include pipelines before this RFC3339 date, defaults to now"
+//	@Param			after			query	string	false	"only include pipelines after this RFC3339 date, defaults to 30 days ago"
+//	@Param			branch			query	string	false	"only include pipelines of this branch"
+//	@Param			interval		query	string	false	"interval of the history (hour, day or week)"	default(day)
+func GetRepoStats(c *gin.Context) {
+	_store := store.FromContext(c)
+	repo := session.Repo(c)
+
+	before := time.Now()
+	if value := c.Query("before"); value != "" {
+		t, err := time.Parse(time.RFC3339, value)
+		if err != nil {
+			_ = c.AbortWithError(http.StatusBadRequest, err)
+			return
+		}
+		before = t
+	}
+
+	after := before.Add(-defaultStatsWindow)
+	if value := c.Query("after"); value != "" {
+		t, err := time.Parse(time.RFC3339, value)
+		if err != nil {
+			_ = c.AbortWithError(http.StatusBadRequest, err)
+			return
+		}
+		after = t
+	}
+
+	if !after.Before(before) {
+		c.String(http.StatusBadRequest, "'after' has to be earlier than 'before'")
+		return
+	}
+
+	interval, ok := statsIntervals[c.DefaultQuery("interval", "day")]
+	if !ok {
+		c.String(http.StatusBadRequest, "interval has to be hour, day or week")
+		return
+	}
+	if before.Sub(after)/interval > maxStatsBuckets {
+		c.String(http.StatusBadRequest, fmt.Sprintf("time window is too large, at most %d intervals are supported", maxStatsBuckets))
+		return
+	}
+
+	filter := &model.PipelineFilter{
+		After:  after.Unix(),
+		Before: before.Unix(),
+		Branch: c.Query("branch"),
+	}
+
+	counts, err := _store.PipelineStatsCount(repo, filter, int64(interval.Seconds()))
+	if err != nil {
+		_ = c.AbortWithError(http.StatusInternalServerError, err)
+		return
+	}
+
+	samples, err := _store.PipelineStatsList(repo, filter, maxStatsSamples)
+	if err != nil {
+		_ = c.AbortWithError(http.StatusInternalServerError, err)
+		return
+	}
+
+	steps, err := _store.StepStatsList(repo, filter)
+	if err != nil {
+		_ = c.AbortWithError(http.StatusInternalServerError, err)
+		return
+	}
+
+	stats := model.NewRepoStats(counts, samples, steps, filter.After, filter.Before, int64(interval.Seconds()))
+	stats.Branch = filter.Branch
+	c.JSON(http.StatusOK, stats)
+}
+
+// GetRepoFlakySteps
+//
+//	@Summary		List flaky steps
+//	@Description	List the steps of a repository which passed after they failed on the same commit, the most flaky step first.
+//	@Router			/repos/{repo_id}/flaky_steps [get]
+//	@Produce		json
+//	@Success		200	{array}	StepFlakiness
+//	@Tags			Repositories
+//	@Param			Authorization	header	string	true	"Insert your personal access token"	default(Bearer <personal access token>)
+//	@Param			repo_id			path	int		true	"the repository id"
+//	@Param			page			query	int		false	"for response pagination, page offset number"	default(1)
+//	@Param			perPage			query	int		false	"for response pagination, max items per page"	default(50)
+func GetRepoFlakySteps(c *gin.Context) {
+	repo := session.Repo(c)
+	list, err := store.FromContext(c).StepFlakinessList(repo, session.Pagination(c))
+	if err != nil {
+		c.String(http.StatusInternalServerError, "Error getting flaky steps. %s", err)
+		return
+	}
+	c.JSON(http.StatusOK, list)
+}
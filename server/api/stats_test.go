@@ -0,0 +1,79 @@
+// Copyright 2025 Woodpecker Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package api
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+
+	"go.woodpecker-ci.org/woodpecker/v3/server/model"
+	store_mocks "go.woodpecker-ci.org/woodpecker/v3/server/store/mocks"
+)
+
+func TestGetRepoStats(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+
+	t.Run("should get stats", func(t *testing.T) {
+		pipelines := []*model.Pipeline{{Status: model.StatusSuccess, Created: 1704070800, Started: 1704070810, Finished: 1704070870}}
+		steps := []*model.StepStats{{Workflow: "test", Name: "unit", Total: 4, Failures: 1}}
+
+		mockStore := store_mocks.NewStore(t)
+		filter := &model.PipelineFilter{After: 1704067200, Before: 1704672000, Branch: "main"}
+		mockStore.On("PipelineStatsCount", mock.Anything, filter, int64(24*60*60)).Return([]*model.PipelineStatusCount{{Bucket: 0, Status: model.StatusSuccess, Total: 1}}, nil)
+		mockStore.On("PipelineStatsList", mock.Anything, filter, maxStatsSamples).Return(pipelines, nil)
+		mockStore.On("StepStatsList", mock.Anything, filter).Return(steps, nil)
+
+		w := httptest.NewRecorder()
+		c, _ := gin.CreateTestContext(w)
+		c.Set("store", mockStore)
+		c.Request, _ = http.NewRequest(http.MethodGet, "/?after=2024-01-01T00:00:00Z&before=2024-01-08T00:00:00Z&branch=main", nil)
+
+		GetRepoStats(c)
+
+		assert.Equal(t, http.StatusOK, w.Code)
+		stats := new(model.RepoStats)
+		assert.NoError(t, json.Unmarshal(w.Body.Bytes(), stats))
+		assert.EqualValues(t, 1, stats.Pipelines)
+		assert.EqualValues(t, 60, stats.Duration.P50)
+		assert.Len(t, stats.History, 7)
+		assert.Equal(t, "main", stats.Branch)
+		if assert.Len(t, stats.Steps, 1) {
+			assert.InDelta(t, 0.25, stats.Steps[0].FailureRate, 0.001)
+		}
+	})
+
+	t.Run("should reject invalid parameters", func(t *testing.T) {
+		for _, query := range []string{
+			"interval=month",
+			"after=2024-01-02T00:00:00Z&before=2024-01-01T00:00:00Z",
+			"after=2020-01-01T00:00:00Z&before=2024-01-01T00:00:00Z",
+			"after=2024-01-01",
+		} {
+			w := httptest.NewRecorder()
+			c, _ := gin.CreateTestContext(w)
+			c.Request, _ = http.NewRequest(http.MethodGet, "/?"+query, nil)
+
+			GetRepoStats(c)
+
+			assert.Equal(t, http.StatusBadRequest, w.Code, query)
+		}
+	})
+}
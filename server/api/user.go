@@ -207,6 +207,7 @@ func DeleteToken(c *gin.Context) {
 		c.String(http.StatusInternalServerError, "Error revoking tokens. %s", err)
 		return
 	}
+	recordAudit(c, &model.AuditEvent{Action: model.AuditActionResetToken, TargetType: model.AuditTargetUser, TargetID: user.ID, TargetName: user.Login})
 
 	t := token.New(token.UserToken)
 	t.Set("user-id", strconv.FormatInt(user.ID, 10))
@@ -93,6 +93,8 @@ func PatchUser(c *gin.Context) {
 		return
 	}
 
+	before := *user
+
 	// TODO: allow to change login (currently used as primary key)
 	// TODO: disallow to change login, email, avatar if the user is using oauth
 	user.Email = in.Email
@@ -105,6 +107,7 @@ func PatchUser(c *gin.Context) {
 		return
 	}
 
+	recordAudit(c, &model.AuditEvent{Action: model.AuditActionUpdate, TargetType: model.AuditTargetUser, TargetID: user.ID, TargetName: user.Login, Details: auditChanges(before, user)})
 	c.JSON(http.StatusOK, user)
 }
 
@@ -143,6 +146,7 @@ func PostUser(c *gin.Context) {
 		c.String(http.StatusInternalServerError, err.Error())
 		return
 	}
+	recordAudit(c, &model.AuditEvent{Action: model.AuditActionCreate, TargetType: model.AuditTargetUser, TargetID: user.ID, TargetName: user.Login})
 	c.JSON(http.StatusOK, user)
 }
 
@@ -168,5 +172,6 @@ func DeleteUser(c *gin.Context) {
 		handleDBError(c, err)
 		return
 	}
+	recordAudit(c, &model.AuditEvent{Action: model.AuditActionDelete, TargetType: model.AuditTargetUser, TargetID: user.ID, TargetName: user.Login})
 	c.Status(http.StatusNoContent)
 }
@@ -0,0 +1,415 @@
+// Copyright 2025 Woodpecker Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package api
+
+import (
+	"net/http"
+	"net/url"
+	"strconv"
+
+	"github.com/gin-gonic/gin"
+
+	"go.woodpecker-ci.org/woodpecker/v3/server"
+	"go.woodpecker-ci.org/woodpecker/v3/server/model"
+	"go.woodpecker-ci.org/woodpecker/v3/server/router/middleware/session"
+	"go.woodpecker-ci.org/woodpecker/v3/server/store"
+)
+
+// GetWebhookList
+//
+//	@Summary	List repository webhooks
+//	@Router		/repos/{repo_id}/webhooks [get]
+//	@Produce	json
+//	@Success	200	{array}	Webhook
+//	@Tags		Repository webhooks
+//	@Param		Authorization	header	string	true	"Insert your personal access token"	default(Bearer <personal access token>)
+//	@Param		repo_id			path	int		true	"the repository id"
+//	@Param		page			query	int		false	"for response pagination, page offset number"	default(1)
+//	@Param		perPage			query	int		false	"for response pagination, max items per page"	default(50)
+func GetWebhookList(c *gin.Context) {
+	repo := session.Repo(c)
+	list, err := store.FromContext(c).WebhookList(repo, session.Pagination(c))
+	if err != nil {
+		c.String(http.StatusInternalServerError, "Error getting webhook list. %s", err)
+		return
+	}
+	c.JSON(http.StatusOK, list)
+}
+
+// GetWebhook
+//
+//	@Summary	Get a repository webhook
+//	@Router		/repos/{repo_id}/webhooks/{webhook_id} [get]
+//	@Produce	json
+//	@Success	200	{object}	Webhook
+//	@Tags		Repository webhooks
+//	@Param		Authorization	header	string	true	"Insert your personal access token"	default(Bearer <personal access token>)
+//	@Param		repo_id			path	int		true	"the repository id"
+//	@Param		webhook_id		path	int		true	"the webhook id"
+func GetWebhook(c *gin.Context) {
+	hook := findRepoWebhook(c)
+	if hook == nil {
+		return
+	}
+	c.JSON(http.StatusOK, hook)
+}
+
+// PostWebhook
+//
+//	@Summary	Create a repository webhook
+//	@Router		/repos/{repo_id}/webhooks [post]
+//	@Produce	json
+//	@Success	200	{object}	Webhook
+//	@Tags		Repository webhooks
+//	@Param		Authorization	header	string			true	"Insert your personal access token"	default(Bearer <personal access token>)
+//	@Param		repo_id			path	int				true	"the repository id"
+//	@Param		webhook			body	WebhookPatch	true	"the new webhook"
+func PostWebhook(c *gin.Context) {
+	repo := session.Repo(c)
+	createWebhook(c, &model.Webhook{RepoID: repo.ID, Active: true})
+}
+
+// PatchWebhook
+//
+//	@Summary	Update a repository webhook
+//	@Router		/repos/{repo_id}/webhooks/{webhook_id} [patch]
+//	@Produce	json
+//	@Success	200	{object}	Webhook
+//	@Tags		Repository webhooks
+//	@Param		Authorization	header	string			true	"Insert your personal access token"	default(Bearer <personal access token>)
+//	@Param		repo_id			path	int				true	"the repository id"
+//	@Param		webhook_id		path	int				true	"the webhook id"
+//	@Param		webhook			body	WebhookPatch	true	"the webhook data"
+func PatchWebhook(c *gin.Context) {
+	hook := findRepoWebhook(c)
+	if hook == nil {
+		return
+	}
+	updateWebhook(c, hook)
+}
+
+// DeleteWebhook
+//
+//	@Summary	Delete a repository webhook
+//	@Router		/repos/{repo_id}/webhooks/{webhook_id} [delete]
+//	@Produce	plain
+//	@Success	204
+//	@Tags		Repository webhooks
+//	@Param		Authorization	header	string	true	"Insert your personal access token"	default(Bearer <personal access token>)
+//	@Param		repo_id			path	int		true	"the repository id"
+//	@Param		webhook_id		path	int		true	"the webhook id"
+func DeleteWebhook(c *gin.Context) {
+	hook := findRepoWebhook(c)
+	if hook == nil {
+		return
+	}
+	deleteWebhook(c, hook)
+}
+
+// GetWebhookDeliveries
+//
+//	@Summary	List the deliveries of a repository webhook
+//	@Router		/repos/{repo_id}/webhooks/{webhook_id}/deliveries [get]
+//	@Produce	json
+//	@Success	200	{array}	WebhookDelivery
+//	@Tags		Repository webhooks
+//	@Param		Authorization	header	string	true	"Insert your personal access token"	default(Bearer <personal access token>)
+//	@Param		repo_id			path	int		true	"the repository id"
+//	@Param		webhook_id		path	int		true	"the webhook id"
+//	@Param		page			query	int		false	"for response pagination, page offset number"	default(1)
+//	@Param		perPage			query	int		false	"for response pagination, max items per page"	default(50)
+func GetWebhookDeliveries(c *gin.Context) {
+	hook := findRepoWebhook(c)
+	if hook == nil {
+		return
+	}
+	listWebhookDeliveries(c, hook)
+}
+
+// PostWebhookRedelivery
+//
+//	@Summary	Send the payload of a delivery of a repository webhook again
+//	@Router		/repos/{repo_id}/webhooks/{webhook_id}/deliveries/{delivery_id}/redeliver [post]
+//	@Produce	json
+//	@Success	200	{object}	WebhookDelivery
+//	@Tags		Repository webhooks
+//	@Param		Authorization	header	string	true	"Insert your personal access token"	default(Bearer <personal access token>)
+//	@Param		repo_id			path	int		true	"the repository id"
+//	@Param		webhook_id		path	int		true	"the webhook id"
+//	@Param		delivery_id		path	int		true	"the delivery id"
+func PostWebhookRedelivery(c *gin.Context) {
+	hook := findRepoWebhook(c)
+	if hook == nil {
+		return
+	}
+	redeliverWebhook(c, hook)
+}
+
+// GetOrgWebhookList
+//
+//	@Summary	List organization webhooks
+//	@Router		/orgs/{org_id}/webhooks [get]
+//	@Produce	json
+//	@Success	200	{array}	Webhook
+//	@Tags		Organization webhooks
+//	@Param		Authorization	header	string	true	"Insert your personal access token"	default(Bearer <personal access token>)
+//	@Param		org_id			path	string	true	"the org's id"
+//	@Param		page			query	int		false	"for response pagination, page offset number"	default(1)
+//	@Param		perPage			query	int		false	"for response pagination, max items per page"	default(50)
+func GetOrgWebhookList(c *gin.Context) {
+	org := session.Org(c)
+	list, err := store.FromContext(c).OrgWebhookList(org.ID, session.Pagination(c))
+	if err != nil {
+		c.String(http.StatusInternalServerError, "Error getting webhook list. %s", err)
+		return
+	}
+	c.JSON(http.StatusOK, list)
+}
+
+// GetOrgWebhook
+//
+//	@Summary	Get an organization webhook
+//	@Router		/orgs/{org_id}/webhooks/{webhook_id} [get]
+//	@Produce	json
+//	@Success	200	{object}	Webhook
+//	@Tags		Organization webhooks
+//	@Param		Authorization	header	string	true	"Insert your personal access token"	default(Bearer <personal access token>)
+//	@Param		org_id			path	string	true	"the org's id"
+//	@Param		webhook_id		path	int		true	"the webhook id"
+func GetOrgWebhook(c *gin.Context) {
+	hook := findOrgWebhook(c)
+	if hook == nil {
+		return
+	}
+	c.JSON(http.StatusOK, hook)
+}
+
+// PostOrgWebhook
+//
+//	@Summary	Create an organization webhook
+//	@Router		/orgs/{org_id}/webhooks [post]
+//	@Produce	json
+//	@Success	200	{object}	Webhook
+//	@Tags		Organization webhooks
+//	@Param		Authorization	header	string			true	"Insert your personal access token"	default(Bearer <personal access token>)
+//	@Param		org_id			path	string			true	"the org's id"
+//	@Param		webhook			body	WebhookPatch	true	"the new webhook"
+func PostOrgWebhook(c *gin.Context) {
+	org := session.Org(c)
+	createWebhook(c, &model.Webhook{OrgID: org.ID, Active: true})
+}
+
+// PatchOrgWebhook
+//
+//	@Summary	Update an organization webhook
+//	@Router		/orgs/{org_id}/webhooks/{webhook_id} [patch]
+//	@Produce	json
+//	@Success	200	{object}	Webhook
+//	@Tags		Organization webhooks
+//	@Param		Authorization	header	string			true	"Insert your personal access token"	default(Bearer <personal access token>)
+//	@Param		org_id			path	string			true	"the org's id"
+//	@Param		webhook_id		path	int				true	"the webhook id"
+//	@Param		webhook			body	WebhookPatch	true	"the webhook data"
+func PatchOrgWebhook(c *gin.Context) {
+	hook := findOrgWebhook(c)
+	if hook == nil {
+		return
+	}
+	updateWebhook(c, hook)
+}
+
+// DeleteOrgWebhook
+//
+//	@Summary	Delete an organization webhook
+//	@Router		/orgs/{org_id}/webhooks/{webhook_id} [delete]
+//	@Produce	plain
+//	@Success	204
+//	@Tags		Organization webhooks
+//	@Param		Authorization	header	string	true	"Insert your personal access token"	default(Bearer <personal access token>)
+//	@Param		org_id			path	string	true	"the org's id"
+//	@Param		webhook_id		path	int		true	"the webhook id"
+func DeleteOrgWebhook(c *gin.Context) {
+	hook := findOrgWebhook(c)
+	if hook == nil {
+		return
+	}
+	deleteWebhook(c, hook)
+}
+
+// GetOrgWebhookDeliveries
+//
+//	@Summary	List the deliveries of an organization webhook
+//	@Router		/orgs/{org_id}/webhooks/{webhook_id}/deliveries [get]
+//	@Produce	json
+//	@Success	200	{array}	WebhookDelivery
+//	@Tags		Organization webhooks
+//	@Param		Authorization	header	string	true	"Insert your personal access token"	default(Bearer <personal access token>)
+//	@Param		org_id			path	string	true	"the org's id"
+//	@Param		webhook_id		path	int		true	"the webhook id"
+//	@Param		page			query	int		false	"for response pagination, page offset number"	default(1)
+//	@Param		perPage			query	int		false	"for response pagination, max items per page"	default(50)
+func GetOrgWebhookDeliveries(c *gin.Context) {
+	hook := findOrgWebhook(c)
+	if hook == nil {
+		return
+	}
+	listWebhookDeliveries(c, hook)
+}
+
+// PostOrgWebhookRedelivery
+//
+//	@Summary	Send the payload of a delivery of an organization webhook again
+//	@Router		/orgs/{org_id}/webhooks/{webhook_id}/deliveries/{delivery_id}/redeliver [post]
+//	@Produce	json
+//	@Success	200	{object}	WebhookDelivery
+//	@Tags		Organization webhooks
+//	@Param		Authorization	header	string	true	"Insert your personal access token"	default(Bearer <personal access token>)
+//	@Param		org_id			path	string	true	"the org's id"
+//	@Param		webhook_id		path	int		true	"the webhook id"
+//	@Param		delivery_id		path	int		true	"the delivery id"
+func PostOrgWebhookRedelivery(c *gin.Context) {
+	hook := findOrgWebhook(c)
+	if hook == nil {
+		return
+	}
+	redeliverWebhook(c, hook)
+}
+
+// findRepoWebhook returns the webhook of the url parameter or writes an error response and returns nil.
+func findRepoWebhook(c *gin.Context) *model.Webhook {
+	repo := session.Repo(c)
+	return findWebhook(c, func(id int64) (*model.Webhook, error) {
+		return store.FromContext(c).WebhookFind(repo, id)
+	})
+}
+
+// findOrgWebhook returns the webhook of the url parameter or writes an error response and returns nil.
+func findOrgWebhook(c *gin.Context) *model.Webhook {
+	org := session.Org(c)
+	return findWebhook(c, func(id int64) (*model.Webhook, error) {
+		return store.FromContext(c).OrgWebhookFind(org.ID, id)
+	})
+}
+
+func findWebhook(c *gin.Context, find func(int64) (*model.Webhook, error)) *model.Webhook {
+	id, err := strconv.ParseInt(c.Param("webhook_id"), 10, 64)
+	if err != nil {
+		c.String(http.StatusBadRequest, "Error parsing webhook id. %s", err)
+		return nil
+	}
+
+	hook, err := find(id)
+	if err != nil {
+		handleDBError(c, err)
+		return nil
+	}
+	return hook
+}
+
+func createWebhook(c *gin.Context, hook *model.Webhook) {
+	in := new(model.WebhookPatch)
+	if err := c.Bind(in); err != nil {
+		c.String(http.StatusBadRequest, "Error parsing request. %s", err)
+		return
+	}
+	in.Apply(hook)
+
+	if err := hook.Validate(); err != nil {
+		c.String(http.StatusUnprocessableEntity, "Error inserting webhook. %s", err)
+		return
+	}
+	if err := server.Config.Services.Manager.ValidateEndpoint(c, hook.URL); err != nil {
+		c.String(http.StatusUnprocessableEntity, "Error inserting webhook. %s", err)
+		return
+	}
+	if err := store.FromContext(c).WebhookCreate(hook); err != nil {
+		c.String(http.StatusInternalServerError, "Error inserting webhook. %s", err)
+		return
+	}
+	recordAudit(c, &model.AuditEvent{Action: model.AuditActionCreate, TargetType: model.AuditTargetWebhook, TargetID: hook.ID, TargetName: webhookAuditName(hook), RepoID: hook.RepoID, OrgID: hook.OrgID})
+	c.JSON(http.StatusOK, hook)
+}
+
+func updateWebhook(c *gin.Context, hook *model.Webhook) {
+	in := new(model.WebhookPatch)
+	if err := c.Bind(in); err != nil {
+		c.String(http.StatusBadRequest, "Error parsing request. %s", err)
+		return
+	}
+	in.Apply(hook)
+
+	if err := hook.Validate(); err != nil {
+		c.String(http.StatusUnprocessableEntity, "Error updating webhook. %s", err)
+		return
+	}
+	if err := server.Config.Services.Manager.ValidateEndpoint(c, hook.URL); err != nil {
+		c.String(http.StatusUnprocessableEntity, "Error updating webhook. %s", err)
+		return
+	}
+	if err := store.FromContext(c).WebhookUpdate(hook); err != nil {
+		c.String(http.StatusInternalServerError, "Error updating webhook. %s", err)
+		return
+	}
+	recordAudit(c, &model.AuditEvent{Action: model.AuditActionUpdate, TargetType: model.AuditTargetWebhook, TargetID: hook.ID, TargetName: webhookAuditName(hook), RepoID: hook.RepoID, OrgID: hook.OrgID})
+	c.JSON(http.StatusOK, hook)
+}
+
+func deleteWebhook(c *gin.Context, hook *model.Webhook) {
+	if err := store.FromContext(c).WebhookDelete(hook); err != nil {
+		handleDBError(c, err)
+		return
+	}
+	recordAudit(c, &model.AuditEvent{Action: model.AuditActionDelete, TargetType: model.AuditTargetWebhook, TargetID: hook.ID, TargetName: webhookAuditName(hook), RepoID: hook.RepoID, OrgID: hook.OrgID})
+	c.Status(http.StatusNoContent)
+}
+
+// webhookAuditName returns the scheme and host of the webhook url, as the path and query often contain tokens.
+func webhookAuditName(hook *model.Webhook) string {
+	u, err := url.Parse(hook.URL)
+	if err != nil {
+		return ""
+	}
+	return u.Scheme + "://" + u.Host
+}
+
+func listWebhookDeliveries(c *gin.Context, hook *model.Webhook) {
+	list, err := store.FromContext(c).WebhookDeliveryList(hook, session.Pagination(c))
+	if err != nil {
+		c.String(http.StatusInternalServerError, "Error getting webhook deliveries. %s", err)
+		return
+	}
+	c.JSON(http.StatusOK, list)
+}
+
+func redeliverWebhook(c *gin.Context, hook *model.Webhook) {
+	id, err := strconv.ParseInt(c.Param("delivery_id"), 10, 64)
+	if err != nil {
+		c.String(http.StatusBadRequest, "Error parsing delivery id. %s", err)
+		return
+	}
+
+	delivery, err := store.FromContext(c).WebhookDeliveryFind(hook, id)
+	if err != nil {
+		handleDBError(c, err)
+		return
+	}
+
+	redelivery, err := server.Config.Services.Manager.WebhookService().Redeliver(hook, delivery)
+	if err != nil {
+		c.String(http.StatusInternalServerError, "Error redelivering webhook payload. %s", err)
+		return
+	}
+	c.JSON(http.StatusOK, redelivery)
+}
@@ -24,18 +24,22 @@ import (
 	"go.woodpecker-ci.org/woodpecker/v3/server/pubsub"
 	"go.woodpecker-ci.org/woodpecker/v3/server/queue"
 	"go.woodpecker-ci.org/woodpecker/v3/server/services"
+	"go.woodpecker-ci.org/woodpecker/v3/server/services/artifact"
 	"go.woodpecker-ci.org/woodpecker/v3/server/services/log"
+	"go.woodpecker-ci.org/woodpecker/v3/server/services/oidc"
 	"go.woodpecker-ci.org/woodpecker/v3/server/services/permissions"
 )
 
 var Config = struct {
 	Services struct {
-		Pubsub     *pubsub.Publisher
-		Queue      queue.Queue
-		Logs       logging.Log
-		Membership cache.MembershipService
-		Manager    services.Manager
-		LogStore   log.Service
+		Pubsub        pubsub.Publisher
+		Queue         queue.Queue
+		Logs          logging.Log
+		Membership    cache.MembershipService
+		Manager       services.Manager
+		LogStore      log.Service
+		ArtifactStore artifact.Service
+		OIDC          *oidc.Provider
 	}
 	Server struct {
 		JWTSecret           string
@@ -75,12 +79,17 @@ var Config = struct {
 		PrivilegedPlugins                   []string
 		DefaultTimeout                      int64
 		MaxTimeout                          int64
+		MaxArtifactSize                     int64
 		Proxy                               struct {
 			No    string
 			HTTP  string
 			HTTPS string
 		}
 	}
+	Retention struct {
+		Default  model.RetentionPolicy
+		Interval time.Duration
+	}
 	Permissions struct {
 		Open            bool
 		Admins          *permissions.Admins
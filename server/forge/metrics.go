@@ -0,0 +1,174 @@
+// Copyright 2025 Woodpecker Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package forge
+
+import (
+	"context"
+	"net/http"
+	"time"
+
+	"go.woodpecker-ci.org/woodpecker/v3/server/forge/types"
+	"go.woodpecker-ci.org/woodpecker/v3/server/metrics"
+	"go.woodpecker-ci.org/woodpecker/v3/server/model"
+)
+
+// WithMetrics wraps a forge to record the latency of its API calls.
+func WithMetrics(forge Forge) Forge {
+	return &metricsForge{forge: forge}
+}
+
+type metricsForge struct {
+	forge Forge
+}
+
+func (f *metricsForge) observe(method string, started time.Time, err error) {
+	metrics.ForgeRequest(f.forge.Name(), method, started, err)
+}
+
+func (f *metricsForge) Name() string {
+	return f.forge.Name()
+}
+
+func (f *metricsForge) URL() string {
+	return f.forge.URL()
+}
+
+func (f *metricsForge) Netrc(u *model.User, r *model.Repo) (*model.Netrc, error) {
+	return f.forge.Netrc(u, r)
+}
+
+// Refresh refreshes the oauth token of the user if the wrapped forge supports it.
+func (f *metricsForge) Refresh(ctx context.Context, u *model.User) (bool, error) {
+	refresher, ok := f.forge.(Refresher)
+	if !ok {
+		return false, nil
+	}
+	started := time.Now()
+	refreshed, err := refresher.Refresh(ctx, u)
+	f.observe("refresh", started, err)
+	return refreshed, err
+}
+
+func (f *metricsForge) Login(ctx context.Context, r *types.OAuthRequest) (*model.User, string, error) {
+	started := time.Now()
+	user, redirect, err := f.forge.Login(ctx, r)
+	f.observe("login", started, err)
+	return user, redirect, err
+}
+
+func (f *metricsForge) Auth(ctx context.Context, token, secret string) (string, error) {
+	started := time.Now()
+	result, err := f.forge.Auth(ctx, token, secret)
+	f.observe("auth", started, err)
+	return result, err
+}
+
+func (f *metricsForge) Teams(ctx context.Context, u *model.User) ([]*model.Team, error) {
+	started := time.Now()
+	result, err := f.forge.Teams(ctx, u)
+	f.observe("teams", started, err)
+	return result, err
+}
+
+func (f *metricsForge) Repo(ctx context.Context, u *model.User, remoteID model.ForgeRemoteID, owner, name string) (*model.Repo, error) {
+	started := time.Now()
+	result, err := f.forge.Repo(ctx, u, remoteID, owner, name)
+	f.observe("repo", started, err)
+	return result, err
+}
+
+func (f *metricsForge) Repos(ctx context.Context, u *model.User) ([]*model.Repo, error) {
+	started := time.Now()
+	result, err := f.forge.Repos(ctx, u)
+	f.observe("repos", started, err)
+	return result, err
+}
+
+func (f *metricsForge) File(ctx context.Context, u *model.User, r *model.Repo, b *model.Pipeline, file string) ([]byte, error) {
+	started := time.Now()
+	result, err := f.forge.File(ctx, u, r, b, file)
+	f.observe("file", started, err)
+	return result, err
+}
+
+func (f *metricsForge) Dir(ctx context.Context, u *model.User, r *model.Repo, b *model.Pipeline, dir string) ([]*types.FileMeta, error) {
+	started := time.Now()
+	result, err := f.forge.Dir(ctx, u, r, b, dir)
+	f.observe("dir", started, err)
+	return result, err
+}
+
+func (f *metricsForge) Status(ctx context.Context, u *model.User, r *model.Repo, b *model.Pipeline, p *model.Workflow) error {
+	started := time.Now()
+	err := f.forge.Status(ctx, u, r, b, p)
+	f.observe("status", started, err)
+	return err
+}
+
+func (f *metricsForge) Activate(ctx context.Context, u *model.User, r *model.Repo, link string) error {
+	started := time.Now()
+	err := f.forge.Activate(ctx, u, r, link)
+	f.observe("activate", started, err)
+	return err
+}
+
+func (f *metricsForge) Deactivate(ctx context.Context, u *model.User, r *model.Repo, link string) error {
+	started := time.Now()
+	err := f.forge.Deactivate(ctx, u, r, link)
+	f.observe("deactivate", started, err)
+	return err
+}
+
+func (f *metricsForge) Branches(ctx context.Context, u *model.User, r *model.Repo, p *model.ListOptions) ([]string, error) {
+	started := time.Now()
+	result, err := f.forge.Branches(ctx, u, r, p)
+	f.observe("branches", started, err)
+	return result, err
+}
+
+func (f *metricsForge) BranchHead(ctx context.Context, u *model.User, r *model.Repo, branch string) (*model.Commit, error) {
+	started := time.Now()
+	result, err := f.forge.BranchHead(ctx, u, r, branch)
+	f.observe("branch_head", started, err)
+	return result, err
+}
+
+func (f *metricsForge) PullRequests(ctx context.Context, u *model.User, r *model.Repo, p *model.ListOptions) ([]*model.PullRequest, error) {
+	started := time.Now()
+	result, err := f.forge.PullRequests(ctx, u, r, p)
+	f.observe("pull_requests", started, err)
+	return result, err
+}
+
+func (f *metricsForge) Hook(ctx context.Context, r *http.Request) (*model.Repo, *model.Pipeline, error) {
+	started := time.Now()
+	repo, pipeline, err := f.forge.Hook(ctx, r)
+	f.observe("hook", started, err)
+	return repo, pipeline, err
+}
+
+func (f *metricsForge) OrgMembership(ctx context.Context, u *model.User, org string) (*model.OrgPerm, error) {
+	started := time.Now()
+	result, err := f.forge.OrgMembership(ctx, u, org)
+	f.observe("org_membership", started, err)
+	return result, err
+}
+
+func (f *metricsForge) Org(ctx context.Context, u *model.User, org string) (*model.Org, error) {
+	started := time.Now()
+	result, err := f.forge.Org(ctx, u, org)
+	f.observe("org", started, err)
+	return result, err
+}
@@ -21,6 +21,7 @@ import (
 	"encoding/json"
 	"errors"
 	"fmt"
+	"io"
 	"strconv"
 	"time"
 
@@ -32,10 +33,12 @@ import (
 	"go.woodpecker-ci.org/woodpecker/v3/server"
 	"go.woodpecker-ci.org/woodpecker/v3/server/forge"
 	"go.woodpecker-ci.org/woodpecker/v3/server/logging"
+	"go.woodpecker-ci.org/woodpecker/v3/server/metrics"
 	"go.woodpecker-ci.org/woodpecker/v3/server/model"
 	"go.woodpecker-ci.org/woodpecker/v3/server/pipeline"
 	"go.woodpecker-ci.org/woodpecker/v3/server/pubsub"
 	"go.woodpecker-ci.org/woodpecker/v3/server/queue"
+	logService "go.woodpecker-ci.org/woodpecker/v3/server/services/log"
 	"go.woodpecker-ci.org/woodpecker/v3/server/store"
 )
 
@@ -44,7 +47,7 @@ const updateAgentLastWorkDelay = time.Minute
 
 type RPC struct {
 	queue         queue.Queue
-	pubsub        *pubsub.Publisher
+	pubsub        pubsub.Publisher
 	logger        logging.Log
 	store         store.Store
 	pipelineTime  *prometheus.GaugeVec
@@ -89,6 +92,7 @@ func (s *RPC) Next(c context.Context, agentFilter rpc.Filter) (*rpc.Workflow, er
 		}
 
 		if task.ShouldRun() {
+			metrics.TaskPolled(task)
 			workflow := new(rpc.Workflow)
 			err = json.Unmarshal(task.Data, workflow)
 			return workflow, err
@@ -187,6 +191,7 @@ func (s *RPC) Update(c context.Context, strWorkflowID string, state rpc.StepStat
 	if err := pipeline.UpdateStepStatus(s.store, step, state); err != nil {
 		log.Error().Err(err).Msg("rpc.update: cannot update step")
 	}
+	pipeline.SendWebhooks(model.HookEventStepStatus, repo, currentPipeline, workflow, step)
 
 	if currentPipeline.Workflows, err = s.store.WorkflowGetTree(currentPipeline); err != nil {
 		log.Error().Err(err).Msg("cannot build tree from step list")
@@ -250,6 +255,8 @@ func (s *RPC) Init(c context.Context, strWorkflowID string, state rpc.WorkflowSt
 	if currentPipeline.Status == model.StatusPending {
 		if currentPipeline, err = pipeline.UpdateToStatusRunning(s.store, *currentPipeline, state.Started); err != nil {
 			log.Error().Err(err).Msgf("init: cannot update pipeline %d state", currentPipeline.ID)
+		} else {
+			pipeline.SendWebhooks(model.HookEventPipelineStarted, repo, currentPipeline, nil, nil)
 		}
 	}
 
@@ -279,6 +286,7 @@ func (s *RPC) Init(c context.Context, strWorkflowID string, state rpc.WorkflowSt
 		return err
 	}
 	s.updateForgeStatus(c, repo, currentPipeline, workflow)
+	pipeline.SendWebhooks(model.HookEventWorkflowStatus, repo, currentPipeline, workflow, nil)
 
 	return s.updateAgentLastWork(agent)
 }
@@ -332,6 +340,19 @@ func (s *RPC) Done(c context.Context, strWorkflowID string, state rpc.WorkflowSt
 
 	if workflow, err = pipeline.UpdateWorkflowStatusToDone(s.store, *workflow, state); err != nil {
 		logger.Error().Err(err).Msgf("pipeline.UpdateWorkflowStatusToDone: cannot update workflow state: %s", err)
+	} else {
+		metrics.WorkflowFinished(workflow)
+		if err := pipeline.UpdateStepFlakiness(s.store, currentPipeline, workflow); err != nil {
+			logger.Error().Err(err).Msg("pipeline.UpdateStepFlakiness: cannot update flakiness of steps")
+		}
+	}
+	pipeline.SendWebhooks(model.HookEventWorkflowStatus, repo, currentPipeline, workflow, nil)
+
+	if workflow.Failing() && cancelsPipeline(workflow) {
+		logger.Debug().Msg("step with failure mode cancel_pipeline failed, cancel other workflows")
+		if err := pipeline.CancelOtherWorkflows(c, s.store, currentPipeline, workflow); err != nil {
+			logger.Error().Err(err).Msg("pipeline.CancelOtherWorkflows: cannot cancel workflows")
+		}
 	}
 
 	var queueErr error
@@ -351,8 +372,13 @@ func (s *RPC) Done(c context.Context, strWorkflowID string, state rpc.WorkflowSt
 	s.completeChildrenIfParentCompleted(workflow)
 
 	if !model.IsThereRunningStage(currentPipeline.Workflows) {
+		// canceled pipelines are already finished
+		wasRunning := currentPipeline.Status == model.StatusRunning || currentPipeline.Status == model.StatusPending
 		if currentPipeline, err = pipeline.UpdateStatusToDone(s.store, *currentPipeline, model.PipelineStatus(currentPipeline.Workflows), workflow.Finished); err != nil {
 			logger.Error().Err(err).Msgf("pipeline.UpdateStatusToDone: cannot update workflows final state")
+		} else if wasRunning {
+			metrics.PipelineFinished(repo, currentPipeline)
+			pipeline.SendWebhooks(model.HookEventPipelineFinished, repo, currentPipeline, nil, nil)
 		}
 	}
 
@@ -361,6 +387,9 @@ func (s *RPC) Done(c context.Context, strWorkflowID string, state rpc.WorkflowSt
 	// make sure writes to pubsub are non blocking (https://github.com/woodpecker-ci/woodpecker/blob/c919f32e0b6432a95e1a6d3d0ad662f591adf73f/server/logging/log.go#L9)
 	go func() {
 		for _, step := range workflow.Children {
+			if err := logService.StepFinished(server.Config.Services.LogStore, step); err != nil {
+				logger.Error().Err(err).Msgf("done: cannot finish logs of step %d", step.ID)
+			}
 			if err := s.logger.Close(c, step.ID); err != nil {
 				logger.Error().Err(err).Msgf("done: cannot close log stream for step %d", step.ID)
 			}
@@ -441,6 +470,98 @@ func (s *RPC) Log(c context.Context, stepUUID string, rpcLogEntries []*rpc.LogEn
 	return nil
 }
 
+// UploadArtifact stores the content of a file uploaded as artifact of a step.
+// An artifact with the same name uploaded by a previous attempt of the step is replaced.
+func (s *RPC) UploadArtifact(c context.Context, strWorkflowID string, rpcArtifact rpc.Artifact, content io.Reader) error {
+	artifactStore := server.Config.Services.ArtifactStore
+	if artifactStore == nil {
+		return errors.New("artifacts are disabled on this server")
+	}
+
+	workflowID, err := strconv.ParseInt(strWorkflowID, 10, 64)
+	if err != nil {
+		return err
+	}
+
+	workflow, err := s.store.WorkflowLoad(workflowID)
+	if err != nil {
+		log.Error().Err(err).Msgf("rpc.upload_artifact: cannot find workflow with id %d", workflowID)
+		return err
+	}
+
+	currentPipeline, err := s.store.GetPipeline(workflow.PipelineID)
+	if err != nil {
+		log.Error().Err(err).Msgf("cannot find pipeline with id %d", workflow.PipelineID)
+		return err
+	}
+
+	agent, err := s.getAgentFromContext(c)
+	if err != nil {
+		return err
+	}
+
+	// check before agent can alter some state
+	if err := s.checkAgentPermissionByWorkflow(c, agent, "", currentPipeline, nil); err != nil {
+		return err
+	}
+
+	step, err := s.store.StepByUUID(rpcArtifact.StepUUID)
+	if err != nil {
+		log.Error().Err(err).Msgf("cannot find step with uuid %s", rpcArtifact.StepUUID)
+		return err
+	}
+
+	if step.PipelineID != currentPipeline.ID {
+		return fmt.Errorf("agent uploaded artifact for step uuid '%s' which does not belong to current pipeline", rpcArtifact.StepUUID)
+	}
+
+	newArtifact := &model.Artifact{
+		PipelineID: currentPipeline.ID,
+		StepID:     step.ID,
+		Name:       rpcArtifact.Name,
+		Size:       rpcArtifact.Size,
+		Created:    time.Now().Unix(),
+	}
+	if err := newArtifact.Validate(); err != nil {
+		return err
+	}
+	if maxSize := server.Config.Pipeline.MaxArtifactSize; maxSize > 0 && newArtifact.Size > maxSize {
+		return fmt.Errorf("artifact '%s' exceeds the maximum size of %d bytes", newArtifact.Name, maxSize)
+	}
+
+	artifacts, err := s.store.ArtifactList(currentPipeline)
+	if err != nil {
+		return err
+	}
+	for _, existing := range artifacts {
+		if existing.StepID != step.ID || existing.Name != newArtifact.Name {
+			continue
+		}
+		if err := artifactStore.ArtifactDelete(existing); err != nil {
+			return err
+		}
+		if err := s.store.ArtifactDelete(existing); err != nil {
+			return err
+		}
+	}
+
+	if err := s.store.ArtifactCreate(newArtifact); err != nil {
+		return err
+	}
+
+	if err := artifactStore.ArtifactPut(newArtifact, &exactReader{r: content, remaining: newArtifact.Size}); err != nil {
+		if dErr := artifactStore.ArtifactDelete(newArtifact); dErr != nil {
+			log.Error().Err(dErr).Msgf("could not remove content of failed upload of artifact %d", newArtifact.ID)
+		}
+		if dErr := s.store.ArtifactDelete(newArtifact); dErr != nil {
+			log.Error().Err(dErr).Msgf("could not remove failed upload of artifact %d", newArtifact.ID)
+		}
+		return fmt.Errorf("could not store artifact '%s': %w", newArtifact.Name, err)
+	}
+
+	return s.updateAgentLastWork(agent)
+}
+
 func (s *RPC) RegisterAgent(ctx context.Context, info rpc.AgentInfo) (int64, error) {
 	agent, err := s.getAgentFromContext(ctx)
 	if err != nil {
@@ -548,6 +669,16 @@ func (s *RPC) completeChildrenIfParentCompleted(completedWorkflow *model.Workflo
 	}
 }
 
+// cancelsPipeline returns true if a step with failure mode cancel_pipeline failed in the workflow.
+func cancelsPipeline(workflow *model.Workflow) bool {
+	for _, step := range workflow.Children {
+		if step.Failure == model.FailureCancelPipeline && step.Failing() {
+			return true
+		}
+	}
+	return false
+}
+
 func (s *RPC) updateForgeStatus(ctx context.Context, repo *model.Repo, pipeline *model.Pipeline, workflow *model.Workflow) {
 	user, err := s.store.GetUser(repo.UserID)
 	if err != nil {
@@ -634,3 +765,25 @@ func (s *RPC) updateAgentLastWork(agent *model.Agent) error {
 
 	return nil
 }
+
+// exactReader fails if the content is not exactly of the announced size.
+type exactReader struct {
+	r         io.Reader
+	remaining int64
+}
+
+func (e *exactReader) Read(p []byte) (int, error) {
+	// read one byte more than remaining to detect oversized content
+	if int64(len(p)) > e.remaining+1 {
+		p = p[:e.remaining+1]
+	}
+	n, err := e.r.Read(p)
+	e.remaining -= int64(n)
+	if e.remaining < 0 {
+		return n, errors.New("content is larger than announced")
+	}
+	if errors.Is(err, io.EOF) && e.remaining > 0 {
+		return n, io.ErrUnexpectedEOF
+	}
+	return n, err
+}
@@ -16,6 +16,8 @@ package grpc
 
 import (
 	"context"
+	"io"
+	"strings"
 	"testing"
 	"time"
 
@@ -24,7 +26,10 @@ import (
 	"google.golang.org/grpc/metadata"
 
 	"go.woodpecker-ci.org/woodpecker/v3/pipeline/rpc"
+	"go.woodpecker-ci.org/woodpecker/v3/server"
 	"go.woodpecker-ci.org/woodpecker/v3/server/model"
+	"go.woodpecker-ci.org/woodpecker/v3/server/services/artifact"
+	artifact_file "go.woodpecker-ci.org/woodpecker/v3/server/services/artifact/file"
 	mocks_store "go.woodpecker-ci.org/woodpecker/v3/server/store/mocks"
 )
 
@@ -160,3 +165,83 @@ func TestUpdateAgentLastWork(t *testing.T) {
 		assert.Equal(t, lastWork, agent.LastWork)
 	})
 }
+
+func TestUploadArtifact(t *testing.T) {
+	artifactStore, err := artifact_file.NewArtifactStore(t.TempDir())
+	if !assert.NoError(t, err) {
+		return
+	}
+	server.Config.Services.ArtifactStore = artifactStore
+	defer func() { server.Config.Services.ArtifactStore = nil }()
+
+	pipeline := &model.Pipeline{ID: 2, RepoID: 3}
+	previous := &model.Artifact{ID: 5, PipelineID: 2, StepID: 4, Name: "dist/app", Size: 3}
+	assert.NoError(t, artifactStore.ArtifactPut(previous, strings.NewReader("old")))
+
+	setup := func() *mocks_store.Store {
+		store := mocks_store.NewStore(t)
+		store.On("WorkflowLoad", int64(1)).Return(&model.Workflow{ID: 1, PipelineID: 2}, nil)
+		store.On("GetPipeline", int64(2)).Return(pipeline, nil)
+		store.On("AgentFind", int64(1337)).Return(&model.Agent{ID: 1337, OrgID: model.IDNotSet, LastWork: time.Now().Unix()}, nil)
+		store.On("GetRepo", int64(3)).Return(&model.Repo{ID: 3}, nil)
+		store.On("StepByUUID", "step-uuid").Return(&model.Step{ID: 4, PipelineID: 2}, nil)
+		return store
+	}
+	ctx := metadata.NewIncomingContext(
+		context.Background(),
+		metadata.Pairs("hostname", "hostname", "agent_id", "1337"),
+	)
+
+	t.Run("replaces artifact of previous attempt", func(t *testing.T) {
+		store := setup()
+		store.On("ArtifactList", pipeline).Return([]*model.Artifact{previous}, nil)
+		store.On("ArtifactDelete", previous).Return(nil)
+		store.On("ArtifactCreate", mock.Anything).Run(func(args mock.Arguments) {
+			args.Get(0).(*model.Artifact).ID = 6
+		}).Return(nil)
+
+		grpc := RPC{store: store}
+		err := grpc.UploadArtifact(ctx, "1", rpc.Artifact{StepUUID: "step-uuid", Name: "dist/app", Size: 6}, strings.NewReader("binary"))
+		assert.NoError(t, err)
+
+		content, err := artifactStore.ArtifactGet(&model.Artifact{ID: 6, PipelineID: 2})
+		if assert.NoError(t, err) {
+			data, _ := io.ReadAll(content)
+			content.Close()
+			assert.Equal(t, "binary", string(data))
+		}
+		_, err = artifactStore.ArtifactGet(previous)
+		assert.ErrorIs(t, err, artifact.ErrNotFound)
+	})
+
+	t.Run("rejects content of wrong size", func(t *testing.T) {
+		store := setup()
+		store.On("ArtifactList", pipeline).Return([]*model.Artifact{}, nil)
+		store.On("ArtifactCreate", mock.Anything).Run(func(args mock.Arguments) {
+			args.Get(0).(*model.Artifact).ID = 7
+		}).Return(nil)
+		store.On("ArtifactDelete", mock.Anything).Return(nil)
+
+		grpc := RPC{store: store}
+		err := grpc.UploadArtifact(ctx, "1", rpc.Artifact{StepUUID: "step-uuid", Name: "report.xml", Size: 2}, strings.NewReader("too long"))
+		assert.Error(t, err)
+
+		_, err = artifactStore.ArtifactGet(&model.Artifact{ID: 7, PipelineID: 2})
+		assert.ErrorIs(t, err, artifact.ErrNotFound)
+	})
+
+	t.Run("rejects artifacts exceeding the maximum size", func(t *testing.T) {
+		server.Config.Pipeline.MaxArtifactSize = 4
+		defer func() { server.Config.Pipeline.MaxArtifactSize = 0 }()
+
+		grpc := RPC{store: setup()}
+		err := grpc.UploadArtifact(ctx, "1", rpc.Artifact{StepUUID: "step-uuid", Name: "dist/app", Size: 6}, strings.NewReader("binary"))
+		assert.ErrorContains(t, err, "exceeds the maximum size of 4 bytes")
+	})
+
+	t.Run("rejects names outside of the workspace", func(t *testing.T) {
+		grpc := RPC{store: setup()}
+		err := grpc.UploadArtifact(ctx, "1", rpc.Artifact{StepUUID: "step-uuid", Name: "../etc/passwd"}, strings.NewReader(""))
+		assert.Error(t, err)
+	})
+}
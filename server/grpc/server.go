@@ -21,6 +21,7 @@ import (
 	"github.com/prometheus/client_golang/prometheus"
 	prometheus_auto "github.com/prometheus/client_golang/prometheus/promauto"
 	"github.com/rs/zerolog/log"
+	"google.golang.org/grpc"
 
 	"go.woodpecker-ci.org/woodpecker/v3/pipeline/rpc"
 	"go.woodpecker-ci.org/woodpecker/v3/pipeline/rpc/proto"
@@ -28,6 +29,7 @@ import (
 	"go.woodpecker-ci.org/woodpecker/v3/server/pubsub"
 	"go.woodpecker-ci.org/woodpecker/v3/server/queue"
 	"go.woodpecker-ci.org/woodpecker/v3/server/store"
+	"go.woodpecker-ci.org/woodpecker/v3/shared/tracing"
 	"go.woodpecker-ci.org/woodpecker/v3/version"
 )
 
@@ -37,7 +39,7 @@ type WoodpeckerServer struct {
 	peer RPC
 }
 
-func NewWoodpeckerServer(queue queue.Queue, logger logging.Log, pubsub *pubsub.Publisher, store store.Store) proto.WoodpeckerServer {
+func NewWoodpeckerServer(queue queue.Queue, logger logging.Log, pubsub pubsub.Publisher, store store.Store) proto.WoodpeckerServer {
 	pipelineTime := prometheus_auto.NewGaugeVec(prometheus.GaugeOpts{
 		Namespace: "woodpecker",
 		Name:      "pipeline_time",
@@ -81,8 +83,18 @@ func (s *WoodpeckerServer) Next(c context.Context, req *proto.NextRequest) (*pro
 	res.Workflow.Id = pipeline.ID
 	res.Workflow.Timeout = pipeline.Timeout
 	res.Workflow.Payload, err = json.Marshal(pipeline.Config)
+	if err != nil {
+		return res, err
+	}
 
-	return res, err
+	if len(pipeline.TraceContext) != 0 {
+		// the trace context is sent as header, so agents continue the trace of the pipeline
+		if err := grpc.SetHeader(c, tracing.ToMetadata(pipeline.TraceContext)); err != nil {
+			log.Debug().Err(err).Msg("could not send trace context")
+		}
+	}
+
+	return res, nil
 }
 
 func (s *WoodpeckerServer) Init(c context.Context, req *proto.InitRequest) (*proto.Empty, error) {
@@ -104,6 +116,8 @@ func (s *WoodpeckerServer) Update(c context.Context, req *proto.UpdateRequest) (
 		Exited:   req.GetState().GetExited(),
 		Error:    req.GetState().GetError(),
 		ExitCode: int(req.GetState().GetExitCode()),
+		Retry:    req.GetState().GetRetry(),
+		LogLines: int(req.GetState().GetLogLines()),
 	}
 	res := new(proto.Empty)
 	err := s.peer.Update(c, req.GetId(), state)
@@ -170,6 +184,25 @@ func (s *WoodpeckerServer) Log(c context.Context, req *proto.LogRequest) (*proto
 	return res, err
 }
 
+func (s *WoodpeckerServer) UploadArtifact(stream proto.Woodpecker_UploadArtifactServer) error {
+	req, err := stream.Recv()
+	if err != nil {
+		return err
+	}
+
+	artifact := rpc.Artifact{
+		StepUUID: req.GetStepUuid(),
+		Name:     req.GetName(),
+		Size:     req.GetSize(),
+	}
+	content := &artifactStreamReader{stream: stream, data: req.GetData()}
+	if err := s.peer.UploadArtifact(stream.Context(), req.GetId(), artifact, content); err != nil {
+		log.Error().Err(err).Msg("could not upload artifact")
+		return err
+	}
+	return stream.SendAndClose(new(proto.Empty))
+}
+
 func (s *WoodpeckerServer) RegisterAgent(c context.Context, req *proto.RegisterAgentRequest) (*proto.RegisterAgentResponse, error) {
 	res := new(proto.RegisterAgentResponse)
 	agentInfo := req.GetInfo()
@@ -194,3 +227,22 @@ func (s *WoodpeckerServer) ReportHealth(c context.Context, req *proto.ReportHeal
 	err := s.peer.ReportHealth(c, req.GetStatus())
 	return res, err
 }
+
+// artifactStreamReader reads the content of an artifact from the chunks of an upload stream.
+type artifactStreamReader struct {
+	stream proto.Woodpecker_UploadArtifactServer
+	data   []byte
+}
+
+func (r *artifactStreamReader) Read(p []byte) (int, error) {
+	for len(r.data) == 0 {
+		req, err := r.stream.Recv()
+		if err != nil {
+			return 0, err
+		}
+		r.data = req.GetData()
+	}
+	n := copy(p, r.data)
+	r.data = r.data[n:]
+	return n, nil
+}
@@ -0,0 +1,107 @@
+// Copyright 2025 Woodpecker Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package logging
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"strconv"
+
+	logger "github.com/rs/zerolog/log"
+
+	"go.woodpecker-ci.org/woodpecker/v3/server/model"
+	"go.woodpecker-ci.org/woodpecker/v3/server/pubsub"
+)
+
+const (
+	labelStepID  = "step_id"
+	labelType    = "type"
+	messageWrite = "write"
+	messageClose = "close"
+)
+
+type pubsubLog struct {
+	local  Log
+	pubsub pubsub.Publisher
+}
+
+// NewPubsub returns a logger which distributes written entries using the publisher,
+// so logs can be tailed on every server instance subscribed to it.
+func NewPubsub(ctx context.Context, publisher pubsub.Publisher) Log {
+	l := &pubsubLog{
+		local:  New(),
+		pubsub: publisher,
+	}
+	go publisher.Subscribe(ctx, l.receive)
+	return l
+}
+
+func (l *pubsubLog) Open(c context.Context, stepID int64) error {
+	return l.local.Open(c, stepID)
+}
+
+func (l *pubsubLog) Write(_ context.Context, stepID int64, entries []*model.LogEntry) error {
+	data, err := json.Marshal(entries)
+	if err != nil {
+		return err
+	}
+	l.publish(stepID, messageWrite, data)
+	return nil
+}
+
+func (l *pubsubLog) Tail(c context.Context, stepID int64, handler LogChan) error {
+	return l.local.Tail(c, stepID, handler)
+}
+
+func (l *pubsubLog) Close(_ context.Context, stepID int64) error {
+	l.publish(stepID, messageClose, nil)
+	return nil
+}
+
+func (l *pubsubLog) publish(stepID int64, typ string, data []byte) {
+	l.pubsub.Publish(pubsub.Message{
+		Data: data,
+		Labels: map[string]string{
+			labelStepID: strconv.FormatInt(stepID, 10),
+			labelType:   typ,
+		},
+	})
+}
+
+// receive applies messages published by any server instance to the local streams.
+func (l *pubsubLog) receive(message pubsub.Message) {
+	stepID, err := strconv.ParseInt(message.Labels[labelStepID], 10, 64)
+	if err != nil {
+		logger.Error().Err(err).Msg("logging: invalid step id in message")
+		return
+	}
+
+	ctx := context.Background()
+	switch message.Labels[labelType] {
+	case messageWrite:
+		var entries []*model.LogEntry
+		if err := json.Unmarshal(message.Data, &entries); err != nil {
+			logger.Error().Err(err).Msgf("logging: invalid log entries for step %d", stepID)
+			return
+		}
+		err = l.local.Write(ctx, stepID, entries)
+	case messageClose:
+		err = l.local.Close(ctx, stepID)
+	}
+	if err != nil && !errors.Is(err, ErrNotFound) {
+		logger.Error().Err(err).Msgf("logging: cannot apply message for step %d", stepID)
+	}
+}
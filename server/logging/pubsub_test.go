@@ -0,0 +1,68 @@
+// Copyright 2025 Woodpecker Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package logging
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+
+	"go.woodpecker-ci.org/woodpecker/v3/server/model"
+	"go.woodpecker-ci.org/woodpecker/v3/server/pubsub"
+)
+
+func TestPubsubLogging(t *testing.T) {
+	testStepID := int64(123)
+
+	ctx, cancel := context.WithCancelCause(context.Background())
+	defer cancel(nil)
+
+	// two loggers sharing one publisher, like two servers would do
+	publisher := pubsub.New()
+	writer := NewPubsub(ctx, publisher)
+	reader := NewPubsub(ctx, publisher)
+
+	<-time.After(100 * time.Millisecond)
+
+	assert.NoError(t, reader.Open(ctx, testStepID))
+	receiver := make(LogChan, 10)
+	tailDone := make(chan error)
+	go func() {
+		tailDone <- reader.Tail(ctx, testStepID, receiver)
+	}()
+
+	<-time.After(100 * time.Millisecond)
+
+	assert.NoError(t, writer.Write(ctx, testStepID, []*model.LogEntry{{StepID: testStepID, Line: 1, Data: []byte("test")}}))
+
+	select {
+	case entries := <-receiver:
+		assert.Len(t, entries, 1)
+		assert.Equal(t, "test", string(entries[0].Data))
+	case <-time.After(time.Second):
+		t.Fatal("expected log entries written on other logger")
+	}
+
+	assert.NoError(t, writer.Close(ctx, testStepID))
+
+	select {
+	case err := <-tailDone:
+		assert.NoError(t, err)
+	case <-time.After(time.Second):
+		t.Fatal("expected tail to end after close on other logger")
+	}
+}
@@ -0,0 +1,50 @@
+// Copyright 2025 Woodpecker Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package metrics
+
+import "sync"
+
+const otherLabel = "other"
+
+// labelGuard limits the number of distinct values of a label to keep the cardinality of metrics bounded.
+type labelGuard struct {
+	sync.Mutex
+	limit  int
+	values map[string]struct{}
+}
+
+func newLabelGuard(limit int) *labelGuard {
+	return &labelGuard{limit: limit, values: make(map[string]struct{})}
+}
+
+func (g *labelGuard) setLimit(limit int) {
+	g.Lock()
+	defer g.Unlock()
+	g.limit = limit
+}
+
+// value returns the given value if it is already known or the limit is not reached yet, otherwise "other".
+func (g *labelGuard) value(v string) string {
+	g.Lock()
+	defer g.Unlock()
+	if _, ok := g.values[v]; ok {
+		return v
+	}
+	if len(g.values) >= g.limit {
+		return otherLabel
+	}
+	g.values[v] = struct{}{}
+	return v
+}
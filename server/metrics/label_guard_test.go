@@ -0,0 +1,32 @@
+// Copyright 2025 Woodpecker Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package metrics
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestLabelGuard(t *testing.T) {
+	guard := newLabelGuard(2)
+	assert.Equal(t, "a", guard.value("a"))
+	assert.Equal(t, "b", guard.value("b"))
+	assert.Equal(t, "other", guard.value("c"))
+	assert.Equal(t, "a", guard.value("a"))
+
+	guard = newLabelGuard(0)
+	assert.Equal(t, "other", guard.value("a"))
+}
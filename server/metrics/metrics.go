@@ -0,0 +1,138 @@
+// Copyright 2025 Woodpecker Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package metrics provides the Prometheus metrics of pipelines, the queue, forges and webhooks.
+package metrics
+
+import (
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus"
+	prometheus_auto "github.com/prometheus/client_golang/prometheus/promauto"
+
+	"go.woodpecker-ci.org/woodpecker/v3/server/model"
+)
+
+const (
+	namespace = "woodpecker"
+
+	resultSuccess = "success"
+	resultError   = "error"
+)
+
+// durationBuckets range from 1 second to about 4.5 hours.
+var durationBuckets = prometheus.ExponentialBuckets(1, 2, 15) //nolint:mnd
+
+var (
+	pipelineDuration = prometheus_auto.NewHistogramVec(prometheus.HistogramOpts{
+		Namespace: namespace,
+		Name:      "pipeline_duration_seconds",
+		Help:      "Duration of finished pipelines.",
+		Buckets:   durationBuckets,
+	}, []string{"status", "event"})
+	pipelinesFinished = prometheus_auto.NewCounterVec(prometheus.CounterOpts{
+		Namespace: namespace,
+		Name:      "pipelines_finished_total",
+		Help:      "Total number of finished pipelines by outcome.",
+	}, []string{"status", "event", "org", "repo"})
+	workflowDuration = prometheus_auto.NewHistogramVec(prometheus.HistogramOpts{
+		Namespace: namespace,
+		Name:      "workflow_duration_seconds",
+		Help:      "Duration of finished workflows.",
+		Buckets:   durationBuckets,
+	}, []string{"status"})
+	stepDuration = prometheus_auto.NewHistogramVec(prometheus.HistogramOpts{
+		Namespace: namespace,
+		Name:      "step_duration_seconds",
+		Help:      "Duration of finished steps.",
+		Buckets:   durationBuckets,
+	}, []string{"status"})
+	queueWait = prometheus_auto.NewHistogram(prometheus.HistogramOpts{
+		Namespace: namespace,
+		Name:      "queue_wait_seconds",
+		Help:      "Time workflows spent in the queue before an agent picked them.",
+		Buckets:   prometheus.ExponentialBuckets(0.1, 2, 16), //nolint:mnd
+	})
+	forgeRequestDuration = prometheus_auto.NewHistogramVec(prometheus.HistogramOpts{
+		Namespace: namespace,
+		Name:      "forge_request_duration_seconds",
+		Help:      "Latency of forge API calls.",
+		Buckets:   prometheus.DefBuckets,
+	}, []string{"forge", "method", "result"})
+	webhookErrors = prometheus_auto.NewCounterVec(prometheus.CounterOpts{
+		Namespace: namespace,
+		Name:      "webhook_errors_total",
+		Help:      "Total number of incoming webhooks which could not be processed.",
+	}, []string{"reason"})
+)
+
+// DefaultMaxRepoLabels is the default number of distinct repositories and organizations used as label values.
+const DefaultMaxRepoLabels = 100
+
+var (
+	repoLabels = newLabelGuard(DefaultMaxRepoLabels)
+	orgLabels  = newLabelGuard(DefaultMaxRepoLabels)
+)
+
+// SetMaxRepoLabels sets the number of distinct repositories and organizations used as label values,
+// all others are reported as "other". A value of zero disables the breakdown.
+func SetMaxRepoLabels(limit int) {
+	repoLabels.setLimit(limit)
+	orgLabels.setLimit(limit)
+}
+
+// PipelineFinished records the outcome and duration of a finished pipeline.
+func PipelineFinished(repo *model.Repo, pipeline *model.Pipeline) {
+	status := string(pipeline.Status)
+	event := string(pipeline.Event)
+	pipelinesFinished.WithLabelValues(status, event, orgLabels.value(repo.Owner), repoLabels.value(repo.FullName)).Inc()
+	if pipeline.Started != 0 && pipeline.Finished >= pipeline.Started {
+		pipelineDuration.WithLabelValues(status, event).Observe(float64(pipeline.Finished - pipeline.Started))
+	}
+}
+
+// WorkflowFinished records the duration of a finished workflow.
+func WorkflowFinished(workflow *model.Workflow) {
+	if workflow.Started != 0 && workflow.Finished >= workflow.Started {
+		workflowDuration.WithLabelValues(string(workflow.State)).Observe(float64(workflow.Finished - workflow.Started))
+	}
+}
+
+// StepFinished records the duration of a finished step.
+func StepFinished(step *model.Step) {
+	if step.Started != 0 && step.Finished >= step.Started {
+		stepDuration.WithLabelValues(string(step.State)).Observe(float64(step.Finished - step.Started))
+	}
+}
+
+// TaskPolled records the time a task spent in the queue.
+func TaskPolled(task *model.Task) {
+	if task.Enqueued != 0 {
+		queueWait.Observe(time.Since(time.Unix(0, task.Enqueued)).Seconds())
+	}
+}
+
+// ForgeRequest records the latency of a forge API call.
+func ForgeRequest(forge, method string, started time.Time, err error) {
+	result := resultSuccess
+	if err != nil {
+		result = resultError
+	}
+	forgeRequestDuration.WithLabelValues(forge, method, result).Observe(time.Since(started).Seconds())
+}
+
+// WebhookError counts an incoming webhook which could not be processed.
+func WebhookError(reason string) {
+	webhookErrors.WithLabelValues(reason).Inc()
+}
@@ -0,0 +1,56 @@
+// Copyright 2025 Woodpecker Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package model
+
+import "slices"
+
+// ApprovalPolicy defines who has to approve a blocked pipeline before it runs.
+type ApprovalPolicy struct {
+	// RequiredApprovals is the number of distinct users who have to approve a pipeline, at least one.
+	RequiredApprovals int `json:"required_approvals,omitempty"`
+	// Teams limits approvers to members of these teams or organizations of the forge.
+	Teams []string `json:"teams,omitempty"`
+	// PreventSelfApproval forbids the author of a pipeline to approve it.
+	PreventSelfApproval bool `json:"prevent_self_approval,omitempty"`
+} //	@name ApprovalPolicy
+
+// Required returns the number of approvals needed to start a pipeline.
+func (p ApprovalPolicy) Required() int {
+	return max(p.RequiredApprovals, 1)
+}
+
+// AllowsTeams returns true if a member of the given teams can approve pipelines.
+func (p ApprovalPolicy) AllowsTeams(teams []*Team) bool {
+	if len(p.Teams) == 0 {
+		return true
+	}
+	return slices.ContainsFunc(teams, func(team *Team) bool {
+		return slices.Contains(p.Teams, team.Login)
+	})
+}
+
+// Approval is the approval of a blocked pipeline by a user.
+type Approval struct {
+	ID         int64  `json:"id"          xorm:"pk autoincr 'id'"`
+	PipelineID int64  `json:"pipeline_id" xorm:"UNIQUE(s) INDEX 'pipeline_id'"`
+	UserID     int64  `json:"user_id"     xorm:"UNIQUE(s) 'user_id'"`
+	Login      string `json:"login"       xorm:"varchar(255) 'login'"`
+	Created    int64  `json:"created"     xorm:"created NOT NULL DEFAULT 0"`
+} //	@name Approval
+
+// TableName returns the database table name for xorm.
+func (Approval) TableName() string {
+	return "approvals"
+}
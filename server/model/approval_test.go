@@ -0,0 +1,32 @@
+// Copyright 2025 Woodpecker Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package model
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestApprovalPolicy(t *testing.T) {
+	assert.Equal(t, 1, ApprovalPolicy{}.Required())
+	assert.Equal(t, 3, ApprovalPolicy{RequiredApprovals: 3}.Required())
+
+	assert.True(t, ApprovalPolicy{}.AllowsTeams(nil))
+	policy := ApprovalPolicy{Teams: []string{"release", "security"}}
+	assert.False(t, policy.AllowsTeams(nil))
+	assert.False(t, policy.AllowsTeams([]*Team{{Login: "dev"}}))
+	assert.True(t, policy.AllowsTeams([]*Team{{Login: "dev"}, {Login: "security"}}))
+}
@@ -0,0 +1,53 @@
+// Copyright 2025 Woodpecker Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package model
+
+import (
+	"errors"
+	"path"
+	"strings"
+)
+
+const maxArtifactNameLength = 255
+
+// Artifact is a file uploaded by a step of a pipeline.
+type Artifact struct {
+	ID         int64  `json:"id"          xorm:"pk autoincr 'id'"`
+	PipelineID int64  `json:"pipeline_id" xorm:"INDEX 'pipeline_id'"`
+	StepID     int64  `json:"step_id"     xorm:"UNIQUE(s) 'step_id'"`
+	Name       string `json:"name"        xorm:"UNIQUE(s) 'name'"`
+	Size       int64  `json:"size"        xorm:"size"`
+	Created    int64  `json:"created"     xorm:"created NOT NULL DEFAULT 0"`
+} //	@name Artifact
+
+// TableName returns the database table name for xorm.
+func (Artifact) TableName() string {
+	return "artifacts"
+}
+
+// Validate ensures the artifact has a valid name, which is a path relative to the workspace.
+func (a *Artifact) Validate() error {
+	switch {
+	case a.Name == "":
+		return errors.New("name is required")
+	case len(a.Name) > maxArtifactNameLength:
+		return errors.New("name is too long")
+	case path.IsAbs(a.Name), path.Clean(a.Name) != a.Name, a.Name == "..", strings.HasPrefix(a.Name, "../"):
+		return errors.New("name has to be a clean path relative to the workspace")
+	case a.Size < 0:
+		return errors.New("size must not be negative")
+	}
+	return nil
+}
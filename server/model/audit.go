@@ -0,0 +1,76 @@
+// Copyright 2025 Woodpecker Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package model
+
+// AuditAction is an action recorded in the audit log.
+type AuditAction string //	@name AuditAction
+
+const (
+	AuditActionCreate     AuditAction = "create"
+	AuditActionUpdate     AuditAction = "update"
+	AuditActionDelete     AuditAction = "delete"
+	AuditActionApprove    AuditAction = "approve"
+	AuditActionDecline    AuditAction = "decline"
+	AuditActionChown      AuditAction = "chown"
+	AuditActionResetToken AuditAction = "reset_token"
+)
+
+// AuditTarget is the type of resource an audited action was performed on.
+type AuditTarget string //	@name AuditTarget
+
+const (
+	AuditTargetSecret      AuditTarget = "secret"
+	AuditTargetRegistry    AuditTarget = "registry"
+	AuditTargetRepo        AuditTarget = "repo"
+	AuditTargetPipeline    AuditTarget = "pipeline"
+	AuditTargetAgent       AuditTarget = "agent"
+	AuditTargetUser        AuditTarget = "user"
+	AuditTargetForge       AuditTarget = "forge"
+	AuditTargetWebhook     AuditTarget = "webhook"
+	AuditTargetToken       AuditTarget = "token"
+	AuditTargetEnvironment AuditTarget = "environment"
+)
+
+// AuditEvent records who performed an administrative or security-relevant action on which resource.
+type AuditEvent struct {
+	ID         int64       `json:"id"          xorm:"pk autoincr 'id'"`
+	Created    int64       `json:"created"     xorm:"created NOT NULL DEFAULT 0 INDEX"`
+	UserID     int64       `json:"user_id"     xorm:"INDEX 'user_id'"`
+	UserLogin  string      `json:"user_login"  xorm:"'user_login'"`
+	Action     AuditAction `json:"action"      xorm:"INDEX 'action'"`
+	TargetType AuditTarget `json:"target_type" xorm:"INDEX 'target_type'"`
+	TargetID   int64       `json:"target_id"   xorm:"'target_id'"`
+	TargetName string      `json:"target_name" xorm:"'target_name'"`
+	// RepoID and OrgID are set if the target belongs to a repository or an organization.
+	RepoID  int64  `json:"repo_id"           xorm:"NOT NULL DEFAULT 0 INDEX 'repo_id'"`
+	OrgID   int64  `json:"org_id"            xorm:"NOT NULL DEFAULT 0 INDEX 'org_id'"`
+	Details string `json:"details,omitempty" xorm:"TEXT 'details'"`
+} //	@name AuditEvent
+
+// TableName returns the database table name for xorm.
+func (AuditEvent) TableName() string {
+	return "audit_events"
+}
+
+// AuditEventFilter limits the audit events returned by the store, unset fields are ignored.
+type AuditEventFilter struct {
+	UserLogin  string
+	Action     AuditAction
+	TargetType AuditTarget
+	RepoID     int64
+	OrgID      int64
+	Before     int64
+	After      int64
+}
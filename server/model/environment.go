@@ -0,0 +1,120 @@
+// Copyright 2025 Woodpecker Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package model
+
+import (
+	"errors"
+	"fmt"
+	"net/url"
+	"regexp"
+	"slices"
+
+	"github.com/bmatcuk/doublestar/v4"
+)
+
+var ErrEnvironmentNameInvalid = errors.New("invalid environment name")
+
+var validEnvironmentName = regexp.MustCompile(`^[\w.\-]+$`)
+
+// Environment is a deployment target of a repository with its protection rules.
+type Environment struct {
+	ID     int64  `json:"id"      xorm:"pk autoincr 'id'"`
+	RepoID int64  `json:"repo_id" xorm:"NOT NULL UNIQUE(s) INDEX 'repo_id'"`
+	Name   string `json:"name"    xorm:"NOT NULL UNIQUE(s) 'name'"`
+	URL    string `json:"url"     xorm:"varchar(500) 'url'"`
+	// RequiredApprovers are the logins of the users allowed to approve deployments,
+	// if set every deployment to the environment waits for the approval of one of them.
+	RequiredApprovers []string `json:"required_approvers" xorm:"json 'required_approvers'"`
+	// AllowedBranches are patterns of the branches deployments are allowed from, all branches if empty.
+	AllowedBranches []string `json:"allowed_branches" xorm:"json 'allowed_branches'"`
+	Created         int64    `json:"created"          xorm:"created NOT NULL DEFAULT 0"`
+	Updated         int64    `json:"updated"          xorm:"updated NOT NULL DEFAULT 0"`
+} //	@name Environment
+
+// TableName returns the database table name for xorm.
+func (Environment) TableName() string {
+	return "environments"
+}
+
+// Validate ensures the environment has a valid name, url and branch patterns.
+func (e *Environment) Validate() error {
+	if !validEnvironmentName.MatchString(e.Name) {
+		return fmt.Errorf("%w: '%s' has to match '%s'", ErrEnvironmentNameInvalid, e.Name, validEnvironmentName.String())
+	}
+
+	if e.URL != "" {
+		u, err := url.Parse(e.URL)
+		if err != nil {
+			return fmt.Errorf("invalid url: %w", err)
+		}
+		if (u.Scheme != "http" && u.Scheme != "https") || u.Host == "" {
+			return fmt.Errorf("url has to be an absolute http or https url")
+		}
+	}
+
+	for _, pattern := range e.AllowedBranches {
+		if !doublestar.ValidatePattern(pattern) {
+			return fmt.Errorf("invalid branch pattern '%s'", pattern)
+		}
+	}
+	return nil
+}
+
+// AllowsBranch returns true if deployments from the branch are allowed.
+func (e *Environment) AllowsBranch(branch string) bool {
+	if len(e.AllowedBranches) == 0 {
+		return true
+	}
+	for _, pattern := range e.AllowedBranches {
+		if ok, _ := doublestar.Match(pattern, branch); ok {
+			return true
+		}
+	}
+	return false
+}
+
+// RequiresApproval returns true if deployments have to be approved.
+func (e *Environment) RequiresApproval() bool {
+	return len(e.RequiredApprovers) != 0
+}
+
+// CanApprove returns true if the user is allowed to approve deployments.
+func (e *Environment) CanApprove(login string) bool {
+	return !e.RequiresApproval() || slices.Contains(e.RequiredApprovers, login)
+}
+
+// EnvironmentPatch represents the options to create or update an environment, unset fields are not changed.
+type EnvironmentPatch struct {
+	Name              *string  `json:"name,omitempty"`
+	URL               *string  `json:"url,omitempty"`
+	RequiredApprovers []string `json:"required_approvers,omitempty"`
+	AllowedBranches   []string `json:"allowed_branches,omitempty"`
+} //	@name EnvironmentPatch
+
+// Apply changes the fields of the environment which are set.
+func (p *EnvironmentPatch) Apply(e *Environment) {
+	if p.Name != nil {
+		e.Name = *p.Name
+	}
+	if p.URL != nil {
+		e.URL = *p.URL
+	}
+	if p.RequiredApprovers != nil {
+		e.RequiredApprovers = p.RequiredApprovers
+	}
+	if p.AllowedBranches != nil {
+		e.AllowedBranches = p.AllowedBranches
+	}
+}
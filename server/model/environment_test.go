@@ -0,0 +1,46 @@
+// Copyright 2025 Woodpecker Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package model
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestEnvironmentValidate(t *testing.T) {
+	assert.NoError(t, (&Environment{Name: "production"}).Validate())
+	assert.NoError(t, (&Environment{Name: "staging-eu.1", URL: "https://staging.example.com", AllowedBranches: []string{"main", "release/*"}}).Validate())
+	assert.ErrorIs(t, (&Environment{}).Validate(), ErrEnvironmentNameInvalid)
+	assert.ErrorIs(t, (&Environment{Name: "prod/eu"}).Validate(), ErrEnvironmentNameInvalid)
+	assert.Error(t, (&Environment{Name: "production", URL: "example.com"}).Validate())
+	assert.Error(t, (&Environment{Name: "production", AllowedBranches: []string{"release/[a"}}).Validate())
+}
+
+func TestEnvironmentRules(t *testing.T) {
+	env := &Environment{Name: "production"}
+	assert.True(t, env.AllowsBranch("feature"))
+	assert.False(t, env.RequiresApproval())
+	assert.True(t, env.CanApprove("anyone"))
+
+	env.AllowedBranches = []string{"main", "release/*"}
+	env.RequiredApprovers = []string{"alice"}
+	assert.True(t, env.AllowsBranch("main"))
+	assert.True(t, env.AllowsBranch("release/v1"))
+	assert.False(t, env.AllowsBranch("feature"))
+	assert.True(t, env.RequiresApproval())
+	assert.True(t, env.CanApprove("alice"))
+	assert.False(t, env.CanApprove("bob"))
+}
@@ -21,9 +21,18 @@ type Org struct {
 	Name    string `json:"name"               xorm:"UNIQUE 'name'"`
 	IsUser  bool   `json:"is_user"            xorm:"is_user"`
 	// if name lookup has to check for membership or not
-	Private bool `json:"-"                    xorm:"private"`
+	Private   bool            `json:"-"                  xorm:"private"`
+	Retention RetentionPolicy `json:"retention"          xorm:"json 'retention'"`
+	// ConfigExtensionEndpoint is used for all repos of the org without an own endpoint.
+	ConfigExtensionEndpoint string `json:"config_extension_endpoint" xorm:"varchar(500) 'config_extension_endpoint'"`
 } //	@name Org
 
+// OrgPatch represents an organization patch object.
+type OrgPatch struct {
+	Retention               *RetentionPolicy `json:"retention,omitempty"`
+	ConfigExtensionEndpoint *string          `json:"config_extension_endpoint,omitempty"`
+} //	@name OrgPatch
+
 // TableName return database table name for xorm.
 func (Org) TableName() string {
 	return "orgs"
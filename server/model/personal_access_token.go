@@ -0,0 +1,111 @@
+// Copyright 2025 Woodpecker Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package model
+
+import (
+	"errors"
+	"fmt"
+	"slices"
+	"time"
+)
+
+// TokenScope limits what a personal access token can be used for.
+type TokenScope string //	@name TokenScope
+
+const (
+	// TokenScopeAll grants the full rights of the user.
+	TokenScopeAll TokenScope = "all"
+	// TokenScopeRead only allows reading requests.
+	TokenScopeRead TokenScope = "read"
+	// TokenScopePipelineWrite allows to create, restart, approve and cancel pipelines.
+	TokenScopePipelineWrite TokenScope = "pipeline:write"
+	// TokenScopeSecretsAdmin allows to manage secrets and registries.
+	TokenScopeSecretsAdmin TokenScope = "secrets:admin"
+)
+
+var (
+	ErrInvalidTokenScope = errors.New("invalid token scope")
+	errTokenNameEmpty    = errors.New("token name must not be empty")
+	errTokenNoScopes     = errors.New("token needs at least one scope")
+	errTokenExpired      = errors.New("token expiration must be in the future")
+)
+
+func (s TokenScope) Validate() error {
+	switch s {
+	case TokenScopeAll, TokenScopeRead, TokenScopePipelineWrite, TokenScopeSecretsAdmin:
+		return nil
+	default:
+		return fmt.Errorf("%w: %s", ErrInvalidTokenScope, s)
+	}
+}
+
+// PersonalAccessToken is a named API token of a user with limited scopes.
+type PersonalAccessToken struct {
+	ID     int64        `json:"id"      xorm:"pk autoincr 'id'"`
+	UserID int64        `json:"user_id" xorm:"NOT NULL INDEX 'user_id'"`
+	Name   string       `json:"name"    xorm:"name"`
+	Scopes []TokenScope `json:"scopes"  xorm:"json 'scopes'"`
+	// RepoID restricts the token to a single repository if set.
+	RepoID int64 `json:"repo_id" xorm:"NOT NULL DEFAULT 0 'repo_id'"`
+	// Secret is combined with the hash of the user to sign the token.
+	Secret   string `json:"-"         xorm:"varchar(500) 'secret'"`
+	Expires  int64  `json:"expires"   xorm:"NOT NULL DEFAULT 0 'expires'"`
+	LastUsed int64  `json:"last_used" xorm:"NOT NULL DEFAULT 0 'last_used'"`
+	Created  int64  `json:"created"   xorm:"created NOT NULL DEFAULT 0"`
+	// Token is only returned once after creating the token.
+	Token string `json:"token,omitempty" xorm:"-"`
+} //	@name PersonalAccessToken
+
+// TableName returns the database table name for xorm.
+func (PersonalAccessToken) TableName() string {
+	return "personal_access_tokens"
+}
+
+// Validate validates the required fields and formats.
+func (t *PersonalAccessToken) Validate() error {
+	if t.Name == "" {
+		return errTokenNameEmpty
+	}
+	if len(t.Scopes) == 0 {
+		return errTokenNoScopes
+	}
+	for _, scope := range t.Scopes {
+		if err := scope.Validate(); err != nil {
+			return err
+		}
+	}
+	if t.Expires != 0 && t.Expires <= time.Now().Unix() {
+		return errTokenExpired
+	}
+	return nil
+}
+
+// Expired returns true if the token has an expiration date in the past.
+func (t *PersonalAccessToken) Expired() bool {
+	return t.Expires != 0 && t.Expires <= time.Now().Unix()
+}
+
+// Allows returns true if the scopes of the token include scope. Every scope includes TokenScopeRead.
+func (t *PersonalAccessToken) Allows(scope TokenScope) bool {
+	return scope == TokenScopeRead || slices.Contains(t.Scopes, TokenScopeAll) || slices.Contains(t.Scopes, scope)
+}
+
+// PersonalAccessTokenOptions represents the options to create a personal access token.
+type PersonalAccessTokenOptions struct {
+	Name    string       `json:"name"`
+	Scopes  []TokenScope `json:"scopes"`
+	RepoID  int64        `json:"repo_id"`
+	Expires int64        `json:"expires"`
+} //	@name PersonalAccessTokenOptions
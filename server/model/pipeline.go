@@ -21,14 +21,14 @@ import (
 
 type Pipeline struct {
 	ID                  int64                  `json:"id"                      xorm:"pk autoincr 'id'"`
-	RepoID              int64                  `json:"-"                       xorm:"UNIQUE(s) INDEX 'repo_id'"`
+	RepoID              int64                  `json:"-"                       xorm:"UNIQUE(s) INDEX INDEX(repo_created) 'repo_id'"`
 	Number              int64                  `json:"number"                  xorm:"UNIQUE(s) 'number'"`
 	Author              string                 `json:"author"                  xorm:"INDEX 'author'"`
 	Parent              int64                  `json:"parent"                  xorm:"parent"`
 	Event               WebhookEvent           `json:"event"                   xorm:"event"`
 	Status              StatusValue            `json:"status"                  xorm:"INDEX 'status'"`
 	Errors              []*types.PipelineError `json:"errors"                  xorm:"json 'errors'"`
-	Created             int64                  `json:"created"                 xorm:"'created' NOT NULL DEFAULT 0 created"`
+	Created             int64                  `json:"created"                 xorm:"'created' NOT NULL DEFAULT 0 created INDEX(repo_created)"`
 	Updated             int64                  `json:"updated"                 xorm:"'updated' NOT NULL DEFAULT 0 updated"`
 	Started             int64                  `json:"started"                 xorm:"started"`
 	Finished            int64                  `json:"finished"                xorm:"finished"`
@@ -48,11 +48,14 @@ type Pipeline struct {
 	Reviewer            string                 `json:"reviewed_by"             xorm:"reviewer"`
 	Reviewed            int64                  `json:"reviewed"                xorm:"reviewed"`
 	Workflows           []*Workflow            `json:"workflows,omitempty"     xorm:"-"`
+	Approvals           []*Approval            `json:"approvals,omitempty"     xorm:"-"`
 	ChangedFiles        []string               `json:"changed_files,omitempty" xorm:"LONGTEXT 'changed_files'"`
 	AdditionalVariables map[string]string      `json:"variables,omitempty"     xorm:"json 'additional_variables'"`
+	Inputs              map[string]any         `json:"inputs,omitempty"        xorm:"json 'inputs'"`
 	PullRequestLabels   []string               `json:"pr_labels,omitempty"     xorm:"json 'pr_labels'"`
 	IsPrerelease        bool                   `json:"is_prerelease,omitempty" xorm:"is_prerelease"`
 	FromFork            bool                   `json:"from_fork,omitempty"     xorm:"from_fork"`
+	LogsDeleted         bool                   `json:"logs_deleted,omitempty"  xorm:"logs_deleted"`
 } //	@name Pipeline
 
 // TableName return database table name for xorm.
@@ -67,6 +70,7 @@ type PipelineFilter struct {
 	Events      []WebhookEvent
 	RefContains string
 	Status      StatusValue
+	DeployTo    string
 }
 
 // IsMultiPipeline checks if step list contain more than one parent step.
@@ -77,4 +81,5 @@ func (p Pipeline) IsMultiPipeline() bool {
 type PipelineOptions struct {
 	Branch    string            `json:"branch"`
 	Variables map[string]string `json:"variables"`
+	Inputs    map[string]any    `json:"inputs"`
 } //	@name PipelineOptions
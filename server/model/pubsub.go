@@ -0,0 +1,30 @@
+// Copyright 2025 Woodpecker Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package model
+
+// PubsubMessage is a message exchanged between server instances by the database pubsub.
+type PubsubMessage struct {
+	ID        int64             `json:"id"         xorm:"pk autoincr 'id'"`
+	Channel   string            `json:"channel"    xorm:"INDEX 'channel'"`
+	MessageID string            `json:"message_id" xorm:"'message_id'"`
+	Data      []byte            `json:"data"       xorm:"LONGBLOB 'data'"`
+	Labels    map[string]string `json:"labels"     xorm:"json 'labels'"`
+	Created   int64             `json:"created"    xorm:"INDEX 'created'"`
+}
+
+// TableName return database table name for xorm.
+func (PubsubMessage) TableName() string {
+	return "pubsub_messages"
+}
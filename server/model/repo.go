@@ -17,6 +17,7 @@ package model
 
 import (
 	"fmt"
+	"net/url"
 	"strings"
 )
 
@@ -64,6 +65,7 @@ type Repo struct {
 	Trusted                      TrustedConfiguration `json:"trusted"                         xorm:"json 'trusted'"`
 	RequireApproval              ApprovalMode         `json:"require_approval"                xorm:"varchar(50) require_approval"`
 	ApprovalAllowedUsers         []string             `json:"approval_allowed_users"          xorm:"json approval_allowed_users"`
+	ApprovalPolicy               ApprovalPolicy       `json:"approval_policy"                 xorm:"json 'approval_policy'"`
 	IsActive                     bool                 `json:"active"                          xorm:"active"`
 	AllowPull                    bool                 `json:"allow_pr"                        xorm:"allow_pr"`
 	AllowDeploy                  bool                 `json:"allow_deploy"                    xorm:"allow_deploy"`
@@ -72,6 +74,9 @@ type Repo struct {
 	Perm                         *Perm                `json:"-"                               xorm:"-"`
 	CancelPreviousPipelineEvents []WebhookEvent       `json:"cancel_previous_pipeline_events" xorm:"json 'cancel_previous_pipeline_events'"`
 	NetrcTrustedPlugins          []string             `json:"netrc_trusted"                   xorm:"json 'netrc_trusted'"`
+	Retention                    RetentionPolicy      `json:"retention"                       xorm:"json 'retention'"`
+	MaxConcurrentPipelines       int64                `json:"max_concurrent_pipelines"        xorm:"max_concurrent_pipelines"`
+	ConfigExtensionEndpoint      string               `json:"config_extension_endpoint"       xorm:"varchar(500) 'config_extension_endpoint'"`
 } //	@name Repo
 
 // TableName return database table name for xorm.
@@ -98,6 +103,21 @@ func ParseRepo(str string) (user, repo string, err error) {
 	return
 }
 
+// ValidateConfigExtensionEndpoint ensures the config extension endpoint of a repo or org is empty or an absolute http or https url.
+func ValidateConfigExtensionEndpoint(endpoint string) error {
+	if endpoint == "" {
+		return nil
+	}
+	u, err := url.Parse(endpoint)
+	if err != nil {
+		return fmt.Errorf("invalid config extension endpoint: %w", err)
+	}
+	if (u.Scheme != "http" && u.Scheme != "https") || u.Host == "" {
+		return fmt.Errorf("config extension endpoint has to be an absolute http or https url")
+	}
+	return nil
+}
+
 // Update updates the repository with values from the given Repo.
 func (r *Repo) Update(from *Repo) {
 	if from.ForgeRemoteID.IsValid() {
@@ -131,6 +151,7 @@ type RepoPatch struct {
 	Config                       *string                    `json:"config_file,omitempty"`
 	RequireApproval              *string                    `json:"require_approval,omitempty"`
 	ApprovalAllowedUsers         *[]string                  `json:"approval_allowed_users,omitempty"`
+	ApprovalPolicy               *ApprovalPolicy            `json:"approval_policy,omitempty"`
 	Timeout                      *int64                     `json:"timeout,omitempty"`
 	Visibility                   *string                    `json:"visibility,omitempty"`
 	AllowPull                    *bool                      `json:"allow_pr,omitempty"`
@@ -138,6 +159,9 @@ type RepoPatch struct {
 	CancelPreviousPipelineEvents *[]WebhookEvent            `json:"cancel_previous_pipeline_events"`
 	NetrcTrusted                 *[]string                  `json:"netrc_trusted"`
 	Trusted                      *TrustedConfigurationPatch `json:"trusted"`
+	Retention                    *RetentionPolicy           `json:"retention,omitempty"`
+	MaxConcurrentPipelines       *int64                     `json:"max_concurrent_pipelines,omitempty"`
+	ConfigExtensionEndpoint      *string                    `json:"config_extension_endpoint,omitempty"`
 } //	@name RepoPatch
 
 type ForgeRemoteID string
@@ -0,0 +1,30 @@
+// Copyright 2025 Woodpecker Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package model
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestValidateConfigExtensionEndpoint(t *testing.T) {
+	assert.NoError(t, ValidateConfigExtensionEndpoint(""))
+	assert.NoError(t, ValidateConfigExtensionEndpoint("https://example.com/config"))
+	assert.NoError(t, ValidateConfigExtensionEndpoint("http://config-service:8000"))
+	assert.Error(t, ValidateConfigExtensionEndpoint("example.com/config"))
+	assert.Error(t, ValidateConfigExtensionEndpoint("ftp://example.com"))
+	assert.Error(t, ValidateConfigExtensionEndpoint("https://"))
+}
@@ -0,0 +1,52 @@
+// Copyright 2025 Woodpecker Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package model
+
+// RetentionPolicy defines which pipelines and logs are removed automatically.
+// A value of zero inherits the setting of the organization or the server,
+// a negative value disables the rule.
+type RetentionPolicy struct {
+	// KeepPipelinesPerBranch is the number of the latest pipelines kept per branch.
+	KeepPipelinesPerBranch int64 `json:"keep_pipelines_per_branch"`
+	// LogMaxAgeDays is the number of days the logs of a finished pipeline are kept.
+	LogMaxAgeDays int64 `json:"log_max_age_days"`
+} //	@name RetentionPolicy
+
+// Inherit returns the policy with all unset values taken from the parent policy.
+func (p RetentionPolicy) Inherit(parent RetentionPolicy) RetentionPolicy {
+	if p.KeepPipelinesPerBranch == 0 {
+		p.KeepPipelinesPerBranch = parent.KeepPipelinesPerBranch
+	}
+	if p.LogMaxAgeDays == 0 {
+		p.LogMaxAgeDays = parent.LogMaxAgeDays
+	}
+	return p
+}
+
+// RetentionReport lists what is removed from a repository by its retention policy.
+type RetentionReport struct {
+	RepoID int64 `json:"repo_id"`
+	// Policy is the effective policy of the repository.
+	Policy RetentionPolicy `json:"policy"`
+	// Pipelines are the numbers of the pipelines which are deleted.
+	Pipelines []int64 `json:"pipelines"`
+	// Logs are the numbers of the pipelines whose logs are deleted.
+	Logs []int64 `json:"logs"`
+} //	@name RetentionReport
+
+// Empty returns true if nothing is removed.
+func (r *RetentionReport) Empty() bool {
+	return len(r.Pipelines) == 0 && len(r.Logs) == 0
+}
@@ -0,0 +1,181 @@
+// Copyright 2025 Woodpecker Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package model
+
+import (
+	"cmp"
+	"math"
+	"slices"
+)
+
+// RepoStats contains statistics of the finished pipelines of a repository in a time window.
+type RepoStats struct {
+	// Since and Until are the unix timestamps the time window starts and ends at.
+	Since  int64  `json:"since"`
+	Until  int64  `json:"until"`
+	Branch string `json:"branch,omitempty"`
+	// Pipelines is the number of finished pipelines, including killed ones.
+	Pipelines  int64 `json:"pipelines"`
+	Successful int64 `json:"successful"`
+	// Failed counts pipelines with status failure or error.
+	Failed int64 `json:"failed"`
+	Killed int64 `json:"killed"`
+	// SuccessRate is the share of successful pipelines of all successful and failed ones.
+	SuccessRate float64 `json:"success_rate"`
+	// Duration of pipelines with status success or failure in seconds, pipelines which ended
+	// with an error are ignored. Like QueueWait it is computed from the newest pipelines of the time window only.
+	Duration DurationStats `json:"duration"`
+	// QueueWait is the time in seconds pipelines waited after their creation or approval until they started.
+	QueueWait DurationStats  `json:"queue_wait"`
+	History   []*StatsBucket `json:"history"`
+	// Steps are sorted by their number of failures, the most failing step first.
+	Steps []*StepStats `json:"steps"`
+} //	@name RepoStats
+
+// DurationStats contains percentiles of durations in seconds.
+type DurationStats struct {
+	P50 int64 `json:"p50"`
+	P95 int64 `json:"p95"`
+} //	@name DurationStats
+
+// StatsBucket contains the statistics of the pipelines created in an interval of the time window.
+type StatsBucket struct {
+	Start       int64         `json:"start"`
+	Pipelines   int64         `json:"pipelines"`
+	SuccessRate float64       `json:"success_rate"`
+	Duration    DurationStats `json:"duration"`
+} //	@name StatsBucket
+
+// PipelineStatusCount is the number of finished pipelines with a status in a bucket of the history.
+type PipelineStatusCount struct {
+	Bucket int64       `xorm:"bucket"`
+	Status StatusValue `xorm:"status"`
+	Total  int64       `xorm:"total"`
+}
+
+// StepStats contains how often a step of a workflow ran and failed.
+type StepStats struct {
+	Workflow    string  `json:"workflow"     xorm:"workflow"`
+	Name        string  `json:"name"         xorm:"name"`
+	Total       int64   `json:"total"        xorm:"total"`
+	Failures    int64   `json:"failures"     xorm:"failures"`
+	FailureRate float64 `json:"failure_rate" xorm:"-"`
+} //	@name StepStats
+
+// NewRepoStats aggregates the pipeline counts, which are grouped into history buckets of the given
+// interval in seconds, the durations of the sampled pipelines and the step counts.
+func NewRepoStats(counts []*PipelineStatusCount, samples []*Pipeline, steps []*StepStats, since, until, interval int64) *RepoStats {
+	stats := &RepoStats{
+		Since: since,
+		Until: until,
+		Steps: steps,
+	}
+
+	buckets := max((until-since+interval-1)/interval, 1)
+	bucketIndex := func(i int64) int64 {
+		return min(max(i, 0), buckets-1)
+	}
+	stats.History = make([]*StatsBucket, buckets)
+	for i := range stats.History {
+		stats.History[i] = &StatsBucket{Start: since + int64(i)*interval}
+	}
+
+	var ended int64
+	successful := make([]int64, buckets)
+	bucketEnded := make([]int64, buckets)
+	for _, count := range counts {
+		i := bucketIndex(count.Bucket)
+		stats.Pipelines += count.Total
+		stats.History[i].Pipelines += count.Total
+		switch count.Status {
+		case StatusSuccess:
+			stats.Successful += count.Total
+			successful[i] += count.Total
+			ended += count.Total
+			bucketEnded[i] += count.Total
+		case StatusFailure, StatusError:
+			stats.Failed += count.Total
+			ended += count.Total
+			bucketEnded[i] += count.Total
+		case StatusKilled:
+			stats.Killed += count.Total
+		}
+	}
+	stats.SuccessRate = rate(stats.Successful, ended)
+
+	var durations, waits []int64
+	bucketDurations := make([][]int64, buckets)
+	for _, pipeline := range samples {
+		if d, ok := pipelineDuration(pipeline); ok {
+			durations = append(durations, d)
+			i := bucketIndex((pipeline.Created - since) / interval)
+			bucketDurations[i] = append(bucketDurations[i], d)
+		}
+		if pipeline.Started != 0 {
+			waits = append(waits, max(pipeline.Started-max(pipeline.Created, pipeline.Reviewed), 0))
+		}
+	}
+	stats.Duration = newDurationStats(durations)
+	stats.QueueWait = newDurationStats(waits)
+
+	for i, bucket := range stats.History {
+		bucket.SuccessRate = rate(successful[i], bucketEnded[i])
+		bucket.Duration = newDurationStats(bucketDurations[i])
+	}
+
+	for _, step := range stats.Steps {
+		step.FailureRate = rate(step.Failures, step.Total)
+	}
+	slices.SortStableFunc(stats.Steps, func(a, b *StepStats) int {
+		return cmp.Compare(b.Failures, a.Failures)
+	})
+
+	return stats
+}
+
+// pipelineDuration returns the duration of a pipeline with status success or failure.
+func pipelineDuration(pipeline *Pipeline) (int64, bool) {
+	if pipeline.Status != StatusSuccess && pipeline.Status != StatusFailure {
+		return 0, false
+	}
+	if pipeline.Started == 0 || pipeline.Finished < pipeline.Started {
+		return 0, false
+	}
+	return pipeline.Finished - pipeline.Started, true
+}
+
+func newDurationStats(durations []int64) DurationStats {
+	slices.Sort(durations)
+	return DurationStats{
+		P50: percentile(durations, 0.5),  //nolint:mnd
+		P95: percentile(durations, 0.95), //nolint:mnd
+	}
+}
+
+// percentile returns the nearest-rank percentile of sorted values.
+func percentile(sorted []int64, p float64) int64 {
+	if len(sorted) == 0 {
+		return 0
+	}
+	rank := int(math.Ceil(p * float64(len(sorted))))
+	return sorted[max(rank, 1)-1]
+}
+
+func rate(count, total int64) float64 {
+	if total == 0 {
+		return 0
+	}
+	return float64(count) / float64(total)
+}
@@ -0,0 +1,83 @@
+// Copyright 2025 Woodpecker Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package model
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestNewRepoStats(t *testing.T) {
+	const day = 24 * 60 * 60
+	pipelines := []*Pipeline{
+		{Status: StatusSuccess, Created: 10, Started: 20, Finished: 120},
+		{Status: StatusSuccess, Created: 30, Started: 35, Finished: 235},
+		{Status: StatusFailure, Created: 40, Started: 100, Finished: 400, Reviewed: 90},
+		{Status: StatusKilled, Created: day + 10, Started: day + 20, Finished: day + 30},
+		{Status: StatusError, Created: day + 50},
+	}
+	steps := []*StepStats{
+		{Workflow: "build", Name: "lint", Total: 4, Failures: 1},
+		{Workflow: "test", Name: "unit", Total: 4, Failures: 3},
+		{Workflow: "build", Name: "compile", Total: 4},
+	}
+
+	counts := []*PipelineStatusCount{
+		{Bucket: 0, Status: StatusSuccess, Total: 2},
+		{Bucket: 0, Status: StatusFailure, Total: 1},
+		{Bucket: 1, Status: StatusKilled, Total: 1},
+		{Bucket: 1, Status: StatusError, Total: 1},
+	}
+
+	stats := NewRepoStats(counts, pipelines, steps, 0, 2*day, day)
+
+	assert.EqualValues(t, 5, stats.Pipelines)
+	assert.EqualValues(t, 2, stats.Successful)
+	assert.EqualValues(t, 2, stats.Failed)
+	assert.EqualValues(t, 1, stats.Killed)
+	assert.InDelta(t, 0.5, stats.SuccessRate, 0.001)
+	assert.Equal(t, DurationStats{P50: 200, P95: 300}, stats.Duration)
+	// waits: 10, 5, 10 (after review) and 10
+	assert.Equal(t, DurationStats{P50: 10, P95: 10}, stats.QueueWait)
+
+	if assert.Len(t, stats.History, 2) {
+		assert.Equal(t, &StatsBucket{Start: 0, Pipelines: 3, SuccessRate: 2.0 / 3, Duration: DurationStats{P50: 200, P95: 300}}, stats.History[0])
+		assert.Equal(t, &StatsBucket{Start: day, Pipelines: 2}, stats.History[1])
+	}
+
+	if assert.Len(t, stats.Steps, 3) {
+		assert.Equal(t, "unit", stats.Steps[0].Name)
+		assert.InDelta(t, 0.75, stats.Steps[0].FailureRate, 0.001)
+		assert.Equal(t, "lint", stats.Steps[1].Name)
+		assert.Equal(t, "compile", stats.Steps[2].Name)
+		assert.Zero(t, stats.Steps[2].FailureRate)
+	}
+}
+
+func TestNewRepoStatsEmpty(t *testing.T) {
+	stats := NewRepoStats(nil, nil, nil, 100, 100, 60)
+	assert.Zero(t, stats.Pipelines)
+	assert.Zero(t, stats.SuccessRate)
+	assert.Len(t, stats.History, 1)
+}
+
+func TestPercentile(t *testing.T) {
+	assert.Zero(t, percentile(nil, 0.5))
+	assert.EqualValues(t, 7, percentile([]int64{7}, 0.95))
+	values := []int64{1, 2, 3, 4, 5, 6, 7, 8, 9, 10}
+	assert.EqualValues(t, 5, percentile(values, 0.5))
+	assert.EqualValues(t, 10, percentile(values, 0.95))
+}
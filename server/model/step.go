@@ -17,30 +17,41 @@ package model
 
 // Different ways to handle failure states.
 const (
-	FailureIgnore = "ignore"
-	FailureFail   = "fail"
-	//nolint:godot
-	// TODO: Not implemented yet.
-	// FailureCancel = "cancel"
+	FailureIgnore         = "ignore"
+	FailureFail           = "fail"
+	FailureCancel         = "cancel"
+	FailureCancelPipeline = "cancel_pipeline"
 )
 
 // Step represents a process in the pipeline.
 type Step struct {
-	ID         int64       `json:"id"                   xorm:"pk autoincr 'id'"`
-	UUID       string      `json:"uuid"                 xorm:"INDEX 'uuid'"`
-	PipelineID int64       `json:"pipeline_id"          xorm:"UNIQUE(s) INDEX 'pipeline_id'"`
-	PID        int         `json:"pid"                  xorm:"UNIQUE(s) 'pid'"`
-	PPID       int         `json:"ppid"                 xorm:"ppid"`
-	Name       string      `json:"name"                 xorm:"name"`
-	State      StatusValue `json:"state"                xorm:"state"`
-	Error      string      `json:"error,omitempty"      xorm:"TEXT 'error'"`
-	Failure    string      `json:"-"                    xorm:"failure"`
-	ExitCode   int         `json:"exit_code"            xorm:"exit_code"`
-	Started    int64       `json:"started,omitempty"    xorm:"started"`
-	Finished   int64       `json:"finished,omitempty"   xorm:"finished"`
-	Type       StepType    `json:"type,omitempty"       xorm:"type"`
+	ID         int64         `json:"id"                   xorm:"pk autoincr 'id'"`
+	UUID       string        `json:"uuid"                 xorm:"INDEX 'uuid'"`
+	PipelineID int64         `json:"pipeline_id"          xorm:"UNIQUE(s) INDEX 'pipeline_id'"`
+	PID        int           `json:"pid"                  xorm:"UNIQUE(s) 'pid'"`
+	PPID       int           `json:"ppid"                 xorm:"ppid"`
+	Name       string        `json:"name"                 xorm:"name"`
+	State      StatusValue   `json:"state"                xorm:"state"`
+	Error      string        `json:"error,omitempty"      xorm:"TEXT 'error'"`
+	Failure    string        `json:"-"                    xorm:"failure"`
+	ExitCode   int           `json:"exit_code"            xorm:"exit_code"`
+	Started    int64         `json:"started,omitempty"    xorm:"started"`
+	Finished   int64         `json:"finished,omitempty"   xorm:"finished"`
+	Type       StepType      `json:"type,omitempty"       xorm:"type"`
+	Attempts   []StepAttempt `json:"attempts,omitempty"   xorm:"json 'attempts'"`
+	Flakiness  float64       `json:"flakiness,omitempty"  xorm:"-"`
 } //	@name Step
 
+// StepAttempt is a failed attempt of a step which got restarted.
+type StepAttempt struct {
+	Finished int64  `json:"finished"`
+	ExitCode int    `json:"exit_code"`
+	Error    string `json:"error,omitempty"`
+	// LogLines is the number of log lines of the step at the end of the attempt,
+	// the logs of the next attempt start at this line.
+	LogLines int `json:"log_lines"`
+} //	@name StepAttempt
+
 // TableName return database table name for xorm.
 func (Step) TableName() string {
 	return "steps"
@@ -53,7 +64,28 @@ func (p *Step) Running() bool {
 
 // Failing returns true if the process state is failed, killed or error.
 func (p *Step) Failing() bool {
-	return p.Failure == FailureFail && (p.State == StatusError || p.State == StatusKilled || p.State == StatusFailure)
+	return (p.Failure == FailureFail || p.CancelsOnFailure()) && (p.State == StatusError || p.State == StatusKilled || p.State == StatusFailure)
+}
+
+// AttemptLogLines returns the log lines [first, last) of the attempt of the step, attempts start
+// at 1. The current attempt has no last line, so last is -1. It returns false if there is no such attempt.
+func (p *Step) AttemptLogLines(attempt int) (first, last int, ok bool) {
+	if attempt < 1 || attempt > len(p.Attempts)+1 {
+		return 0, 0, false
+	}
+	first, last = 0, -1
+	if attempt > 1 {
+		first = p.Attempts[attempt-2].LogLines
+	}
+	if attempt <= len(p.Attempts) {
+		last = p.Attempts[attempt-1].LogLines
+	}
+	return first, last, true
+}
+
+// CancelsOnFailure returns true if the step cancels the rest of the workflow when it fails.
+func (p *Step) CancelsOnFailure() bool {
+	return p.Failure == FailureCancel || p.Failure == FailureCancelPipeline
 }
 
 // StepType identifies the type of step.
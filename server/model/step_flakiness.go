@@ -0,0 +1,48 @@
+// Copyright 2025 Woodpecker Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package model
+
+// StepFlakiness counts how often a step of a repository was flaky. Runs is the number of
+// times the step succeeded or failed, Flakes the number of times it succeeded after a failed
+// attempt or after it failed in a previous pipeline of the same commit and branch.
+type StepFlakiness struct {
+	ID        int64   `json:"id"                   xorm:"pk autoincr 'id'"`
+	RepoID    int64   `json:"repo_id"              xorm:"UNIQUE(s) INDEX 'repo_id'"`
+	Workflow  string  `json:"workflow"             xorm:"UNIQUE(s) 'workflow'"`
+	Name      string  `json:"name"                 xorm:"UNIQUE(s) 'name'"`
+	Runs      int64   `json:"runs"                 xorm:"runs"`
+	Flakes    int64   `json:"flakes"               xorm:"flakes"`
+	LastFlake int64   `json:"last_flake,omitempty" xorm:"last_flake"`
+	Score     float64 `json:"score"                xorm:"-"`
+} //	@name StepFlakiness
+
+// TableName returns the database table name for xorm.
+func (StepFlakiness) TableName() string {
+	return "step_flakiness"
+}
+
+// UpdateScore sets the score to the share of flaky runs.
+func (f *StepFlakiness) UpdateScore() {
+	f.Score = rate(f.Flakes, f.Runs)
+}
+
+// IsFlaky returns true if the step passed after it failed before on the same commit,
+// previous is the state of the step in the last pipeline of the commit.
+func IsFlaky(step *Step, previous StatusValue) bool {
+	if step.State != StatusSuccess {
+		return false
+	}
+	return len(step.Attempts) > 0 || previous == StatusFailure
+}
@@ -43,4 +43,28 @@ func TestStepStatus(t *testing.T) {
 	assert.Equal(t, step.Failing(), false)
 	step.State = StatusSuccess
 	assert.Equal(t, step.Failing(), false)
+	step.Failure = FailureCancel
+	step.State = StatusFailure
+	assert.Equal(t, step.Failing(), true)
+	step.Failure = FailureCancelPipeline
+	assert.Equal(t, step.Failing(), true)
+}
+
+func TestStepAttemptLogLines(t *testing.T) {
+	step := &Step{Attempts: []StepAttempt{{LogLines: 3}, {LogLines: 7}}}
+
+	first, last, ok := step.AttemptLogLines(1)
+	assert.True(t, ok)
+	assert.Equal(t, []int{0, 3}, []int{first, last})
+	first, last, ok = step.AttemptLogLines(2)
+	assert.True(t, ok)
+	assert.Equal(t, []int{3, 7}, []int{first, last})
+	first, last, ok = step.AttemptLogLines(3)
+	assert.True(t, ok)
+	assert.Equal(t, []int{7, -1}, []int{first, last})
+
+	_, _, ok = step.AttemptLogLines(0)
+	assert.False(t, ok)
+	_, _, ok = step.AttemptLogLines(4)
+	assert.False(t, ok)
 }
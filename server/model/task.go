@@ -28,8 +28,31 @@ type Task struct {
 	RunOn        []string               `json:"run_on"       xorm:"json 'run_on'"`
 	DepStatus    map[string]StatusValue `json:"dep_status"   xorm:"json 'dependencies_status'"`
 	AgentID      int64                  `json:"agent_id"     xorm:"'agent_id'"`
+	// RepoID, PipelineID, MaxConcurrentPipelines and ConcurrencyGroup are used to limit
+	// how many tasks of a repository run at the same time.
+	RepoID                 int64  `json:"repo_id"                     xorm:"'repo_id'"`
+	PipelineID             int64  `json:"pipeline_id"                 xorm:"'pipeline_id'"`
+	MaxConcurrentPipelines int64  `json:"max_concurrent_pipelines"    xorm:"'max_concurrent_pipelines'"`
+	ConcurrencyGroup       string `json:"concurrency_group,omitempty" xorm:"'concurrency_group'"`
+	// State, Deadline, Error and Version are only used by the database queue
+	// to share the queue state between multiple server instances.
+	// Enqueued is also used to measure how long a task waited in the queue.
+	State    TaskState `json:"-" xorm:"'state'"`
+	Deadline int64     `json:"-" xorm:"'deadline'"`
+	Error    string    `json:"-" xorm:"TEXT 'error'"`
+	Enqueued int64     `json:"-" xorm:"INDEX 'enqueued'"`
+	Version  int64     `json:"-" xorm:"version 'version'"`
 } //	@name Task
 
+// TaskState represents the state of a task in the database queue.
+type TaskState string
+
+const (
+	TaskStatePending TaskState = "pending"
+	TaskStateRunning TaskState = "running"
+	TaskStateDone    TaskState = "done"
+)
+
 // TableName return database table name for xorm.
 func (Task) TableName() string {
 	return "tasks"
@@ -0,0 +1,147 @@
+// Copyright 2025 Woodpecker Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package model
+
+import (
+	"errors"
+	"fmt"
+	"net/url"
+	"slices"
+)
+
+// HookEvent is a pipeline lifecycle event outgoing webhooks can subscribe to.
+type HookEvent string //	@name HookEvent
+
+const (
+	HookEventPipelineCreated  HookEvent = "pipeline_created"
+	HookEventPipelineStarted  HookEvent = "pipeline_started"
+	HookEventPipelineFinished HookEvent = "pipeline_finished"
+	HookEventWorkflowStatus   HookEvent = "workflow_status"
+	HookEventStepStatus       HookEvent = "step_status"
+)
+
+var ErrInvalidHookEvent = errors.New("invalid hook event")
+
+func (e HookEvent) Validate() error {
+	switch e {
+	case HookEventPipelineCreated, HookEventPipelineStarted, HookEventPipelineFinished, HookEventWorkflowStatus, HookEventStepStatus:
+		return nil
+	default:
+		return fmt.Errorf("%w: %s", ErrInvalidHookEvent, e)
+	}
+}
+
+// Webhook is an outgoing webhook of a repository or an organization.
+type Webhook struct {
+	ID      int64       `json:"id"      xorm:"pk autoincr 'id'"`
+	OrgID   int64       `json:"org_id"  xorm:"NOT NULL DEFAULT 0 INDEX 'org_id'"`
+	RepoID  int64       `json:"repo_id" xorm:"NOT NULL DEFAULT 0 INDEX 'repo_id'"`
+	URL     string      `json:"url"     xorm:"NOT NULL 'url'"`
+	Events  []HookEvent `json:"events"  xorm:"json 'events'"`
+	Active  bool        `json:"active"  xorm:"active"`
+	Created int64       `json:"created" xorm:"created NOT NULL DEFAULT 0"`
+	Updated int64       `json:"updated" xorm:"updated NOT NULL DEFAULT 0"`
+} //	@name Webhook
+
+// TableName returns the database table name for xorm.
+func (Webhook) TableName() string {
+	return "webhooks"
+}
+
+// Validate ensures the webhook has a valid url and events.
+func (w *Webhook) Validate() error {
+	u, err := url.Parse(w.URL)
+	if err != nil {
+		return fmt.Errorf("invalid url: %w", err)
+	}
+	if (u.Scheme != "http" && u.Scheme != "https") || u.Host == "" {
+		return fmt.Errorf("url has to be an absolute http or https url")
+	}
+
+	if len(w.Events) == 0 {
+		return fmt.Errorf("%w: no event specified", ErrInvalidHookEvent)
+	}
+	for _, event := range w.Events {
+		if err := event.Validate(); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// Subscribed returns true if the webhook is active and subscribed to the event.
+func (w *Webhook) Subscribed(event HookEvent) bool {
+	return w.Active && slices.Contains(w.Events, event)
+}
+
+// WebhookPatch represents the options to create or update a webhook, unset fields are not changed.
+type WebhookPatch struct {
+	URL    *string     `json:"url,omitempty"`
+	Events []HookEvent `json:"events,omitempty"`
+	Active *bool       `json:"active,omitempty"`
+} //	@name WebhookPatch
+
+// Apply changes the fields of the webhook which are set.
+func (p *WebhookPatch) Apply(w *Webhook) {
+	if p.URL != nil {
+		w.URL = *p.URL
+	}
+	if p.Events != nil {
+		w.Events = p.Events
+	}
+	if p.Active != nil {
+		w.Active = *p.Active
+	}
+}
+
+// WebhookDeliveryError is the class of the error of a failed delivery attempt.
+type WebhookDeliveryError string //	@name WebhookDeliveryError
+
+const (
+	WebhookDeliveryErrorForbiddenAddress WebhookDeliveryError = "forbidden_address"
+	WebhookDeliveryErrorTimeout          WebhookDeliveryError = "timeout"
+	WebhookDeliveryErrorConnection       WebhookDeliveryError = "connection_failed"
+	WebhookDeliveryErrorStatus           WebhookDeliveryError = "unexpected_status"
+)
+
+// WebhookDelivery is the log entry of a payload sent to a webhook.
+type WebhookDelivery struct {
+	ID         int64     `json:"id"          xorm:"pk autoincr 'id'"`
+	WebhookID  int64     `json:"webhook_id"  xorm:"INDEX 'webhook_id'"`
+	Event      HookEvent `json:"event"       xorm:"event"`
+	Payload    string    `json:"payload"     xorm:"LONGTEXT 'payload'"`
+	Attempts   int       `json:"attempts"    xorm:"attempts"`
+	Success    bool      `json:"success"     xorm:"success"`
+	StatusCode int       `json:"status_code" xorm:"status_code"`
+	// Error is only the class of the error, neither the response nor the error message is stored
+	// as they could disclose information about the network of the server.
+	Error    WebhookDeliveryError `json:"error"    xorm:"error"`
+	Duration int64                `json:"duration" xorm:"duration"` // of the last attempt in milliseconds
+	Created  int64                `json:"created"  xorm:"created NOT NULL DEFAULT 0"`
+} //	@name WebhookDelivery
+
+// TableName returns the database table name for xorm.
+func (WebhookDelivery) TableName() string {
+	return "webhook_deliveries"
+}
+
+// WebhookPayload is the JSON body sent to webhooks.
+type WebhookPayload struct {
+	Event    HookEvent `json:"event"`
+	Repo     *Repo     `json:"repo"`
+	Pipeline *Pipeline `json:"pipeline"`
+	Workflow *Workflow `json:"workflow,omitempty"`
+	Step     *Step     `json:"step,omitempty"`
+} //	@name WebhookPayload
@@ -0,0 +1,65 @@
+// Copyright 2025 Woodpecker Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package pipeline
+
+import (
+	"context"
+	"fmt"
+	"slices"
+	"strings"
+
+	"go.woodpecker-ci.org/woodpecker/v3/server/forge"
+	"go.woodpecker-ci.org/woodpecker/v3/server/model"
+	"go.woodpecker-ci.org/woodpecker/v3/server/store"
+)
+
+// addApproval records the approval of a blocked pipeline by a user according to the
+// approval policy of the repository. It returns true if the pipeline has enough approvals to start
+// and this approval changed its status from blocked to pending.
+func addApproval(ctx context.Context, _forge forge.Forge, store store.Store, repo *model.Repo, pipeline *model.Pipeline, user *model.User) (bool, error) {
+	policy := repo.ApprovalPolicy
+
+	if policy.PreventSelfApproval && pipeline.Author == user.Login {
+		return false, &ErrForbidden{Msg: fmt.Sprintf("%s cannot approve their own pipeline", user.Login)}
+	}
+
+	if len(policy.Teams) != 0 {
+		forge.Refresh(ctx, _forge, store, user)
+		teams, err := _forge.Teams(ctx, user)
+		if err != nil {
+			return false, fmt.Errorf("error loading teams of %s: %w", user.Login, err)
+		}
+		if !policy.AllowsTeams(teams) {
+			return false, &ErrForbidden{Msg: fmt.Sprintf("only members of %s can approve pipelines", strings.Join(policy.Teams, ", "))}
+		}
+	}
+
+	approvals, err := store.ApprovalList(pipeline)
+	if err != nil {
+		return false, fmt.Errorf("error loading approvals: %w", err)
+	}
+	if slices.ContainsFunc(approvals, func(approval *model.Approval) bool { return approval.UserID == user.ID }) {
+		return false, &ErrBadRequest{Msg: fmt.Sprintf("%s already approved the pipeline", user.Login)}
+	}
+
+	approval := &model.Approval{PipelineID: pipeline.ID, UserID: user.ID, Login: user.Login}
+	approvals, approved, err := store.ApprovalAdd(approval, policy.Required())
+	if err != nil {
+		return false, fmt.Errorf("error saving approval: %w", err)
+	}
+	pipeline.Approvals = approvals
+
+	return approved, nil
+}
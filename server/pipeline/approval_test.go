@@ -0,0 +1,118 @@
+// Copyright 2025 Woodpecker Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package pipeline
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+
+	forge_mocks "go.woodpecker-ci.org/woodpecker/v3/server/forge/mocks"
+	"go.woodpecker-ci.org/woodpecker/v3/server/model"
+	store_mocks "go.woodpecker-ci.org/woodpecker/v3/server/store/mocks"
+)
+
+func TestAddApproval(t *testing.T) {
+	t.Parallel()
+
+	alice := &model.User{ID: 1, Login: "alice"}
+	bob := &model.User{ID: 2, Login: "bob"}
+	pipeline := &model.Pipeline{ID: 1, Author: "alice", Status: model.StatusBlocked}
+
+	t.Run("single approval", func(t *testing.T) {
+		t.Parallel()
+		store := store_mocks.NewStore(t)
+		store.On("ApprovalList", mock.Anything).Return(nil, nil)
+		store.On("ApprovalAdd", mock.Anything, 1).Return([]*model.Approval{{UserID: alice.ID}}, true, nil)
+
+		approved, err := addApproval(context.Background(), nil, store, &model.Repo{}, &model.Pipeline{ID: 1}, alice)
+		assert.NoError(t, err)
+		assert.True(t, approved)
+	})
+
+	t.Run("self approval", func(t *testing.T) {
+		t.Parallel()
+		repo := &model.Repo{ApprovalPolicy: model.ApprovalPolicy{PreventSelfApproval: true}}
+		_, err := addApproval(context.Background(), nil, store_mocks.NewStore(t), repo, pipeline, alice)
+		assert.ErrorIs(t, err, &ErrForbidden{})
+	})
+
+	t.Run("team membership", func(t *testing.T) {
+		t.Parallel()
+		repo := &model.Repo{ApprovalPolicy: model.ApprovalPolicy{Teams: []string{"release"}}}
+		forge := forge_mocks.NewForge(t)
+		forge.On("Teams", mock.Anything, alice).Return([]*model.Team{{Login: "dev"}}, nil)
+		forge.On("Teams", mock.Anything, bob).Return([]*model.Team{{Login: "dev"}, {Login: "release"}}, nil)
+		store := store_mocks.NewStore(t)
+		store.On("ApprovalList", mock.Anything).Return(nil, nil)
+		store.On("ApprovalAdd", mock.Anything, 1).Return([]*model.Approval{{UserID: bob.ID}}, true, nil)
+
+		_, err := addApproval(context.Background(), forge, store, repo, pipeline, alice)
+		assert.ErrorIs(t, err, &ErrForbidden{})
+		approved, err := addApproval(context.Background(), forge, store, repo, pipeline, bob)
+		assert.NoError(t, err)
+		assert.True(t, approved)
+	})
+
+	t.Run("team lookup fails", func(t *testing.T) {
+		t.Parallel()
+		repo := &model.Repo{ApprovalPolicy: model.ApprovalPolicy{Teams: []string{"release"}}}
+		forge := forge_mocks.NewForge(t)
+		forge.On("Teams", mock.Anything, bob).Return(nil, errors.New("forge unavailable"))
+		_, err := addApproval(context.Background(), forge, store_mocks.NewStore(t), repo, pipeline, bob)
+		assert.Error(t, err)
+	})
+
+	t.Run("distinct approvals", func(t *testing.T) {
+		t.Parallel()
+		repo := &model.Repo{ApprovalPolicy: model.ApprovalPolicy{RequiredApprovals: 2}}
+		store := store_mocks.NewStore(t)
+		aliceApproval := &model.Approval{PipelineID: 2, UserID: alice.ID, Login: alice.Login}
+		bobApproval := &model.Approval{PipelineID: 2, UserID: bob.ID, Login: bob.Login}
+		store.On("ApprovalList", mock.Anything).Return(nil, nil).Once()
+		store.On("ApprovalAdd", aliceApproval, 2).Return([]*model.Approval{aliceApproval}, false, nil).Once()
+
+		pipeline := &model.Pipeline{ID: 2, Author: "carol", Status: model.StatusBlocked}
+		approved, err := addApproval(context.Background(), nil, store, repo, pipeline, alice)
+		assert.NoError(t, err)
+		assert.False(t, approved)
+		assert.Len(t, pipeline.Approvals, 1)
+
+		store.On("ApprovalList", mock.Anything).Return(pipeline.Approvals, nil)
+		_, err = addApproval(context.Background(), nil, store, repo, pipeline, alice)
+		assert.ErrorIs(t, err, &ErrBadRequest{})
+
+		store.On("ApprovalAdd", bobApproval, 2).Return([]*model.Approval{aliceApproval, bobApproval}, true, nil).Once()
+		approved, err = addApproval(context.Background(), nil, store, repo, pipeline, bob)
+		assert.NoError(t, err)
+		assert.True(t, approved)
+		assert.Len(t, pipeline.Approvals, 2)
+	})
+
+	t.Run("already started", func(t *testing.T) {
+		t.Parallel()
+		store := store_mocks.NewStore(t)
+		store.On("ApprovalList", mock.Anything).Return(nil, nil)
+		// a concurrent approval already changed the status of the pipeline
+		store.On("ApprovalAdd", mock.Anything, 1).Return([]*model.Approval{{UserID: bob.ID}, {UserID: alice.ID}}, false, nil)
+
+		approved, err := addApproval(context.Background(), nil, store, &model.Repo{}, &model.Pipeline{ID: 3, Status: model.StatusBlocked}, alice)
+		assert.NoError(t, err)
+		assert.False(t, approved)
+	})
+}
@@ -33,6 +33,10 @@ func Approve(ctx context.Context, store store.Store, currentPipeline *model.Pipe
 		return nil, ErrBadRequest{Msg: fmt.Sprintf("cannot approve a pipeline with status %s", currentPipeline.Status)}
 	}
 
+	if err := checkEnvironmentApprover(store, repo, currentPipeline, user); err != nil {
+		return nil, err
+	}
+
 	forge, err := server.Config.Services.Manager.ForgeFromRepo(repo)
 	if err != nil {
 		msg := fmt.Sprintf("failure to load forge for repo '%s'", repo.FullName)
@@ -40,6 +44,15 @@ func Approve(ctx context.Context, store store.Store, currentPipeline *model.Pipe
 		return nil, errors.New(msg)
 	}
 
+	approved, err := addApproval(ctx, forge, store, repo, currentPipeline, user)
+	if err != nil {
+		return nil, err
+	}
+	if !approved {
+		// wait for more approvals
+		return currentPipeline, nil
+	}
+
 	// fetch the pipeline file from the database
 	configs, err := store.ConfigsForPipeline(currentPipeline.ID)
 	if err != nil {
@@ -22,6 +22,7 @@ import (
 
 	"go.woodpecker-ci.org/woodpecker/v3/server"
 	"go.woodpecker-ci.org/woodpecker/v3/server/forge"
+	"go.woodpecker-ci.org/woodpecker/v3/server/metrics"
 	"go.woodpecker-ci.org/woodpecker/v3/server/model"
 	"go.woodpecker-ci.org/woodpecker/v3/server/queue"
 	"go.woodpecker-ci.org/woodpecker/v3/server/store"
@@ -38,6 +39,48 @@ func Cancel(ctx context.Context, _forge forge.Forge, store store.Store, repo *mo
 		return &ErrNotFound{Msg: err.Error()}
 	}
 
+	cancelWorkflows(ctx, store, workflows)
+
+	killedPipeline, err := UpdateToStatusKilled(store, *pipeline)
+	if err != nil {
+		log.Error().Err(err).Msgf("UpdateToStatusKilled: %v", pipeline)
+		return err
+	}
+	metrics.PipelineFinished(repo, killedPipeline)
+
+	updatePipelineStatus(ctx, _forge, killedPipeline, repo, user)
+
+	if killedPipeline.Workflows, err = store.WorkflowGetTree(killedPipeline); err != nil {
+		return err
+	}
+	publishToTopic(killedPipeline, repo)
+	SendWebhooks(model.HookEventPipelineFinished, repo, killedPipeline, nil, nil)
+
+	return nil
+}
+
+// CancelOtherWorkflows stops all running and pending workflows of a pipeline except the given one.
+// It is used if a step with failure mode cancel_pipeline failed. In contrast to Cancel the pipeline
+// is not marked as killed, its status is set once the agents report the stopped workflows as done.
+func CancelOtherWorkflows(ctx context.Context, store store.Store, pipeline *model.Pipeline, workflow *model.Workflow) error {
+	workflows, err := store.WorkflowGetTree(pipeline)
+	if err != nil {
+		return err
+	}
+
+	others := make([]*model.Workflow, 0, len(workflows))
+	for _, w := range workflows {
+		if w.ID != workflow.ID {
+			others = append(others, w)
+		}
+	}
+	cancelWorkflows(ctx, store, others)
+
+	return nil
+}
+
+// cancelWorkflows cancels running and evicts pending workflows from the queue and skips their pending steps.
+func cancelWorkflows(ctx context.Context, store store.Store, workflows []*model.Workflow) {
 	// First cancel/evict steps in the queue in one go
 	var (
 		stepsToCancel []string
@@ -70,33 +113,18 @@ func Cancel(ctx context.Context, _forge forge.Forge, store store.Store, repo *mo
 	// Running ones will be set when the agents stop on the cancel signal
 	for _, workflow := range workflows {
 		if workflow.State == model.StatusPending {
-			if _, err = UpdateWorkflowToStatusSkipped(store, *workflow); err != nil {
+			if _, err := UpdateWorkflowToStatusSkipped(store, *workflow); err != nil {
 				log.Error().Err(err).Msgf("cannot update workflow with id %d state", workflow.ID)
 			}
 		}
 		for _, step := range workflow.Children {
 			if step.State == model.StatusPending {
-				if _, err = UpdateStepToStatusSkipped(store, *step, 0); err != nil {
+				if _, err := UpdateStepToStatusSkipped(store, *step, 0); err != nil {
 					log.Error().Err(err).Msgf("cannot update workflow with id %d state", workflow.ID)
 				}
 			}
 		}
 	}
-
-	killedPipeline, err := UpdateToStatusKilled(store, *pipeline)
-	if err != nil {
-		log.Error().Err(err).Msgf("UpdateToStatusKilled: %v", pipeline)
-		return err
-	}
-
-	updatePipelineStatus(ctx, _forge, killedPipeline, repo, user)
-
-	if killedPipeline.Workflows, err = store.WorkflowGetTree(killedPipeline); err != nil {
-		return err
-	}
-	publishToTopic(killedPipeline, repo)
-
-	return nil
 }
 
 func cancelPreviousPipelines(
@@ -0,0 +1,58 @@
+// Copyright 2025 Woodpecker Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package pipeline
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+
+	"go.woodpecker-ci.org/woodpecker/v3/server"
+	"go.woodpecker-ci.org/woodpecker/v3/server/model"
+	"go.woodpecker-ci.org/woodpecker/v3/server/queue"
+	queue_mocks "go.woodpecker-ci.org/woodpecker/v3/server/queue/mocks"
+	"go.woodpecker-ci.org/woodpecker/v3/server/store/mocks"
+)
+
+func TestCancelOtherWorkflows(t *testing.T) {
+	pipeline := &model.Pipeline{ID: 1}
+	failed := &model.Workflow{ID: 1, State: model.StatusFailure}
+	running := &model.Workflow{ID: 2, State: model.StatusRunning, Children: []*model.Step{
+		{ID: 21, State: model.StatusRunning},
+		{ID: 22, State: model.StatusPending},
+	}}
+	pending := &model.Workflow{ID: 3, State: model.StatusPending, Children: []*model.Step{
+		{ID: 31, State: model.StatusPending},
+	}}
+
+	store := mocks.NewStore(t)
+	store.On("WorkflowGetTree", pipeline).Return([]*model.Workflow{failed, running, pending}, nil)
+	store.On("WorkflowUpdate", mock.MatchedBy(func(w *model.Workflow) bool {
+		return w.ID == 3 && w.State == model.StatusSkipped
+	})).Once().Return(nil)
+	store.On("StepUpdate", mock.MatchedBy(func(s *model.Step) bool {
+		return (s.ID == 22 || s.ID == 31) && s.State == model.StatusSkipped
+	})).Twice().Return(nil)
+
+	q := queue_mocks.NewQueue(t)
+	q.On("EvictAtOnce", mock.Anything, []string{"3"}).Once().Return(nil)
+	q.On("ErrorAtOnce", mock.Anything, []string{"3"}, queue.ErrCancel).Once().Return(nil)
+	q.On("ErrorAtOnce", mock.Anything, []string{"2"}, queue.ErrCancel).Once().Return(nil)
+	server.Config.Services.Queue = q
+
+	assert.NoError(t, CancelOtherWorkflows(context.Background(), store, pipeline, failed))
+}
@@ -26,8 +26,10 @@ import (
 	"go.woodpecker-ci.org/woodpecker/v3/server"
 	"go.woodpecker-ci.org/woodpecker/v3/server/forge"
 	forge_types "go.woodpecker-ci.org/woodpecker/v3/server/forge/types"
+	"go.woodpecker-ci.org/woodpecker/v3/server/metrics"
 	"go.woodpecker-ci.org/woodpecker/v3/server/model"
 	"go.woodpecker-ci.org/woodpecker/v3/server/store"
+	"go.woodpecker-ci.org/woodpecker/v3/shared/tracing"
 )
 
 var skipPipelineRegex = regexp.MustCompile(`\[(?i:ci *skip|skip *ci)\]`)
@@ -69,6 +71,10 @@ func Create(ctx context.Context, _store store.Store, repo *model.Repo, pipeline
 	pipeline.RepoID = repo.ID
 	pipeline.Status = model.StatusCreated
 	setApprovalState(repo, pipeline)
+	if err := applyEnvironmentRules(_store, repo, pipeline); err != nil {
+		log.Debug().Str("repo", repo.FullName).Err(err).Msg("deployment rejected")
+		return nil, err
+	}
 	err = _store.CreatePipeline(pipeline)
 	if err != nil {
 		msg := fmt.Errorf("failed to save pipeline for %s", repo.FullName)
@@ -78,7 +84,10 @@ func Create(ctx context.Context, _store store.Store, repo *model.Repo, pipeline
 
 	// fetch the pipeline file from the forge
 	configService := server.Config.Services.Manager.ConfigServiceFromRepo(repo)
-	forgeYamlConfigs, configFetchErr := configService.Fetch(ctx, _forge, repoUser, repo, pipeline, nil, false)
+	fetchCtx, span := tracing.Tracer.Start(ctx, "config fetch")
+	forgeYamlConfigs, configFetchErr := configService.Fetch(fetchCtx, _forge, repoUser, repo, pipeline, nil, false)
+	tracing.RecordError(span, configFetchErr)
+	span.End()
 	if errors.Is(configFetchErr, &forge_types.ErrConfigNotFound{}) {
 		log.Debug().Str("repo", repo.FullName).Err(configFetchErr).Msgf("cannot find config '%s' in '%s' with user: '%s'", repo.Config, pipeline.Ref, repoUser.Login)
 		if err := _store.DeletePipeline(pipeline); err != nil {
@@ -91,7 +100,21 @@ func Create(ctx context.Context, _store store.Store, repo *model.Repo, pipeline
 		return nil, updatePipelineWithErr(ctx, _forge, _store, pipeline, repo, repoUser, fmt.Errorf("could not load config from forge: %w", err))
 	}
 
-	pipelineItems, parseErr := parsePipeline(_forge, _store, pipeline, repoUser, repo, forgeYamlConfigs, nil)
+	if err := resolveInputs(pipeline, forgeYamlConfigs); err != nil {
+		log.Debug().Str("repo", repo.FullName).Err(err).Msg("invalid pipeline inputs")
+		if err := _store.DeletePipeline(pipeline); err != nil {
+			log.Error().Str("repo", repo.FullName).Err(err).Msg("failed to delete pipeline with invalid inputs")
+		}
+
+		return nil, &ErrBadRequest{Msg: err.Error()}
+	}
+	if len(pipeline.Inputs) != 0 {
+		if err := _store.UpdatePipeline(pipeline); err != nil {
+			log.Error().Str("repo", repo.FullName).Err(err).Msg("failed to save pipeline inputs")
+		}
+	}
+
+	pipelineItems, parseErr := parsePipeline(ctx, _forge, _store, pipeline, repoUser, repo, forgeYamlConfigs, nil)
 	if pipeline_errors.HasBlockingErrors(parseErr) {
 		log.Debug().Str("repo", repo.FullName).Err(parseErr).Msg("failed to parse yaml")
 		return pipeline, updatePipelineWithErr(ctx, _forge, _store, pipeline, repo, repoUser, parseErr)
@@ -158,6 +181,7 @@ func updatePipelineWithErr(ctx context.Context, _forge forge.Forge, _store store
 	}
 	// update value in ref
 	*pipeline = *_pipeline
+	metrics.PipelineFinished(repo, pipeline)
 
 	publishPipeline(ctx, _forge, pipeline, repo, repoUser)
 
@@ -22,6 +22,7 @@ import (
 	"github.com/rs/zerolog/log"
 
 	"go.woodpecker-ci.org/woodpecker/v3/server"
+	"go.woodpecker-ci.org/woodpecker/v3/server/metrics"
 	"go.woodpecker-ci.org/woodpecker/v3/server/model"
 	"go.woodpecker-ci.org/woodpecker/v3/server/store"
 )
@@ -43,6 +44,7 @@ func Decline(ctx context.Context, store store.Store, pipeline *model.Pipeline, u
 	if err != nil {
 		return nil, fmt.Errorf("error updating pipeline. %w", err)
 	}
+	metrics.PipelineFinished(repo, pipeline)
 
 	if pipeline.Workflows, err = store.WorkflowGetTree(pipeline); err != nil {
 		log.Error().Err(err).Msg("cannot build tree from step list")
@@ -65,6 +67,7 @@ func Decline(ctx context.Context, store store.Store, pipeline *model.Pipeline, u
 	updatePipelineStatus(ctx, forge, pipeline, repo, user)
 
 	publishToTopic(pipeline, repo)
+	SendWebhooks(model.HookEventPipelineFinished, repo, pipeline, nil, nil)
 
 	return pipeline, nil
 }
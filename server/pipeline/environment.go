@@ -0,0 +1,71 @@
+// Copyright 2025 Woodpecker Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package pipeline
+
+import (
+	"errors"
+	"fmt"
+
+	"go.woodpecker-ci.org/woodpecker/v3/server/model"
+	"go.woodpecker-ci.org/woodpecker/v3/server/store"
+	"go.woodpecker-ci.org/woodpecker/v3/server/store/types"
+)
+
+// deployEnvironment returns the environment a deployment pipeline targets
+// or nil if it is no deployment or the target has no environment configured.
+func deployEnvironment(store store.Store, repo *model.Repo, pipeline *model.Pipeline) (*model.Environment, error) {
+	if pipeline.Event != model.EventDeploy {
+		return nil, nil
+	}
+
+	env, err := store.EnvironmentFind(repo, pipeline.DeployTo)
+	if errors.Is(err, types.RecordNotExist) {
+		return nil, nil
+	}
+	return env, err
+}
+
+// applyEnvironmentRules enforces the protection rules of the environment a deployment targets
+// and blocks the pipeline if deployments to the environment require an approval.
+func applyEnvironmentRules(store store.Store, repo *model.Repo, pipeline *model.Pipeline) error {
+	env, err := deployEnvironment(store, repo, pipeline)
+	if err != nil {
+		return fmt.Errorf("error loading environment %s: %w", pipeline.DeployTo, err)
+	}
+	if env == nil {
+		return nil
+	}
+
+	if !env.AllowsBranch(pipeline.Branch) {
+		return &ErrForbidden{Msg: fmt.Sprintf("deployments to %s are not allowed from branch %s", env.Name, pipeline.Branch)}
+	}
+
+	if env.RequiresApproval() {
+		pipeline.Status = model.StatusBlocked
+	}
+	return nil
+}
+
+// checkEnvironmentApprover ensures the user is allowed to approve deployments to the environment of the pipeline.
+func checkEnvironmentApprover(store store.Store, repo *model.Repo, pipeline *model.Pipeline, user *model.User) error {
+	env, err := deployEnvironment(store, repo, pipeline)
+	if err != nil {
+		return fmt.Errorf("error loading environment %s: %w", pipeline.DeployTo, err)
+	}
+	if env != nil && !env.CanApprove(user.Login) {
+		return &ErrForbidden{Msg: fmt.Sprintf("%s is not allowed to approve deployments to %s", user.Login, env.Name)}
+	}
+	return nil
+}
@@ -0,0 +1,67 @@
+// Copyright 2025 Woodpecker Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package pipeline
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+
+	"go.woodpecker-ci.org/woodpecker/v3/server/model"
+	"go.woodpecker-ci.org/woodpecker/v3/server/store/mocks"
+	"go.woodpecker-ci.org/woodpecker/v3/server/store/types"
+)
+
+func TestApplyEnvironmentRules(t *testing.T) {
+	t.Parallel()
+
+	repo := &model.Repo{ID: 1}
+	production := &model.Environment{RepoID: repo.ID, Name: "production", RequiredApprovers: []string{"alice"}, AllowedBranches: []string{"main"}}
+
+	store := mocks.NewStore(t)
+	store.On("EnvironmentFind", repo, "production").Return(production, nil)
+	store.On("EnvironmentFind", repo, "preview").Return(nil, types.RecordNotExist)
+
+	pipeline := &model.Pipeline{Event: model.EventPush, Branch: "feature", Status: model.StatusCreated}
+	assert.NoError(t, applyEnvironmentRules(store, repo, pipeline))
+	assert.Equal(t, model.StatusCreated, pipeline.Status)
+
+	pipeline = &model.Pipeline{Event: model.EventDeploy, DeployTo: "preview", Branch: "feature", Status: model.StatusCreated}
+	assert.NoError(t, applyEnvironmentRules(store, repo, pipeline))
+	assert.Equal(t, model.StatusCreated, pipeline.Status)
+
+	pipeline = &model.Pipeline{Event: model.EventDeploy, DeployTo: "production", Branch: "feature", Status: model.StatusCreated}
+	assert.ErrorIs(t, applyEnvironmentRules(store, repo, pipeline), &ErrForbidden{})
+
+	pipeline = &model.Pipeline{Event: model.EventDeploy, DeployTo: "production", Branch: "main", Status: model.StatusCreated}
+	assert.NoError(t, applyEnvironmentRules(store, repo, pipeline))
+	assert.Equal(t, model.StatusBlocked, pipeline.Status)
+}
+
+func TestCheckEnvironmentApprover(t *testing.T) {
+	t.Parallel()
+
+	repo := &model.Repo{ID: 1}
+	production := &model.Environment{RepoID: repo.ID, Name: "production", RequiredApprovers: []string{"alice"}}
+
+	store := mocks.NewStore(t)
+	store.On("EnvironmentFind", repo, mock.Anything).Return(production, nil)
+
+	pipeline := &model.Pipeline{Event: model.EventDeploy, DeployTo: "production"}
+	assert.NoError(t, checkEnvironmentApprover(store, repo, pipeline, &model.User{Login: "alice"}))
+	assert.ErrorIs(t, checkEnvironmentApprover(store, repo, pipeline, &model.User{Login: "bob"}), &ErrForbidden{})
+	assert.NoError(t, checkEnvironmentApprover(store, repo, &model.Pipeline{Event: model.EventPull}, &model.User{Login: "bob"}))
+}
@@ -48,4 +48,20 @@ func (e ErrBadRequest) Is(target error) bool {
 	return ok
 }
 
+type ErrForbidden struct {
+	Msg string
+}
+
+func (e ErrForbidden) Error() string {
+	return e.Msg
+}
+
+func (e ErrForbidden) Is(target error) bool {
+	_, ok := target.(ErrForbidden)
+	if !ok {
+		_, ok = target.(*ErrForbidden)
+	}
+	return ok
+}
+
 var ErrFiltered = errors.New("ignoring hook: 'when' filters filtered out all steps")
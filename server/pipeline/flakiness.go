@@ -0,0 +1,79 @@
+// Copyright 2025 Woodpecker Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package pipeline
+
+import (
+	"go.woodpecker-ci.org/woodpecker/v3/server/model"
+	"go.woodpecker-ci.org/woodpecker/v3/server/store"
+)
+
+// UpdateStepFlakiness counts the finished steps of a workflow and the steps which passed
+// after they failed in an earlier attempt or an earlier pipeline of the same commit.
+func UpdateStepFlakiness(store store.Store, pipeline *model.Pipeline, workflow *model.Workflow) error {
+	previousSteps, err := store.StepListPrevious(pipeline, workflow.Name)
+	if err != nil {
+		return err
+	}
+	// the steps are sorted by pipeline, the first one of a name is the latest
+	previous := make(map[string]model.StatusValue, len(previousSteps))
+	for _, step := range previousSteps {
+		if _, ok := previous[step.Name]; !ok {
+			previous[step.Name] = step.State
+		}
+	}
+
+	for _, step := range workflow.Children {
+		if step.State != model.StatusSuccess && step.State != model.StatusFailure {
+			continue
+		}
+
+		flakiness := &model.StepFlakiness{
+			RepoID:   pipeline.RepoID,
+			Workflow: workflow.Name,
+			Name:     step.Name,
+			Runs:     1,
+		}
+		if model.IsFlaky(step, previous[step.Name]) {
+			flakiness.Flakes = 1
+			flakiness.LastFlake = step.Finished
+		}
+		if err := store.StepFlakinessAdd(flakiness); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// SetStepFlakiness sets the flakiness score of the steps of the workflows which were flaky before.
+func SetStepFlakiness(store store.Store, repo *model.Repo, workflows []*model.Workflow) error {
+	flakiness, err := store.StepFlakinessList(repo, &model.ListOptions{All: true})
+	if err != nil {
+		return err
+	}
+	if len(flakiness) == 0 {
+		return nil
+	}
+
+	scores := make(map[[2]string]float64, len(flakiness))
+	for _, f := range flakiness {
+		scores[[2]string{f.Workflow, f.Name}] = f.Score
+	}
+	for _, workflow := range workflows {
+		for _, step := range workflow.Children {
+			step.Flakiness = scores[[2]string{workflow.Name, step.Name}]
+		}
+	}
+	return nil
+}
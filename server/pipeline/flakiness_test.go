@@ -0,0 +1,80 @@
+// Copyright 2025 Woodpecker Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package pipeline
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+
+	"go.woodpecker-ci.org/woodpecker/v3/server/model"
+	store_mocks "go.woodpecker-ci.org/woodpecker/v3/server/store/mocks"
+)
+
+func TestUpdateStepFlakiness(t *testing.T) {
+	t.Parallel()
+
+	pipeline := &model.Pipeline{ID: 3, RepoID: 1, Number: 3, Commit: "abc", Branch: "main"}
+	workflow := &model.Workflow{
+		Name: "test",
+		Children: []*model.Step{
+			{Name: "clone", State: model.StatusSuccess, Finished: 10},
+			{Name: "unit", State: model.StatusSuccess, Finished: 20},
+			{Name: "e2e", State: model.StatusSuccess, Finished: 30, Attempts: []model.StepAttempt{{ExitCode: 1}}},
+			{Name: "lint", State: model.StatusFailure, Finished: 40},
+			{Name: "deploy", State: model.StatusSkipped},
+		},
+	}
+
+	store := store_mocks.NewStore(t)
+	store.On("StepListPrevious", pipeline, "test").Return([]*model.Step{
+		{Name: "unit", State: model.StatusFailure},
+		{Name: "clone", State: model.StatusSuccess},
+		{Name: "clone", State: model.StatusFailure},
+	}, nil)
+	var added []*model.StepFlakiness
+	store.On("StepFlakinessAdd", mock.Anything).Run(func(args mock.Arguments) {
+		added = append(added, args.Get(0).(*model.StepFlakiness))
+	}).Return(nil)
+
+	assert.NoError(t, UpdateStepFlakiness(store, pipeline, workflow))
+	assert.Equal(t, []*model.StepFlakiness{
+		{RepoID: 1, Workflow: "test", Name: "clone", Runs: 1},
+		{RepoID: 1, Workflow: "test", Name: "unit", Runs: 1, Flakes: 1, LastFlake: 20},
+		{RepoID: 1, Workflow: "test", Name: "e2e", Runs: 1, Flakes: 1, LastFlake: 30},
+		{RepoID: 1, Workflow: "test", Name: "lint", Runs: 1},
+	}, added)
+}
+
+func TestSetStepFlakiness(t *testing.T) {
+	t.Parallel()
+
+	repo := &model.Repo{ID: 1}
+	workflows := []*model.Workflow{
+		{Name: "test", Children: []*model.Step{{Name: "unit"}, {Name: "lint"}}},
+		{Name: "e2e", Children: []*model.Step{{Name: "unit"}}},
+	}
+
+	store := store_mocks.NewStore(t)
+	store.On("StepFlakinessList", repo, mock.Anything).Return([]*model.StepFlakiness{
+		{Workflow: "test", Name: "unit", Score: 0.25},
+	}, nil)
+
+	assert.NoError(t, SetStepFlakiness(store, repo, workflows))
+	assert.InDelta(t, 0.25, workflows[0].Children[0].Flakiness, 0.001)
+	assert.Zero(t, workflows[0].Children[1].Flakiness)
+	assert.Zero(t, workflows[1].Children[0].Flakiness)
+}
@@ -0,0 +1,82 @@
+// Copyright 2025 Woodpecker Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package pipeline
+
+import (
+	"fmt"
+	"maps"
+	"slices"
+
+	"go.woodpecker-ci.org/woodpecker/v3/pipeline/frontend/yaml"
+	"go.woodpecker-ci.org/woodpecker/v3/pipeline/frontend/yaml/types"
+	forge_types "go.woodpecker-ci.org/woodpecker/v3/server/forge/types"
+	"go.woodpecker-ci.org/woodpecker/v3/server/model"
+)
+
+// resolveInputs validates the inputs given for a pipeline against the inputs
+// declared by its workflows and replaces them with their typed values.
+// Declared inputs which are not given fall back to their default.
+func resolveInputs(pipeline *model.Pipeline, configs []*forge_types.FileMeta) error {
+	given := pipeline.Inputs
+	declared := map[string]bool{}
+	resolved := map[string]any{}
+
+	for _, config := range configs {
+		workflow, err := yaml.ParseBytes(config.Data)
+		if err != nil {
+			// parse errors are reported when the pipeline is built
+			continue
+		}
+
+		for _, name := range slices.Sorted(maps.Keys(workflow.Inputs)) {
+			input := workflow.Inputs[name]
+			if input == nil {
+				input = &types.Input{}
+			}
+			declared[name] = true
+
+			value := given[name]
+			if value == nil {
+				value = input.Default
+			}
+			if value == nil {
+				if input.Required && pipeline.Event == model.EventManual {
+					return fmt.Errorf("input '%s' of workflow '%s' is required", name, config.Name)
+				}
+				continue
+			}
+
+			typed, err := input.Value(value)
+			if err != nil {
+				return fmt.Errorf("input '%s' of workflow '%s': %w", name, config.Name, err)
+			}
+			if _, exists := resolved[name]; !exists {
+				resolved[name] = typed
+			}
+		}
+	}
+
+	for _, name := range slices.Sorted(maps.Keys(given)) {
+		if !declared[name] {
+			return fmt.Errorf("input '%s' is not declared by any workflow", name)
+		}
+	}
+
+	if len(resolved) == 0 {
+		resolved = nil
+	}
+	pipeline.Inputs = resolved
+	return nil
+}
@@ -0,0 +1,86 @@
+// Copyright 2025 Woodpecker Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package pipeline
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	forge_types "go.woodpecker-ci.org/woodpecker/v3/server/forge/types"
+	"go.woodpecker-ci.org/woodpecker/v3/server/model"
+)
+
+func TestResolveInputs(t *testing.T) {
+	t.Parallel()
+
+	configs := []*forge_types.FileMeta{
+		{Name: "release.yaml", Data: []byte(`
+inputs:
+  version:
+    required: true
+  environment:
+    type: choice
+    options: [staging, production]
+    default: staging
+  dry_run:
+    type: bool
+  replicas:
+    type: number
+    default: 2
+steps:
+  release:
+    image: alpine
+`)},
+		{Name: "broken.yaml", Data: []byte(`steps: [`)},
+	}
+
+	t.Run("typed values and defaults", func(t *testing.T) {
+		t.Parallel()
+		pipeline := &model.Pipeline{Event: model.EventManual, Inputs: map[string]any{"version": "1.2.0", "dry_run": "true"}}
+		assert.NoError(t, resolveInputs(pipeline, configs))
+		assert.Equal(t, map[string]any{
+			"version":     "1.2.0",
+			"environment": "staging",
+			"dry_run":     true,
+			"replicas":    float64(2),
+		}, pipeline.Inputs)
+	})
+
+	t.Run("missing required input", func(t *testing.T) {
+		t.Parallel()
+		pipeline := &model.Pipeline{Event: model.EventManual}
+		assert.ErrorContains(t, resolveInputs(pipeline, configs), "input 'version' of workflow 'release.yaml' is required")
+	})
+
+	t.Run("required input is only enforced for manual pipelines", func(t *testing.T) {
+		t.Parallel()
+		pipeline := &model.Pipeline{Event: model.EventPush}
+		assert.NoError(t, resolveInputs(pipeline, configs))
+		assert.Equal(t, map[string]any{"environment": "staging", "replicas": float64(2)}, pipeline.Inputs)
+	})
+
+	t.Run("invalid value", func(t *testing.T) {
+		t.Parallel()
+		pipeline := &model.Pipeline{Event: model.EventManual, Inputs: map[string]any{"version": "1.2.0", "environment": "qa"}}
+		assert.ErrorContains(t, resolveInputs(pipeline, configs), "input 'environment' of workflow 'release.yaml'")
+	})
+
+	t.Run("undeclared input", func(t *testing.T) {
+		t.Parallel()
+		pipeline := &model.Pipeline{Event: model.EventManual, Inputs: map[string]any{"version": "1.2.0", "debug": true}}
+		assert.ErrorContains(t, resolveInputs(pipeline, configs), "input 'debug' is not declared by any workflow")
+	})
+}
@@ -19,14 +19,24 @@ import (
 	"encoding/json"
 	"fmt"
 	"maps"
+	"time"
 
 	"go.woodpecker-ci.org/woodpecker/v3/pipeline/rpc"
 	"go.woodpecker-ci.org/woodpecker/v3/server"
 	"go.woodpecker-ci.org/woodpecker/v3/server/model"
 	"go.woodpecker-ci.org/woodpecker/v3/server/pipeline/stepbuilder"
+	"go.woodpecker-ci.org/woodpecker/v3/shared/tracing"
 )
 
-func queuePipeline(ctx context.Context, repo *model.Repo, pipelineItems []*stepbuilder.Item) error {
+func queuePipeline(ctx context.Context, repo *model.Repo, pipelineItems []*stepbuilder.Item) (err error) {
+	// the agents continue the trace of the pipeline, not the one of queueing it
+	traceContext := tracing.Inject(ctx)
+	ctx, span := tracing.Tracer.Start(ctx, "queue")
+	defer func() {
+		tracing.RecordError(span, err)
+		span.End()
+	}()
+
 	var tasks []*model.Task
 	for _, item := range pipelineItems {
 		if item.Workflow.State == model.StatusSkipped {
@@ -37,18 +47,24 @@ func queuePipeline(ctx context.Context, repo *model.Repo, pipelineItems []*stepb
 			Labels: make(map[string]string),
 		}
 		maps.Copy(task.Labels, item.Labels)
-		err := task.ApplyLabelsFromRepo(repo)
+		err = task.ApplyLabelsFromRepo(repo)
 		if err != nil {
 			return err
 		}
 		task.Dependencies = taskIDs(item.DependsOn, pipelineItems)
 		task.RunOn = item.RunsOn
 		task.DepStatus = make(map[string]model.StatusValue)
+		task.RepoID = repo.ID
+		task.PipelineID = item.Workflow.PipelineID
+		task.MaxConcurrentPipelines = repo.MaxConcurrentPipelines
+		task.ConcurrencyGroup = item.Concurrency
+		task.Enqueued = time.Now().UnixNano()
 
 		task.Data, err = json.Marshal(rpc.Workflow{
-			ID:      fmt.Sprint(item.Workflow.ID),
-			Config:  item.Config,
-			Timeout: repo.Timeout,
+			ID:           fmt.Sprint(item.Workflow.ID),
+			Config:       item.Config,
+			Timeout:      repo.Timeout,
+			TraceContext: traceContext,
 		})
 		if err != nil {
 			return err
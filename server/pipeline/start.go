@@ -50,6 +50,7 @@ func prepareStart(ctx context.Context, forge forge.Forge, store store.Store, act
 	}
 
 	publishPipeline(ctx, forge, activePipeline, repo, user)
+	SendWebhooks(model.HookEventPipelineCreated, repo, activePipeline, nil, nil)
 	return nil
 }
 
@@ -20,12 +20,21 @@ import (
 
 	"go.woodpecker-ci.org/woodpecker/v3/pipeline"
 	"go.woodpecker-ci.org/woodpecker/v3/pipeline/rpc"
+	"go.woodpecker-ci.org/woodpecker/v3/server/metrics"
 	"go.woodpecker-ci.org/woodpecker/v3/server/model"
 	"go.woodpecker-ci.org/woodpecker/v3/server/store"
 )
 
 func UpdateStepStatus(store store.Store, step *model.Step, state rpc.StepState) error {
-	if state.Exited {
+	if state.Exited && state.Retry {
+		// the step gets restarted, so only record the failed attempt
+		step.Attempts = append(step.Attempts, model.StepAttempt{
+			Finished: state.Finished,
+			ExitCode: state.ExitCode,
+			Error:    state.Error,
+			LogLines: state.LogLines,
+		})
+	} else if state.Exited {
 		step.Finished = state.Finished
 		step.ExitCode = state.ExitCode
 		step.Error = state.Error
@@ -40,7 +49,13 @@ func UpdateStepStatus(store store.Store, step *model.Step, state rpc.StepState)
 		step.Started = state.Started
 		step.State = model.StatusRunning
 	}
-	return store.StepUpdate(step)
+	if err := store.StepUpdate(step); err != nil {
+		return err
+	}
+	if state.Exited && !state.Retry {
+		metrics.StepFinished(step)
+	}
+	return nil
 }
 
 func UpdateStepToStatusStarted(store store.Store, step model.Step, state rpc.StepState) (*model.Step, error) {
@@ -149,6 +149,30 @@ func TestUpdateStepStatusExitedWithCode(t *testing.T) {
 	assert.Equal(t, 1, step.ExitCode)
 }
 
+func TestUpdateStepStatusExitedWithRetry(t *testing.T) {
+	t.Parallel()
+
+	// step in db before update
+	step := &model.Step{Started: 42, State: model.StatusRunning}
+
+	// advertised step status
+	state := rpc.StepState{
+		Started:  int64(42),
+		Exited:   true,
+		Finished: int64(50),
+		ExitCode: 1,
+		Retry:    true,
+		LogLines: 12,
+	}
+
+	err := UpdateStepStatus(mockStoreStep(t), step, state)
+	assert.NoError(t, err)
+	assert.EqualValues(t, model.StatusRunning, step.State)
+	assert.EqualValues(t, 0, step.Finished)
+	assert.EqualValues(t, 0, step.ExitCode)
+	assert.Equal(t, []model.StepAttempt{{Finished: 50, ExitCode: 1, LogLines: 12}}, step.Attempts)
+}
+
 func TestUpdateStepToStatusStarted(t *testing.T) {
 	t.Parallel()
 
@@ -135,7 +135,9 @@ func metadataPipelineFromModelPipeline(pipeline *model.Pipeline, includeParent b
 			ChangedFiles:      pipeline.ChangedFiles,
 			PullRequestLabels: pipeline.PullRequestLabels,
 			IsPrerelease:      pipeline.IsPrerelease,
+			FromFork:          pipeline.FromFork,
 		},
-		Cron: cron,
+		Cron:   cron,
+		Inputs: pipeline.Inputs,
 	}
 }
@@ -87,6 +87,30 @@ func TestMetadataFromStruct(t *testing.T) {
 				"CI_SYSTEM_NAME": "woodpecker", "CI_SYSTEM_URL": "https://example.com", "CI_WORKFLOW_NAME": "hello", "CI_WORKFLOW_NUMBER": "0",
 			},
 		},
+		{
+			name:     "Test with inputs",
+			pipeline: &model.Pipeline{Number: 4, Event: model.EventManual, Inputs: map[string]any{"version": "1.2.0", "dry_run": false, "replicas": float64(3), "message": ""}},
+			expectedMetadata: metadata.Metadata{
+				Sys: metadata.System{Name: "woodpecker"},
+				Curr: metadata.Pipeline{
+					Number: 4,
+					Event:  "manual",
+					Inputs: map[string]any{"version": "1.2.0", "dry_run": false, "replicas": float64(3), "message": ""},
+				},
+			},
+			expectedEnviron: map[string]string{
+				"CI":               "woodpecker",
+				"CI_INPUT_DRY_RUN": "false", "CI_INPUT_MESSAGE": "", "CI_INPUT_REPLICAS": "3", "CI_INPUT_VERSION": "1.2.0",
+				"CI_PIPELINE_CREATED": "0", "CI_PIPELINE_EVENT": "manual", "CI_PIPELINE_FILES": "[]", "CI_PIPELINE_NUMBER": "4",
+				"CI_PIPELINE_PARENT": "0", "CI_PIPELINE_STARTED": "0", "CI_PIPELINE_URL": "/repos/0/pipeline/4",
+				"CI_PREV_PIPELINE_CREATED":  "0",
+				"CI_PREV_PIPELINE_FINISHED": "0", "CI_PREV_PIPELINE_NUMBER": "0", "CI_PREV_PIPELINE_PARENT": "0",
+				"CI_PREV_PIPELINE_STARTED": "0", "CI_PREV_PIPELINE_URL": "/repos/0/pipeline/0",
+				"CI_REPO_PRIVATE": "false", "CI_REPO_TRUSTED": "false", "CI_REPO_TRUSTED_NETWORK": "false", "CI_REPO_TRUSTED_SECURITY": "false", "CI_REPO_TRUSTED_VOLUMES": "false",
+				"CI_STEP_NUMBER": "0", "CI_STEP_URL": "/repos/0/pipeline/4", "CI_SYSTEM_NAME": "woodpecker",
+				"CI_WORKFLOW_NUMBER": "0",
+			},
+		},
 	}
 
 	for _, testCase := range testCases {
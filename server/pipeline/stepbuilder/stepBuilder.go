@@ -20,6 +20,7 @@ import (
 	"maps"
 	"path/filepath"
 	"strings"
+	"time"
 
 	"github.com/oklog/ulid/v2"
 	"github.com/rs/zerolog/log"
@@ -37,8 +38,12 @@ import (
 	"go.woodpecker-ci.org/woodpecker/v3/server"
 	forge_types "go.woodpecker-ci.org/woodpecker/v3/server/forge/types"
 	"go.woodpecker-ci.org/woodpecker/v3/server/model"
+	"go.woodpecker-ci.org/woodpecker/v3/server/services/oidc"
 )
 
+// oidcTokenQueueTime is the time an identity token stays valid in addition to the timeout of the repo.
+const oidcTokenQueueTime = time.Hour
+
 // StepBuilder Takes the hook data and the yaml and returns in internal data model.
 type StepBuilder struct {
 	Repo          *model.Repo
@@ -56,11 +61,12 @@ type StepBuilder struct {
 }
 
 type Item struct {
-	Workflow  *model.Workflow
-	Labels    map[string]string
-	DependsOn []string
-	RunsOn    []string
-	Config    *backend_types.Config
+	Workflow    *model.Workflow
+	Labels      map[string]string
+	DependsOn   []string
+	RunsOn      []string
+	Concurrency string
+	Config      *backend_types.Config
 }
 
 func (b *StepBuilder) Build() (items []*Item, errorsAndWarnings error) {
@@ -182,11 +188,12 @@ func (b *StepBuilder) genItemForWorkflow(workflow *model.Workflow, axis matrix.A
 	}
 
 	item = &Item{
-		Workflow:  workflow,
-		Config:    ir,
-		Labels:    parsed.Labels,
-		DependsOn: parsed.DependsOn,
-		RunsOn:    parsed.RunsOn,
+		Workflow:    workflow,
+		Config:      ir,
+		Labels:      parsed.Labels,
+		DependsOn:   parsed.DependsOn,
+		RunsOn:      parsed.RunsOn,
+		Concurrency: parsed.Concurrency,
 	}
 	if len(item.Labels) == 0 {
 		item.Labels = make(map[string]string, len(b.DefaultLabels))
@@ -304,9 +311,49 @@ func (b *StepBuilder) toInternalRepresentation(parsed *yaml_types.Workflow, envi
 		compiler.WithWorkspaceFromURL(compiler.DefaultWorkspaceBase, b.Repo.ForgeURL),
 		compiler.WithMetadata(metadata),
 		compiler.WithTrustedSecurity(b.Repo.Trusted.Security),
+		compiler.WithOption(
+			compiler.WithOIDCTokenIssuer(b.oidcTokenIssuer(metadata)),
+			server.Config.Services.OIDC != nil,
+		),
 	).Compile(parsed)
 }
 
+// oidcTokenIssuer returns an issuer of the identity tokens of the workflow. The tokens are valid
+// for the timeout of the repo plus oidcTokenQueueTime, as they are issued before the workflow is queued.
+// Pull requests of forks control their own config, so they don't get tokens.
+func (b *StepBuilder) oidcTokenIssuer(metadata metadata.Metadata) compiler.OIDCTokenIssuer {
+	return func(audience string) (string, error) {
+		if metadata.Curr.Commit.FromFork {
+			return "", fmt.Errorf("oidc tokens are not issued for pull requests from forks")
+		}
+
+		// the branch of pull requests is the target branch, it must not be trusted like a push to it
+		branch := b.Curr.Branch
+		if b.Curr.Event == model.EventPull || b.Curr.Event == model.EventPullClosed {
+			branch = ""
+		}
+
+		timeout := b.Repo.Timeout
+		if timeout == 0 {
+			timeout = server.Config.Pipeline.DefaultTimeout
+		}
+		expires := time.Now().Add(time.Duration(timeout)*time.Minute + oidcTokenQueueTime)
+
+		return server.Config.Services.OIDC.Issue(&oidc.Claims{
+			RepoID:         b.Repo.ID,
+			Repo:           b.Repo.FullName,
+			RepoOwner:      b.Repo.Owner,
+			Ref:            b.Curr.Ref,
+			Branch:         branch,
+			Commit:         b.Curr.Commit,
+			Event:          string(b.Curr.Event),
+			PipelineNumber: b.Curr.Number,
+			Workflow:       metadata.Workflow.Name,
+			DeployTarget:   b.Curr.DeployTo,
+		}, audience, expires)
+	}
+}
+
 func SanitizePath(path string) string {
 	path = filepath.Base(path)
 	path = strings.TrimSuffix(path, ".yml")
@@ -16,16 +16,22 @@
 package stepbuilder
 
 import (
+	"crypto/rand"
+	"crypto/rsa"
 	"fmt"
 	"testing"
 
+	"github.com/golang-jwt/jwt/v5"
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
 
 	"go.woodpecker-ci.org/woodpecker/v3/pipeline/errors"
+	"go.woodpecker-ci.org/woodpecker/v3/server"
 	"go.woodpecker-ci.org/woodpecker/v3/server/forge"
 	"go.woodpecker-ci.org/woodpecker/v3/server/forge/mocks"
 	forge_types "go.woodpecker-ci.org/woodpecker/v3/server/forge/types"
 	"go.woodpecker-ci.org/woodpecker/v3/server/model"
+	"go.woodpecker-ci.org/woodpecker/v3/server/services/oidc"
 )
 
 func TestGlobalEnvsubst(t *testing.T) {
@@ -584,6 +590,107 @@ depends_on: [ shouldbefiltered ]
 	}
 }
 
+func TestOIDCToken(t *testing.T) {
+	key, err := rsa.GenerateKey(rand.Reader, 2048)
+	require.NoError(t, err)
+	server.Config.Services.OIDC = oidc.New("https://ci.example.com", key)
+	defer func() { server.Config.Services.OIDC = nil }()
+
+	b := StepBuilder{
+		Forge: getMockForge(t),
+		Repo:  &model.Repo{ID: 1, FullName: "octocat/hello-world", Owner: "octocat", Timeout: 60},
+		Curr: &model.Pipeline{
+			Number: 7,
+			Event:  model.EventPush,
+			Ref:    "refs/heads/main",
+			Branch: "main",
+		},
+		Prev:  &model.Pipeline{},
+		Netrc: &model.Netrc{},
+		Yamls: []*forge_types.FileMeta{
+			{Name: ".woodpecker/deploy.yaml", Data: []byte(`
+when:
+  event: push
+skip_clone: true
+steps:
+  deploy:
+    image: amazon/aws-cli
+    commands: aws sts get-caller-identity
+    oidc_token:
+      audience: sts.amazonaws.com
+`)},
+		},
+	}
+
+	items, err := b.Build()
+	require.NoError(t, err)
+	require.Len(t, items, 1)
+
+	signed := items[0].Config.Stages[0].Steps[0].Environment["WOODPECKER_OIDC_TOKEN"]
+	claims := new(oidc.Claims)
+	_, err = jwt.ParseWithClaims(signed, claims, func(*jwt.Token) (any, error) {
+		return &key.PublicKey, nil
+	}, jwt.WithAudience("sts.amazonaws.com"))
+	require.NoError(t, err)
+	assert.Equal(t, "octocat/hello-world", claims.Repo)
+	assert.Equal(t, "deploy", claims.Workflow)
+	assert.Equal(t, "push", claims.Event)
+	assert.EqualValues(t, 7, claims.PipelineNumber)
+}
+
+func TestOIDCTokenPullRequest(t *testing.T) {
+	key, err := rsa.GenerateKey(rand.Reader, 2048)
+	require.NoError(t, err)
+	server.Config.Services.OIDC = oidc.New("https://ci.example.com", key)
+	defer func() { server.Config.Services.OIDC = nil }()
+
+	build := func(fromFork bool) ([]*Item, error) {
+		b := StepBuilder{
+			Forge: getMockForge(t),
+			Repo:  &model.Repo{ID: 1, FullName: "octocat/hello-world", Owner: "octocat", Timeout: 60},
+			Curr: &model.Pipeline{
+				Number:   8,
+				Event:    model.EventPull,
+				Ref:      "refs/pull/1/head",
+				Branch:   "main",
+				FromFork: fromFork,
+			},
+			Prev:  &model.Pipeline{},
+			Netrc: &model.Netrc{},
+			Yamls: []*forge_types.FileMeta{
+				{Name: ".woodpecker/deploy.yaml", Data: []byte(`
+when:
+  event: pull_request
+skip_clone: true
+steps:
+  deploy:
+    image: amazon/aws-cli
+    commands: aws sts get-caller-identity
+    oidc_token:
+      audience: sts.amazonaws.com
+`)},
+			},
+		}
+		return b.Build()
+	}
+
+	_, err = build(true)
+	assert.ErrorContains(t, err, "oidc tokens are not issued for pull requests from forks")
+
+	items, err := build(false)
+	require.NoError(t, err)
+	require.Len(t, items, 1)
+
+	signed := items[0].Config.Stages[0].Steps[0].Environment["WOODPECKER_OIDC_TOKEN"]
+	claims := new(oidc.Claims)
+	_, err = jwt.ParseWithClaims(signed, claims, func(*jwt.Token) (any, error) {
+		return &key.PublicKey, nil
+	}, jwt.WithAudience("sts.amazonaws.com"))
+	require.NoError(t, err)
+	assert.Equal(t, "pull_request", claims.Event)
+	assert.Empty(t, claims.Branch)
+}
+
 func TestSanitizePath(t *testing.T) {
 	t.Parallel()
 
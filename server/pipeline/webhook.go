@@ -0,0 +1,31 @@
+// Copyright 2025 Woodpecker Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package pipeline
+
+import (
+	"go.woodpecker-ci.org/woodpecker/v3/server"
+	"go.woodpecker-ci.org/woodpecker/v3/server/model"
+)
+
+// SendWebhooks sends the event to the outgoing webhooks of the repository, workflow and step are optional.
+func SendWebhooks(event model.HookEvent, repo *model.Repo, pipeline *model.Pipeline, workflow *model.Workflow, step *model.Step) {
+	server.Config.Services.Manager.WebhookService().Send(repo, &model.WebhookPayload{
+		Event:    event,
+		Repo:     repo,
+		Pipeline: pipeline,
+		Workflow: workflow,
+		Step:     step,
+	})
+}
@@ -0,0 +1,167 @@
+// Copyright 2025 Woodpecker Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package pubsub
+
+import (
+	"context"
+	"sync"
+	"time"
+
+	"github.com/rs/zerolog/log"
+
+	"go.woodpecker-ci.org/woodpecker/v3/server/model"
+	"go.woodpecker-ci.org/woodpecker/v3/server/store"
+)
+
+const (
+	// databasePollInterval is how often new messages are read from the database.
+	databasePollInterval = 250 * time.Millisecond
+
+	// databaseLookback is how far back messages are read on every poll. Messages can become visible
+	// out of id order if they are inserted in concurrent transactions, so we do not only read
+	// messages newer than the last one seen.
+	databaseLookback = 5 * time.Second
+
+	// databaseRetention is how long messages are kept in the database.
+	databaseRetention = time.Minute
+
+	// databaseSubscriberBuffer is how many messages are queued for a subscriber
+	// before new ones are dropped.
+	databaseSubscriberBuffer = 1000
+)
+
+type database struct {
+	sync.Mutex
+
+	subs     map[chan Message]struct{}
+	store    store.Store
+	channel  string
+	interval time.Duration
+	seen     map[int64]int64
+}
+
+// NewDatabase creates a publisher which exchanges messages using the database, so
+// subscribers of all server instances sharing the database receive them.
+// Publishers using a different channel do not receive each other's messages.
+func NewDatabase(ctx context.Context, s store.Store, channel string) Publisher {
+	return newDatabase(ctx, s, channel, databasePollInterval)
+}
+
+func newDatabase(ctx context.Context, s store.Store, channel string, interval time.Duration) *database {
+	p := &database{
+		subs:     make(map[chan Message]struct{}),
+		store:    s,
+		channel:  channel,
+		interval: interval,
+		seen:     make(map[int64]int64),
+	}
+	go p.poll(ctx)
+	return p
+}
+
+func (p *database) Publish(message Message) {
+	err := p.store.PubsubMessageCreate(&model.PubsubMessage{
+		Channel:   p.channel,
+		MessageID: message.ID,
+		Data:      message.Data,
+		Labels:    message.Labels,
+		Created:   time.Now().Unix(),
+	})
+	if err != nil {
+		log.Error().Err(err).Msgf("pubsub: cannot publish message to channel %s", p.channel)
+	}
+}
+
+// Subscribe calls the receiver for every message in the order they were published.
+func (p *database) Subscribe(c context.Context, receiver Receiver) {
+	messages := make(chan Message, databaseSubscriberBuffer)
+	p.Lock()
+	p.subs[messages] = struct{}{}
+	p.Unlock()
+
+	defer func() {
+		p.Lock()
+		delete(p.subs, messages)
+		p.Unlock()
+	}()
+
+	for {
+		select {
+		case <-c.Done():
+			return
+		case message := <-messages:
+			receiver(message)
+		}
+	}
+}
+
+func (p *database) deliver(message Message) {
+	p.Lock()
+	defer p.Unlock()
+
+	for sub := range p.subs {
+		select {
+		case sub <- message:
+		default:
+			log.Info().Msgf("pubsub: subscriber of channel %s is full -- dropping message", p.channel)
+		}
+	}
+}
+
+func (p *database) poll(ctx context.Context) {
+	// only deliver messages published after we started
+	since := time.Now().Unix()
+	lastPrune := time.Time{}
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-time.After(p.interval):
+		}
+
+		now := time.Now()
+		messages, err := p.store.PubsubMessageList(p.channel, max(since, now.Add(-databaseLookback).Unix()))
+		if err != nil {
+			log.Error().Err(err).Msgf("pubsub: cannot read messages of channel %s", p.channel)
+			continue
+		}
+
+		for _, m := range messages {
+			if _, ok := p.seen[m.ID]; ok {
+				continue
+			}
+			p.seen[m.ID] = m.Created
+			p.deliver(Message{
+				ID:     m.MessageID,
+				Data:   m.Data,
+				Labels: m.Labels,
+			})
+		}
+
+		for id, created := range p.seen {
+			if created < now.Add(-databaseLookback).Unix() {
+				delete(p.seen, id)
+			}
+		}
+
+		if now.Sub(lastPrune) > databaseRetention {
+			lastPrune = now
+			if err := p.store.PubsubMessagePrune(now.Add(-databaseRetention).Unix()); err != nil {
+				log.Error().Err(err).Msg("pubsub: cannot prune old messages")
+			}
+		}
+	}
+}
@@ -0,0 +1,76 @@
+// Copyright 2025 Woodpecker Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package pubsub
+
+import (
+	"context"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"go.woodpecker-ci.org/woodpecker/v3/server/store"
+	"go.woodpecker-ci.org/woodpecker/v3/server/store/datastore"
+)
+
+func TestDatabasePubsub(t *testing.T) {
+	ctx, cancel := context.WithCancelCause(context.Background())
+	defer cancel(nil)
+
+	s, err := datastore.NewEngine(&store.Opts{
+		Driver: "sqlite3",
+		Config: filepath.Join(t.TempDir(), "pubsub.sqlite"),
+	})
+	require.NoError(t, err)
+	require.NoError(t, s.Migrate(ctx, true))
+	defer s.Close()
+
+	// two publishers sharing one database, like two servers would do
+	interval := 10 * time.Millisecond
+	publisher := newDatabase(ctx, s, "events", interval)
+	subscriber := newDatabase(ctx, s, "events", interval)
+	other := newDatabase(ctx, s, "logs", interval)
+
+	received := make(chan Message, 10)
+	go subscriber.Subscribe(ctx, func(m Message) { received <- m })
+	otherReceived := make(chan Message, 10)
+	go other.Subscribe(ctx, func(m Message) { otherReceived <- m })
+
+	<-time.After(100 * time.Millisecond)
+
+	for _, data := range []string{"first", "second", "third"} {
+		publisher.Publish(Message{Data: []byte(data), Labels: map[string]string{"repo": "foo/bar"}})
+	}
+
+	for _, data := range []string{"first", "second", "third"} {
+		select {
+		case m := <-received:
+			assert.Equal(t, data, string(m.Data))
+			assert.Equal(t, "foo/bar", m.Labels["repo"])
+		case <-time.After(time.Second):
+			t.Fatalf("expected message %s", data)
+		}
+	}
+
+	select {
+	case <-received:
+		t.Fatal("expected every message to be delivered only once")
+	case <-otherReceived:
+		t.Fatal("expected no message on other channel")
+	case <-time.After(100 * time.Millisecond):
+	}
+}
@@ -34,20 +34,29 @@ type Message struct {
 // Receiver receives published messages.
 type Receiver func(Message)
 
-type Publisher struct {
+// Publisher delivers published messages to all subscribers.
+type Publisher interface {
+	// Publish sends the message to all subscribers.
+	Publish(message Message)
+
+	// Subscribe calls the receiver for every published message until the context is done.
+	Subscribe(c context.Context, receiver Receiver)
+}
+
+type memory struct {
 	sync.Mutex
 
 	subs map[*Receiver]struct{}
 }
 
 // New creates an in-memory publisher.
-func New() *Publisher {
-	return &Publisher{
+func New() Publisher {
+	return &memory{
 		subs: make(map[*Receiver]struct{}),
 	}
 }
 
-func (p *Publisher) Publish(message Message) {
+func (p *memory) Publish(message Message) {
 	p.Lock()
 	for s := range p.subs {
 		go (*s)(message)
@@ -55,7 +64,7 @@ func (p *Publisher) Publish(message Message) {
 	p.Unlock()
 }
 
-func (p *Publisher) Subscribe(c context.Context, receiver Receiver) {
+func (p *memory) Subscribe(c context.Context, receiver Receiver) {
 	p.Lock()
 	p.subs[&receiver] = struct{}{}
 	p.Unlock()
@@ -0,0 +1,45 @@
+// Copyright 2025 Woodpecker Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package queue
+
+import "go.woodpecker-ci.org/woodpecker/v3/server/model"
+
+// concurrencyLimited returns true if the task has to stay pending, because its repository
+// already runs the maximum number of pipelines or a task of the same concurrency group is running.
+func concurrencyLimited(task *model.Task, running []*model.Task) bool {
+	if task.MaxConcurrentPipelines <= 0 && task.ConcurrencyGroup == "" {
+		return false
+	}
+
+	pipelines := make(map[int64]struct{})
+	for _, other := range running {
+		if other.ID == task.ID || other.RepoID != task.RepoID {
+			continue
+		}
+		if task.ConcurrencyGroup != "" && other.ConcurrencyGroup == task.ConcurrencyGroup {
+			return true
+		}
+		pipelines[other.PipelineID] = struct{}{}
+	}
+
+	if task.MaxConcurrentPipelines <= 0 {
+		return false
+	}
+	// workflows of a pipeline which already runs do not take another slot
+	if _, ok := pipelines[task.PipelineID]; ok {
+		return false
+	}
+	return int64(len(pipelines)) >= task.MaxConcurrentPipelines
+}
@@ -0,0 +1,480 @@
+// Copyright 2025 Woodpecker Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package queue
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"slices"
+	"sync"
+	"time"
+
+	"github.com/rs/zerolog/log"
+
+	"go.woodpecker-ci.org/woodpecker/v3/server/model"
+	"go.woodpecker-ci.org/woodpecker/v3/server/store"
+	"go.woodpecker-ci.org/woodpecker/v3/server/store/types"
+	"go.woodpecker-ci.org/woodpecker/v3/shared/constant"
+)
+
+const (
+	// databaseProcessInterval is the time till the database queue rearranges things.
+	// It is higher than the one of the memory queue as every run reads the whole task table.
+	databaseProcessInterval = time.Second
+
+	// databaseUpdateRetries is how often an update of a task is retried
+	// if another server changed it in the meantime.
+	databaseUpdateRetries = 5
+
+	pausedServerConfigKey = "queue-paused"
+)
+
+type database struct {
+	sync.Mutex
+
+	ctx       context.Context
+	store     store.Store
+	workers   map[*worker]struct{}
+	extension time.Duration
+	interval  time.Duration
+}
+
+// NewDatabaseQueue returns a queue which keeps all tasks and their state in the database.
+// This allows multiple server instances to share one queue. Workers are only known to
+// the server instance the agent is connected to, tasks are handed out by updating the
+// task row guarded by its version, so each task is only assigned once.
+func NewDatabaseQueue(ctx context.Context, s store.Store) Queue {
+	return newDatabaseQueue(ctx, s, databaseProcessInterval)
+}
+
+func newDatabaseQueue(ctx context.Context, s store.Store, interval time.Duration) *database {
+	q := &database{
+		ctx:       ctx,
+		store:     s,
+		workers:   map[*worker]struct{}{},
+		extension: constant.TaskTimeout,
+		interval:  interval,
+	}
+	go q.process()
+	return q
+}
+
+// Push pushes a task to the tail of this queue.
+func (q *database) Push(c context.Context, task *model.Task) error {
+	return q.PushAtOnce(c, []*model.Task{task})
+}
+
+// PushAtOnce pushes multiple tasks to the tail of this queue.
+func (q *database) PushAtOnce(_ context.Context, tasks []*model.Task) error {
+	enqueued := time.Now().UnixNano()
+	for i, task := range tasks {
+		task.State = model.TaskStatePending
+		task.Enqueued = enqueued + int64(i)
+		if err := q.store.TaskInsert(task); err != nil {
+			for _, inserted := range tasks[:i] {
+				if err2 := q.store.TaskDelete(inserted.ID); err2 != nil {
+					log.Error().Err(err2).Msgf("queue: cannot delete task '%s' after failed push", inserted.ID)
+				}
+			}
+			return err
+		}
+	}
+	return nil
+}
+
+// Poll retrieves and removes a task head of this queue.
+func (q *database) Poll(c context.Context, agentID int64, filter FilterFn) (*model.Task, error) {
+	q.Lock()
+	ctx, stop := context.WithCancelCause(c)
+
+	_worker := &worker{
+		agentID: agentID,
+		channel: make(chan *model.Task, 1),
+		filter:  filter,
+		stop:    stop,
+	}
+	q.workers[_worker] = struct{}{}
+	q.Unlock()
+
+	select {
+	case <-ctx.Done():
+		q.Lock()
+		delete(q.workers, _worker)
+		q.Unlock()
+		return nil, ctx.Err()
+	case t := <-_worker.channel:
+		return t, nil
+	}
+}
+
+// Done signals the task is complete.
+func (q *database) Done(_ context.Context, id string, exitStatus model.StatusValue) error {
+	return q.finished([]string{id}, exitStatus, nil)
+}
+
+// Error signals the task is done with an error.
+func (q *database) Error(_ context.Context, id string, err error) error {
+	return q.finished([]string{id}, model.StatusFailure, err)
+}
+
+// ErrorAtOnce signals multiple done are complete with an error.
+func (q *database) ErrorAtOnce(_ context.Context, ids []string, err error) error {
+	return q.finished(ids, model.StatusFailure, err)
+}
+
+func (q *database) finished(ids []string, exitStatus model.StatusValue, err error) error {
+	for _, id := range ids {
+		task, loadErr := q.store.TaskLoad(id)
+		switch {
+		case errors.Is(loadErr, types.RecordNotExist):
+		case loadErr != nil:
+			return loadErr
+		case task.State == model.TaskStateRunning && err != nil:
+			// keep the task until its deadline so Wait on any server can report the error
+			if updateErr := q.update(id, func(task *model.Task) error {
+				task.State = model.TaskStateDone
+				task.Error = err.Error()
+				task.Deadline = time.Now().Add(q.extension).Unix()
+				return nil
+			}); updateErr != nil && !errors.Is(updateErr, ErrNotFound) {
+				return updateErr
+			}
+		default:
+			if deleteErr := q.store.TaskDelete(id); deleteErr != nil && !errors.Is(deleteErr, types.RecordNotExist) {
+				return deleteErr
+			}
+		}
+
+		if depErr := q.updateDepStatus(id, exitStatus); depErr != nil {
+			return depErr
+		}
+	}
+	return nil
+}
+
+// Evict removes a pending task from the queue.
+func (q *database) Evict(ctx context.Context, taskID string) error {
+	return q.EvictAtOnce(ctx, []string{taskID})
+}
+
+// EvictAtOnce removes multiple pending tasks from the queue.
+func (q *database) EvictAtOnce(_ context.Context, taskIDs []string) error {
+	evicted := false
+	for _, id := range taskIDs {
+		task, err := q.store.TaskLoad(id)
+		if errors.Is(err, types.RecordNotExist) {
+			continue
+		}
+		if err != nil {
+			return err
+		}
+		if task.State != model.TaskStatePending {
+			continue
+		}
+		if err := q.store.TaskDelete(id); err != nil && !errors.Is(err, types.RecordNotExist) {
+			return err
+		}
+		evicted = true
+	}
+	if !evicted {
+		return ErrNotFound
+	}
+	return nil
+}
+
+// Wait waits until the item is done executing.
+func (q *database) Wait(ctx context.Context, taskID string) error {
+	for {
+		task, err := q.store.TaskLoad(taskID)
+		if errors.Is(err, types.RecordNotExist) {
+			return nil
+		}
+		if err != nil {
+			return err
+		}
+
+		switch task.State {
+		case model.TaskStateDone:
+			if task.Error == ErrCancel.Error() {
+				return ErrCancel
+			}
+			return errors.New(task.Error)
+		case model.TaskStateRunning:
+		default:
+			return nil
+		}
+
+		select {
+		case <-ctx.Done():
+			return nil
+		case <-time.After(q.interval):
+		}
+	}
+}
+
+// Extend extends the task execution deadline.
+func (q *database) Extend(_ context.Context, agentID int64, taskID string) error {
+	return q.update(taskID, func(task *model.Task) error {
+		if task.State != model.TaskStateRunning {
+			return ErrNotFound
+		}
+		if task.AgentID != agentID {
+			return ErrAgentMissMatch
+		}
+		task.Deadline = time.Now().Add(q.extension).Unix()
+		return nil
+	})
+}
+
+// Info returns internal queue information.
+// Only workers connected to this server instance are counted.
+func (q *database) Info(_ context.Context) InfoT {
+	stats := InfoT{}
+
+	tasks, err := q.store.TaskList()
+	if err != nil {
+		log.Error().Err(err).Msg("queue: cannot list tasks")
+	}
+
+	for _, task := range tasks {
+		switch task.State {
+		case model.TaskStateRunning:
+			stats.Running = append(stats.Running, task)
+		case model.TaskStatePending:
+			if depsInTasks(task, tasks) {
+				stats.WaitingOnDeps = append(stats.WaitingOnDeps, task)
+			} else {
+				stats.Pending = append(stats.Pending, task)
+			}
+		}
+	}
+
+	q.Lock()
+	stats.Stats.Workers = len(q.workers)
+	q.Unlock()
+	stats.Stats.Pending = len(stats.Pending)
+	stats.Stats.WaitingOnDeps = len(stats.WaitingOnDeps)
+	stats.Stats.Running = len(stats.Running)
+	stats.Paused = q.paused()
+
+	return stats
+}
+
+// Pause stops all server instances from handing out new work items in Poll.
+func (q *database) Pause() {
+	if err := q.store.ServerConfigSet(pausedServerConfigKey, "true"); err != nil {
+		log.Error().Err(err).Msg("queue: cannot pause")
+	}
+}
+
+// Resume starts the queue again.
+func (q *database) Resume() {
+	if err := q.store.ServerConfigDelete(pausedServerConfigKey); err != nil && !errors.Is(err, types.RecordNotExist) {
+		log.Error().Err(err).Msg("queue: cannot resume")
+	}
+}
+
+// KickAgentWorkers kicks all workers for a given agent
+// which are connected to this server instance.
+func (q *database) KickAgentWorkers(agentID int64) {
+	q.Lock()
+	defer q.Unlock()
+
+	for worker := range q.workers {
+		if worker.agentID == agentID {
+			worker.stop(ErrWorkerKicked)
+			delete(q.workers, worker)
+		}
+	}
+}
+
+func (q *database) paused() bool {
+	value, err := q.store.ServerConfigGet(pausedServerConfigKey)
+	if err != nil && !errors.Is(err, types.RecordNotExist) {
+		log.Error().Err(err).Msg("queue: cannot get paused state")
+	}
+	return value == "true"
+}
+
+// helper function that loops through the queue and attempts to
+// match the item to a single subscriber until context got cancel.
+func (q *database) process() {
+	for {
+		select {
+		case <-time.After(q.interval):
+		case <-q.ctx.Done():
+			return
+		}
+
+		tasks, err := q.store.TaskList()
+		if err != nil {
+			log.Error().Err(err).Msg("queue: cannot list tasks")
+			continue
+		}
+
+		q.cleanupExpired(tasks)
+
+		if q.paused() {
+			continue
+		}
+
+		q.Lock()
+		q.assignToWorkers(tasks)
+		q.Unlock()
+	}
+}
+
+// cleanupExpired resubmits running tasks whose deadline passed and removes
+// tasks which finished with an error and are not of interest for Wait anymore.
+func (q *database) cleanupExpired(tasks []*model.Task) {
+	now := time.Now().Unix()
+	for _, task := range tasks {
+		if task.Deadline == 0 || now <= task.Deadline {
+			continue
+		}
+
+		switch task.State {
+		case model.TaskStateRunning:
+			task.State = model.TaskStatePending
+			task.Deadline = 0
+			// another server may have extended or resubmitted the task already
+			if err := q.store.TaskUpdate(task); err != nil && !errors.Is(err, types.RecordNotExist) {
+				log.Error().Err(err).Msgf("queue: cannot resubmit expired task %s", task.ID)
+			}
+		case model.TaskStateDone:
+			if err := q.store.TaskDelete(task.ID); err != nil && !errors.Is(err, types.RecordNotExist) {
+				log.Error().Err(err).Msgf("queue: cannot remove finished task %s", task.ID)
+			}
+		}
+	}
+}
+
+func (q *database) assignToWorkers(tasks []*model.Task) {
+	for _, task := range tasks {
+		if len(q.workers) == 0 {
+			return
+		}
+		if task.State != model.TaskStatePending {
+			continue
+		}
+		if depsInTasks(task, tasks) {
+			log.Debug().Msgf("queue: waiting due to unmet dependencies %v", task.ID)
+			continue
+		}
+		if concurrencyLimited(task, runningTasks(tasks)) {
+			log.Debug().Msgf("queue: waiting due to concurrency limit %v", task.ID)
+			continue
+		}
+
+		var bestWorker *worker
+		var bestScore int
+		for worker := range q.workers {
+			matched, score := worker.filter(task)
+			if matched && score > bestScore {
+				bestWorker = worker
+				bestScore = score
+			}
+		}
+		if bestWorker == nil {
+			continue
+		}
+
+		task.State = model.TaskStateRunning
+		task.AgentID = bestWorker.agentID
+		task.Deadline = time.Now().Add(q.extension).Unix()
+		if err := q.store.TaskUpdate(task); err != nil {
+			if !errors.Is(err, types.RecordNotExist) {
+				log.Error().Err(err).Msgf("queue: cannot assign task %s", task.ID)
+			}
+			// the task got assigned by another server in the meantime
+			continue
+		}
+
+		log.Debug().Msgf("queue: assigned task: %v with deps %v to worker with score %d", task.ID, task.Dependencies, bestScore)
+		delete(q.workers, bestWorker)
+		bestWorker.channel <- task
+	}
+}
+
+// update loads the task, applies fn and writes it back. If the task was changed
+// by someone else in between, this is retried.
+func (q *database) update(taskID string, fn func(*model.Task) error) error {
+	for i := 0; i < databaseUpdateRetries; i++ {
+		task, err := q.store.TaskLoad(taskID)
+		if errors.Is(err, types.RecordNotExist) {
+			return ErrNotFound
+		}
+		if err != nil {
+			return err
+		}
+
+		if err := fn(task); err != nil {
+			return err
+		}
+
+		err = q.store.TaskUpdate(task)
+		if !errors.Is(err, types.RecordNotExist) {
+			return err
+		}
+	}
+	return fmt.Errorf("queue: task %s was concurrently modified too often", taskID)
+}
+
+func (q *database) updateDepStatus(taskID string, status model.StatusValue) error {
+	tasks, err := q.store.TaskList()
+	if err != nil {
+		return err
+	}
+
+	for _, task := range tasks {
+		if task.State == model.TaskStateDone || !slices.Contains(task.Dependencies, taskID) {
+			continue
+		}
+		if err := q.update(task.ID, func(task *model.Task) error {
+			if task.DepStatus == nil {
+				task.DepStatus = make(map[string]model.StatusValue)
+			}
+			task.DepStatus[taskID] = status
+			return nil
+		}); err != nil && !errors.Is(err, ErrNotFound) {
+			return err
+		}
+	}
+	return nil
+}
+
+// depsInTasks reports whether one of the dependencies of the task is still pending or running.
+func depsInTasks(task *model.Task, tasks []*model.Task) bool {
+	for _, possibleDep := range tasks {
+		if possibleDep.State == model.TaskStateDone {
+			continue
+		}
+		if slices.Contains(task.Dependencies, possibleDep.ID) {
+			return true
+		}
+	}
+	return false
+}
+
+func runningTasks(tasks []*model.Task) []*model.Task {
+	var running []*model.Task
+	for _, task := range tasks {
+		if task.State == model.TaskStateRunning {
+			running = append(running, task)
+		}
+	}
+	return running
+}
@@ -0,0 +1,194 @@
+// Copyright 2025 Woodpecker Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package queue
+
+import (
+	"context"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"go.woodpecker-ci.org/woodpecker/v3/server/model"
+	"go.woodpecker-ci.org/woodpecker/v3/server/store"
+	"go.woodpecker-ci.org/woodpecker/v3/server/store/datastore"
+)
+
+const testDatabaseInterval = 10 * time.Millisecond
+
+// newTestDatabaseQueues returns two queues sharing one database, like two server instances would do.
+func newTestDatabaseQueues(t *testing.T) (context.Context, *database, *database) {
+	ctx, cancel := context.WithCancelCause(context.Background())
+
+	s, err := datastore.NewEngine(&store.Opts{
+		Driver: "sqlite3",
+		Config: filepath.Join(t.TempDir(), "queue.sqlite"),
+	})
+	require.NoError(t, err)
+	require.NoError(t, s.Migrate(ctx, true))
+	// stop the queues before closing the store, running queries of them would create files in the temp dir
+	t.Cleanup(func() {
+		cancel(nil)
+		time.Sleep(5 * testDatabaseInterval)
+		_ = s.Close()
+	})
+
+	return ctx, newDatabaseQueue(ctx, s, testDatabaseInterval), newDatabaseQueue(ctx, s, testDatabaseInterval)
+}
+
+func pollWithTimeout(ctx context.Context, q Queue, agentID int64, f FilterFn) (*model.Task, error) {
+	ctx, cancel := context.WithTimeout(ctx, time.Second)
+	defer cancel()
+	return q.Poll(ctx, agentID, f)
+}
+
+func TestDatabaseQueue(t *testing.T) {
+	ctx, q1, q2 := newTestDatabaseQueues(t)
+
+	assert.NoError(t, q1.Push(ctx, genDummyTask()))
+	info := q2.Info(ctx)
+	assert.Len(t, info.Pending, 1, "expect task in pending queue of other server")
+
+	got, err := pollWithTimeout(ctx, q2, 1, filterFnTrue)
+	assert.NoError(t, err)
+	assert.Equal(t, "1", got.ID)
+	assert.Equal(t, "{}", string(got.Data))
+
+	info = q1.Info(ctx)
+	assert.Len(t, info.Pending, 0, "expect task removed from pending queue")
+	assert.Len(t, info.Running, 1, "expect task in running queue")
+
+	assert.ErrorIs(t, q1.Extend(ctx, 2, got.ID), ErrAgentMissMatch)
+	assert.NoError(t, q1.Extend(ctx, 1, got.ID))
+
+	assert.NoError(t, q1.Done(ctx, got.ID, model.StatusSuccess))
+	assert.NoError(t, q2.Wait(ctx, got.ID))
+	info = q2.Info(ctx)
+	assert.Len(t, info.Running, 0, "expect task removed from running queue")
+}
+
+func TestDatabaseQueueAssignOnce(t *testing.T) {
+	ctx, q1, q2 := newTestDatabaseQueues(t)
+
+	assert.NoError(t, q1.Push(ctx, genDummyTask()))
+
+	results := make(chan *model.Task, 2)
+	for _, q := range []*database{q1, q2} {
+		go func() {
+			task, _ := pollWithTimeout(ctx, q, 1, filterFnTrue)
+			results <- task
+		}()
+	}
+
+	assigned := 0
+	for range 2 {
+		if task := <-results; task != nil {
+			assigned++
+		}
+	}
+	assert.Equal(t, 1, assigned, "expect task to be assigned by only one server")
+}
+
+func TestDatabaseQueueCancel(t *testing.T) {
+	ctx, q1, q2 := newTestDatabaseQueues(t)
+
+	assert.NoError(t, q1.Push(ctx, genDummyTask()))
+	got, err := pollWithTimeout(ctx, q1, 1, filterFnTrue)
+	assert.NoError(t, err)
+
+	waitErr := make(chan error)
+	go func() {
+		waitErr <- q1.Wait(ctx, got.ID)
+	}()
+
+	assert.NoError(t, q2.Error(ctx, got.ID, ErrCancel))
+	assert.ErrorIs(t, <-waitErr, ErrCancel)
+}
+
+func TestDatabaseQueueDependencies(t *testing.T) {
+	ctx, q1, q2 := newTestDatabaseQueues(t)
+
+	task1 := &model.Task{ID: "1"}
+	task2 := &model.Task{
+		ID:           "2",
+		Dependencies: []string{"1"},
+		DepStatus:    make(map[string]model.StatusValue),
+	}
+	assert.NoError(t, q1.PushAtOnce(ctx, []*model.Task{task2, task1}))
+
+	info := q2.Info(ctx)
+	assert.Len(t, info.Pending, 1)
+	assert.Len(t, info.WaitingOnDeps, 1)
+
+	got, err := pollWithTimeout(ctx, q2, 1, filterFnTrue)
+	assert.NoError(t, err)
+	assert.Equal(t, task1.ID, got.ID)
+
+	assert.NoError(t, q2.Done(ctx, got.ID, model.StatusFailure))
+
+	got, err = pollWithTimeout(ctx, q1, 1, filterFnTrue)
+	assert.NoError(t, err)
+	assert.Equal(t, task2.ID, got.ID)
+	assert.Equal(t, model.StatusFailure, got.DepStatus[task1.ID])
+	assert.False(t, got.ShouldRun())
+}
+
+func TestDatabaseQueuePause(t *testing.T) {
+	ctx, q1, q2 := newTestDatabaseQueues(t)
+
+	q1.Pause()
+	assert.True(t, q2.Info(ctx).Paused)
+
+	assert.NoError(t, q1.Push(ctx, genDummyTask()))
+	_, err := pollWithTimeout(ctx, q2, 1, filterFnTrue)
+	assert.ErrorIs(t, err, context.DeadlineExceeded)
+
+	q2.Resume()
+	got, err := pollWithTimeout(ctx, q2, 1, filterFnTrue)
+	assert.NoError(t, err)
+	assert.Equal(t, "1", got.ID)
+}
+
+func TestDatabaseQueueEvict(t *testing.T) {
+	ctx, q1, q2 := newTestDatabaseQueues(t)
+
+	assert.NoError(t, q1.Push(ctx, genDummyTask()))
+	assert.NoError(t, q2.Evict(ctx, "1"))
+	assert.ErrorIs(t, q2.Evict(ctx, "1"), ErrNotFound)
+	assert.Len(t, q1.Info(ctx).Pending, 0)
+}
+
+func TestDatabaseQueueConcurrencyLimit(t *testing.T) {
+	ctx, q1, q2 := newTestDatabaseQueues(t)
+
+	task1 := &model.Task{ID: "1", RepoID: 1, PipelineID: 1, MaxConcurrentPipelines: 1}
+	task2 := &model.Task{ID: "2", RepoID: 1, PipelineID: 2, MaxConcurrentPipelines: 1}
+	assert.NoError(t, q1.PushAtOnce(ctx, []*model.Task{task1, task2}))
+
+	got, err := pollWithTimeout(ctx, q1, 1, filterFnTrue)
+	assert.NoError(t, err)
+	assert.Equal(t, task1.ID, got.ID)
+
+	_, err = pollWithTimeout(ctx, q2, 1, filterFnTrue)
+	assert.ErrorIs(t, err, context.DeadlineExceeded)
+	assert.Len(t, q2.Info(ctx).Pending, 1, "expect task of second pipeline to stay pending")
+
+	assert.NoError(t, q1.Done(ctx, task1.ID, model.StatusSuccess))
+	got, err = pollWithTimeout(ctx, q2, 1, filterFnTrue)
+	assert.NoError(t, err)
+	assert.Equal(t, task2.ID, got.ID)
+}
@@ -330,9 +330,18 @@ func (q *fifo) assignToWorker() (*list.Element, *worker) {
 	var bestWorker *worker
 	var bestScore int
 
+	running := make([]*model.Task, 0, len(q.running))
+	for _, e := range q.running {
+		running = append(running, e.item)
+	}
+
 	for element := q.pending.Front(); element != nil; element = next {
 		next = element.Next()
 		task, _ := element.Value.(*model.Task)
+		if concurrencyLimited(task, running) {
+			log.Debug().Msgf("queue: waiting due to concurrency limit %v", task.ID)
+			continue
+		}
 		log.Debug().Msgf("queue: trying to assign task: %v with deps %v", task.ID, task.Dependencies)
 
 		for worker := range q.workers {
@@ -686,3 +686,59 @@ func TestFifoWithScoring(t *testing.T) {
 		assert.Contains(t, expectedAgents, agentID, "Task %s should be assigned to one of the expected agents", taskID)
 	}
 }
+
+func TestFifoConcurrencyLimit(t *testing.T) {
+	ctx, cancel := context.WithCancelCause(context.Background())
+	t.Cleanup(func() { cancel(nil) })
+
+	task1 := &model.Task{ID: "1", RepoID: 1, PipelineID: 1, MaxConcurrentPipelines: 1}
+	task2 := &model.Task{ID: "2", RepoID: 1, PipelineID: 1, MaxConcurrentPipelines: 1}
+	task3 := &model.Task{ID: "3", RepoID: 1, PipelineID: 2, MaxConcurrentPipelines: 1}
+	task4 := &model.Task{ID: "4", RepoID: 2, PipelineID: 3, MaxConcurrentPipelines: 1}
+
+	q := NewMemoryQueue(ctx)
+	assert.NoError(t, q.PushAtOnce(ctx, []*model.Task{task1, task3, task2, task4}))
+
+	for _, want := range []string{"1", "2", "4"} {
+		got, err := pollWithTimeout(ctx, q, 1, filterFnTrue)
+		assert.NoError(t, err)
+		assert.Equal(t, want, got.ID)
+	}
+
+	_, err := pollWithTimeout(ctx, q, 1, filterFnTrue)
+	assert.ErrorIs(t, err, context.DeadlineExceeded)
+	info := q.Info(ctx)
+	assert.Len(t, info.Pending, 1, "expect task of second pipeline to stay pending")
+
+	assert.NoError(t, q.Done(ctx, task1.ID, model.StatusSuccess))
+	assert.NoError(t, q.Done(ctx, task2.ID, model.StatusSuccess))
+	got, err := pollWithTimeout(ctx, q, 1, filterFnTrue)
+	assert.NoError(t, err)
+	assert.Equal(t, task3.ID, got.ID)
+}
+
+func TestFifoConcurrencyGroup(t *testing.T) {
+	ctx, cancel := context.WithCancelCause(context.Background())
+	t.Cleanup(func() { cancel(nil) })
+
+	task1 := &model.Task{ID: "1", RepoID: 1, PipelineID: 1, ConcurrencyGroup: "deploy"}
+	task2 := &model.Task{ID: "2", RepoID: 1, PipelineID: 2, ConcurrencyGroup: "deploy"}
+	task3 := &model.Task{ID: "3", RepoID: 2, PipelineID: 3, ConcurrencyGroup: "deploy"}
+
+	q := NewMemoryQueue(ctx)
+	assert.NoError(t, q.PushAtOnce(ctx, []*model.Task{task1, task2, task3}))
+
+	for _, want := range []string{"1", "3"} {
+		got, err := pollWithTimeout(ctx, q, 1, filterFnTrue)
+		assert.NoError(t, err)
+		assert.Equal(t, want, got.ID)
+	}
+
+	_, err := pollWithTimeout(ctx, q, 1, filterFnTrue)
+	assert.ErrorIs(t, err, context.DeadlineExceeded)
+
+	assert.NoError(t, q.Done(ctx, task1.ID, model.StatusSuccess))
+	got, err := pollWithTimeout(ctx, q, 1, filterFnTrue)
+	assert.NoError(t, err)
+	assert.Equal(t, task2.ID, got.ID)
+}
@@ -130,7 +130,8 @@ type Config struct {
 type Type string
 
 const (
-	TypeMemory Type = "memory"
+	TypeMemory   Type = "memory"
+	TypeDatabase Type = "database"
 )
 
 // New creates a new queue based on the provided configuration.
@@ -143,6 +144,11 @@ func New(ctx context.Context, config Config) (Queue, error) {
 		if config.Store != nil {
 			q = WithTaskStore(ctx, q, config.Store)
 		}
+	case TypeDatabase:
+		if config.Store == nil {
+			return nil, fmt.Errorf("queue backend %s requires a store", config.Backend)
+		}
+		q = NewDatabaseQueue(ctx, config.Store)
 	default:
 		return nil, fmt.Errorf("unsupported queue backend: %s", config.Backend)
 	}
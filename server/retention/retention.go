@@ -0,0 +1,245 @@
+// Copyright 2025 Woodpecker Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package retention removes old pipelines, logs and artifacts according to the
+// retention policies of the server, organizations and repositories.
+package retention
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io/fs"
+	"time"
+
+	"github.com/rs/zerolog/log"
+
+	"go.woodpecker-ci.org/woodpecker/v3/server/model"
+	"go.woodpecker-ci.org/woodpecker/v3/server/services/artifact"
+	logService "go.woodpecker-ci.org/woodpecker/v3/server/services/log"
+	"go.woodpecker-ci.org/woodpecker/v3/server/store"
+	"go.woodpecker-ci.org/woodpecker/v3/server/store/types"
+)
+
+// Janitor applies the retention policies.
+type Janitor struct {
+	store         store.Store
+	logStore      logService.Service
+	artifactStore artifact.Service
+	defaults      model.RetentionPolicy
+	now           func() time.Time
+}
+
+// New creates a janitor using defaults as server wide retention policy.
+// Artifacts are removed together with the logs, artifactStore may be nil if no artifact store is configured.
+func New(store store.Store, logStore logService.Service, artifactStore artifact.Service, defaults model.RetentionPolicy) *Janitor {
+	return &Janitor{
+		store:         store,
+		logStore:      logStore,
+		artifactStore: artifactStore,
+		defaults:      defaults,
+		now:           time.Now,
+	}
+}
+
+// Run cleans up all active repositories every interval until the context is canceled.
+// A zero interval disables the clean up.
+func (j *Janitor) Run(ctx context.Context, interval time.Duration) error {
+	if interval <= 0 {
+		return nil
+	}
+
+	for {
+		select {
+		case <-ctx.Done():
+			return nil
+		case <-time.After(interval):
+			log.Trace().Msg("retention: clean up repositories")
+			if err := j.CleanAll(ctx); err != nil {
+				log.Error().Err(err).Msg("retention: clean up failed")
+			}
+		}
+	}
+}
+
+// DryRunAll reports what would be removed from all active repositories.
+// Repositories without anything to remove are omitted.
+func (j *Janitor) DryRunAll() ([]*model.RetentionReport, error) {
+	repos, err := j.store.RepoListAll(true, &model.ListOptions{All: true})
+	if err != nil {
+		return nil, err
+	}
+
+	reports := make([]*model.RetentionReport, 0, len(repos))
+	for _, repo := range repos {
+		report, err := j.DryRun(repo)
+		if err != nil {
+			return nil, err
+		}
+		if !report.Empty() {
+			reports = append(reports, report)
+		}
+	}
+	return reports, nil
+}
+
+// CleanAll removes the pipelines and logs of all active repositories.
+func (j *Janitor) CleanAll(ctx context.Context) error {
+	repos, err := j.store.RepoListAll(true, &model.ListOptions{All: true})
+	if err != nil {
+		return err
+	}
+
+	for _, repo := range repos {
+		if ctx.Err() != nil {
+			return nil
+		}
+		report, err := j.Clean(repo)
+		if err != nil {
+			log.Error().Err(err).Msgf("retention: cannot clean up repo %s", repo.FullName)
+			continue
+		}
+		if !report.Empty() {
+			log.Debug().Msgf("retention: deleted %d pipelines and logs of %d pipelines of repo %s", len(report.Pipelines), len(report.Logs), repo.FullName)
+		}
+	}
+	return nil
+}
+
+// Policy returns the effective retention policy of the repository.
+func (j *Janitor) Policy(repo *model.Repo) (model.RetentionPolicy, error) {
+	policy := repo.Retention
+	if repo.OrgID != 0 {
+		org, err := j.store.OrgGet(repo.OrgID)
+		if err != nil && !errors.Is(err, types.RecordNotExist) {
+			return policy, err
+		}
+		if org != nil {
+			policy = policy.Inherit(org.Retention)
+		}
+	}
+	return policy.Inherit(j.defaults), nil
+}
+
+// DryRun reports what would be removed from the repository without removing anything.
+func (j *Janitor) DryRun(repo *model.Repo) (*model.RetentionReport, error) {
+	report, _, err := j.plan(repo)
+	return report, err
+}
+
+// Clean removes the pipelines and logs of the repository according to its retention policy.
+func (j *Janitor) Clean(repo *model.Repo) (*model.RetentionReport, error) {
+	report, pipelines, err := j.plan(repo)
+	if err != nil {
+		return nil, err
+	}
+
+	done := &model.RetentionReport{RepoID: report.RepoID, Policy: report.Policy}
+	for _, number := range report.Pipelines {
+		pipeline := pipelines[number]
+		if err := j.deleteLogs(pipeline); err != nil {
+			return done, err
+		}
+		if err := j.store.DeletePipeline(pipeline); err != nil {
+			return done, fmt.Errorf("delete pipeline %d: %w", number, err)
+		}
+		done.Pipelines = append(done.Pipelines, number)
+	}
+
+	for _, number := range report.Logs {
+		pipeline := pipelines[number]
+		if err := j.deleteLogs(pipeline); err != nil {
+			return done, err
+		}
+		pipeline.LogsDeleted = true
+		if err := j.store.UpdatePipeline(pipeline); err != nil {
+			return done, fmt.Errorf("update pipeline %d: %w", number, err)
+		}
+		done.Logs = append(done.Logs, number)
+	}
+
+	return done, nil
+}
+
+// plan collects the pipelines to remove, it returns the report and the
+// affected pipelines by their number.
+func (j *Janitor) plan(repo *model.Repo) (*model.RetentionReport, map[int64]*model.Pipeline, error) {
+	policy, err := j.Policy(repo)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	report := &model.RetentionReport{
+		RepoID:    repo.ID,
+		Policy:    policy,
+		Pipelines: []int64{},
+		Logs:      []int64{},
+	}
+	affected := make(map[int64]*model.Pipeline)
+	if policy.KeepPipelinesPerBranch <= 0 && policy.LogMaxAgeDays <= 0 {
+		return report, affected, nil
+	}
+
+	// newest pipelines first
+	pipelines, err := j.store.GetPipelineList(repo, &model.ListOptions{All: true}, nil)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	logsBefore := j.now().Add(-time.Duration(policy.LogMaxAgeDays) * 24 * time.Hour).Unix() //nolint:mnd
+	perBranch := make(map[string]int64)
+	for _, pipeline := range pipelines {
+		perBranch[pipeline.Branch]++
+		if !finished(pipeline) {
+			continue
+		}
+
+		switch {
+		case policy.KeepPipelinesPerBranch > 0 && perBranch[pipeline.Branch] > policy.KeepPipelinesPerBranch:
+			report.Pipelines = append(report.Pipelines, pipeline.Number)
+			affected[pipeline.Number] = pipeline
+		case policy.LogMaxAgeDays > 0 && !pipeline.LogsDeleted && pipeline.Finished != 0 && pipeline.Finished < logsBefore:
+			report.Logs = append(report.Logs, pipeline.Number)
+			affected[pipeline.Number] = pipeline
+		}
+	}
+
+	return report, affected, nil
+}
+
+func (j *Janitor) deleteLogs(pipeline *model.Pipeline) error {
+	steps, err := j.store.StepList(pipeline)
+	if err != nil {
+		return err
+	}
+	for _, step := range steps {
+		// steps which never ran, e.g. skipped ones, have no logs
+		if lErr := j.logStore.LogDelete(step); lErr != nil && !errors.Is(lErr, fs.ErrNotExist) && !errors.Is(lErr, types.RecordNotExist) {
+			err = errors.Join(err, lErr)
+		}
+	}
+	if err != nil {
+		return fmt.Errorf("delete logs of pipeline %d: %w", pipeline.Number, err)
+	}
+	return artifact.DeletePipelineArtifacts(j.artifactStore, j.store, pipeline)
+}
+
+// finished returns true if the pipeline is not running or waiting to run anymore.
+func finished(pipeline *model.Pipeline) bool {
+	switch pipeline.Status {
+	case model.StatusRunning, model.StatusPending, model.StatusBlocked:
+		return false
+	}
+	return true
+}
@@ -0,0 +1,153 @@
+// Copyright 2025 Woodpecker Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package retention
+
+import (
+	"context"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"go.woodpecker-ci.org/woodpecker/v3/server/model"
+	"go.woodpecker-ci.org/woodpecker/v3/server/services/log/file"
+	"go.woodpecker-ci.org/woodpecker/v3/server/store"
+	"go.woodpecker-ci.org/woodpecker/v3/server/store/datastore"
+)
+
+func newTestStore(t *testing.T) store.Store {
+	s, err := datastore.NewEngine(&store.Opts{
+		Driver: "sqlite3",
+		Config: filepath.Join(t.TempDir(), "retention.sqlite"),
+	})
+	require.NoError(t, err)
+	require.NoError(t, s.Migrate(context.Background(), true))
+	t.Cleanup(func() { _ = s.Close() })
+	return s
+}
+
+func createPipeline(t *testing.T, s store.Store, repo *model.Repo, branch string, status model.StatusValue, finished time.Time) *model.Pipeline {
+	pipeline := &model.Pipeline{
+		RepoID:   repo.ID,
+		Branch:   branch,
+		Status:   status,
+		Finished: finished.Unix(),
+	}
+	step := &model.Step{UUID: branch + string(status) + finished.String(), Name: "build", State: status}
+	require.NoError(t, s.CreatePipeline(pipeline, step))
+	require.NoError(t, s.LogAppend(step, []*model.LogEntry{{StepID: step.ID, Data: []byte("hello")}}))
+	require.NoError(t, s.ArtifactCreate(&model.Artifact{PipelineID: pipeline.ID, StepID: step.ID, Name: "app", Size: 1}))
+	return pipeline
+}
+
+func TestPolicy(t *testing.T) {
+	s := newTestStore(t)
+	org := &model.Org{Name: "org", Retention: model.RetentionPolicy{LogMaxAgeDays: 7}}
+	require.NoError(t, s.OrgCreate(org))
+
+	j := New(s, s, nil, model.RetentionPolicy{KeepPipelinesPerBranch: 10, LogMaxAgeDays: 30})
+
+	policy, err := j.Policy(&model.Repo{OrgID: org.ID, Retention: model.RetentionPolicy{KeepPipelinesPerBranch: -1}})
+	assert.NoError(t, err)
+	assert.Equal(t, model.RetentionPolicy{KeepPipelinesPerBranch: -1, LogMaxAgeDays: 7}, policy)
+
+	policy, err = j.Policy(&model.Repo{})
+	assert.NoError(t, err)
+	assert.Equal(t, model.RetentionPolicy{KeepPipelinesPerBranch: 10, LogMaxAgeDays: 30}, policy)
+}
+
+func TestClean(t *testing.T) {
+	s := newTestStore(t)
+	repo := &model.Repo{FullName: "org/repo", Owner: "org", Name: "repo", IsActive: true}
+	require.NoError(t, s.CreateRepo(repo))
+
+	now := time.Now()
+	old := now.Add(-10 * 24 * time.Hour)
+	createPipeline(t, s, repo, "main", model.StatusSuccess, old) // 1: deleted, more than 2 newer on main
+	createPipeline(t, s, repo, "main", model.StatusRunning, now) // 2: kept, still running
+	createPipeline(t, s, repo, "dev", model.StatusFailure, old)  // 3: logs deleted, too old
+	createPipeline(t, s, repo, "main", model.StatusSuccess, old) // 4: logs deleted, too old
+	createPipeline(t, s, repo, "main", model.StatusSuccess, now) // 5: kept
+
+	j := New(s, s, nil, model.RetentionPolicy{KeepPipelinesPerBranch: 2, LogMaxAgeDays: 5})
+
+	report, err := j.DryRun(repo)
+	assert.NoError(t, err)
+	assert.Equal(t, []int64{1}, report.Pipelines)
+	assert.Equal(t, []int64{4, 3}, report.Logs)
+
+	// dry run does not remove anything
+	count, err := s.GetPipelineCount()
+	assert.NoError(t, err)
+	assert.EqualValues(t, 5, count)
+
+	report, err = j.Clean(repo)
+	assert.NoError(t, err)
+	assert.Equal(t, []int64{1}, report.Pipelines)
+	assert.Equal(t, []int64{4, 3}, report.Logs)
+
+	_, err = s.GetPipelineNumber(repo, 1)
+	assert.Error(t, err)
+
+	pipeline, err := s.GetPipelineNumber(repo, 4)
+	require.NoError(t, err)
+	assert.True(t, pipeline.LogsDeleted)
+	steps, err := s.StepList(pipeline)
+	require.NoError(t, err)
+	logs, err := s.LogFind(steps[0])
+	assert.NoError(t, err)
+	assert.Empty(t, logs)
+	artifacts, err := s.ArtifactList(pipeline)
+	assert.NoError(t, err)
+	assert.Empty(t, artifacts)
+
+	pipeline, err = s.GetPipelineNumber(repo, 5)
+	require.NoError(t, err)
+	steps, err = s.StepList(pipeline)
+	require.NoError(t, err)
+	logs, err = s.LogFind(steps[0])
+	assert.NoError(t, err)
+	assert.Len(t, logs, 1)
+	artifacts, err = s.ArtifactList(pipeline)
+	assert.NoError(t, err)
+	assert.Len(t, artifacts, 1)
+
+	// nothing is left to clean up
+	report, err = j.DryRun(repo)
+	assert.NoError(t, err)
+	assert.True(t, report.Empty())
+}
+
+func TestCleanMissingLogs(t *testing.T) {
+	s := newTestStore(t)
+	repo := &model.Repo{FullName: "org/repo", Owner: "org", Name: "repo", IsActive: true}
+	require.NoError(t, s.CreateRepo(repo))
+	createPipeline(t, s, repo, "main", model.StatusSkipped, time.Now().Add(-10*24*time.Hour))
+
+	// the file log store has no log of the step
+	logStore, err := file.NewLogStore(t.TempDir(), file.CompressionNone, 0)
+	require.NoError(t, err)
+	j := New(s, logStore, nil, model.RetentionPolicy{LogMaxAgeDays: 5})
+
+	report, err := j.Clean(repo)
+	assert.NoError(t, err)
+	assert.Equal(t, []int64{1}, report.Logs)
+
+	pipeline, err := s.GetPipelineNumber(repo, 1)
+	require.NoError(t, err)
+	assert.True(t, pipeline.LogsDeleted)
+}
@@ -35,6 +35,9 @@ func apiRoutes(e *gin.RouterGroup) {
 			user.GET("/repos", api.GetRepos)
 			user.POST("/token", api.PostToken)
 			user.DELETE("/token", api.DeleteToken)
+			user.GET("/tokens", api.GetPersonalAccessTokens)
+			user.POST("/tokens", api.PostPersonalAccessToken)
+			user.DELETE("/tokens/:token_id", api.DeletePersonalAccessToken)
 		}
 
 		users := apiBase.Group("/users")
@@ -61,6 +64,7 @@ func apiRoutes(e *gin.RouterGroup) {
 				org := orgBase.Group("")
 				{
 					org.Use(session.MustOrgMember(true))
+					org.PATCH("", api.PatchOrg)
 					org.DELETE("", session.MustAdmin(), api.DeleteOrg)
 
 					org.GET("/secrets", api.GetOrgSecretList)
@@ -75,6 +79,14 @@ func apiRoutes(e *gin.RouterGroup) {
 					org.PATCH("/registries/:registry", api.PatchOrgRegistry)
 					org.DELETE("/registries/:registry", api.DeleteOrgRegistry)
 
+					org.GET("/webhooks", api.GetOrgWebhookList)
+					org.POST("/webhooks", api.PostOrgWebhook)
+					org.GET("/webhooks/:webhook_id", api.GetOrgWebhook)
+					org.PATCH("/webhooks/:webhook_id", api.PatchOrgWebhook)
+					org.DELETE("/webhooks/:webhook_id", api.DeleteOrgWebhook)
+					org.GET("/webhooks/:webhook_id/deliveries", api.GetOrgWebhookDeliveries)
+					org.POST("/webhooks/:webhook_id/deliveries/:delivery_id/redeliver", api.PostOrgWebhookRedelivery)
+
 					if !server.Config.Agent.DisableUserRegisteredAgentRegistration {
 						org.GET("/agents", api.GetOrgAgents)
 						org.POST("/agents", api.PostOrgAgent)
@@ -106,6 +118,8 @@ func apiRoutes(e *gin.RouterGroup) {
 
 					repo.GET("/branches", api.GetRepoBranches)
 					repo.GET("/pull_requests", api.GetRepoPullRequests)
+					repo.GET("/stats", api.GetRepoStats)
+					repo.GET("/flaky_steps", api.GetRepoFlakySteps)
 
 					repo.GET("/pipelines", api.GetPipelines)
 					repo.POST("/pipelines", session.MustPush, api.CreatePipeline)
@@ -113,6 +127,8 @@ func apiRoutes(e *gin.RouterGroup) {
 					repo.GET("/pipelines/:number", api.GetPipeline)
 					repo.GET("/pipelines/:number/config", api.GetPipelineConfig)
 					repo.GET("/pipelines/:number/metadata", session.MustPush, api.GetPipelineMetadata)
+					repo.GET("/pipelines/:number/artifacts", api.GetPipelineArtifacts)
+					repo.GET("/pipelines/:number/artifacts/:artifact_id", api.GetPipelineArtifact)
 
 					// requires push permissions
 					repo.POST("/pipelines/:number", session.MustPush, api.PostPipeline)
@@ -140,6 +156,10 @@ func apiRoutes(e *gin.RouterGroup) {
 					repo.PATCH("/registries/:registry", session.MustPush, api.PatchRegistry)
 					repo.DELETE("/registries/:registry", session.MustPush, api.DeleteRegistry)
 
+					repo.GET("/environments", api.GetEnvironmentList)
+					repo.GET("/environments/:environment", api.GetEnvironment)
+					repo.GET("/environments/:environment/deployments", api.GetEnvironmentDeployments)
+
 					// requires push permissions
 					repo.GET("/cron", session.MustPush, api.GetCronList)
 					repo.POST("/cron", session.MustPush, api.PostCron)
@@ -154,6 +174,22 @@ func apiRoutes(e *gin.RouterGroup) {
 					repo.POST("/chown", session.MustRepoAdmin(), api.ChownRepo)
 					repo.POST("/repair", session.MustRepoAdmin(), api.RepairRepo)
 					repo.POST("/move", session.MustRepoAdmin(), api.MoveRepo)
+					repo.GET("/retention/dry-run", session.MustRepoAdmin(), api.GetRepoRetentionDryRun)
+					repo.POST("/environments", session.MustRepoAdmin(), api.PostEnvironment)
+					repo.PATCH("/environments/:environment", session.MustRepoAdmin(), api.PatchEnvironment)
+					repo.DELETE("/environments/:environment", session.MustRepoAdmin(), api.DeleteEnvironment)
+					repo.GET("/environments/:environment/secrets", session.MustRepoAdmin(), api.GetEnvironmentSecretList)
+					repo.POST("/environments/:environment/secrets", session.MustRepoAdmin(), api.PostEnvironmentSecret)
+					repo.GET("/environments/:environment/secrets/:secret", session.MustRepoAdmin(), api.GetEnvironmentSecret)
+					repo.PATCH("/environments/:environment/secrets/:secret", session.MustRepoAdmin(), api.PatchEnvironmentSecret)
+					repo.DELETE("/environments/:environment/secrets/:secret", session.MustRepoAdmin(), api.DeleteEnvironmentSecret)
+					repo.GET("/webhooks", session.MustRepoAdmin(), api.GetWebhookList)
+					repo.POST("/webhooks", session.MustRepoAdmin(), api.PostWebhook)
+					repo.GET("/webhooks/:webhook_id", session.MustRepoAdmin(), api.GetWebhook)
+					repo.PATCH("/webhooks/:webhook_id", session.MustRepoAdmin(), api.PatchWebhook)
+					repo.DELETE("/webhooks/:webhook_id", session.MustRepoAdmin(), api.DeleteWebhook)
+					repo.GET("/webhooks/:webhook_id/deliveries", session.MustRepoAdmin(), api.GetWebhookDeliveries)
+					repo.POST("/webhooks/:webhook_id/deliveries/:delivery_id/redeliver", session.MustRepoAdmin(), api.PostWebhookRedelivery)
 				}
 			}
 		}
@@ -185,6 +221,12 @@ func apiRoutes(e *gin.RouterGroup) {
 			queue.GET("/norunningpipelines", api.BlockTilQueueHasRunningItem)
 		}
 
+		retention := apiBase.Group("/retention")
+		{
+			retention.Use(session.MustAdmin())
+			retention.GET("/dry-run", api.GetRetentionDryRun)
+		}
+
 		// global secrets can be read without actual values by any user
 		readGlobalSecrets := apiBase.Group("/secrets")
 		{
@@ -215,6 +257,12 @@ func apiRoutes(e *gin.RouterGroup) {
 			registries.DELETE("/:registry", api.DeleteGlobalRegistry)
 		}
 
+		auditEvents := apiBase.Group("/audit-events")
+		{
+			auditEvents.Use(session.MustAdmin())
+			auditEvents.GET("", api.GetAuditEvents)
+		}
+
 		logLevel := apiBase.Group("/log-level")
 		{
 			logLevel.Use(session.MustAdmin())
@@ -244,6 +292,7 @@ func apiRoutes(e *gin.RouterGroup) {
 		}
 
 		apiBase.GET("/signature/public-key", session.MustUser(), api.GetSignaturePublicKey)
+		apiBase.GET("/signature/user-public-key", session.MustUser(), api.GetUserSignaturePublicKey)
 
 		apiBase.POST("/hook", api.PostHook)
 
@@ -67,6 +67,11 @@ func SetRepo() gin.HandlerFunc {
 		}
 
 		if repo != nil && err == nil {
+			if pat := PersonalAccessToken(c); pat != nil && pat.RepoID != 0 && pat.RepoID != repo.ID {
+				c.String(http.StatusForbidden, "Token is restricted to another repository")
+				c.Abort()
+				return
+			}
 			c.Set("repo", repo)
 			c.Next()
 			return
@@ -0,0 +1,119 @@
+// Copyright 2025 Woodpecker Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package session
+
+import (
+	"errors"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/rs/zerolog/log"
+
+	"go.woodpecker-ci.org/woodpecker/v3/server/model"
+	"go.woodpecker-ci.org/woodpecker/v3/server/store"
+	"go.woodpecker-ci.org/woodpecker/v3/shared/token"
+)
+
+// lastUsedInterval limits how often the last usage of a personal access token is saved.
+const lastUsedInterval = time.Minute
+
+var errTokenUserMismatch = errors.New("personal access token belongs to another user")
+
+// PersonalAccessToken returns the personal access token the request was authenticated with or nil.
+func PersonalAccessToken(c *gin.Context) *model.PersonalAccessToken {
+	v, ok := c.Get("token")
+	if !ok {
+		return nil
+	}
+	t, ok := v.(*model.PersonalAccessToken)
+	if !ok {
+		return nil
+	}
+	return t
+}
+
+func findPersonalAccessToken(c *gin.Context, t *token.Token, user *model.User) (*model.PersonalAccessToken, error) {
+	tokenID, err := strconv.ParseInt(t.Get("token-id"), 10, 64)
+	if err != nil {
+		return nil, err
+	}
+	pat, err := store.FromContext(c).PersonalAccessTokenFind(tokenID)
+	if err != nil {
+		return nil, err
+	}
+	if pat.UserID != user.ID {
+		return nil, errTokenUserMismatch
+	}
+	return pat, nil
+}
+
+// checkPersonalAccessToken aborts the request if the personal access token is expired
+// or its scopes do not allow the request.
+func checkPersonalAccessToken(c *gin.Context, pat *model.PersonalAccessToken) bool {
+	if pat.Expired() {
+		c.String(http.StatusUnauthorized, "Token expired")
+		c.Abort()
+		return false
+	}
+
+	// otherwise a token could issue the user token or a token without its scopes and expiry
+	if path := c.FullPath(); path == "/api/user/token" || strings.HasPrefix(path, "/api/user/tokens") {
+		c.String(http.StatusForbidden, "Personal access tokens cannot manage tokens")
+		c.Abort()
+		return false
+	}
+
+	if !pat.Allows(requiredScope(c)) {
+		c.String(http.StatusForbidden, "Token scopes do not allow this request")
+		c.Abort()
+		return false
+	}
+
+	// repository restricted tokens can only be used for repository routes, the repository itself is checked by SetRepo
+	if pat.RepoID != 0 && c.Param("repo_id") == "" && c.Param("repo_full_name") == "" {
+		c.String(http.StatusForbidden, "Token is restricted to a repository")
+		c.Abort()
+		return false
+	}
+
+	if now := time.Now(); now.Sub(time.Unix(pat.LastUsed, 0)) > lastUsedInterval {
+		pat.LastUsed = now.Unix()
+		if err := store.FromContext(c).PersonalAccessTokenUpdate(pat); err != nil {
+			log.Error().Err(err).Msgf("could not update last usage of personal access token %d", pat.ID)
+		}
+	}
+	return true
+}
+
+// requiredScope returns the token scope needed for the matched route.
+func requiredScope(c *gin.Context) model.TokenScope {
+	switch c.Request.Method {
+	case http.MethodGet, http.MethodHead, http.MethodOptions:
+		return model.TokenScopeRead
+	}
+
+	path := c.FullPath()
+	switch {
+	case strings.Contains(path, "/secrets") || strings.Contains(path, "/registries"):
+		return model.TokenScopeSecretsAdmin
+	case strings.HasPrefix(path, "/api/repos/:repo_id/pipelines") || strings.HasPrefix(path, "/api/repos/:repo_id/logs"):
+		return model.TokenScopePipelineWrite
+	default:
+		return model.TokenScopeAll
+	}
+}
@@ -0,0 +1,98 @@
+// Copyright 2025 Woodpecker Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package session
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"strconv"
+	"testing"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+
+	"go.woodpecker-ci.org/woodpecker/v3/server/model"
+	store_mocks "go.woodpecker-ci.org/woodpecker/v3/server/store/mocks"
+	"go.woodpecker-ci.org/woodpecker/v3/shared/token"
+)
+
+func TestPersonalAccessTokenScopes(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	user := &model.User{ID: 1, Hash: "user-hash"}
+
+	tests := []struct {
+		name   string
+		token  model.PersonalAccessToken
+		method string
+		path   string
+		status int
+	}{
+		{"read allows get", model.PersonalAccessToken{Scopes: []model.TokenScope{model.TokenScopeRead}}, http.MethodGet, "/api/repos/5/pipelines", http.StatusOK},
+		{"read denies pipeline creation", model.PersonalAccessToken{Scopes: []model.TokenScope{model.TokenScopeRead}}, http.MethodPost, "/api/repos/5/pipelines", http.StatusForbidden},
+		{"pipeline write allows pipeline creation", model.PersonalAccessToken{Scopes: []model.TokenScope{model.TokenScopePipelineWrite}}, http.MethodPost, "/api/repos/5/pipelines", http.StatusOK},
+		{"pipeline write denies secrets", model.PersonalAccessToken{Scopes: []model.TokenScope{model.TokenScopePipelineWrite}}, http.MethodPost, "/api/repos/5/secrets", http.StatusForbidden},
+		{"secrets admin allows secrets", model.PersonalAccessToken{Scopes: []model.TokenScope{model.TokenScopeSecretsAdmin}}, http.MethodPost, "/api/repos/5/secrets", http.StatusOK},
+		{"all allows everything", model.PersonalAccessToken{Scopes: []model.TokenScope{model.TokenScopeAll}}, http.MethodPost, "/api/repos/5/secrets", http.StatusOK},
+		{"all denies user token", model.PersonalAccessToken{Scopes: []model.TokenScope{model.TokenScopeAll}}, http.MethodPost, "/api/user/token", http.StatusForbidden},
+		{"all denies token creation", model.PersonalAccessToken{Scopes: []model.TokenScope{model.TokenScopeAll}}, http.MethodPost, "/api/user/tokens", http.StatusForbidden},
+		{"all denies token listing", model.PersonalAccessToken{Scopes: []model.TokenScope{model.TokenScopeAll}}, http.MethodGet, "/api/user/tokens", http.StatusForbidden},
+		{"all denies token removal", model.PersonalAccessToken{Scopes: []model.TokenScope{model.TokenScopeAll}}, http.MethodDelete, "/api/user/tokens/3", http.StatusForbidden},
+		{"expired token", model.PersonalAccessToken{Scopes: []model.TokenScope{model.TokenScopeAll}, Expires: time.Now().Add(-time.Hour).Unix()}, http.MethodGet, "/api/user", http.StatusUnauthorized},
+		{"repo restriction allows repo routes", model.PersonalAccessToken{Scopes: []model.TokenScope{model.TokenScopeRead}, RepoID: 5}, http.MethodGet, "/api/repos/5/pipelines", http.StatusOK},
+		{"repo restriction denies other routes", model.PersonalAccessToken{Scopes: []model.TokenScope{model.TokenScopeRead}, RepoID: 5}, http.MethodGet, "/api/user", http.StatusForbidden},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			pat := tt.token
+			pat.ID = 2
+			pat.UserID = user.ID
+			pat.Secret = "token-secret"
+
+			mockStore := store_mocks.NewStore(t)
+			mockStore.On("GetUser", user.ID).Return(user, nil)
+			mockStore.On("PersonalAccessTokenFind", pat.ID).Return(&pat, nil)
+			mockStore.On("PersonalAccessTokenUpdate", mock.Anything).Return(nil).Maybe()
+
+			e := gin.New()
+			e.Use(func(c *gin.Context) { c.Set("store", mockStore) }, SetUser())
+			ok := func(c *gin.Context) { c.Status(http.StatusOK) }
+			e.GET("/api/user", ok)
+			e.POST("/api/user/token", ok)
+			e.GET("/api/user/tokens", ok)
+			e.POST("/api/user/tokens", ok)
+			e.DELETE("/api/user/tokens/:token_id", ok)
+			e.GET("/api/repos/:repo_id/pipelines", ok)
+			e.POST("/api/repos/:repo_id/pipelines", ok)
+			e.POST("/api/repos/:repo_id/secrets", ok)
+
+			_token := token.New(token.PersonalToken)
+			_token.Set("user-id", strconv.FormatInt(user.ID, 10))
+			_token.Set("token-id", strconv.FormatInt(pat.ID, 10))
+			signed, err := _token.Sign(user.Hash + pat.Secret)
+			require.NoError(t, err)
+
+			w := httptest.NewRecorder()
+			req, _ := http.NewRequest(tt.method, tt.path, nil)
+			req.Header.Set("Authorization", "Bearer "+signed)
+			e.ServeHTTP(w, req)
+
+			assert.Equal(t, tt.status, w.Code)
+		})
+	}
+}
@@ -42,17 +42,34 @@ func User(c *gin.Context) *model.User {
 func SetUser() gin.HandlerFunc {
 	return func(c *gin.Context) {
 		var user *model.User
+		var pat *model.PersonalAccessToken
 
-		t, err := token.ParseRequest([]token.Type{token.UserToken, token.SessToken}, c.Request, func(t *token.Token) (string, error) {
+		t, err := token.ParseRequest([]token.Type{token.UserToken, token.SessToken, token.PersonalToken}, c.Request, func(t *token.Token) (string, error) {
 			var err error
 			userID, err := strconv.ParseInt(t.Get("user-id"), 10, 64)
 			if err != nil {
 				return "", err
 			}
 			user, err = store.FromContext(c).GetUser(userID)
-			return user.Hash, err
+			if err != nil || t.Type != token.PersonalToken {
+				return user.Hash, err
+			}
+
+			// personal access tokens are signed with the user hash and their own secret,
+			// so they can be revoked one by one or all at once by resetting the user token
+			pat, err = findPersonalAccessToken(c, t, user)
+			if err != nil {
+				return "", err
+			}
+			return user.Hash + pat.Secret, nil
 		})
 		if err == nil {
+			if t.Type == token.PersonalToken {
+				if !checkPersonalAccessToken(c, pat) {
+					return
+				}
+				c.Set("token", pat)
+			}
 			c.Set("user", user)
 
 			// if this is a session token (ie not the API token)
@@ -30,6 +30,7 @@ import (
 	"go.woodpecker-ci.org/woodpecker/v3/server/router/middleware/header"
 	"go.woodpecker-ci.org/woodpecker/v3/server/router/middleware/session"
 	"go.woodpecker-ci.org/woodpecker/v3/server/router/middleware/token"
+	"go.woodpecker-ci.org/woodpecker/v3/server/services/oidc"
 	"go.woodpecker-ci.org/woodpecker/v3/server/web"
 )
 
@@ -67,6 +68,9 @@ func Load(noRouteHandler http.HandlerFunc, middleware ...gin.HandlerFunc) http.H
 		base.GET("/metrics", metrics.PromHandler())
 		base.GET("/version", api.Version)
 		base.GET("/healthz", api.Health)
+
+		base.GET(oidc.DiscoveryPath, api.GetOIDCConfiguration)
+		base.GET(oidc.KeysPath, api.GetOIDCKeys)
 	}
 
 	apiRoutes(base)
@@ -0,0 +1,89 @@
+// Copyright 2025 Woodpecker Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package file
+
+import (
+	"errors"
+	"io"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"strconv"
+
+	"go.woodpecker-ci.org/woodpecker/v3/server/model"
+	"go.woodpecker-ci.org/woodpecker/v3/server/services/artifact"
+)
+
+type artifactStore struct {
+	base string
+}
+
+// NewArtifactStore returns an artifact store writing one file per artifact into a directory per pipeline.
+func NewArtifactStore(base string) (artifact.Service, error) {
+	if base == "" {
+		return nil, errors.New("no base directory configured for file based artifact store")
+	}
+	if err := os.MkdirAll(base, 0o700); err != nil {
+		return nil, err
+	}
+	return &artifactStore{base: base}, nil
+}
+
+func (s *artifactStore) path(a *model.Artifact) string {
+	return filepath.Join(s.base, strconv.FormatInt(a.PipelineID, 10), strconv.FormatInt(a.ID, 10))
+}
+
+func (s *artifactStore) ArtifactPut(a *model.Artifact, content io.Reader) error {
+	file := s.path(a)
+	if err := os.MkdirAll(filepath.Dir(file), 0o700); err != nil {
+		return err
+	}
+
+	tmp, err := os.CreateTemp(filepath.Dir(file), ".upload-*")
+	if err != nil {
+		return err
+	}
+	defer os.Remove(tmp.Name())
+
+	if _, err := io.Copy(tmp, content); err != nil {
+		tmp.Close()
+		return err
+	}
+	if err := tmp.Close(); err != nil {
+		return err
+	}
+	return os.Rename(tmp.Name(), file)
+}
+
+func (s *artifactStore) ArtifactGet(a *model.Artifact) (io.ReadCloser, error) {
+	f, err := os.Open(s.path(a))
+	if errors.Is(err, fs.ErrNotExist) {
+		return nil, artifact.ErrNotFound
+	}
+	if err != nil {
+		return nil, err
+	}
+	return f, nil
+}
+
+func (s *artifactStore) ArtifactDelete(a *model.Artifact) error {
+	file := s.path(a)
+	if err := os.Remove(file); err != nil && !errors.Is(err, fs.ErrNotExist) {
+		return err
+	}
+	// fails as long as the pipeline has other artifacts
+	_ = os.Remove(filepath.Dir(file))
+	return nil
+}
@@ -0,0 +1,59 @@
+// Copyright 2025 Woodpecker Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package file
+
+import (
+	"io"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"go.woodpecker-ci.org/woodpecker/v3/server/model"
+	"go.woodpecker-ci.org/woodpecker/v3/server/services/artifact"
+)
+
+func TestArtifactStore(t *testing.T) {
+	base := t.TempDir()
+	store, err := NewArtifactStore(base)
+	require.NoError(t, err)
+
+	first := &model.Artifact{ID: 1, PipelineID: 3, Name: "dist/app"}
+	second := &model.Artifact{ID: 2, PipelineID: 3, Name: "report.xml"}
+	assert.NoError(t, store.ArtifactPut(first, strings.NewReader("binary")))
+	assert.NoError(t, store.ArtifactPut(second, strings.NewReader("<xml/>")))
+	assert.FileExists(t, filepath.Join(base, "3", "1"))
+
+	content, err := store.ArtifactGet(first)
+	require.NoError(t, err)
+	data, err := io.ReadAll(content)
+	assert.NoError(t, err)
+	assert.NoError(t, content.Close())
+	assert.Equal(t, "binary", string(data))
+
+	_, err = store.ArtifactGet(&model.Artifact{ID: 4, PipelineID: 3})
+	assert.ErrorIs(t, err, artifact.ErrNotFound)
+
+	// the directory of the pipeline is removed with its last artifact
+	assert.NoError(t, store.ArtifactDelete(first))
+	assert.DirExists(t, filepath.Join(base, "3"))
+	assert.NoError(t, store.ArtifactDelete(second))
+	assert.NoError(t, store.ArtifactDelete(second))
+	_, err = os.Stat(filepath.Join(base, "3"))
+	assert.ErrorIs(t, err, os.ErrNotExist)
+}
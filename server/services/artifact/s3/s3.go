@@ -0,0 +1,65 @@
+// Copyright 2025 Woodpecker Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package s3
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"path"
+
+	"go.woodpecker-ci.org/woodpecker/v3/server/model"
+	"go.woodpecker-ci.org/woodpecker/v3/server/services/artifact"
+	s3client "go.woodpecker-ci.org/woodpecker/v3/shared/s3"
+)
+
+type artifactStore struct {
+	client *s3client.Client
+	prefix string
+}
+
+// NewArtifactStore returns an artifact store which uploads every artifact
+// as object to an S3 compatible object storage.
+func NewArtifactStore(config s3client.Config, prefix string) (artifact.Service, error) {
+	client, err := s3client.New(config)
+	if err != nil {
+		return nil, err
+	}
+	return &artifactStore{
+		client: client,
+		prefix: prefix,
+	}, nil
+}
+
+func (s *artifactStore) objectKey(a *model.Artifact) string {
+	return path.Join(s.prefix, fmt.Sprintf("%d/%d", a.PipelineID, a.ID))
+}
+
+func (s *artifactStore) ArtifactPut(a *model.Artifact, content io.Reader) error {
+	return s.client.PutStream(context.Background(), s.objectKey(a), content, a.Size, "application/octet-stream")
+}
+
+func (s *artifactStore) ArtifactGet(a *model.Artifact) (io.ReadCloser, error) {
+	body, err := s.client.Get(context.Background(), s.objectKey(a))
+	if errors.Is(err, s3client.ErrNotFound) {
+		return nil, artifact.ErrNotFound
+	}
+	return body, err
+}
+
+func (s *artifactStore) ArtifactDelete(a *model.Artifact) error {
+	return s.client.Delete(context.Background(), s.objectKey(a))
+}
@@ -0,0 +1,62 @@
+// Copyright 2025 Woodpecker Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package s3
+
+import (
+	"io"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"go.woodpecker-ci.org/woodpecker/v3/server/model"
+	"go.woodpecker-ci.org/woodpecker/v3/server/services/artifact"
+	s3client "go.woodpecker-ci.org/woodpecker/v3/shared/s3"
+	"go.woodpecker-ci.org/woodpecker/v3/shared/s3/fixtures"
+)
+
+func TestArtifactStore(t *testing.T) {
+	bucket := fixtures.Handler()
+	server := httptest.NewServer(bucket)
+	defer server.Close()
+
+	store, err := NewArtifactStore(s3client.Config{
+		Endpoint:  server.URL,
+		Bucket:    "woodpecker",
+		AccessKey: "access",
+		SecretKey: "secret",
+		PathStyle: true,
+	}, "artifacts")
+	require.NoError(t, err)
+
+	a := &model.Artifact{ID: 1, PipelineID: 3, Name: "dist/app", Size: 6}
+	assert.NoError(t, store.ArtifactPut(a, strings.NewReader("binary")))
+	assert.Contains(t, bucket.Objects, "woodpecker/artifacts/3/1")
+
+	content, err := store.ArtifactGet(a)
+	require.NoError(t, err)
+	data, err := io.ReadAll(content)
+	assert.NoError(t, err)
+	assert.NoError(t, content.Close())
+	assert.Equal(t, "binary", string(data))
+
+	_, err = store.ArtifactGet(&model.Artifact{ID: 2, PipelineID: 3})
+	assert.ErrorIs(t, err, artifact.ErrNotFound)
+
+	assert.NoError(t, store.ArtifactDelete(a))
+	assert.Empty(t, bucket.Objects)
+}
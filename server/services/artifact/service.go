@@ -0,0 +1,73 @@
+// Copyright 2025 Woodpecker Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package artifact
+
+import (
+	"errors"
+	"fmt"
+	"io"
+
+	"go.woodpecker-ci.org/woodpecker/v3/server/model"
+	"go.woodpecker-ci.org/woodpecker/v3/server/store"
+)
+
+// ErrNotFound is returned if the content of an artifact does not exist.
+var ErrNotFound = errors.New("artifact not found")
+
+// Service stores the content of artifacts, their metadata is kept in the database.
+type Service interface {
+	ArtifactPut(artifact *model.Artifact, content io.Reader) error
+	ArtifactGet(artifact *model.Artifact) (io.ReadCloser, error)
+	ArtifactDelete(artifact *model.Artifact) error
+}
+
+// DeletePipelineArtifacts removes all artifacts of the pipeline.
+func DeletePipelineArtifacts(s Service, _store store.Store, pipeline *model.Pipeline) error {
+	artifacts, err := _store.ArtifactList(pipeline)
+	if err != nil {
+		return err
+	}
+	if err := deleteArtifacts(s, _store, artifacts); err != nil {
+		return fmt.Errorf("delete artifacts of pipeline %d: %w", pipeline.Number, err)
+	}
+	return nil
+}
+
+// DeleteRepoArtifacts removes all artifacts of the repo.
+func DeleteRepoArtifacts(s Service, _store store.Store, repo *model.Repo) error {
+	artifacts, err := _store.ArtifactListRepo(repo)
+	if err != nil {
+		return err
+	}
+	if err := deleteArtifacts(s, _store, artifacts); err != nil {
+		return fmt.Errorf("delete artifacts of repo %s: %w", repo.FullName, err)
+	}
+	return nil
+}
+
+func deleteArtifacts(s Service, _store store.Store, artifacts []*model.Artifact) (err error) {
+	for _, artifact := range artifacts {
+		if s != nil {
+			if dErr := s.ArtifactDelete(artifact); dErr != nil {
+				err = errors.Join(err, dErr)
+				continue
+			}
+		}
+		if dErr := _store.ArtifactDelete(artifact); dErr != nil {
+			err = errors.Join(err, dErr)
+		}
+	}
+	return err
+}
@@ -35,6 +35,7 @@ import (
 	forge_types "go.woodpecker-ci.org/woodpecker/v3/server/forge/types"
 	"go.woodpecker-ci.org/woodpecker/v3/server/model"
 	"go.woodpecker-ci.org/woodpecker/v3/server/services/config"
+	"go.woodpecker-ci.org/woodpecker/v3/server/services/utils"
 )
 
 func TestFetchFromConfigService(t *testing.T) {
@@ -240,3 +241,35 @@ func TestFetchFromConfigService(t *testing.T) {
 		})
 	}
 }
+
+func TestRepoHTTPForbiddenAddress(t *testing.T) {
+	t.Parallel()
+
+	_, privEd25519Key, err := ed25519.GenerateKey(rand.Reader)
+	assert.NoError(t, err)
+
+	var requests int
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		requests++
+		assert.Equal(t, "config", r.Header.Get("X-Woodpecker-Extension"))
+		fmt.Fprint(w, `{"configs": [{"name": "override", "data": "steps: []"}]}`)
+	}))
+	defer ts.Close()
+
+	fetch := func(filter *utils.AddressFilter) ([]*forge_types.FileMeta, error) {
+		return config.NewRepoHTTP(ts.URL, privEd25519Key, filter).Fetch(context.Background(), new(mocks.Forge), &model.User{}, &model.Repo{}, &model.Pipeline{}, nil, false)
+	}
+
+	filter, err := utils.NewAddressFilter(nil)
+	assert.NoError(t, err)
+	_, err = fetch(filter)
+	assert.ErrorIs(t, err, utils.ErrForbiddenAddress)
+	assert.Zero(t, requests)
+
+	filter, err = utils.NewAddressFilter([]string{"127.0.0.1"})
+	assert.NoError(t, err)
+	files, err := fetch(filter)
+	assert.NoError(t, err)
+	assert.Len(t, files, 1)
+	assert.Equal(t, 1, requests)
+}
@@ -29,6 +29,8 @@ import (
 type http struct {
 	endpoint   string
 	privateKey ed25519.PrivateKey
+	filter     *utils.AddressFilter
+	withNetrc  bool
 }
 
 // configData same as forge.FileMeta but with json tags and string data.
@@ -40,7 +42,7 @@ type configData struct {
 type requestStructure struct {
 	Repo     *model.Repo     `json:"repo"`
 	Pipeline *model.Pipeline `json:"pipeline"`
-	Netrc    *model.Netrc    `json:"netrc"`
+	Netrc    *model.Netrc    `json:"netrc,omitempty"`
 }
 
 type responseStructure struct {
@@ -48,23 +50,32 @@ type responseStructure struct {
 }
 
 func NewHTTP(endpoint string, privateKey ed25519.PrivateKey) Service {
-	return &http{endpoint, privateKey}
+	return &http{endpoint, privateKey, nil, true}
 }
 
-func (h *http) Fetch(ctx context.Context, forge forge.Forge, user *model.User, repo *model.Repo, pipeline *model.Pipeline, oldConfigData []*types.FileMeta, _ bool) ([]*types.FileMeta, error) {
-	netrc, err := forge.Netrc(user, repo)
-	if err != nil {
-		return nil, fmt.Errorf("could not get Netrc data from forge: %w", err)
-	}
+// NewRepoHTTP returns a service for an endpoint configured by a repo or org. Unlike the
+// endpoint of the instance it does not receive the netrc credentials of the repo owner
+// and it is only called if its address is accepted by the filter.
+func NewRepoHTTP(endpoint string, privateKey ed25519.PrivateKey, filter *utils.AddressFilter) Service {
+	return &http{endpoint, privateKey, filter, false}
+}
 
+func (h *http) Fetch(ctx context.Context, forge forge.Forge, user *model.User, repo *model.Repo, pipeline *model.Pipeline, oldConfigData []*types.FileMeta, _ bool) ([]*types.FileMeta, error) {
 	response := new(responseStructure)
 	body := requestStructure{
 		Repo:     repo,
 		Pipeline: pipeline,
-		Netrc:    netrc,
 	}
 
-	status, err := utils.Send(ctx, net_http.MethodPost, h.endpoint, h.privateKey, body, response)
+	if h.withNetrc {
+		netrc, err := forge.Netrc(user, repo)
+		if err != nil {
+			return nil, fmt.Errorf("could not get Netrc data from forge: %w", err)
+		}
+		body.Netrc = netrc
+	}
+
+	status, err := utils.Send(ctx, h.filter, net_http.MethodPost, h.endpoint, utils.ExtensionConfig, h.privateKey, body, response)
 	if err != nil && status != 204 {
 		return nil, fmt.Errorf("failed to fetch config via http (%d) %w", status, err)
 	}
@@ -2,12 +2,18 @@ package file
 
 import (
 	"bufio"
+	"bytes"
+	"compress/gzip"
 	"encoding/json"
+	"errors"
 	"fmt"
+	"io"
 	"os"
 	"path/filepath"
 	"strings"
+	"time"
 
+	"github.com/klauspost/compress/zstd"
 	logger "github.com/rs/zerolog/log"
 
 	"go.woodpecker-ci.org/woodpecker/v3/pipeline"
@@ -20,36 +26,90 @@ const (
 	maxLineLength int = (pipeline.MaxLogLineLength/3)*4 + (64 * 1024) //nolint:mnd
 )
 
+// Compression defines how logs of finished steps are compressed.
+type Compression string
+
+const (
+	CompressionNone Compression = "none"
+	CompressionGzip Compression = "gzip"
+	CompressionZstd Compression = "zstd"
+)
+
+var compressionExtensions = map[Compression]string{
+	CompressionGzip: ".gz",
+	CompressionZstd: ".zst",
+}
+
 type logStore struct {
-	base string
+	base        string
+	compression Compression
+	// maxStepSize is the maximum number of bytes stored per step, 0 means unlimited.
+	maxStepSize int64
 }
 
-func NewLogStore(base string) (log.Service, error) {
+// NewLogStore returns a log store writing one file per step into the base directory.
+// Logs of finished steps are compressed with the given compression and the log of
+// a step is truncated once it exceeds maxStepSize bytes, if it is greater than zero.
+func NewLogStore(base string, compression Compression, maxStepSize int64) (log.Service, error) {
 	if base == "" {
 		return nil, fmt.Errorf("file storage base path is required")
 	}
+	if compression == "" {
+		compression = CompressionNone
+	}
+	if _, ok := compressionExtensions[compression]; !ok && compression != CompressionNone {
+		return nil, fmt.Errorf("unsupported log compression: %s", compression)
+	}
 	if _, err := os.Stat(base); err != nil && os.IsNotExist(err) {
 		err = os.MkdirAll(base, 0o700)
 		if err != nil {
 			return nil, err
 		}
 	}
-	return logStore{base: base}, nil
+	return logStore{base: base, compression: compression, maxStepSize: maxStepSize}, nil
 }
 
 func (l logStore) filePath(id int64) string {
 	return filepath.Join(l.base, fmt.Sprintf("%d.json", id))
 }
 
+// truncatedPath returns the path of the file marking a log as truncated, as the marker
+// entry can be smaller than the entry it replaced and leave the log below the limit.
+func (l logStore) truncatedPath(id int64) string {
+	return l.filePath(id) + ".truncated"
+}
+
+// openLog opens the uncompressed log of a running step or the compressed one of a finished step.
+func (l logStore) openLog(id int64) (io.ReadCloser, error) {
+	path := l.filePath(id)
+	file, err := os.Open(path)
+	if err == nil || !os.IsNotExist(err) {
+		return file, err
+	}
+
+	for compression, extension := range compressionExtensions {
+		file, err := os.Open(path + extension)
+		if os.IsNotExist(err) {
+			continue
+		}
+		if err != nil {
+			return nil, err
+		}
+		return newDecompressor(compression, file)
+	}
+
+	return nil, os.ErrNotExist
+}
+
 func (l logStore) LogFind(step *model.Step) ([]*model.LogEntry, error) {
-	filename := l.filePath(step.ID)
-	file, err := os.Open(filename)
+	file, err := l.openLog(step.ID)
 	if err != nil {
 		if os.IsNotExist(err) {
 			return nil, nil
 		}
 		return nil, err
 	}
+	defer file.Close()
 
 	buf := make([]byte, 0, bufio.MaxScanTokenSize)
 	s := bufio.NewScanner(file)
@@ -69,36 +129,252 @@ func (l logStore) LogFind(step *model.Step) ([]*model.LogEntry, error) {
 		entries = append(entries, entry)
 	}
 
-	return entries, nil
+	return entries, s.Err()
 }
 
 func (l logStore) LogAppend(step *model.Step, logEntries []*model.LogEntry) error {
 	path := l.filePath(step.ID)
 
+	if l.maxStepSize > 0 {
+		if _, err := os.Stat(l.truncatedPath(step.ID)); err == nil {
+			return nil
+		}
+	}
+
+	if compression, compressedPath, ok := l.compressedLog(step.ID); ok {
+		return l.appendCompressed(step, compression, compressedPath, logEntries)
+	}
+
 	file, err := os.OpenFile(path, os.O_APPEND|os.O_CREATE|os.O_WRONLY, 0o600)
 	if err != nil {
 		logger.Error().Err(err).Msgf("could not open log file %s", path)
 		return err
 	}
 
-	var bytes []byte
+	var size int64
+	if l.maxStepSize > 0 {
+		info, err := file.Stat()
+		if err != nil {
+			file.Close()
+			return err
+		}
+		size = info.Size()
+		if size >= l.maxStepSize {
+			// already truncated
+			return file.Close()
+		}
+	}
+
+	data, truncated := l.encodeEntries(size, logEntries)
+	if _, err = file.Write(data); err != nil {
+		logger.Error().Err(err).Msg("could not write out log entries")
+	}
 
-	for _, logEntry := range logEntries {
-		if jsonLine, err := json.Marshal(logEntry); err == nil {
-			bytes = append(bytes, jsonLine...)
-			bytes = append(bytes, byte('\n'))
-		} else {
-			logger.Error().Err(err).Msg("could not convert log entry to JSON")
+	if truncated {
+		if err := os.WriteFile(l.truncatedPath(step.ID), nil, 0o600); err != nil {
+			file.Close()
+			return err
+		}
+	}
+
+	return file.Close()
+}
+
+// compressedLog returns the compression and path of the compressed log of a finished step if it exists.
+func (l logStore) compressedLog(id int64) (Compression, string, bool) {
+	for compression, extension := range compressionExtensions {
+		path := l.filePath(id) + extension
+		if _, err := os.Stat(path); err == nil {
+			return compression, path, true
+		}
+	}
+	return "", "", false
+}
+
+// appendCompressed appends entries received after the step finished to its compressed log.
+// They are written as a new frame, gzip and zstd readers continue with the next frame.
+func (l logStore) appendCompressed(step *model.Step, compression Compression, path string, logEntries []*model.LogEntry) error {
+	var size int64
+	if l.maxStepSize > 0 {
+		src, err := l.openLog(step.ID)
+		if err != nil {
+			return err
+		}
+		size, err = io.Copy(io.Discard, src)
+		src.Close()
+		if err != nil {
+			return err
+		}
+		if size >= l.maxStepSize {
+			// already truncated
+			return nil
 		}
 	}
 
-	if _, err = file.Write(bytes); err != nil {
+	file, err := os.OpenFile(path, os.O_APPEND|os.O_WRONLY, 0o600)
+	if err != nil {
+		logger.Error().Err(err).Msgf("could not open log file %s", path)
+		return err
+	}
+
+	data, truncated := l.encodeEntries(size, logEntries)
+	if err := compress(compression, file, bytes.NewReader(data)); err != nil {
 		logger.Error().Err(err).Msg("could not write out log entries")
 	}
 
+	if truncated {
+		if err := os.WriteFile(l.truncatedPath(step.ID), nil, 0o600); err != nil {
+			file.Close()
+			return err
+		}
+	}
+
 	return file.Close()
 }
 
+// encodeEntries returns the JSON lines of the entries appended to a log of size bytes and
+// whether they were truncated, as they would have exceeded the limit.
+func (l logStore) encodeEntries(size int64, logEntries []*model.LogEntry) ([]byte, bool) {
+	var data []byte
+	for _, logEntry := range logEntries {
+		jsonLine, err := json.Marshal(logEntry)
+		if err != nil {
+			logger.Error().Err(err).Msg("could not convert log entry to JSON")
+			continue
+		}
+
+		if l.maxStepSize > 0 && size+int64(len(data)+len(jsonLine)+1) > l.maxStepSize {
+			return append(data, l.truncatedEntry(logEntry)...), true
+		}
+
+		data = append(data, jsonLine...)
+		data = append(data, byte('\n'))
+	}
+	return data, false
+}
+
+// truncatedEntry returns the marker written instead of the entry which exceeded the limit.
+// It is always written, even if it exceeds the limit itself, and further appends are dropped.
+func (l logStore) truncatedEntry(next *model.LogEntry) []byte {
+	jsonLine, _ := json.Marshal(&model.LogEntry{
+		StepID: next.StepID,
+		Time:   next.Time,
+		Line:   next.Line,
+		Type:   model.LogEntryMetadata,
+		Data:   []byte(fmt.Sprintf("log output truncated: step exceeded the limit of %d bytes", l.maxStepSize)),
+	})
+	return append(jsonLine, '\n')
+}
+
+// LogStepFinished compresses the log of the finished step.
+func (l logStore) LogStepFinished(step *model.Step) error {
+	if l.compression == CompressionNone {
+		return nil
+	}
+
+	path := l.filePath(step.ID)
+	src, err := os.Open(path)
+	if err != nil {
+		if os.IsNotExist(err) {
+			return nil
+		}
+		return err
+	}
+	defer src.Close()
+
+	// write to a temporary file first, so a partially written file is never read
+	compressedPath := path + compressionExtensions[l.compression]
+	tmpPath := fmt.Sprintf("%s.%d.tmp", compressedPath, time.Now().UnixNano())
+	dst, err := os.OpenFile(tmpPath, os.O_CREATE|os.O_EXCL|os.O_WRONLY, 0o600)
+	if err != nil {
+		return err
+	}
+
+	if err := compress(l.compression, dst, src); err != nil {
+		dst.Close()
+		os.Remove(tmpPath)
+		return err
+	}
+	if err := dst.Close(); err != nil {
+		os.Remove(tmpPath)
+		return err
+	}
+
+	if err := os.Rename(tmpPath, compressedPath); err != nil {
+		os.Remove(tmpPath)
+		return err
+	}
+	return os.Remove(path)
+}
+
 func (l logStore) LogDelete(step *model.Step) error {
-	return os.Remove(l.filePath(step.ID))
+	path := l.filePath(step.ID)
+	if err := os.Remove(l.truncatedPath(step.ID)); err != nil && !os.IsNotExist(err) {
+		return err
+	}
+	err := os.Remove(path)
+	for _, extension := range compressionExtensions {
+		if removeErr := os.Remove(path + extension); removeErr == nil {
+			err = nil
+		} else if !os.IsNotExist(removeErr) {
+			return removeErr
+		}
+	}
+	return err
+}
+
+func compress(compression Compression, dst io.Writer, src io.Reader) error {
+	var writer io.WriteCloser
+	switch compression {
+	case CompressionGzip:
+		writer = gzip.NewWriter(dst)
+	case CompressionZstd:
+		var err error
+		writer, err = zstd.NewWriter(dst)
+		if err != nil {
+			return err
+		}
+	default:
+		return fmt.Errorf("unsupported log compression: %s", compression)
+	}
+
+	if _, err := io.Copy(writer, src); err != nil {
+		writer.Close()
+		return err
+	}
+	return writer.Close()
+}
+
+func newDecompressor(compression Compression, file *os.File) (io.ReadCloser, error) {
+	var reader io.ReadCloser
+	switch compression {
+	case CompressionGzip:
+		gzipReader, err := gzip.NewReader(file)
+		if err != nil {
+			file.Close()
+			return nil, err
+		}
+		reader = gzipReader
+	case CompressionZstd:
+		zstdReader, err := zstd.NewReader(file)
+		if err != nil {
+			file.Close()
+			return nil, err
+		}
+		reader = zstdReader.IOReadCloser()
+	default:
+		file.Close()
+		return nil, errors.New("unsupported log compression")
+	}
+	return &decompressor{ReadCloser: reader, file: file}, nil
+}
+
+// decompressor closes the underlying file together with the decompressing reader.
+type decompressor struct {
+	io.ReadCloser
+	file *os.File
+}
+
+func (d *decompressor) Close() error {
+	return errors.Join(d.ReadCloser.Close(), d.file.Close())
 }
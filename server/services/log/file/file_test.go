@@ -0,0 +1,156 @@
+package file
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"go.woodpecker-ci.org/woodpecker/v3/server/model"
+	"go.woodpecker-ci.org/woodpecker/v3/server/services/log"
+)
+
+func testEntries(stepID int64, lines ...string) []*model.LogEntry {
+	entries := make([]*model.LogEntry, 0, len(lines))
+	for i, line := range lines {
+		entries = append(entries, &model.LogEntry{StepID: stepID, Line: i, Data: []byte(line)})
+	}
+	return entries
+}
+
+func TestLogStoreCompression(t *testing.T) {
+	for _, compression := range []Compression{CompressionNone, CompressionGzip, CompressionZstd} {
+		t.Run(string(compression), func(t *testing.T) {
+			base := t.TempDir()
+			store, err := NewLogStore(base, compression, 0)
+			require.NoError(t, err)
+
+			step := &model.Step{ID: 1}
+			assert.NoError(t, store.LogAppend(step, testEntries(step.ID, "hello", "world")))
+			assert.NoError(t, log.StepFinished(store, step))
+
+			if compression != CompressionNone {
+				assert.NoFileExists(t, filepath.Join(base, "1.json"))
+				assert.FileExists(t, filepath.Join(base, "1.json"+compressionExtensions[compression]))
+			}
+
+			entries, err := store.LogFind(step)
+			assert.NoError(t, err)
+			if assert.Len(t, entries, 2) {
+				assert.Equal(t, "hello", string(entries[0].Data))
+				assert.Equal(t, "world", string(entries[1].Data))
+			}
+
+			assert.NoError(t, store.LogDelete(step))
+			files, err := os.ReadDir(base)
+			assert.NoError(t, err)
+			assert.Empty(t, files)
+		})
+	}
+}
+
+func TestLogStoreAppendAfterCompression(t *testing.T) {
+	for _, compression := range []Compression{CompressionGzip, CompressionZstd} {
+		t.Run(string(compression), func(t *testing.T) {
+			base := t.TempDir()
+			store, err := NewLogStore(base, compression, 0)
+			require.NoError(t, err)
+
+			step := &model.Step{ID: 1}
+			assert.NoError(t, store.LogAppend(step, testEntries(step.ID, "hello")))
+			assert.NoError(t, log.StepFinished(store, step))
+			assert.NoError(t, store.LogAppend(step, testEntries(step.ID, "world")))
+			assert.NoFileExists(t, filepath.Join(base, "1.json"))
+
+			entries, err := store.LogFind(step)
+			assert.NoError(t, err)
+			if assert.Len(t, entries, 2) {
+				assert.Equal(t, "hello", string(entries[0].Data))
+				assert.Equal(t, "world", string(entries[1].Data))
+			}
+		})
+	}
+}
+
+func TestLogStoreAppendAfterCompressionMaxStepSize(t *testing.T) {
+	store, err := NewLogStore(t.TempDir(), CompressionZstd, 100)
+	require.NoError(t, err)
+
+	step := &model.Step{ID: 1}
+	assert.NoError(t, store.LogAppend(step, testEntries(step.ID, "hello")))
+	assert.NoError(t, log.StepFinished(store, step))
+	assert.NoError(t, store.LogAppend(step, testEntries(step.ID, strings.Repeat("a", 100))))
+	assert.NoError(t, store.LogAppend(step, testEntries(step.ID, "dropped")))
+
+	entries, err := store.LogFind(step)
+	assert.NoError(t, err)
+	if assert.Len(t, entries, 2) {
+		assert.Equal(t, "hello", string(entries[0].Data))
+		assert.Equal(t, model.LogEntryMetadata, entries[1].Type)
+	}
+}
+
+func TestLogStoreReadOtherCompression(t *testing.T) {
+	base := t.TempDir()
+	gzipStore, err := NewLogStore(base, CompressionGzip, 0)
+	require.NoError(t, err)
+	step := &model.Step{ID: 1}
+	assert.NoError(t, gzipStore.LogAppend(step, testEntries(step.ID, "hello")))
+	assert.NoError(t, log.StepFinished(gzipStore, step))
+
+	// logs compressed before the compression was changed can still be read
+	zstdStore, err := NewLogStore(base, CompressionZstd, 0)
+	require.NoError(t, err)
+	entries, err := zstdStore.LogFind(step)
+	assert.NoError(t, err)
+	assert.Len(t, entries, 1)
+}
+
+func TestLogStoreMaxStepSize(t *testing.T) {
+	// every entry takes 66 bytes, so only two of them fit
+	store, err := NewLogStore(t.TempDir(), CompressionNone, 150)
+	require.NoError(t, err)
+
+	step := &model.Step{ID: 1}
+	assert.NoError(t, store.LogAppend(step, testEntries(step.ID, "first", "second", "third")))
+	assert.NoError(t, store.LogAppend(step, testEntries(step.ID, "dropped")))
+
+	entries, err := store.LogFind(step)
+	assert.NoError(t, err)
+	require.Len(t, entries, 3)
+	assert.Equal(t, "first", string(entries[0].Data))
+	assert.Equal(t, "second", string(entries[1].Data))
+	assert.Equal(t, model.LogEntryMetadata, entries[2].Type)
+	assert.Contains(t, string(entries[2].Data), "truncated")
+}
+
+func TestLogStoreMaxStepSizeSmallMarker(t *testing.T) {
+	store, err := NewLogStore(t.TempDir(), CompressionNone, 500)
+	require.NoError(t, err)
+
+	// the marker replacing the large entry leaves the log below the limit
+	step := &model.Step{ID: 1}
+	assert.NoError(t, store.LogAppend(step, testEntries(step.ID, "first", strings.Repeat("x", 1000))))
+	assert.NoError(t, store.LogAppend(step, testEntries(step.ID, "dropped")))
+
+	entries, err := store.LogFind(step)
+	assert.NoError(t, err)
+	require.Len(t, entries, 2)
+	assert.Equal(t, "first", string(entries[0].Data))
+	assert.Contains(t, string(entries[1].Data), "truncated")
+
+	assert.NoError(t, store.LogDelete(step))
+	entries, err = store.LogFind(step)
+	assert.NoError(t, err)
+	assert.Empty(t, entries)
+}
+
+func TestNewLogStoreValidation(t *testing.T) {
+	_, err := NewLogStore("", CompressionNone, 0)
+	assert.Error(t, err)
+	_, err = NewLogStore(t.TempDir(), "brotli", 0)
+	assert.Error(t, err)
+}
@@ -0,0 +1,289 @@
+package s3
+
+import (
+	"bufio"
+	"bytes"
+	"compress/gzip"
+	"context"
+	"encoding/json"
+	"fmt"
+	"path"
+	"slices"
+	"strings"
+	"sync"
+	"time"
+
+	logger "github.com/rs/zerolog/log"
+
+	"go.woodpecker-ci.org/woodpecker/v3/pipeline"
+	"go.woodpecker-ci.org/woodpecker/v3/server/model"
+	"go.woodpecker-ci.org/woodpecker/v3/server/services/log"
+	s3client "go.woodpecker-ci.org/woodpecker/v3/shared/s3"
+)
+
+const (
+	// Add base64 overhead and space for other JSON fields (just to be safe).
+	maxLineLength int = (pipeline.MaxLogLineLength/3)*4 + (64 * 1024) //nolint:mnd
+
+	// chunkSize is the number of buffered bytes of a step after which they are uploaded.
+	chunkSize = 1024 * 1024
+	// bufferTTL is the time after which the buffer of a step without new entries is uploaded
+	// and dropped, e.g. because the agent died and the step never finished.
+	bufferTTL = 10 * time.Minute
+	// sweepInterval is the minimal time between two checks for idle buffers.
+	sweepInterval = time.Minute
+)
+
+type logStore struct {
+	sync.Mutex
+
+	client *s3client.Client
+	prefix string
+	// buffers holds the entries of running steps which are not uploaded yet.
+	buffers   map[int64]*stepBuffer
+	lastSweep time.Time
+}
+
+type stepBuffer struct {
+	sync.Mutex
+
+	entries []*model.LogEntry
+	size    int
+	updated time.Time
+	// closed is set once the buffer was removed, entries must be appended to a new buffer.
+	closed bool
+}
+
+// NewLogStore returns a log store which uploads the logs of steps as gzip compressed
+// chunks to an S3 compatible object storage. Entries of running steps are kept in memory
+// until a chunk is full, the step finished or no entries were appended for some time.
+func NewLogStore(config s3client.Config, prefix string) (log.Service, error) {
+	client, err := s3client.New(config)
+	if err != nil {
+		return nil, err
+	}
+	return &logStore{
+		client:  client,
+		prefix:  prefix,
+		buffers: make(map[int64]*stepBuffer),
+	}, nil
+}
+
+func (l *logStore) stepPrefix(id int64) string {
+	return path.Join(l.prefix, fmt.Sprint(id)) + "/"
+}
+
+// chunkKey returns the key of the chunk starting with the given line, the padding keeps
+// the keys of a step sorted.
+func (l *logStore) chunkKey(id int64, line int) string {
+	return fmt.Sprintf("%s%010d.json.gz", l.stepPrefix(id), line)
+}
+
+func (l *logStore) LogFind(step *model.Step) ([]*model.LogEntry, error) {
+	l.Lock()
+	buffer, running := l.buffers[step.ID]
+	l.Unlock()
+	if running {
+		// prevent the buffer from being uploaded between listing the chunks and reading it
+		buffer.Lock()
+		defer buffer.Unlock()
+	}
+
+	objects, err := l.client.List(context.Background(), l.stepPrefix(step.ID))
+	if err != nil {
+		return nil, err
+	}
+	slices.SortFunc(objects, func(a, b s3client.Object) int {
+		return strings.Compare(a.Key, b.Key)
+	})
+
+	var entries []*model.LogEntry
+	for _, object := range objects {
+		chunk, err := l.readChunk(object.Key)
+		if err != nil {
+			return nil, err
+		}
+		entries = append(entries, chunk...)
+	}
+
+	if running {
+		entries = append(entries, buffer.entries...)
+	}
+	return entries, nil
+}
+
+func (l *logStore) readChunk(key string) ([]*model.LogEntry, error) {
+	body, err := l.client.Get(context.Background(), key)
+	if err != nil {
+		return nil, err
+	}
+	defer body.Close()
+
+	reader, err := gzip.NewReader(body)
+	if err != nil {
+		return nil, err
+	}
+	defer reader.Close()
+
+	buf := make([]byte, 0, bufio.MaxScanTokenSize)
+	s := bufio.NewScanner(reader)
+	s.Buffer(buf, maxLineLength)
+
+	var entries []*model.LogEntry
+	for s.Scan() {
+		j := s.Text()
+		if len(strings.TrimSpace(j)) == 0 {
+			continue
+		}
+		entry := &model.LogEntry{}
+		if err := json.Unmarshal([]byte(j), entry); err != nil {
+			return nil, err
+		}
+		entries = append(entries, entry)
+	}
+
+	return entries, s.Err()
+}
+
+func (l *logStore) LogAppend(step *model.Step, logEntries []*model.LogEntry) error {
+	l.sweep()
+
+	buffer := l.buffer(step.ID)
+	defer buffer.Unlock()
+
+	buffer.entries = append(buffer.entries, logEntries...)
+	for _, entry := range logEntries {
+		buffer.size += len(entry.Data)
+	}
+	buffer.updated = time.Now()
+
+	if buffer.size < chunkSize {
+		return nil
+	}
+	return l.flush(step.ID, buffer)
+}
+
+// buffer returns the locked buffer of the step, it is created if it does not exist.
+func (l *logStore) buffer(id int64) *stepBuffer {
+	for {
+		l.Lock()
+		buffer, ok := l.buffers[id]
+		if !ok {
+			buffer = new(stepBuffer)
+			l.buffers[id] = buffer
+		}
+		l.Unlock()
+
+		buffer.Lock()
+		if !buffer.closed {
+			return buffer
+		}
+		buffer.Unlock()
+	}
+}
+
+// flush uploads the entries of the locked buffer as new chunk.
+func (l *logStore) flush(id int64, buffer *stepBuffer) error {
+	if len(buffer.entries) == 0 {
+		return nil
+	}
+
+	var data bytes.Buffer
+	writer := gzip.NewWriter(&data)
+	encoder := json.NewEncoder(writer)
+	for _, entry := range buffer.entries {
+		if err := encoder.Encode(entry); err != nil {
+			return err
+		}
+	}
+	if err := writer.Close(); err != nil {
+		return err
+	}
+
+	if err := l.client.Put(context.Background(), l.chunkKey(id, buffer.entries[0].Line), data.Bytes(), "application/gzip"); err != nil {
+		return err
+	}
+
+	buffer.entries = nil
+	buffer.size = 0
+	return nil
+}
+
+// drop uploads the remaining entries of the buffer and removes it.
+func (l *logStore) drop(id int64, buffer *stepBuffer) error {
+	buffer.Lock()
+	defer buffer.Unlock()
+	if buffer.closed {
+		return nil
+	}
+	if err := l.flush(id, buffer); err != nil {
+		return err
+	}
+
+	buffer.closed = true
+	l.Lock()
+	delete(l.buffers, id)
+	l.Unlock()
+	return nil
+}
+
+// sweep drops the buffers of steps which did not get new entries for a while.
+func (l *logStore) sweep() {
+	l.Lock()
+	if time.Since(l.lastSweep) < sweepInterval {
+		l.Unlock()
+		return
+	}
+	l.lastSweep = time.Now()
+	buffers := make(map[int64]*stepBuffer, len(l.buffers))
+	for id, buffer := range l.buffers {
+		buffers[id] = buffer
+	}
+	l.Unlock()
+
+	for id, buffer := range buffers {
+		buffer.Lock()
+		idle := time.Since(buffer.updated) > bufferTTL
+		buffer.Unlock()
+		if !idle {
+			continue
+		}
+		if err := l.drop(id, buffer); err != nil {
+			logger.Error().Err(err).Msgf("cannot upload log buffer of idle step %d", id)
+		}
+	}
+}
+
+// LogStepFinished uploads the remaining buffered entries of the step.
+func (l *logStore) LogStepFinished(step *model.Step) error {
+	l.Lock()
+	buffer, ok := l.buffers[step.ID]
+	l.Unlock()
+	if !ok {
+		return nil
+	}
+	return l.drop(step.ID, buffer)
+}
+
+func (l *logStore) LogDelete(step *model.Step) error {
+	l.Lock()
+	buffer, ok := l.buffers[step.ID]
+	delete(l.buffers, step.ID)
+	l.Unlock()
+	if ok {
+		buffer.Lock()
+		buffer.closed = true
+		buffer.Unlock()
+	}
+
+	objects, err := l.client.List(context.Background(), l.stepPrefix(step.ID))
+	if err != nil {
+		return err
+	}
+	for _, object := range objects {
+		if err := l.client.Delete(context.Background(), object.Key); err != nil {
+			return err
+		}
+	}
+	return nil
+}
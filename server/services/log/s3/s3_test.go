@@ -0,0 +1,112 @@
+package s3
+
+import (
+	"bytes"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"go.woodpecker-ci.org/woodpecker/v3/server/model"
+	"go.woodpecker-ci.org/woodpecker/v3/server/services/log"
+	s3client "go.woodpecker-ci.org/woodpecker/v3/shared/s3"
+	"go.woodpecker-ci.org/woodpecker/v3/shared/s3/fixtures"
+)
+
+func TestLogStore(t *testing.T) {
+	bucket := fixtures.Handler()
+	server := httptest.NewServer(bucket)
+	defer server.Close()
+
+	store, err := NewLogStore(s3client.Config{
+		Endpoint:  server.URL,
+		Bucket:    "woodpecker",
+		AccessKey: "access",
+		SecretKey: "secret",
+		PathStyle: true,
+	}, "logs")
+	require.NoError(t, err)
+
+	step := &model.Step{ID: 1}
+	assert.NoError(t, store.LogAppend(step, []*model.LogEntry{{StepID: 1, Line: 0, Data: []byte("hello")}}))
+	assert.NoError(t, store.LogAppend(step, []*model.LogEntry{{StepID: 1, Line: 1, Data: []byte("world")}}))
+
+	// running steps are served from the buffer
+	entries, err := store.LogFind(step)
+	assert.NoError(t, err)
+	assert.Len(t, entries, 2)
+	assert.Empty(t, bucket.Objects)
+
+	assert.NoError(t, log.StepFinished(store, step))
+	assert.Contains(t, bucket.Objects, "woodpecker/logs/1/0000000000.json.gz")
+
+	entries, err = store.LogFind(step)
+	assert.NoError(t, err)
+	if assert.Len(t, entries, 2) {
+		assert.Equal(t, "hello", string(entries[0].Data))
+		assert.Equal(t, 1, entries[1].Line)
+		assert.Equal(t, "world", string(entries[1].Data))
+	}
+
+	// unknown steps have no logs
+	entries, err = store.LogFind(&model.Step{ID: 2})
+	assert.NoError(t, err)
+	assert.Empty(t, entries)
+
+	assert.NoError(t, store.LogDelete(step))
+	assert.Empty(t, bucket.Objects)
+}
+
+func TestLogStoreChunks(t *testing.T) {
+	bucket := fixtures.Handler()
+	server := httptest.NewServer(bucket)
+	defer server.Close()
+
+	service, err := NewLogStore(s3client.Config{
+		Endpoint:  server.URL,
+		Bucket:    "woodpecker",
+		AccessKey: "access",
+		SecretKey: "secret",
+		PathStyle: true,
+	}, "logs")
+	require.NoError(t, err)
+	store, _ := service.(*logStore)
+
+	step := &model.Step{ID: 1}
+	data := bytes.Repeat([]byte("a"), chunkSize/2)
+	for line := range 3 {
+		assert.NoError(t, store.LogAppend(step, []*model.LogEntry{{StepID: 1, Line: line, Data: data}}))
+	}
+
+	// full chunks are uploaded while the step is running
+	assert.Contains(t, bucket.Objects, "woodpecker/logs/1/0000000000.json.gz")
+	entries, err := store.LogFind(step)
+	assert.NoError(t, err)
+	if assert.Len(t, entries, 3) {
+		assert.Equal(t, 2, entries[2].Line)
+	}
+
+	// buffers of idle steps are uploaded and dropped
+	store.buffers[step.ID].updated = time.Now().Add(-2 * bufferTTL)
+	store.lastSweep = time.Time{}
+	assert.NoError(t, store.LogAppend(&model.Step{ID: 2}, []*model.LogEntry{{StepID: 2, Data: []byte("hello")}}))
+	assert.NotContains(t, store.buffers, step.ID)
+	assert.Contains(t, bucket.Objects, "woodpecker/logs/1/0000000002.json.gz")
+
+	// late entries start a new chunk
+	assert.NoError(t, store.LogAppend(step, []*model.LogEntry{{StepID: 1, Line: 3, Data: []byte("late")}}))
+	assert.NoError(t, log.StepFinished(store, step))
+	assert.Empty(t, store.buffers[step.ID])
+	entries, err = store.LogFind(step)
+	assert.NoError(t, err)
+	if assert.Len(t, entries, 4) {
+		assert.Equal(t, "late", string(entries[3].Data))
+	}
+
+	assert.NoError(t, store.LogDelete(step))
+	assert.Len(t, bucket.Objects, 0)
+	assert.NoError(t, store.LogDelete(&model.Step{ID: 2}))
+	assert.Empty(t, bucket.Objects)
+}
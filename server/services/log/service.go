@@ -7,3 +7,17 @@ type Service interface {
 	LogAppend(step *model.Step, logEntries []*model.LogEntry) error
 	LogDelete(step *model.Step) error
 }
+
+// StepFinisher is implemented by services which need to know when no more
+// entries are appended to the log of a step.
+type StepFinisher interface {
+	LogStepFinished(step *model.Step) error
+}
+
+// StepFinished notifies the service that the step finished, if the service wants to know.
+func StepFinished(s Service, step *model.Step) error {
+	if finisher, ok := s.(StepFinisher); ok {
+		return finisher.LogStepFinished(step)
+	}
+	return nil
+}
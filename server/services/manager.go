@@ -15,10 +15,13 @@
 package services
 
 import (
+	"context"
 	"crypto"
+	"crypto/ed25519"
 	"time"
 
 	"github.com/jellydator/ttlcache/v3"
+	"github.com/rs/zerolog/log"
 	"github.com/urfave/cli/v3"
 
 	"go.woodpecker-ci.org/woodpecker/v3/server/forge"
@@ -27,6 +30,8 @@ import (
 	"go.woodpecker-ci.org/woodpecker/v3/server/services/environment"
 	"go.woodpecker-ci.org/woodpecker/v3/server/services/registry"
 	"go.woodpecker-ci.org/woodpecker/v3/server/services/secret"
+	"go.woodpecker-ci.org/woodpecker/v3/server/services/utils"
+	"go.woodpecker-ci.org/woodpecker/v3/server/services/webhook"
 	"go.woodpecker-ci.org/woodpecker/v3/server/store"
 )
 
@@ -38,12 +43,19 @@ type SetupForge func(forge *model.Forge) (forge.Forge, error)
 
 type Manager interface {
 	SignaturePublicKey() crypto.PublicKey
+	// UserSignaturePublicKey returns the key of the signatures of requests to endpoints configured
+	// by users, like webhooks or config extensions of repos and orgs.
+	UserSignaturePublicKey() crypto.PublicKey
 	SecretServiceFromRepo(repo *model.Repo) secret.Service
 	SecretService() secret.Service
 	RegistryServiceFromRepo(repo *model.Repo) registry.Service
 	RegistryService() registry.Service
 	ConfigServiceFromRepo(repo *model.Repo) config.Service
 	EnvironmentService() environment.Service
+	WebhookService() webhook.Service
+	// ValidateEndpoint returns an error if an endpoint configured by a user, like a webhook,
+	// points to a loopback, link-local or private address which isn't allowed by an admin.
+	ValidateEndpoint(ctx context.Context, endpoint string) error
 	ForgeFromRepo(repo *model.Repo) (forge.Forge, error)
 	ForgeFromUser(user *model.User) (forge.Forge, error)
 	ForgeByID(forgeID int64) (forge.Forge, error)
@@ -52,17 +64,28 @@ type Manager interface {
 type manager struct {
 	signaturePrivateKey crypto.PrivateKey
 	signaturePublicKey  crypto.PublicKey
+	userPrivateKey      ed25519.PrivateKey
+	userPublicKey       crypto.PublicKey
 	store               store.Store
 	secret              secret.Service
 	registry            registry.Service
 	config              config.Service
+	forgeConfig         config.Service
 	environment         environment.Service
+	webhook             webhook.Service
+	addressFilter       *utils.AddressFilter
 	forgeCache          *ttlcache.Cache[int64, forge.Forge]
 	setupForge          SetupForge
 }
 
 func NewManager(c *cli.Command, store store.Store, setupForge SetupForge) (Manager, error) {
-	signaturePrivateKey, signaturePublicKey, err := setupSignatureKeys(store)
+	signaturePrivateKey, signaturePublicKey, err := setupSignatureKeys(store, "signature-private-key")
+	if err != nil {
+		return nil, err
+	}
+
+	// endpoints configured by users get their own key, so their requests can't be replayed to the extensions of the instance
+	userPrivateKey, userPublicKey, err := setupSignatureKeys(store, "user-signature-private-key")
 	if err != nil {
 		return nil, err
 	}
@@ -72,7 +95,12 @@ func NewManager(c *cli.Command, store store.Store, setupForge SetupForge) (Manag
 		return nil, err
 	}
 
-	configService, err := setupConfigService(c, signaturePrivateKey)
+	forgeConfigService, err := setupForgeConfigService(c)
+	if err != nil {
+		return nil, err
+	}
+
+	addressFilter, err := utils.NewAddressFilter(c.StringSlice("extensions-allowed-hosts"))
 	if err != nil {
 		return nil, err
 	}
@@ -80,11 +108,16 @@ func NewManager(c *cli.Command, store store.Store, setupForge SetupForge) (Manag
 	return &manager{
 		signaturePrivateKey: signaturePrivateKey,
 		signaturePublicKey:  signaturePublicKey,
+		userPrivateKey:      userPrivateKey,
+		userPublicKey:       userPublicKey,
 		store:               store,
-		secret:              setupSecretService(store),
-		registry:            setupRegistryService(store, c.String("docker-config")),
-		config:              configService,
+		secret:              setupSecretService(store, c.String("secret-service-endpoint"), signaturePrivateKey),
+		registry:            setupRegistryService(store, c.String("docker-config"), c.String("registry-service-endpoint"), signaturePrivateKey),
+		config:              setupConfigService(c, forgeConfigService, signaturePrivateKey),
+		forgeConfig:         forgeConfigService,
 		environment:         environment.Parse(c.StringSlice("environment")),
+		webhook:             webhook.New(store, userPrivateKey, addressFilter),
+		addressFilter:       addressFilter,
 		forgeCache:          ttlcache.New(ttlcache.WithDisableTouchOnHit[int64, forge.Forge]()),
 		setupForge:          setupForge,
 	}, nil
@@ -94,6 +127,10 @@ func (m *manager) SignaturePublicKey() crypto.PublicKey {
 	return m.signaturePublicKey
 }
 
+func (m *manager) UserSignaturePublicKey() crypto.PublicKey {
+	return m.userPublicKey
+}
+
 func (m *manager) SecretServiceFromRepo(_ *model.Repo) secret.Service {
 	return m.SecretService()
 }
@@ -110,15 +147,38 @@ func (m *manager) RegistryService() registry.Service {
 	return m.registry
 }
 
-func (m *manager) ConfigServiceFromRepo(_ *model.Repo) config.Service {
-	// TODO: decide based on repo property which config service to use
-	return m.config
+// ConfigServiceFromRepo returns the config service of the repo: the config extension of the repo,
+// of its org or of the instance, whatever is set first, combined with the forge config service.
+func (m *manager) ConfigServiceFromRepo(repo *model.Repo) config.Service {
+	endpoint := repo.ConfigExtensionEndpoint
+	if endpoint == "" && repo.OrgID != 0 {
+		org, err := m.store.OrgGet(repo.OrgID)
+		if err != nil {
+			log.Error().Err(err).Msgf("could not load org of repo %s", repo.FullName)
+		} else {
+			endpoint = org.ConfigExtensionEndpoint
+		}
+	}
+
+	if endpoint == "" {
+		return m.config
+	}
+
+	return config.NewCombined(m.forgeConfig, config.NewRepoHTTP(endpoint, m.userPrivateKey, m.addressFilter))
 }
 
 func (m *manager) EnvironmentService() environment.Service {
 	return m.environment
 }
 
+func (m *manager) WebhookService() webhook.Service {
+	return m.webhook
+}
+
+func (m *manager) ValidateEndpoint(ctx context.Context, endpoint string) error {
+	return m.addressFilter.ValidateURL(ctx, endpoint)
+}
+
 func (m *manager) ForgeFromRepo(repo *model.Repo) (forge.Forge, error) {
 	return m.ForgeByID(repo.ForgeID)
 }
@@ -138,12 +198,13 @@ func (m *manager) ForgeByID(id int64) (forge.Forge, error) {
 		return nil, err
 	}
 
-	forge, err := m.setupForge(forgeModel)
+	_forge, err := m.setupForge(forgeModel)
 	if err != nil {
 		return nil, err
 	}
+	_forge = forge.WithMetrics(_forge)
 
-	m.forgeCache.Set(id, forge, forgeCacheTTL)
+	m.forgeCache.Set(id, _forge, forgeCacheTTL)
 
-	return forge, nil
+	return _forge, nil
 }
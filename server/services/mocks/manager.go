@@ -6,6 +6,8 @@
 package mocks
 
 import (
+	context "context"
+
 	crypto "crypto"
 
 	config "go.woodpecker-ci.org/woodpecker/v3/server/services/config"
@@ -21,6 +23,8 @@ import (
 	registry "go.woodpecker-ci.org/woodpecker/v3/server/services/registry"
 
 	secret "go.woodpecker-ci.org/woodpecker/v3/server/services/secret"
+
+	webhook "go.woodpecker-ci.org/woodpecker/v3/server/services/webhook"
 )
 
 // Manager is an autogenerated mock type for the Manager type
@@ -258,6 +262,62 @@ func (_m *Manager) SignaturePublicKey() crypto.PublicKey {
 	return r0
 }
 
+// UserSignaturePublicKey provides a mock function with no fields
+func (_m *Manager) UserSignaturePublicKey() crypto.PublicKey {
+	ret := _m.Called()
+
+	if len(ret) == 0 {
+		panic("no return value specified for UserSignaturePublicKey")
+	}
+
+	var r0 crypto.PublicKey
+	if rf, ok := ret.Get(0).(func() crypto.PublicKey); ok {
+		r0 = rf()
+	} else {
+		r0 = ret.Get(0).(crypto.PublicKey)
+	}
+
+	return r0
+}
+
+// ValidateEndpoint provides a mock function with given fields: ctx, endpoint
+func (_m *Manager) ValidateEndpoint(ctx context.Context, endpoint string) error {
+	ret := _m.Called(ctx, endpoint)
+
+	if len(ret) == 0 {
+		panic("no return value specified for ValidateEndpoint")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, string) error); ok {
+		r0 = rf(ctx, endpoint)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// WebhookService provides a mock function with no fields
+func (_m *Manager) WebhookService() webhook.Service {
+	ret := _m.Called()
+
+	if len(ret) == 0 {
+		panic("no return value specified for WebhookService")
+	}
+
+	var r0 webhook.Service
+	if rf, ok := ret.Get(0).(func() webhook.Service); ok {
+		r0 = rf()
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(webhook.Service)
+		}
+	}
+
+	return r0
+}
+
 // NewManager creates a new instance of Manager. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
 // The first argument is typically a *testing.T value.
 func NewManager(t interface {
@@ -0,0 +1,70 @@
+// Copyright 2025 Woodpecker Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package oidc
+
+import (
+	"crypto/rand"
+	"crypto/rsa"
+	"crypto/x509"
+	"encoding/hex"
+	"errors"
+	"fmt"
+
+	"github.com/rs/zerolog/log"
+
+	"go.woodpecker-ci.org/woodpecker/v3/server/store"
+	"go.woodpecker-ci.org/woodpecker/v3/server/store/types"
+)
+
+const (
+	privateKeyID = "oidc-private-key"
+	keySize      = 2048
+)
+
+// LoadOrCreateKey loads the signing key of the identity tokens or generates and stores a new one.
+func LoadOrCreateKey(_store store.Store) (*rsa.PrivateKey, error) {
+	privKey, err := _store.ServerConfigGet(privateKeyID)
+	if errors.Is(err, types.RecordNotExist) {
+		key, err := rsa.GenerateKey(rand.Reader, keySize)
+		if err != nil {
+			return nil, fmt.Errorf("failed to generate oidc key: %w", err)
+		}
+		der, err := x509.MarshalPKCS8PrivateKey(key)
+		if err != nil {
+			return nil, fmt.Errorf("failed to encode oidc key: %w", err)
+		}
+		if err := _store.ServerConfigSet(privateKeyID, hex.EncodeToString(der)); err != nil {
+			return nil, fmt.Errorf("failed to store oidc key: %w", err)
+		}
+		log.Debug().Msg("created oidc key")
+		return key, nil
+	} else if err != nil {
+		return nil, fmt.Errorf("failed to load oidc key: %w", err)
+	}
+
+	der, err := hex.DecodeString(privKey)
+	if err != nil {
+		return nil, fmt.Errorf("failed to decode oidc key: %w", err)
+	}
+	key, err := x509.ParsePKCS8PrivateKey(der)
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse oidc key: %w", err)
+	}
+	rsaKey, ok := key.(*rsa.PrivateKey)
+	if !ok {
+		return nil, errors.New("oidc key is no rsa key")
+	}
+	return rsaKey, nil
+}
@@ -0,0 +1,160 @@
+// Copyright 2025 Woodpecker Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package oidc issues OIDC identity tokens to workflows, which external
+// systems can verify using the published discovery document and keys.
+package oidc
+
+import (
+	"crypto/rsa"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/json"
+	"fmt"
+	"math/big"
+	"time"
+
+	"github.com/golang-jwt/jwt/v5"
+)
+
+const (
+	// DiscoveryPath is the path of the discovery document relative to the issuer.
+	DiscoveryPath = "/.well-known/openid-configuration"
+	// KeysPath is the path of the JSON web key set relative to the issuer.
+	KeysPath = "/.well-known/jwks.json"
+
+	signingAlgorithm = "RS256"
+)
+
+// Claims are the claims of a workflow identity token.
+type Claims struct {
+	jwt.RegisteredClaims
+	RepoID         int64  `json:"repo_id"`
+	Repo           string `json:"repo"`
+	RepoOwner      string `json:"repo_owner"`
+	Ref            string `json:"ref"`
+	Branch         string `json:"branch,omitempty"`
+	Commit         string `json:"commit"`
+	Event          string `json:"event"`
+	PipelineNumber int64  `json:"pipeline_number"`
+	Workflow       string `json:"workflow"`
+	DeployTarget   string `json:"deploy_target,omitempty"`
+}
+
+// Provider issues the identity tokens.
+type Provider struct {
+	issuer string
+	key    *rsa.PrivateKey
+	keyID  string
+}
+
+// New returns a provider issuing tokens for the issuer url signed with key.
+func New(issuer string, key *rsa.PrivateKey) *Provider {
+	return &Provider{
+		issuer: issuer,
+		key:    key,
+		keyID:  thumbprint(&key.PublicKey),
+	}
+}
+
+// Issuer returns the issuer url of the tokens.
+func (p *Provider) Issuer() string {
+	return p.issuer
+}
+
+// Issue returns a signed token with the claims for the audience. The subject is
+// derived from the repo and ref and the token is valid until expires.
+func (p *Provider) Issue(claims *Claims, audience string, expires time.Time) (string, error) {
+	now := time.Now()
+	claims.Issuer = p.issuer
+	claims.Subject = fmt.Sprintf("repo:%s:ref:%s", claims.Repo, claims.Ref)
+	claims.Audience = jwt.ClaimStrings{audience}
+	claims.IssuedAt = jwt.NewNumericDate(now)
+	claims.NotBefore = jwt.NewNumericDate(now)
+	claims.ExpiresAt = jwt.NewNumericDate(expires)
+
+	token := jwt.NewWithClaims(jwt.SigningMethodRS256, claims)
+	token.Header["kid"] = p.keyID
+	return token.SignedString(p.key)
+}
+
+// Discovery is the OpenID provider metadata.
+type Discovery struct {
+	Issuer                           string   `json:"issuer"`
+	JWKSURI                          string   `json:"jwks_uri"`
+	ResponseTypesSupported           []string `json:"response_types_supported"`
+	SubjectTypesSupported            []string `json:"subject_types_supported"`
+	IDTokenSigningAlgValuesSupported []string `json:"id_token_signing_alg_values_supported"`
+	ClaimsSupported                  []string `json:"claims_supported"`
+}
+
+// Discovery returns the OpenID provider metadata served at DiscoveryPath.
+func (p *Provider) Discovery() *Discovery {
+	return &Discovery{
+		Issuer:                           p.issuer,
+		JWKSURI:                          p.issuer + KeysPath,
+		ResponseTypesSupported:           []string{"id_token"},
+		SubjectTypesSupported:            []string{"public"},
+		IDTokenSigningAlgValuesSupported: []string{signingAlgorithm},
+		ClaimsSupported: []string{
+			"iss", "sub", "aud", "exp", "iat", "nbf",
+			"repo_id", "repo", "repo_owner", "ref", "branch", "commit", "event", "pipeline_number", "workflow", "deploy_target",
+		},
+	}
+}
+
+// JWK is the public part of a RSA signing key.
+type JWK struct {
+	KeyType   string `json:"kty"`
+	Use       string `json:"use"`
+	Algorithm string `json:"alg"`
+	KeyID     string `json:"kid"`
+	Modulus   string `json:"n"`
+	Exponent  string `json:"e"`
+}
+
+// JWKS is a JSON web key set.
+type JWKS struct {
+	Keys []JWK `json:"keys"`
+}
+
+// Keys returns the key set to verify the tokens served at KeysPath.
+func (p *Provider) Keys() *JWKS {
+	return &JWKS{
+		Keys: []JWK{{
+			KeyType:   "RSA",
+			Use:       "sig",
+			Algorithm: signingAlgorithm,
+			KeyID:     p.keyID,
+			Modulus:   encodeBigInt(p.key.PublicKey.N),
+			Exponent:  encodeBigInt(big.NewInt(int64(p.key.PublicKey.E))),
+		}},
+	}
+}
+
+// thumbprint returns the JWK thumbprint of the key as defined by RFC 7638.
+func thumbprint(key *rsa.PublicKey) string {
+	// the members have to be ordered lexicographically, which json.Marshal does for maps
+	data, _ := json.Marshal(map[string]string{
+		"e":   encodeBigInt(big.NewInt(int64(key.E))),
+		"kty": "RSA",
+		"n":   encodeBigInt(key.N),
+	})
+	hash := sha256.Sum256(data)
+	return base64.RawURLEncoding.EncodeToString(hash[:])
+}
+
+func encodeBigInt(i *big.Int) string {
+	return base64.RawURLEncoding.EncodeToString(i.Bytes())
+}
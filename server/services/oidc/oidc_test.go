@@ -0,0 +1,74 @@
+// Copyright 2025 Woodpecker Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package oidc
+
+import (
+	"crypto/rand"
+	"crypto/rsa"
+	"encoding/base64"
+	"math/big"
+	"testing"
+	"time"
+
+	"github.com/golang-jwt/jwt/v5"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestIssue(t *testing.T) {
+	key, err := rsa.GenerateKey(rand.Reader, keySize)
+	require.NoError(t, err)
+	provider := New("https://ci.example.com", key)
+
+	signed, err := provider.Issue(&Claims{
+		RepoID:         1,
+		Repo:           "octocat/hello-world",
+		RepoOwner:      "octocat",
+		Ref:            "refs/heads/main",
+		Branch:         "main",
+		Event:          "push",
+		PipelineNumber: 7,
+		Workflow:       "deploy",
+	}, "sts.amazonaws.com", time.Now().Add(time.Hour))
+	require.NoError(t, err)
+
+	// verify the token like an external system using the published key set
+	jwk := provider.Keys().Keys[0]
+	claims := new(Claims)
+	token, err := jwt.ParseWithClaims(signed, claims, func(token *jwt.Token) (any, error) {
+		assert.Equal(t, jwk.KeyID, token.Header["kid"])
+		n, err := base64.RawURLEncoding.DecodeString(jwk.Modulus)
+		require.NoError(t, err)
+		e, err := base64.RawURLEncoding.DecodeString(jwk.Exponent)
+		require.NoError(t, err)
+		return &rsa.PublicKey{N: new(big.Int).SetBytes(n), E: int(new(big.Int).SetBytes(e).Int64())}, nil
+	}, jwt.WithValidMethods([]string{jwk.Algorithm}), jwt.WithAudience("sts.amazonaws.com"), jwt.WithIssuer(provider.Discovery().Issuer))
+	require.NoError(t, err)
+	assert.True(t, token.Valid)
+
+	assert.Equal(t, "repo:octocat/hello-world:ref:refs/heads/main", claims.Subject)
+	assert.Equal(t, "deploy", claims.Workflow)
+	assert.EqualValues(t, 7, claims.PipelineNumber)
+}
+
+func TestDiscovery(t *testing.T) {
+	key, err := rsa.GenerateKey(rand.Reader, keySize)
+	require.NoError(t, err)
+
+	discovery := New("https://ci.example.com/woodpecker", key).Discovery()
+	assert.Equal(t, "https://ci.example.com/woodpecker", discovery.Issuer)
+	assert.Equal(t, "https://ci.example.com/woodpecker/.well-known/jwks.json", discovery.JWKSURI)
+	assert.Equal(t, []string{"RS256"}, discovery.IDTokenSigningAlgValuesSupported)
+}
@@ -0,0 +1,86 @@
+// Copyright 2025 Woodpecker Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package registry
+
+import (
+	"crypto/ed25519"
+	"fmt"
+
+	"go.woodpecker-ci.org/woodpecker/v3/server/model"
+	"go.woodpecker-ci.org/woodpecker/v3/server/services/utils"
+)
+
+// http serves the registries of the wrapped service, extended by the read-only credentials of the extension.
+type http struct {
+	Service
+	extension *utils.PipelineExtension
+}
+
+// registryData is a registry credential returned by the external service.
+type registryData struct {
+	Address  string `json:"address"`
+	Username string `json:"username"`
+	Password string `json:"password"`
+}
+
+type responseStructure struct {
+	Registries []*registryData `json:"registries"`
+}
+
+// NewHTTP returns a registry service which asks the endpoint for the registry credentials of a pipeline
+// in addition to the given service. Credentials of the given service take precedence over credentials
+// for the same address returned by the endpoint.
+func NewHTTP(service Service, endpoint string, privateKey ed25519.PrivateKey) Service {
+	return &http{service, utils.NewPipelineExtension(endpoint, utils.ExtensionRegistry, privateKey)}
+}
+
+func (h *http) RegistryListPipeline(repo *model.Repo, pipeline *model.Pipeline) ([]*model.Registry, error) {
+	registries, err := h.Service.RegistryListPipeline(repo, pipeline)
+	if err != nil {
+		return nil, err
+	}
+
+	response := new(responseStructure)
+	ok, err := h.extension.Fetch(repo, pipeline, response)
+	if err != nil {
+		return nil, err
+	}
+	if !ok {
+		return registries, nil
+	}
+
+	exists := make(map[string]struct{}, len(registries))
+	for _, registry := range registries {
+		exists[registry.Address] = struct{}{}
+	}
+	for _, data := range response.Registries {
+		registry := &model.Registry{
+			Address:  data.Address,
+			Username: data.Username,
+			Password: data.Password,
+			ReadOnly: true,
+		}
+		if err := registry.Validate(); err != nil {
+			return nil, fmt.Errorf("invalid registry '%s' returned by registry service: %w", data.Address, err)
+		}
+		if _, ok := exists[registry.Address]; ok {
+			continue
+		}
+		exists[registry.Address] = struct{}{}
+		registries = append(registries, registry)
+	}
+
+	return registries, nil
+}
@@ -0,0 +1,66 @@
+// Copyright 2025 Woodpecker Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package registry_test
+
+import (
+	"crypto/ed25519"
+	"crypto/rand"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+
+	"go.woodpecker-ci.org/woodpecker/v3/server/model"
+	"go.woodpecker-ci.org/woodpecker/v3/server/services/registry"
+	mocks_store "go.woodpecker-ci.org/woodpecker/v3/server/store/mocks"
+)
+
+func TestHTTPRegistryListPipeline(t *testing.T) {
+	_, privateKey, err := ed25519.GenerateKey(rand.Reader)
+	require.NoError(t, err)
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+		_, _ = w.Write([]byte(`{"registries": [
+			{"address": "docker.io", "username": "http", "password": "short-lived"},
+			{"address": "ghcr.io", "username": "http", "password": "short-lived"}
+		]}`))
+	}))
+	defer server.Close()
+
+	mockStore := mocks_store.NewStore(t)
+	mockStore.On("RegistryList", mock.Anything, mock.Anything, mock.Anything).Return([]*model.Registry{
+		{ID: 1, RepoID: 1, Address: "docker.io", Username: "db", Password: "long-lived"},
+	}, nil)
+
+	registries, err := registry.NewHTTP(registry.NewDB(mockStore), server.URL, privateKey).RegistryListPipeline(&model.Repo{ID: 1}, &model.Pipeline{})
+	assert.NoError(t, err)
+	if assert.Len(t, registries, 2) {
+		assert.Equal(t, "db", registries[0].Username)
+		assert.Equal(t, "ghcr.io", registries[1].Address)
+		assert.Equal(t, "short-lived", registries[1].Password)
+		assert.True(t, registries[1].ReadOnly)
+	}
+
+	invalid := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+		_, _ = w.Write([]byte(`{"registries": [{"address": "ghcr.io"}]}`))
+	}))
+	defer invalid.Close()
+
+	_, err = registry.NewHTTP(registry.NewDB(mockStore), invalid.URL, privateKey).RegistryListPipeline(&model.Repo{ID: 1}, &model.Pipeline{})
+	assert.Error(t, err)
+}
@@ -15,8 +15,11 @@
 package secret
 
 import (
+	"errors"
+
 	"go.woodpecker-ci.org/woodpecker/v3/server/model"
 	"go.woodpecker-ci.org/woodpecker/v3/server/store"
+	"go.woodpecker-ci.org/woodpecker/v3/server/store/types"
 )
 
 type db struct {
@@ -36,16 +39,25 @@ func (d *db) SecretList(repo *model.Repo, p *model.ListOptions) ([]*model.Secret
 	return d.store.SecretList(repo, false, p)
 }
 
-func (d *db) SecretListPipeline(repo *model.Repo, _ *model.Pipeline) ([]*model.Secret, error) {
+func (d *db) SecretListPipeline(repo *model.Repo, pipeline *model.Pipeline) ([]*model.Secret, error) {
 	s, err := d.store.SecretList(repo, true, &model.ListOptions{All: true})
 	if err != nil {
 		return nil, err
 	}
 
+	envSecrets, err := d.environmentSecrets(repo, pipeline)
+	if err != nil {
+		return nil, err
+	}
+
 	// Return only secrets with unique name
-	// Priority order in case of duplicate names are repository, user/organization, global
-	secrets := make([]*model.Secret, 0, len(s))
+	// Priority order in case of duplicate names are environment, repository, user/organization, global
+	secrets := make([]*model.Secret, 0, len(envSecrets)+len(s))
 	uniq := make(map[string]struct{})
+	for _, secret := range envSecrets {
+		uniq[secret.Name] = struct{}{}
+		secrets = append(secrets, secret)
+	}
 	for _, condition := range []struct {
 		IsRepository   bool
 		IsOrganization bool
@@ -69,6 +81,22 @@ func (d *db) SecretListPipeline(repo *model.Repo, _ *model.Pipeline) ([]*model.S
 	return secrets, nil
 }
 
+// environmentSecrets returns the secrets of the environment a deployment pipeline targets.
+func (d *db) environmentSecrets(repo *model.Repo, pipeline *model.Pipeline) ([]*model.Secret, error) {
+	if pipeline == nil || pipeline.Event != model.EventDeploy {
+		return nil, nil
+	}
+
+	env, err := d.store.EnvironmentFind(repo, pipeline.DeployTo)
+	if errors.Is(err, types.RecordNotExist) {
+		return nil, nil
+	} else if err != nil {
+		return nil, err
+	}
+
+	return d.store.EnvironmentSecretList(env, &model.ListOptions{All: true})
+}
+
 func (d *db) SecretCreate(_ *model.Repo, in *model.Secret) error {
 	return d.store.SecretCreate(in)
 }
@@ -87,3 +87,27 @@ func TestSecretListPipeline(t *testing.T) {
 	assert.Len(t, s, 1)
 	assert.Equal(t, "value-global", s[0].Value)
 }
+
+func TestSecretListPipelineEnvironment(t *testing.T) {
+	mockStore := mocks_store.NewStore(t)
+	env := &model.Environment{ID: 1, RepoID: 1, Name: "production"}
+	envSecret := &model.Secret{ID: 4, RepoID: 1, EnvironmentID: env.ID, Name: "secret", Value: "value-env"}
+
+	mockStore.On("SecretList", mock.Anything, mock.Anything, mock.Anything).Return([]*model.Secret{
+		globalSecret,
+		repoSecret,
+	}, nil)
+	mockStore.On("EnvironmentFind", mock.Anything, "production").Once().Return(env, nil)
+	mockStore.On("EnvironmentSecretList", env, mock.Anything).Once().Return([]*model.Secret{envSecret}, nil)
+
+	s, err := secret.NewDB(mockStore).SecretListPipeline(&model.Repo{ID: 1}, &model.Pipeline{Event: model.EventDeploy, DeployTo: "production"})
+	assert.NoError(t, err)
+	assert.Len(t, s, 1)
+	assert.Equal(t, "value-env", s[0].Value)
+
+	// other events never get environment secrets
+	s, err = secret.NewDB(mockStore).SecretListPipeline(&model.Repo{ID: 1}, &model.Pipeline{Event: model.EventPush, DeployTo: "production"})
+	assert.NoError(t, err)
+	assert.Len(t, s, 1)
+	assert.Equal(t, "value-repo", s[0].Value)
+}
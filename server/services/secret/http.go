@@ -0,0 +1,86 @@
+// Copyright 2025 Woodpecker Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package secret
+
+import (
+	"crypto/ed25519"
+	"fmt"
+
+	"go.woodpecker-ci.org/woodpecker/v3/server/model"
+	"go.woodpecker-ci.org/woodpecker/v3/server/services/utils"
+)
+
+// http wraps a secret service and adds the secrets returned by the extension.
+type http struct {
+	Service
+	extension *utils.PipelineExtension
+}
+
+// secretData is a secret returned by the external service.
+type secretData struct {
+	Name   string               `json:"name"`
+	Value  string               `json:"value"`
+	Images []string             `json:"images"`
+	Events []model.WebhookEvent `json:"events"`
+}
+
+type responseStructure struct {
+	Secrets []*secretData `json:"secrets"`
+}
+
+// NewHTTP returns a secret service which asks the endpoint for the secrets of a pipeline in addition to the given service.
+// Secrets of the given service take precedence over secrets with the same name returned by the endpoint.
+func NewHTTP(service Service, endpoint string, privateKey ed25519.PrivateKey) Service {
+	return &http{service, utils.NewPipelineExtension(endpoint, utils.ExtensionSecret, privateKey)}
+}
+
+func (h *http) SecretListPipeline(repo *model.Repo, pipeline *model.Pipeline) ([]*model.Secret, error) {
+	secrets, err := h.Service.SecretListPipeline(repo, pipeline)
+	if err != nil {
+		return nil, err
+	}
+
+	response := new(responseStructure)
+	ok, err := h.extension.Fetch(repo, pipeline, response)
+	if err != nil {
+		return nil, err
+	}
+	if !ok {
+		return secrets, nil
+	}
+
+	uniq := make(map[string]struct{}, len(secrets))
+	for _, secret := range secrets {
+		uniq[secret.Name] = struct{}{}
+	}
+	for _, data := range response.Secrets {
+		secret := &model.Secret{
+			Name:   data.Name,
+			Value:  data.Value,
+			Images: data.Images,
+			Events: data.Events,
+		}
+		if err := secret.Validate(); err != nil {
+			return nil, fmt.Errorf("invalid secret '%s' returned by secret service: %w", data.Name, err)
+		}
+		if _, ok := uniq[secret.Name]; ok {
+			continue
+		}
+		uniq[secret.Name] = struct{}{}
+		secrets = append(secrets, secret)
+	}
+
+	return secrets, nil
+}
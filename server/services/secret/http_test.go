@@ -0,0 +1,110 @@
+// Copyright 2025 Woodpecker Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package secret_test
+
+import (
+	"crypto/ed25519"
+	"crypto/rand"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+
+	"go.woodpecker-ci.org/woodpecker/v3/server/model"
+	"go.woodpecker-ci.org/woodpecker/v3/server/services/secret"
+	mocks_store "go.woodpecker-ci.org/woodpecker/v3/server/store/mocks"
+)
+
+func TestHTTPSecretListPipeline(t *testing.T) {
+	_, privateKey, err := ed25519.GenerateKey(rand.Reader)
+	require.NoError(t, err)
+
+	var request struct {
+		Repo     *model.Repo     `json:"repo"`
+		Pipeline *model.Pipeline `json:"pipeline"`
+	}
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.NoError(t, json.NewDecoder(r.Body).Decode(&request))
+		assert.NotEmpty(t, r.Header.Get("Signature"))
+		_, _ = w.Write([]byte(`{"secrets": [
+			{"name": "secret", "value": "value-http", "events": ["push"]},
+			{"name": "other", "value": "value-other", "events": ["push"]}
+		]}`))
+	}))
+	defer server.Close()
+
+	mockStore := mocks_store.NewStore(t)
+	mockStore.On("SecretList", mock.Anything, mock.Anything, mock.Anything).Once().Return([]*model.Secret{
+		globalSecret,
+	}, nil)
+
+	service := secret.NewHTTP(secret.NewDB(mockStore), server.URL, privateKey)
+	s, err := service.SecretListPipeline(&model.Repo{ID: 1, FullName: "foo/bar"}, &model.Pipeline{Number: 7})
+	assert.NoError(t, err)
+
+	assert.Equal(t, "foo/bar", request.Repo.FullName)
+	assert.EqualValues(t, 7, request.Pipeline.Number)
+	if assert.Len(t, s, 2) {
+		assert.Equal(t, "value-global", s[0].Value)
+		assert.Equal(t, "value-other", s[1].Value)
+		assert.Equal(t, []model.WebhookEvent{model.EventPush}, s[1].Events)
+	}
+}
+
+func TestHTTPSecretListPipelineNoContent(t *testing.T) {
+	_, privateKey, err := ed25519.GenerateKey(rand.Reader)
+	require.NoError(t, err)
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+		w.WriteHeader(http.StatusNoContent)
+	}))
+	defer server.Close()
+
+	mockStore := mocks_store.NewStore(t)
+	mockStore.On("SecretList", mock.Anything, mock.Anything, mock.Anything).Once().Return([]*model.Secret{
+		repoSecret,
+	}, nil)
+
+	s, err := secret.NewHTTP(secret.NewDB(mockStore), server.URL, privateKey).SecretListPipeline(&model.Repo{}, &model.Pipeline{})
+	assert.NoError(t, err)
+	assert.Equal(t, []*model.Secret{repoSecret}, s)
+}
+
+func TestHTTPSecretListPipelineInvalid(t *testing.T) {
+	_, privateKey, err := ed25519.GenerateKey(rand.Reader)
+	require.NoError(t, err)
+
+	for _, handler := range []http.HandlerFunc{
+		func(w http.ResponseWriter, _ *http.Request) {
+			w.WriteHeader(http.StatusInternalServerError)
+		},
+		func(w http.ResponseWriter, _ *http.Request) {
+			_, _ = w.Write([]byte(`{"secrets": [{"name": "secret", "value": "value"}]}`))
+		},
+	} {
+		server := httptest.NewServer(handler)
+
+		mockStore := mocks_store.NewStore(t)
+		mockStore.On("SecretList", mock.Anything, mock.Anything, mock.Anything).Once().Return(nil, nil)
+
+		_, err := secret.NewHTTP(secret.NewDB(mockStore), server.URL, privateKey).SecretListPipeline(&model.Repo{}, &model.Pipeline{})
+		assert.Error(t, err)
+		server.Close()
+	}
+}
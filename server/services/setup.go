@@ -34,18 +34,22 @@ import (
 	"go.woodpecker-ci.org/woodpecker/v3/server/store/types"
 )
 
-func setupRegistryService(store store.Store, dockerConfig string) registry.Service {
+func setupRegistryService(store store.Store, dockerConfig, endpoint string, privateSignatureKey ed25519.PrivateKey) registry.Service {
+	registryService := registry.NewDB(store)
 	if dockerConfig != "" {
-		return registry.NewCombined(
-			registry.NewDB(store),
+		registryService = registry.NewCombined(
+			registryService,
 			registry.NewFilesystem(dockerConfig),
 		)
 	}
+	if endpoint != "" {
+		registryService = registry.NewHTTP(registryService, endpoint, privateSignatureKey)
+	}
 
-	return registry.NewDB(store)
+	return registryService
 }
 
-func setupSecretService(store store.Store) secret.Service {
+func setupSecretService(store store.Store, endpoint string, privateSignatureKey ed25519.PrivateKey) secret.Service {
 	// TODO(1544): fix encrypted store
 	// // encryption
 	// encryptedSecretStore := encryptedStore.NewSecretStore(v)
@@ -54,29 +58,34 @@ func setupSecretService(store store.Store) secret.Service {
 	// 	log.Fatal().Err(err).Msg("could not create encryption service")
 	// }
 
-	return secret.NewDB(store)
+	secretService := secret.NewDB(store)
+	if endpoint != "" {
+		return secret.NewHTTP(secretService, endpoint, privateSignatureKey)
+	}
+
+	return secretService
 }
 
-func setupConfigService(c *cli.Command, privateSignatureKey ed25519.PrivateKey) (config.Service, error) {
+func setupForgeConfigService(c *cli.Command) (config.Service, error) {
 	timeout := c.Duration("forge-timeout")
 	retries := c.Uint("forge-retry")
 	if retries == 0 {
 		return nil, fmt.Errorf("WOODPECKER_FORGE_RETRY can not be 0")
 	}
-	configFetcher := config.NewForge(timeout, uint(retries))
+	return config.NewForge(timeout, uint(retries)), nil
+}
 
+func setupConfigService(c *cli.Command, configFetcher config.Service, privateSignatureKey ed25519.PrivateKey) config.Service {
 	if endpoint := c.String("config-service-endpoint"); endpoint != "" {
 		httpFetcher := config.NewHTTP(endpoint, privateSignatureKey)
-		return config.NewCombined(configFetcher, httpFetcher), nil
+		return config.NewCombined(configFetcher, httpFetcher)
 	}
 
-	return configFetcher, nil
+	return configFetcher
 }
 
-// setupSignatureKeys generate or load key pair to sign webhooks requests (i.e. used for service extensions).
-func setupSignatureKeys(_store store.Store) (ed25519.PrivateKey, crypto.PublicKey, error) {
-	privKeyID := "signature-private-key"
-
+// setupSignatureKeys generate or load the key pair stored as privKeyID to sign webhooks requests (i.e. used for service extensions).
+func setupSignatureKeys(_store store.Store, privKeyID string) (ed25519.PrivateKey, crypto.PublicKey, error) {
 	privKey, err := _store.ServerConfigGet(privKeyID)
 	if errors.Is(err, types.RecordNotExist) {
 		_, privKey, err := ed25519.GenerateKey(rand.Reader)
@@ -0,0 +1,165 @@
+// Copyright 2025 Woodpecker Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package utils
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net"
+	"net/http"
+	"net/url"
+	"slices"
+	"strings"
+	"syscall"
+	"time"
+)
+
+const (
+	dialTimeout     = 10 * time.Second
+	idleConnTimeout = 90 * time.Second
+	maxIdleConns    = 100
+)
+
+// ErrForbiddenAddress is returned if an endpoint is in a network which is not allowed.
+var ErrForbiddenAddress = errors.New("address is not allowed")
+
+// sharedAddressSpace is the carrier-grade NAT range (RFC 6598), which is not covered by net.IP.IsPrivate.
+var sharedAddressSpace = &net.IPNet{IP: net.IPv4(100, 64, 0, 0), Mask: net.CIDRMask(10, 32)} //nolint:mnd
+
+// AddressFilter guards requests to endpoints configured by users. It rejects loopback,
+// link-local and private addresses unless the host or network was allowed by an admin.
+type AddressFilter struct {
+	hosts    []string
+	networks []*net.IPNet
+	client   *http.Client
+}
+
+// NewAddressFilter returns a filter which additionally allows the given hosts, IPs and CIDR ranges.
+func NewAddressFilter(allowed []string) (*AddressFilter, error) {
+	f := &AddressFilter{}
+	for _, entry := range allowed {
+		entry = strings.TrimSpace(entry)
+		switch {
+		case entry == "":
+			continue
+		case strings.Contains(entry, "/"):
+			_, network, err := net.ParseCIDR(entry)
+			if err != nil {
+				return nil, fmt.Errorf("invalid allowed network %s: %w", entry, err)
+			}
+			f.networks = append(f.networks, network)
+		case net.ParseIP(entry) != nil:
+			ip := net.ParseIP(entry)
+			if ip4 := ip.To4(); ip4 != nil {
+				ip = ip4
+			}
+			f.networks = append(f.networks, &net.IPNet{IP: ip, Mask: net.CIDRMask(len(ip)*8, len(ip)*8)}) //nolint:mnd
+		default:
+			f.hosts = append(f.hosts, strings.ToLower(entry))
+		}
+	}
+
+	// requests don't use a proxy, the filter could only check the address of the proxy
+	f.client = &http.Client{
+		Transport: &http.Transport{
+			DialContext:         f.dialContext,
+			ForceAttemptHTTP2:   true,
+			TLSHandshakeTimeout: dialTimeout,
+			MaxIdleConns:        maxIdleConns,
+			IdleConnTimeout:     idleConnTimeout,
+		},
+	}
+	return f, nil
+}
+
+// ValidateURL returns an error if the host of the url resolves to an address which is not allowed.
+// Hosts which can't be resolved are accepted, the address is checked again for every request.
+func (f *AddressFilter) ValidateURL(ctx context.Context, rawURL string) error {
+	u, err := url.Parse(rawURL)
+	if err != nil {
+		return err
+	}
+	host := u.Hostname()
+	if f.hostAllowed(host) {
+		return nil
+	}
+
+	if ip := net.ParseIP(host); ip != nil {
+		return f.checkIP(ip)
+	}
+	addrs, err := net.DefaultResolver.LookupIPAddr(ctx, host)
+	if err != nil {
+		return nil
+	}
+	for _, addr := range addrs {
+		if err := f.checkIP(addr.IP); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// Client returns an http client which checks the address of every connection it opens,
+// so a host can't point to another address after it was validated. Without a filter it
+// returns the default client.
+func (f *AddressFilter) Client() *http.Client {
+	if f == nil {
+		return http.DefaultClient
+	}
+	return f.client
+}
+
+func (f *AddressFilter) dialContext(ctx context.Context, network, address string) (net.Conn, error) {
+	host, _, err := net.SplitHostPort(address)
+	if err != nil {
+		return nil, err
+	}
+
+	dialer := &net.Dialer{Timeout: dialTimeout}
+	if !f.hostAllowed(host) {
+		dialer.Control = func(_, address string, _ syscall.RawConn) error {
+			host, _, err := net.SplitHostPort(address)
+			if err != nil {
+				return err
+			}
+			ip := net.ParseIP(host)
+			if ip == nil {
+				return fmt.Errorf("%w: %s", ErrForbiddenAddress, host)
+			}
+			return f.checkIP(ip)
+		}
+	}
+	return dialer.DialContext(ctx, network, address)
+}
+
+func (f *AddressFilter) hostAllowed(host string) bool {
+	return slices.Contains(f.hosts, strings.ToLower(host))
+}
+
+func (f *AddressFilter) checkIP(ip net.IP) error {
+	for _, network := range f.networks {
+		if network.Contains(ip) {
+			return nil
+		}
+	}
+
+	if ip.IsLoopback() || ip.IsPrivate() || ip.IsUnspecified() ||
+		ip.IsLinkLocalUnicast() || ip.IsLinkLocalMulticast() || ip.IsInterfaceLocalMulticast() ||
+		sharedAddressSpace.Contains(ip) {
+		return fmt.Errorf("%w: %s", ErrForbiddenAddress, ip)
+	}
+	return nil
+}
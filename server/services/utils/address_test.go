@@ -0,0 +1,90 @@
+// Copyright 2025 Woodpecker Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package utils
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestAddressFilterValidateURL(t *testing.T) {
+	filter, err := NewAddressFilter([]string{"internal.example.com", "10.1.0.0/16", "192.168.1.1"})
+	require.NoError(t, err)
+
+	tests := []struct {
+		url     string
+		allowed bool
+	}{
+		{url: "https://93.184.215.14/hook", allowed: true},
+		{url: "https://[2606:2800:21f:cb07:6820:80da:af6b:8b2c]/hook", allowed: true},
+		{url: "http://127.0.0.1:8000/hook"},
+		{url: "http://[::1]/hook"},
+		{url: "http://[::ffff:127.0.0.1]/hook"},
+		{url: "http://0.0.0.0/hook"},
+		{url: "http://169.254.169.254/latest/meta-data"},
+		{url: "http://[fe80::1]/hook"},
+		{url: "http://10.0.0.1/hook"},
+		{url: "http://172.16.0.1/hook"},
+		{url: "http://100.64.0.1/hook"},
+		{url: "http://[fd00::1]/hook"},
+		{url: "http://localhost/hook"},
+		{url: "http://10.1.2.3/hook", allowed: true},
+		{url: "http://192.168.1.1/hook", allowed: true},
+		{url: "http://192.168.1.2/hook"},
+		{url: "http://internal.example.com/hook", allowed: true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.url, func(t *testing.T) {
+			err := filter.ValidateURL(context.Background(), tt.url)
+			if tt.allowed {
+				assert.NoError(t, err)
+			} else {
+				assert.ErrorIs(t, err, ErrForbiddenAddress)
+			}
+		})
+	}
+}
+
+func TestAddressFilterInvalid(t *testing.T) {
+	_, err := NewAddressFilter([]string{"10.0.0.0/33"})
+	assert.Error(t, err)
+}
+
+func TestAddressFilterClient(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+		w.WriteHeader(http.StatusNoContent)
+	}))
+	defer server.Close()
+
+	filter, err := NewAddressFilter(nil)
+	require.NoError(t, err)
+	_, err = filter.Client().Get(server.URL) //nolint:noctx
+	assert.ErrorIs(t, err, ErrForbiddenAddress)
+
+	filter, err = NewAddressFilter([]string{"127.0.0.1"})
+	require.NoError(t, err)
+	resp, err := filter.Client().Get(server.URL) //nolint:noctx
+	require.NoError(t, err)
+	defer resp.Body.Close()
+	assert.Equal(t, http.StatusNoContent, resp.StatusCode)
+
+	// the connections are reused by all requests
+	assert.Same(t, filter.Client(), filter.Client())
+}
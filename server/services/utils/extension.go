@@ -0,0 +1,62 @@
+// Copyright 2025 Woodpecker Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package utils
+
+import (
+	"context"
+	"crypto/ed25519"
+	"fmt"
+	"net/http"
+	"time"
+
+	"go.woodpecker-ci.org/woodpecker/v3/server/model"
+)
+
+const extensionTimeout = 30 * time.Second
+
+// PipelineExtension is an external service of the instance which adds data, like secrets
+// or registry credentials, to a pipeline.
+type PipelineExtension struct {
+	endpoint   string
+	extension  string
+	privateKey ed25519.PrivateKey
+}
+
+type pipelineRequest struct {
+	Repo     *model.Repo     `json:"repo"`
+	Pipeline *model.Pipeline `json:"pipeline"`
+}
+
+// NewPipelineExtension returns an extension of the given kind which signs its requests with the private key.
+func NewPipelineExtension(endpoint, extension string, privateKey ed25519.PrivateKey) *PipelineExtension {
+	return &PipelineExtension{endpoint, extension, privateKey}
+}
+
+// Fetch sends the repo and the pipeline to the extension and decodes its response into out.
+// It returns false if the extension has nothing to add and responded with another status than 200.
+func (e *PipelineExtension) Fetch(repo *model.Repo, pipeline *model.Pipeline, out any) (bool, error) {
+	ctx, cancel := context.WithTimeout(context.Background(), extensionTimeout)
+	defer cancel()
+
+	body := pipelineRequest{
+		Repo:     repo,
+		Pipeline: pipeline,
+	}
+	status, err := Send(ctx, nil, http.MethodPost, e.endpoint, e.extension, e.privateKey, body, out)
+	if err != nil && status != http.StatusNoContent {
+		return false, fmt.Errorf("failed to fetch from %s extension via http (%d) %w", e.extension, status, err)
+	}
+	return status == http.StatusOK, nil
+}
@@ -0,0 +1,67 @@
+// Copyright 2025 Woodpecker Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package utils
+
+import (
+	"crypto/ed25519"
+	"crypto/rand"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"go.woodpecker-ci.org/woodpecker/v3/server/model"
+)
+
+func TestPipelineExtensionFetch(t *testing.T) {
+	_, privateKey, err := ed25519.GenerateKey(rand.Reader)
+	require.NoError(t, err)
+
+	status := http.StatusOK
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, ExtensionSecret, r.Header.Get(ExtensionHeader))
+		var request pipelineRequest
+		assert.NoError(t, json.NewDecoder(r.Body).Decode(&request))
+		assert.Equal(t, "foo/bar", request.Repo.FullName)
+		w.WriteHeader(status)
+		if status == http.StatusOK {
+			_, _ = w.Write([]byte(`{"value": "ok"}`))
+		}
+	}))
+	defer server.Close()
+
+	extension := NewPipelineExtension(server.URL, ExtensionSecret, privateKey)
+	repo := &model.Repo{FullName: "foo/bar"}
+
+	var response struct {
+		Value string `json:"value"`
+	}
+	ok, err := extension.Fetch(repo, &model.Pipeline{}, &response)
+	assert.NoError(t, err)
+	assert.True(t, ok)
+	assert.Equal(t, "ok", response.Value)
+
+	status = http.StatusNoContent
+	ok, err = extension.Fetch(repo, &model.Pipeline{}, &response)
+	assert.NoError(t, err)
+	assert.False(t, ok)
+
+	status = http.StatusInternalServerError
+	_, err = extension.Fetch(repo, &model.Pipeline{}, &response)
+	assert.Error(t, err)
+}
@@ -23,13 +23,26 @@ import (
 	"io"
 	"net/http"
 	"net/url"
+	"strings"
 
 	"github.com/yaronf/httpsign"
 )
 
+// ExtensionHeader is the signed header which holds the kind of extension a request is sent to,
+// so a request to one kind of extension can't be replayed to another.
+const ExtensionHeader = "X-Woodpecker-Extension"
+
+const (
+	ExtensionConfig   = "config"
+	ExtensionSecret   = "secret"
+	ExtensionRegistry = "registry"
+	ExtensionWebhook  = "webhook"
+)
+
 // Send makes an http request to the given endpoint, writing the input
 // to the request body and un-marshaling the output from the response body.
-func Send(ctx context.Context, method, path string, privateKey ed25519.PrivateKey, in, out any) (int, error) {
+// The request is signed for the given kind of extension, a nil filter doesn't check the address.
+func Send(ctx context.Context, filter *AddressFilter, method, path, extension string, privateKey ed25519.PrivateKey, in, out any) (int, error) {
 	uri, err := url.Parse(path)
 	if err != nil {
 		return 0, err
@@ -53,8 +66,9 @@ func Send(ctx context.Context, method, path string, privateKey ed25519.PrivateKe
 	if in != nil {
 		req.Header.Set("Content-Type", "application/json")
 	}
+	req.Header.Set(ExtensionHeader, extension)
 
-	client, err := signClient(privateKey)
+	client, err := signClient(privateKey, filter.Client())
 	if err != nil {
 		return 0, err
 	}
@@ -79,14 +93,48 @@ func Send(ctx context.Context, method, path string, privateKey ed25519.PrivateKe
 	return resp.StatusCode, err
 }
 
-func signClient(privateKey ed25519.PrivateKey) (*httpsign.Client, error) {
+// SendSigned makes a signed http request with the given body and headers to the endpoint.
+// Unlike Send it accepts any response and only returns the status code, the body is discarded.
+func SendSigned(ctx context.Context, filter *AddressFilter, method, path, extension string, privateKey ed25519.PrivateKey, header http.Header, body []byte) (int, error) {
+	uri, err := url.Parse(path)
+	if err != nil {
+		return 0, err
+	}
+
+	req, err := http.NewRequestWithContext(ctx, method, uri.String(), bytes.NewReader(body))
+	if err != nil {
+		return 0, err
+	}
+	for key, values := range header {
+		for _, value := range values {
+			req.Header.Add(key, value)
+		}
+	}
+	req.Header.Set(ExtensionHeader, extension)
+
+	client, err := signClient(privateKey, filter.Client())
+	if err != nil {
+		return 0, err
+	}
+
+	resp, err := client.Do(req)
+	if err != nil {
+		return 0, err
+	}
+	defer resp.Body.Close()
+
+	_, _ = io.Copy(io.Discard, resp.Body)
+	return resp.StatusCode, nil
+}
+
+func signClient(privateKey ed25519.PrivateKey, httpClient *http.Client) (*httpsign.Client, error) {
 	pubKeyID := "woodpecker-ci-extensions"
 
 	signer, err := httpsign.NewEd25519Signer(privateKey,
 		httpsign.NewSignConfig(),
-		httpsign.Headers("@request-target", "content-digest")) // The Content-Digest header will be auto-generated
+		httpsign.Headers("@request-target", "@authority", "content-digest", strings.ToLower(ExtensionHeader))) // The Content-Digest header will be auto-generated
 	if err != nil {
 		return nil, err
 	}
-	return httpsign.NewDefaultClient(httpsign.NewClientConfig().SetSignatureName(pubKeyID).SetSigner(signer)), nil // sign requests, don't verify responses
+	return httpsign.NewClient(*httpClient, httpsign.NewClientConfig().SetSignatureName(pubKeyID).SetSigner(signer)), nil // sign requests, don't verify responses
 }
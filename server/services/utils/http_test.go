@@ -16,14 +16,17 @@ package utils
 
 import (
 	"bytes"
+	"context"
 	"crypto/ed25519"
 	"crypto/rand"
+	"io"
 	"net/http"
 	"net/http/httptest"
 	"testing"
 	"time"
 
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
 	"github.com/yaronf/httpsign"
 )
 
@@ -40,7 +43,7 @@ func TestSignClient(t *testing.T) {
 	verifyHandler := func(w http.ResponseWriter, r *http.Request) {
 		verifier, err := httpsign.NewEd25519Verifier(pubEd25519Key,
 			httpsign.NewVerifyConfig(),
-			httpsign.Headers("@request-target", "content-digest")) // The Content-Digest header will be auto-generated
+			httpsign.Headers("@request-target", "@authority", "content-digest", "x-woodpecker-extension")) // The Content-Digest header will be auto-generated
 		assert.NoError(t, err)
 
 		err = httpsign.VerifyRequest(pubKeyID, *verifier, r)
@@ -58,8 +61,9 @@ func TestSignClient(t *testing.T) {
 
 	req.Header.Set("Date", time.Now().Format(time.RFC3339))
 	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set(ExtensionHeader, ExtensionConfig)
 
-	client, err := signClient(privEd25519Key)
+	client, err := signClient(privEd25519Key, http.DefaultClient)
 	if !assert.NoError(t, err) {
 		return
 	}
@@ -70,3 +74,53 @@ func TestSignClient(t *testing.T) {
 
 	assert.Equal(t, http.StatusOK, rr.StatusCode)
 }
+
+func TestSendReplay(t *testing.T) {
+	pubKey, privKey, err := ed25519.GenerateKey(rand.Reader)
+	require.NoError(t, err)
+
+	// only verify the components checked by older extensions
+	verifier, err := httpsign.NewEd25519Verifier(pubKey, httpsign.NewVerifyConfig(), httpsign.Headers("@request-target", "content-digest"))
+	require.NoError(t, err)
+	verify := func(r *http.Request) error {
+		return httpsign.VerifyRequest("woodpecker-ci-extensions", *verifier, r)
+	}
+
+	var captured *http.Request
+	var body []byte
+	configExtension := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.NoError(t, verify(r))
+		assert.Equal(t, ExtensionConfig, r.Header.Get(ExtensionHeader))
+		captured = r.Clone(context.Background())
+		body, _ = io.ReadAll(r.Body)
+		_, _ = w.Write([]byte("{}"))
+	}))
+	defer configExtension.Close()
+
+	var replayErr error
+	secretExtension := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		replayErr = verify(r)
+		w.WriteHeader(http.StatusNoContent)
+	}))
+	defer secretExtension.Close()
+
+	_, err = Send(context.Background(), nil, http.MethodPost, configExtension.URL+"/", ExtensionConfig, privKey, map[string]string{"repo": "a"}, &struct{}{})
+	require.NoError(t, err)
+	require.NotNil(t, captured)
+
+	// the signature covers the host of the config extension
+	replay, err := http.NewRequest(http.MethodPost, secretExtension.URL+"/", bytes.NewReader(body))
+	require.NoError(t, err)
+	replay.Header = captured.Header.Clone()
+	resp, err := http.DefaultClient.Do(replay)
+	require.NoError(t, err)
+	resp.Body.Close()
+	assert.Error(t, replayErr)
+
+	// the signature covers the kind of extension
+	replay, err = http.NewRequest(http.MethodPost, configExtension.URL+"/", bytes.NewReader(body))
+	require.NoError(t, err)
+	replay.Header = captured.Header.Clone()
+	replay.Header.Set(ExtensionHeader, ExtensionSecret)
+	assert.Error(t, verify(replay))
+}
@@ -0,0 +1,180 @@
+// Copyright 2025 Woodpecker Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package webhook delivers pipeline lifecycle events to outgoing webhooks.
+package webhook
+
+import (
+	"context"
+	"crypto/ed25519"
+	"encoding/json"
+	"errors"
+	"net"
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/rs/zerolog/log"
+
+	"go.woodpecker-ci.org/woodpecker/v3/server/model"
+	"go.woodpecker-ci.org/woodpecker/v3/server/services/utils"
+	"go.woodpecker-ci.org/woodpecker/v3/server/store"
+)
+
+const (
+	// maxAttempts is how often a payload is sent until the webhook responds successfully.
+	maxAttempts    = 3
+	defaultBackoff = 10 * time.Second
+	attemptTimeout = 10 * time.Second
+	// keepDeliveries is the number of deliveries kept per webhook.
+	keepDeliveries = 100
+)
+
+// Service delivers pipeline lifecycle events to outgoing webhooks.
+type Service interface {
+	// Send delivers the payload in the background to all active webhooks of the
+	// repository and its organization which are subscribed to the event of the payload.
+	Send(repo *model.Repo, payload *model.WebhookPayload)
+	// Redeliver sends the payload of a previous delivery again in the background and returns the new delivery.
+	Redeliver(hook *model.Webhook, delivery *model.WebhookDelivery) (*model.WebhookDelivery, error)
+}
+
+type service struct {
+	store      store.Store
+	privateKey ed25519.PrivateKey
+	filter     *utils.AddressFilter
+	backoff    time.Duration
+}
+
+// New returns a service which signs the payloads with the private key of the server
+// and only sends them to addresses accepted by the filter.
+func New(store store.Store, privateKey ed25519.PrivateKey, filter *utils.AddressFilter) Service {
+	return &service{
+		store:      store,
+		privateKey: privateKey,
+		filter:     filter,
+		backoff:    defaultBackoff,
+	}
+}
+
+func (s *service) Send(repo *model.Repo, payload *model.WebhookPayload) {
+	// marshal right away, the pipeline may change until the payload is delivered
+	body, err := json.Marshal(payload)
+	if err != nil {
+		log.Error().Err(err).Msgf("webhook: cannot marshal %s payload", payload.Event)
+		return
+	}
+
+	go func() {
+		hooks, err := s.store.WebhookListActive(repo)
+		if err != nil {
+			log.Error().Err(err).Msgf("webhook: cannot list webhooks of repo %s", repo.FullName)
+			return
+		}
+
+		for _, hook := range hooks {
+			if !hook.Subscribed(payload.Event) {
+				continue
+			}
+
+			delivery := &model.WebhookDelivery{
+				WebhookID: hook.ID,
+				Event:     payload.Event,
+				Payload:   string(body),
+			}
+			if err := s.store.WebhookDeliveryCreate(delivery); err != nil {
+				log.Error().Err(err).Msgf("webhook: cannot create delivery for webhook %d", hook.ID)
+				continue
+			}
+			go s.deliver(hook, delivery)
+		}
+	}()
+}
+
+func (s *service) Redeliver(hook *model.Webhook, delivery *model.WebhookDelivery) (*model.WebhookDelivery, error) {
+	redelivery := &model.WebhookDelivery{
+		WebhookID: hook.ID,
+		Event:     delivery.Event,
+		Payload:   delivery.Payload,
+	}
+	if err := s.store.WebhookDeliveryCreate(redelivery); err != nil {
+		return nil, err
+	}
+
+	go s.deliver(hook, redelivery)
+	return redelivery, nil
+}
+
+// deliver sends the payload until the webhook responds successfully, failed
+// attempts are retried with exponential backoff. The result of every attempt is recorded.
+func (s *service) deliver(hook *model.Webhook, delivery *model.WebhookDelivery) {
+	backoff := s.backoff
+	for delivery.Attempts < maxAttempts {
+		if delivery.Attempts > 0 {
+			time.Sleep(backoff)
+			backoff *= 2
+		}
+
+		s.attempt(hook, delivery)
+		if err := s.store.WebhookDeliveryUpdate(delivery); err != nil {
+			log.Error().Err(err).Msgf("webhook: cannot update delivery %d", delivery.ID)
+		}
+		if delivery.Success {
+			break
+		}
+		log.Debug().Msgf("webhook: delivery %d to webhook %d failed: %s", delivery.ID, hook.ID, delivery.Error)
+	}
+
+	if err := s.store.WebhookDeliveryPrune(hook, keepDeliveries); err != nil {
+		log.Error().Err(err).Msgf("webhook: cannot prune deliveries of webhook %d", hook.ID)
+	}
+}
+
+func (s *service) attempt(hook *model.Webhook, delivery *model.WebhookDelivery) {
+	ctx, cancel := context.WithTimeout(context.Background(), attemptTimeout)
+	defer cancel()
+
+	header := http.Header{}
+	header.Set("Content-Type", "application/json")
+	header.Set("X-Woodpecker-Event", string(delivery.Event))
+	header.Set("X-Woodpecker-Delivery", strconv.FormatInt(delivery.ID, 10))
+
+	start := time.Now()
+	status, err := utils.SendSigned(ctx, s.filter, http.MethodPost, hook.URL, utils.ExtensionWebhook, s.privateKey, header, []byte(delivery.Payload))
+
+	delivery.Attempts++
+	delivery.Duration = time.Since(start).Milliseconds()
+	delivery.StatusCode = status
+	switch {
+	case err != nil:
+		delivery.Error = errorClass(err)
+	case status < http.StatusOK || status >= http.StatusMultipleChoices:
+		delivery.Error = model.WebhookDeliveryErrorStatus
+	default:
+		delivery.Error = ""
+	}
+	delivery.Success = delivery.Error == ""
+}
+
+func errorClass(err error) model.WebhookDeliveryError {
+	var netErr net.Error
+	switch {
+	case errors.Is(err, utils.ErrForbiddenAddress):
+		return model.WebhookDeliveryErrorForbiddenAddress
+	case errors.Is(err, context.DeadlineExceeded), errors.As(err, &netErr) && netErr.Timeout():
+		return model.WebhookDeliveryErrorTimeout
+	default:
+		return model.WebhookDeliveryErrorConnection
+	}
+}
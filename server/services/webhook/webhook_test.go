@@ -0,0 +1,160 @@
+// Copyright 2025 Woodpecker Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package webhook
+
+import (
+	"context"
+	"crypto/ed25519"
+	"crypto/rand"
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"path/filepath"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/yaronf/httpsign"
+
+	"go.woodpecker-ci.org/woodpecker/v3/server/model"
+	"go.woodpecker-ci.org/woodpecker/v3/server/services/utils"
+	"go.woodpecker-ci.org/woodpecker/v3/server/store"
+	"go.woodpecker-ci.org/woodpecker/v3/server/store/datastore"
+)
+
+func newTestStore(t *testing.T) store.Store {
+	s, err := datastore.NewEngine(&store.Opts{
+		Driver: "sqlite3",
+		Config: filepath.Join(t.TempDir(), "webhook.sqlite"),
+	})
+	require.NoError(t, err)
+	require.NoError(t, s.Migrate(context.Background(), true))
+	t.Cleanup(func() { _ = s.Close() })
+	return s
+}
+
+func newTestFilter(t *testing.T, allowed ...string) *utils.AddressFilter {
+	filter, err := utils.NewAddressFilter(allowed)
+	require.NoError(t, err)
+	return filter
+}
+
+func TestDeliver(t *testing.T) {
+	publicKey, privateKey, err := ed25519.GenerateKey(rand.Reader)
+	require.NoError(t, err)
+
+	var requests atomic.Int32
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		verifier, err := httpsign.NewEd25519Verifier(publicKey, httpsign.NewVerifyConfig(), httpsign.Headers("@request-target", "content-digest"))
+		assert.NoError(t, err)
+		assert.NoError(t, httpsign.VerifyRequest("woodpecker-ci-extensions", *verifier, r))
+		assert.Equal(t, "pipeline_finished", r.Header.Get("X-Woodpecker-Event"))
+		assert.Equal(t, "webhook", r.Header.Get("X-Woodpecker-Extension"))
+
+		body, err := io.ReadAll(r.Body)
+		assert.NoError(t, err)
+		assert.JSONEq(t, `{"event":"pipeline_finished"}`, string(body))
+
+		// fail the first attempt
+		if requests.Add(1) == 1 {
+			w.WriteHeader(http.StatusServiceUnavailable)
+			return
+		}
+		_, _ = w.Write([]byte("ok"))
+	}))
+	defer server.Close()
+
+	_store := newTestStore(t)
+	hook := &model.Webhook{RepoID: 1, URL: server.URL, Events: []model.HookEvent{model.HookEventPipelineFinished}, Active: true}
+	require.NoError(t, _store.WebhookCreate(hook))
+	delivery := &model.WebhookDelivery{WebhookID: hook.ID, Event: model.HookEventPipelineFinished, Payload: `{"event":"pipeline_finished"}`}
+	require.NoError(t, _store.WebhookDeliveryCreate(delivery))
+
+	s := &service{store: _store, privateKey: privateKey, filter: newTestFilter(t, "127.0.0.1"), backoff: time.Millisecond}
+	s.deliver(hook, delivery)
+
+	delivery, err = _store.WebhookDeliveryFind(hook, delivery.ID)
+	assert.NoError(t, err)
+	assert.True(t, delivery.Success)
+	assert.Equal(t, 2, delivery.Attempts)
+	assert.Equal(t, http.StatusOK, delivery.StatusCode)
+	assert.Empty(t, delivery.Error)
+}
+
+func TestDeliverForbiddenAddress(t *testing.T) {
+	_, privateKey, err := ed25519.GenerateKey(rand.Reader)
+	require.NoError(t, err)
+
+	var requests atomic.Int32
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+		requests.Add(1)
+		_, _ = w.Write([]byte("internal"))
+	}))
+	defer server.Close()
+
+	_store := newTestStore(t)
+	hook := &model.Webhook{RepoID: 1, URL: server.URL, Events: []model.HookEvent{model.HookEventPipelineFinished}, Active: true}
+	require.NoError(t, _store.WebhookCreate(hook))
+	delivery := &model.WebhookDelivery{WebhookID: hook.ID, Event: model.HookEventPipelineFinished, Payload: `{}`}
+	require.NoError(t, _store.WebhookDeliveryCreate(delivery))
+
+	s := &service{store: _store, privateKey: privateKey, filter: newTestFilter(t), backoff: time.Millisecond}
+	s.deliver(hook, delivery)
+
+	delivery, err = _store.WebhookDeliveryFind(hook, delivery.ID)
+	assert.NoError(t, err)
+	assert.False(t, delivery.Success)
+	assert.Zero(t, delivery.StatusCode)
+	assert.Equal(t, model.WebhookDeliveryErrorForbiddenAddress, delivery.Error)
+	assert.Zero(t, requests.Load())
+}
+
+func TestSend(t *testing.T) {
+	_, privateKey, err := ed25519.GenerateKey(rand.Reader)
+	require.NoError(t, err)
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+		w.WriteHeader(http.StatusNoContent)
+	}))
+	defer server.Close()
+
+	_store := newTestStore(t)
+	repo := &model.Repo{ID: 1, OrgID: 2, FullName: "org/repo"}
+	repoHook := &model.Webhook{RepoID: repo.ID, URL: server.URL, Events: []model.HookEvent{model.HookEventPipelineFinished}, Active: true}
+	orgHook := &model.Webhook{OrgID: repo.OrgID, URL: server.URL, Events: []model.HookEvent{model.HookEventStepStatus}, Active: true}
+	require.NoError(t, _store.WebhookCreate(repoHook))
+	require.NoError(t, _store.WebhookCreate(orgHook))
+
+	s := New(_store, privateKey, newTestFilter(t, "127.0.0.0/8"))
+	s.Send(repo, &model.WebhookPayload{Event: model.HookEventPipelineFinished, Repo: repo, Pipeline: &model.Pipeline{Number: 3}})
+
+	var deliveries []*model.WebhookDelivery
+	assert.Eventually(t, func() bool {
+		deliveries, err = _store.WebhookDeliveryList(repoHook, &model.ListOptions{All: true})
+		return err == nil && len(deliveries) == 1 && deliveries[0].Success
+	}, 5*time.Second, 10*time.Millisecond)
+
+	var payload model.WebhookPayload
+	assert.NoError(t, json.Unmarshal([]byte(deliveries[0].Payload), &payload))
+	assert.EqualValues(t, 3, payload.Pipeline.Number)
+
+	// the org webhook is not subscribed to the event
+	deliveries, err = _store.WebhookDeliveryList(orgHook, &model.ListOptions{All: true})
+	assert.NoError(t, err)
+	assert.Empty(t, deliveries)
+}
@@ -0,0 +1,59 @@
+// Copyright 2025 Woodpecker Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package datastore
+
+import (
+	"go.woodpecker-ci.org/woodpecker/v3/server/model"
+)
+
+func (s storage) ApprovalList(pipeline *model.Pipeline) ([]*model.Approval, error) {
+	var approvals []*model.Approval
+	return approvals, s.engine.Where("pipeline_id = ?", pipeline.ID).OrderBy("created, id").Find(&approvals)
+}
+
+func (s storage) ApprovalAdd(approval *model.Approval, required int) ([]*model.Approval, bool, error) {
+	sess := s.engine.NewSession()
+	defer sess.Close()
+	if err := sess.Begin(); err != nil {
+		return nil, false, err
+	}
+
+	// lock the pipeline, so concurrent approvals are counted one after another
+	if _, err := sess.Exec("UPDATE pipelines SET status = status WHERE id = ?", approval.PipelineID); err != nil {
+		return nil, false, err
+	}
+
+	// only Insert set auto created ID back to object
+	if _, err := sess.Insert(approval); err != nil {
+		return nil, false, err
+	}
+
+	var approvals []*model.Approval
+	if err := sess.Where("pipeline_id = ?", approval.PipelineID).OrderBy("created, id").Find(&approvals); err != nil {
+		return nil, false, err
+	}
+
+	approved := false
+	if len(approvals) >= required {
+		// only the approval which moves the pipeline out of blocked may start it
+		count, err := sess.ID(approval.PipelineID).Where("status = ?", model.StatusBlocked).Cols("status").Update(&model.Pipeline{Status: model.StatusPending})
+		if err != nil {
+			return nil, false, err
+		}
+		approved = count == 1
+	}
+
+	return approvals, approved, sess.Commit()
+}
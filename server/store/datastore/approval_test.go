@@ -0,0 +1,67 @@
+// Copyright 2025 Woodpecker Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package datastore
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"go.woodpecker-ci.org/woodpecker/v3/server/model"
+)
+
+func TestApprovals(t *testing.T) {
+	store, closer := newTestStore(t, new(model.Approval), new(model.Pipeline))
+	defer closer()
+
+	pipeline := &model.Pipeline{ID: 1, RepoID: 1, Number: 1, Status: model.StatusBlocked}
+	_, err := store.engine.Insert(pipeline, &model.Pipeline{ID: 2, RepoID: 1, Number: 2, Status: model.StatusBlocked})
+	assert.NoError(t, err)
+
+	approvals, approved, err := store.ApprovalAdd(&model.Approval{PipelineID: pipeline.ID, UserID: 1, Login: "alice"}, 2)
+	assert.NoError(t, err)
+	assert.False(t, approved)
+	assert.Len(t, approvals, 1)
+
+	// users can approve a pipeline only once
+	_, _, err = store.ApprovalAdd(&model.Approval{PipelineID: pipeline.ID, UserID: 1, Login: "alice"}, 2)
+	assert.Error(t, err)
+
+	approvals, approved, err = store.ApprovalAdd(&model.Approval{PipelineID: pipeline.ID, UserID: 2, Login: "bob"}, 2)
+	assert.NoError(t, err)
+	assert.True(t, approved)
+	assert.Len(t, approvals, 2)
+
+	// the pipeline is only started by the approval which unblocked it
+	_, approved, err = store.ApprovalAdd(&model.Approval{PipelineID: pipeline.ID, UserID: 3, Login: "carol"}, 2)
+	assert.NoError(t, err)
+	assert.False(t, approved)
+
+	pipeline, err = store.GetPipeline(pipeline.ID)
+	assert.NoError(t, err)
+	assert.Equal(t, model.StatusPending, pipeline.Status)
+
+	_, approved, err = store.ApprovalAdd(&model.Approval{PipelineID: 2, UserID: 1, Login: "alice"}, 1)
+	assert.NoError(t, err)
+	assert.True(t, approved)
+
+	approvals, err = store.ApprovalList(pipeline)
+	assert.NoError(t, err)
+	if assert.Len(t, approvals, 3) {
+		assert.Equal(t, "alice", approvals[0].Login)
+		assert.Equal(t, "bob", approvals[1].Login)
+		assert.Equal(t, "carol", approvals[2].Login)
+	}
+}
@@ -0,0 +1,50 @@
+// Copyright 2025 Woodpecker Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package datastore
+
+import (
+	"go.woodpecker-ci.org/woodpecker/v3/server/model"
+)
+
+func (s storage) ArtifactList(pipeline *model.Pipeline) ([]*model.Artifact, error) {
+	var artifacts []*model.Artifact
+	return artifacts, s.engine.Where("pipeline_id = ?", pipeline.ID).OrderBy("step_id, name").Find(&artifacts)
+}
+
+func (s storage) ArtifactListRepo(repo *model.Repo) ([]*model.Artifact, error) {
+	var artifacts []*model.Artifact
+	return artifacts, s.engine.Table("artifacts").
+		Select("artifacts.*").
+		Join("INNER", "pipelines", "artifacts.pipeline_id = pipelines.id").
+		Where("pipelines.repo_id = ?", repo.ID).
+		Find(&artifacts)
+}
+
+func (s storage) ArtifactFind(pipeline *model.Pipeline, id int64) (*model.Artifact, error) {
+	artifact := new(model.Artifact)
+	return artifact, wrapGet(s.engine.ID(id).Where("pipeline_id = ?", pipeline.ID).Get(artifact))
+}
+
+func (s storage) ArtifactCreate(artifact *model.Artifact) error {
+	if err := artifact.Validate(); err != nil {
+		return err
+	}
+	_, err := s.engine.Insert(artifact)
+	return err
+}
+
+func (s storage) ArtifactDelete(artifact *model.Artifact) error {
+	return wrapDelete(s.engine.ID(artifact.ID).Delete(new(model.Artifact)))
+}
@@ -0,0 +1,66 @@
+// Copyright 2025 Woodpecker Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package datastore
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"go.woodpecker-ci.org/woodpecker/v3/server/model"
+	"go.woodpecker-ci.org/woodpecker/v3/server/store/types"
+)
+
+func TestArtifacts(t *testing.T) {
+	store, closer := newTestStore(t, new(model.Artifact), new(model.Pipeline))
+	defer closer()
+
+	pipeline := &model.Pipeline{ID: 1}
+	binary := &model.Artifact{PipelineID: pipeline.ID, StepID: 2, Name: "dist/app", Size: 42}
+	assert.NoError(t, store.ArtifactCreate(binary))
+	assert.NotEqualValues(t, 0, binary.ID)
+	assert.NoError(t, store.ArtifactCreate(&model.Artifact{PipelineID: pipeline.ID, StepID: 1, Name: "report.xml", Size: 1}))
+	assert.NoError(t, store.ArtifactCreate(&model.Artifact{PipelineID: 2, StepID: 3, Name: "report.xml", Size: 1}))
+
+	// names are unique per step
+	assert.Error(t, store.ArtifactCreate(&model.Artifact{PipelineID: pipeline.ID, StepID: 2, Name: "dist/app"}))
+	assert.Error(t, store.ArtifactCreate(&model.Artifact{PipelineID: pipeline.ID, StepID: 2, Name: "../app"}))
+
+	artifacts, err := store.ArtifactList(pipeline)
+	assert.NoError(t, err)
+	if assert.Len(t, artifacts, 2) {
+		assert.Equal(t, "report.xml", artifacts[0].Name)
+		assert.Equal(t, "dist/app", artifacts[1].Name)
+	}
+
+	_, err = store.engine.Insert(&model.Pipeline{ID: pipeline.ID, RepoID: 1})
+	assert.NoError(t, err)
+	artifacts, err = store.ArtifactListRepo(&model.Repo{ID: 1})
+	assert.NoError(t, err)
+	assert.Len(t, artifacts, 2)
+	artifacts, err = store.ArtifactListRepo(&model.Repo{ID: 2})
+	assert.NoError(t, err)
+	assert.Empty(t, artifacts)
+
+	artifact, err := store.ArtifactFind(pipeline, binary.ID)
+	assert.NoError(t, err)
+	assert.EqualValues(t, 42, artifact.Size)
+	_, err = store.ArtifactFind(&model.Pipeline{ID: 2}, binary.ID)
+	assert.ErrorIs(t, err, types.RecordNotExist)
+
+	assert.NoError(t, store.ArtifactDelete(binary))
+	_, err = store.ArtifactFind(pipeline, binary.ID)
+	assert.ErrorIs(t, err, types.RecordNotExist)
+}
@@ -0,0 +1,57 @@
+// Copyright 2025 Woodpecker Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package datastore
+
+import (
+	"xorm.io/builder"
+
+	"go.woodpecker-ci.org/woodpecker/v3/server/model"
+)
+
+func (s storage) AuditEventCreate(event *model.AuditEvent) error {
+	// only Insert set auto created ID back to object
+	_, err := s.engine.Insert(event)
+	return err
+}
+
+func (s storage) AuditEventList(f *model.AuditEventFilter, p *model.ListOptions) ([]*model.AuditEvent, error) {
+	cond := builder.NewCond()
+	if f != nil {
+		if f.UserLogin != "" {
+			cond = cond.And(builder.Eq{"user_login": f.UserLogin})
+		}
+		if f.Action != "" {
+			cond = cond.And(builder.Eq{"action": f.Action})
+		}
+		if f.TargetType != "" {
+			cond = cond.And(builder.Eq{"target_type": f.TargetType})
+		}
+		if f.RepoID != 0 {
+			cond = cond.And(builder.Eq{"repo_id": f.RepoID})
+		}
+		if f.OrgID != 0 {
+			cond = cond.And(builder.Eq{"org_id": f.OrgID})
+		}
+		if f.After != 0 {
+			cond = cond.And(builder.Gt{"created": f.After})
+		}
+		if f.Before != 0 {
+			cond = cond.And(builder.Lt{"created": f.Before})
+		}
+	}
+
+	events := make([]*model.AuditEvent, 0, 16)
+	return events, s.paginate(p).Where(cond).Desc("id").Find(&events)
+}
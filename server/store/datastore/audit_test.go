@@ -0,0 +1,60 @@
+// Copyright 2025 Woodpecker Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package datastore
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"go.woodpecker-ci.org/woodpecker/v3/server/model"
+)
+
+func TestAuditEvents(t *testing.T) {
+	store, closer := newTestStore(t, new(model.AuditEvent))
+	defer closer()
+
+	events := []*model.AuditEvent{
+		{UserLogin: "alice", Action: model.AuditActionCreate, TargetType: model.AuditTargetSecret, TargetName: "token", RepoID: 1},
+		{UserLogin: "bob", Action: model.AuditActionApprove, TargetType: model.AuditTargetPipeline, TargetID: 7, RepoID: 1},
+		{UserLogin: "alice", Action: model.AuditActionDelete, TargetType: model.AuditTargetAgent, TargetID: 3},
+	}
+	for _, event := range events {
+		require.NoError(t, store.AuditEventCreate(event))
+		assert.NotZero(t, event.ID)
+	}
+
+	list, err := store.AuditEventList(nil, &model.ListOptions{All: true})
+	assert.NoError(t, err)
+	if assert.Len(t, list, 3) {
+		// newest first
+		assert.Equal(t, events[2].ID, list[0].ID)
+	}
+
+	list, err = store.AuditEventList(&model.AuditEventFilter{UserLogin: "alice"}, &model.ListOptions{All: true})
+	assert.NoError(t, err)
+	assert.Len(t, list, 2)
+
+	list, err = store.AuditEventList(&model.AuditEventFilter{RepoID: 1, Action: model.AuditActionApprove}, &model.ListOptions{All: true})
+	assert.NoError(t, err)
+	if assert.Len(t, list, 1) {
+		assert.Equal(t, "bob", list[0].UserLogin)
+	}
+
+	list, err = store.AuditEventList(&model.AuditEventFilter{TargetType: model.AuditTargetUser}, &model.ListOptions{All: true})
+	assert.NoError(t, err)
+	assert.Empty(t, list)
+}
@@ -0,0 +1,77 @@
+// Copyright 2025 Woodpecker Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package datastore
+
+import (
+	"xorm.io/builder"
+
+	"go.woodpecker-ci.org/woodpecker/v3/server/model"
+)
+
+func (s storage) EnvironmentFind(repo *model.Repo, name string) (*model.Environment, error) {
+	env := new(model.Environment)
+	return env, wrapGet(s.engine.Where(builder.Eq{"repo_id": repo.ID, "name": name}).Get(env))
+}
+
+func (s storage) EnvironmentList(repo *model.Repo, p *model.ListOptions) ([]*model.Environment, error) {
+	var envs []*model.Environment
+	return envs, s.paginate(p).Where("repo_id = ?", repo.ID).OrderBy("name").Find(&envs)
+}
+
+func (s storage) EnvironmentCreate(env *model.Environment) error {
+	if err := env.Validate(); err != nil {
+		return err
+	}
+	_, err := s.engine.Insert(env)
+	return err
+}
+
+func (s storage) EnvironmentUpdate(env *model.Environment) error {
+	if err := env.Validate(); err != nil {
+		return err
+	}
+	_, err := s.engine.ID(env.ID).AllCols().Update(env)
+	return err
+}
+
+// EnvironmentDelete removes the environment including its secrets.
+func (s storage) EnvironmentDelete(env *model.Environment) error {
+	sess := s.engine.NewSession()
+	defer sess.Close()
+	if err := sess.Begin(); err != nil {
+		return err
+	}
+
+	if _, err := sess.Where("environment_id = ?", env.ID).Delete(new(model.Secret)); err != nil {
+		return err
+	}
+	if err := wrapDelete(sess.ID(env.ID).Delete(new(model.Environment))); err != nil {
+		return err
+	}
+
+	return sess.Commit()
+}
+
+func (s storage) EnvironmentSecretFind(env *model.Environment, name string) (*model.Secret, error) {
+	secret := new(model.Secret)
+	return secret, wrapGet(s.engine.Where(
+		builder.Eq{"environment_id": env.ID, "name": name},
+	).Get(secret))
+}
+
+func (s storage) EnvironmentSecretList(env *model.Environment, p *model.ListOptions) ([]*model.Secret, error) {
+	secrets := make([]*model.Secret, 0)
+	return secrets, s.paginate(p).Where("environment_id = ?", env.ID).OrderBy(orderSecretsBy).Find(&secrets)
+}
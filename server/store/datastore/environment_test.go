@@ -0,0 +1,82 @@
+// Copyright 2025 Woodpecker Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package datastore
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"go.woodpecker-ci.org/woodpecker/v3/server/model"
+	"go.woodpecker-ci.org/woodpecker/v3/server/store/types"
+)
+
+func TestEnvironments(t *testing.T) {
+	store, closer := newTestStore(t, new(model.Environment), new(model.Secret))
+	defer closer()
+
+	repo := &model.Repo{ID: 1}
+	otherRepo := &model.Repo{ID: 2}
+
+	production := &model.Environment{RepoID: repo.ID, Name: "production", RequiredApprovers: []string{"alice"}, AllowedBranches: []string{"main"}}
+	staging := &model.Environment{RepoID: repo.ID, Name: "staging"}
+	for _, env := range []*model.Environment{production, staging, {RepoID: otherRepo.ID, Name: "production"}} {
+		require.NoError(t, store.EnvironmentCreate(env))
+	}
+	assert.Error(t, store.EnvironmentCreate(&model.Environment{RepoID: repo.ID, Name: "production"}))
+	assert.ErrorIs(t, store.EnvironmentCreate(&model.Environment{RepoID: repo.ID, Name: "in valid"}), model.ErrEnvironmentNameInvalid)
+
+	envs, err := store.EnvironmentList(repo, &model.ListOptions{All: true})
+	assert.NoError(t, err)
+	if assert.Len(t, envs, 2) {
+		assert.Equal(t, "production", envs[0].Name)
+		assert.Equal(t, []string{"alice"}, envs[0].RequiredApprovers)
+	}
+
+	staging.URL = "https://staging.example.com"
+	assert.NoError(t, store.EnvironmentUpdate(staging))
+	env, err := store.EnvironmentFind(repo, "staging")
+	assert.NoError(t, err)
+	assert.Equal(t, "https://staging.example.com", env.URL)
+
+	// environment secrets are separated from the repository secrets of the same name
+	repoSecret := &model.Secret{RepoID: repo.ID, Name: "token", Value: "repo", Events: []model.WebhookEvent{model.EventDeploy}}
+	envSecret := &model.Secret{RepoID: repo.ID, EnvironmentID: production.ID, Name: "token", Value: "production", Events: []model.WebhookEvent{model.EventDeploy}}
+	require.NoError(t, store.SecretCreate(repoSecret))
+	require.NoError(t, store.SecretCreate(envSecret))
+
+	secret, err := store.SecretFind(repo, "token")
+	assert.NoError(t, err)
+	assert.Equal(t, "repo", secret.Value)
+	secrets, err := store.SecretList(repo, false, &model.ListOptions{All: true})
+	assert.NoError(t, err)
+	assert.Len(t, secrets, 1)
+
+	secret, err = store.EnvironmentSecretFind(production, "token")
+	assert.NoError(t, err)
+	assert.Equal(t, "production", secret.Value)
+	secrets, err = store.EnvironmentSecretList(staging, &model.ListOptions{All: true})
+	assert.NoError(t, err)
+	assert.Len(t, secrets, 0)
+
+	assert.NoError(t, store.EnvironmentDelete(production))
+	_, err = store.EnvironmentFind(repo, "production")
+	assert.ErrorIs(t, err, types.RecordNotExist)
+	_, err = store.EnvironmentSecretFind(production, "token")
+	assert.ErrorIs(t, err, types.RecordNotExist)
+	_, err = store.SecretFind(repo, "token")
+	assert.NoError(t, err)
+}
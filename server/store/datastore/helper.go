@@ -88,3 +88,11 @@ func (s storage) quoteIdentifier(identifier string) string {
 		return identifier
 	}
 }
+
+// intDiv returns an SQL expression of the integer division of two non-negative integers.
+func (s storage) intDiv(dividend, divisor string) string {
+	if s.engine.DriverName() == DriverMysql {
+		return dividend + " DIV " + divisor
+	}
+	return dividend + " / " + divisor
+}
@@ -0,0 +1,44 @@
+// Copyright 2025 Woodpecker Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package migration
+
+import (
+	"fmt"
+
+	"src.techknowlogick.com/xormigrate"
+	"xorm.io/xorm"
+)
+
+var addTaskQueueState = xormigrate.Migration{
+	ID: "add-task-queue-state",
+	MigrateSession: func(sess *xorm.Session) (err error) {
+		type tasks struct {
+			ID      string `xorm:"PK UNIQUE 'id'"`
+			State   string `xorm:"'state'"`
+			Version int64  `xorm:"'version'"`
+		}
+
+		if err := sess.Sync(new(tasks)); err != nil {
+			return fmt.Errorf("sync models failed: %w", err)
+		}
+
+		// tasks persisted by the memory queue are all waiting to be picked up
+		_, err = sess.Cols("state", "version").Update(&tasks{
+			State:   "pending",
+			Version: 1,
+		})
+		return err
+	},
+}
@@ -52,6 +52,7 @@ var migrationTasks = []*xormigrate.Migration{
 	&renameTokenFields,
 	&setNewDefaultsForRequireApproval,
 	&removeRepoScm,
+	&addTaskQueueState,
 }
 
 var allBeans = []any{
@@ -60,8 +61,15 @@ var allBeans = []any{
 	new(model.PipelineConfig),
 	new(model.Config),
 	new(model.LogEntry),
+	new(model.Artifact),
+	new(model.Approval),
+	new(model.Webhook),
+	new(model.WebhookDelivery),
+	new(model.AuditEvent),
+	new(model.PersonalAccessToken),
 	new(model.Perm),
 	new(model.Step),
+	new(model.StepFlakiness),
 	new(model.Registry),
 	new(model.Repo),
 	new(model.Secret),
@@ -69,10 +77,12 @@ var allBeans = []any{
 	new(model.User),
 	new(model.ServerConfig),
 	new(model.Cron),
+	new(model.Environment),
 	new(model.Redirection),
 	new(model.Forge),
 	new(model.Workflow),
 	new(model.Org),
+	new(model.PubsubMessage),
 }
 
 // TODO: make xormigrate context aware
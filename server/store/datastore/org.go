@@ -18,6 +18,7 @@ import (
 	"fmt"
 	"strings"
 
+	"xorm.io/builder"
 	"xorm.io/xorm"
 
 	"go.woodpecker-ci.org/woodpecker/v3/server/model"
@@ -63,6 +64,9 @@ func (s storage) orgDelete(sess *xorm.Session, id int64) error {
 	if _, err := sess.Where("org_id = ?", id).Delete(new(model.Secret)); err != nil {
 		return err
 	}
+	if err := deleteWebhooks(sess, builder.Eq{"org_id": id, "repo_id": 0}); err != nil {
+		return err
+	}
 
 	var repos []*model.Repo
 	if err := sess.Where("org_id = ?", id).Find(&repos); err != nil {
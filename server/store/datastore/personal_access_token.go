@@ -0,0 +1,44 @@
+// Copyright 2025 Woodpecker Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package datastore
+
+import (
+	"go.woodpecker-ci.org/woodpecker/v3/server/model"
+)
+
+func (s storage) PersonalAccessTokenCreate(token *model.PersonalAccessToken) error {
+	// only Insert set auto created ID back to object
+	_, err := s.engine.Insert(token)
+	return err
+}
+
+func (s storage) PersonalAccessTokenFind(id int64) (*model.PersonalAccessToken, error) {
+	token := new(model.PersonalAccessToken)
+	return token, wrapGet(s.engine.ID(id).Get(token))
+}
+
+func (s storage) PersonalAccessTokenList(user *model.User, p *model.ListOptions) ([]*model.PersonalAccessToken, error) {
+	tokens := make([]*model.PersonalAccessToken, 0, 4)
+	return tokens, s.paginate(p).Where("user_id = ?", user.ID).OrderBy("id").Find(&tokens)
+}
+
+func (s storage) PersonalAccessTokenUpdate(token *model.PersonalAccessToken) error {
+	_, err := s.engine.ID(token.ID).AllCols().Update(token)
+	return err
+}
+
+func (s storage) PersonalAccessTokenDelete(token *model.PersonalAccessToken) error {
+	return wrapDelete(s.engine.ID(token.ID).Delete(new(model.PersonalAccessToken)))
+}
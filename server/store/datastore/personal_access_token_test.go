@@ -0,0 +1,60 @@
+// Copyright 2025 Woodpecker Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package datastore
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"go.woodpecker-ci.org/woodpecker/v3/server/model"
+	"go.woodpecker-ci.org/woodpecker/v3/server/store/types"
+)
+
+func TestPersonalAccessTokens(t *testing.T) {
+	store, closer := newTestStore(t, new(model.PersonalAccessToken))
+	defer closer()
+
+	user := &model.User{ID: 1}
+	token := &model.PersonalAccessToken{
+		UserID: user.ID,
+		Name:   "ci",
+		Scopes: []model.TokenScope{model.TokenScopeRead, model.TokenScopePipelineWrite},
+		RepoID: 3,
+		Secret: "secret",
+	}
+	require.NoError(t, store.PersonalAccessTokenCreate(token))
+	require.NoError(t, store.PersonalAccessTokenCreate(&model.PersonalAccessToken{UserID: 2, Name: "other", Scopes: []model.TokenScope{model.TokenScopeAll}}))
+
+	found, err := store.PersonalAccessTokenFind(token.ID)
+	assert.NoError(t, err)
+	assert.Equal(t, "ci", found.Name)
+	assert.Equal(t, "secret", found.Secret)
+	assert.Equal(t, []model.TokenScope{model.TokenScopeRead, model.TokenScopePipelineWrite}, found.Scopes)
+
+	found.LastUsed = 1000
+	assert.NoError(t, store.PersonalAccessTokenUpdate(found))
+
+	tokens, err := store.PersonalAccessTokenList(user, &model.ListOptions{All: true})
+	assert.NoError(t, err)
+	if assert.Len(t, tokens, 1) {
+		assert.EqualValues(t, 1000, tokens[0].LastUsed)
+	}
+
+	assert.NoError(t, store.PersonalAccessTokenDelete(token))
+	_, err = store.PersonalAccessTokenFind(token.ID)
+	assert.ErrorIs(t, err, types.RecordNotExist)
+}
@@ -90,6 +90,10 @@ func (s storage) GetPipelineList(repo *model.Repo, p *model.ListOptions, f *mode
 		if f.RefContains != "" {
 			cond = cond.And(builder.Like{"ref", f.RefContains})
 		}
+
+		if f.DeployTo != "" {
+			cond = cond.And(builder.Eq{"deploy": f.DeployTo})
+		}
 	}
 
 	return pipelines, s.paginate(p).Where(cond).
@@ -204,5 +208,11 @@ func (s storage) deletePipeline(sess *xorm.Session, pipelineID int64) error {
 	if _, err := sess.Where("pipeline_id = ?", pipelineID).Delete(new(model.PipelineConfig)); err != nil {
 		return err
 	}
+	if _, err := sess.Where("pipeline_id = ?", pipelineID).Delete(new(model.Artifact)); err != nil {
+		return err
+	}
+	if _, err := sess.Where("pipeline_id = ?", pipelineID).Delete(new(model.Approval)); err != nil {
+		return err
+	}
 	return wrapDelete(sess.ID(pipelineID).Delete(new(model.Pipeline)))
 }
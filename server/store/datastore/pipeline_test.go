@@ -226,7 +226,7 @@ func TestPipelineIncrement(t *testing.T) {
 
 func TestDeletePipeline(t *testing.T) {
 	store, closer := newTestStore(t, new(model.Pipeline), new(model.Repo), new(model.Workflow),
-		new(model.Step), new(model.LogEntry), new(model.PipelineConfig), new(model.Config))
+		new(model.Step), new(model.LogEntry), new(model.PipelineConfig), new(model.Config), new(model.Artifact), new(model.Approval))
 	defer closer()
 
 	_, err := store.engine.Insert(
@@ -269,6 +269,16 @@ func TestDeletePipeline(t *testing.T) {
 			PipelineID: 8,
 			ConfigID:   25,
 		},
+		&model.Artifact{
+			PipelineID: 8,
+			StepID:     1,
+			Name:       "app",
+		},
+		&model.Approval{
+			PipelineID: 8,
+			UserID:     1,
+			Login:      "alice",
+		},
 	)
 	assert.NoError(t, err)
 
@@ -286,4 +296,10 @@ func TestDeletePipeline(t *testing.T) {
 	count, err = store.engine.Count(new(model.Config))
 	assert.NoError(t, err)
 	assert.EqualValues(t, 1, count)
+	count, err = store.engine.Count(new(model.Artifact))
+	assert.NoError(t, err)
+	assert.EqualValues(t, 0, count)
+	count, err = store.engine.Count(new(model.Approval))
+	assert.NoError(t, err)
+	assert.EqualValues(t, 0, count)
 }
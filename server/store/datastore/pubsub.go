@@ -0,0 +1,37 @@
+// Copyright 2025 Woodpecker Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package datastore
+
+import (
+	"go.woodpecker-ci.org/woodpecker/v3/server/model"
+)
+
+func (s storage) PubsubMessageCreate(message *model.PubsubMessage) error {
+	_, err := s.engine.Insert(message)
+	return err
+}
+
+func (s storage) PubsubMessageList(channel string, since int64) ([]*model.PubsubMessage, error) {
+	messages := make([]*model.PubsubMessage, 0, perPage)
+	return messages, s.engine.
+		Where("channel = ? AND created >= ?", channel, since).
+		OrderBy("id").
+		Find(&messages)
+}
+
+func (s storage) PubsubMessagePrune(before int64) error {
+	_, err := s.engine.Where("created < ?", before).Delete(new(model.PubsubMessage))
+	return err
+}
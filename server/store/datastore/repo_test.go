@@ -267,7 +267,13 @@ func TestRepoCrud(t *testing.T) {
 		new(model.Registry),
 		new(model.Config),
 		new(model.Redirection),
-		new(model.Workflow))
+		new(model.Workflow),
+		new(model.Artifact),
+		new(model.Approval),
+		new(model.Webhook),
+		new(model.WebhookDelivery),
+		new(model.Environment),
+		new(model.StepFlakiness))
 	defer closer()
 
 	repo := model.Repo{
@@ -25,13 +25,13 @@ const orderSecretsBy = "name"
 func (s storage) SecretFind(repo *model.Repo, name string) (*model.Secret, error) {
 	secret := new(model.Secret)
 	return secret, wrapGet(s.engine.Where(
-		builder.Eq{"repo_id": repo.ID, "name": name},
+		builder.Eq{"repo_id": repo.ID, "environment_id": 0, "name": name},
 	).Get(secret))
 }
 
 func (s storage) SecretList(repo *model.Repo, includeGlobalAndOrgSecrets bool, p *model.ListOptions) ([]*model.Secret, error) {
 	var secrets []*model.Secret
-	var cond builder.Cond = builder.Eq{"repo_id": repo.ID}
+	var cond builder.Cond = builder.Eq{"repo_id": repo.ID, "environment_id": 0}
 	if includeGlobalAndOrgSecrets {
 		cond = cond.Or(builder.Eq{"org_id": repo.OrgID}).
 			Or(builder.And(builder.Eq{"org_id": 0}, builder.Eq{"repo_id": 0}))
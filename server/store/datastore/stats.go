@@ -0,0 +1,89 @@
+// Copyright 2025 Woodpecker Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package datastore
+
+import (
+	"fmt"
+	"strconv"
+
+	"xorm.io/builder"
+
+	"go.woodpecker-ci.org/woodpecker/v3/server/model"
+)
+
+// finishedStatuses are the statuses of pipelines and steps included in statistics.
+var finishedStatuses = []model.StatusValue{model.StatusSuccess, model.StatusFailure, model.StatusError, model.StatusKilled}
+
+func (s storage) PipelineStatsCount(repo *model.Repo, f *model.PipelineFilter, interval int64) ([]*model.PipelineStatusCount, error) {
+	var since int64
+	if f != nil {
+		since = f.After
+	}
+	bucket := s.intDiv(fmt.Sprintf("(pipelines.created - %d)", since), strconv.FormatInt(interval, 10))
+
+	counts := make([]*model.PipelineStatusCount, 0, perPage)
+	return counts, s.engine.Table("pipelines").
+		Select(bucket + " AS bucket, pipelines.status AS status, COUNT(*) AS total").
+		Where(statsCond(repo, f)).
+		GroupBy(bucket + ", pipelines.status").
+		Find(&counts)
+}
+
+func (s storage) PipelineStatsList(repo *model.Repo, f *model.PipelineFilter, limit int) ([]*model.Pipeline, error) {
+	pipelines := make([]*model.Pipeline, 0, perPage)
+	return pipelines, s.engine.
+		Cols("status", "created", "started", "finished", "reviewed").
+		Where(statsCond(repo, f)).
+		Desc("created").
+		Limit(limit).
+		Find(&pipelines)
+}
+
+func (s storage) StepStatsList(repo *model.Repo, f *model.PipelineFilter) ([]*model.StepStats, error) {
+	stats := make([]*model.StepStats, 0, perPage)
+	return stats, s.engine.Table("steps").
+		Select(`workflows.name AS workflow, steps.name AS name, COUNT(*) AS total,
+SUM(CASE WHEN steps.state = '`+string(model.StatusFailure)+`' THEN 1 ELSE 0 END) AS failures`).
+		Join("INNER", "pipelines", "steps.pipeline_id = pipelines.id").
+		Join("INNER", "workflows", "workflows.pipeline_id = steps.pipeline_id AND workflows.pid = steps.ppid").
+		Where(statsCond(repo, f)).
+		And(builder.In("steps.state", model.StatusSuccess, model.StatusFailure)).
+		GroupBy("workflows.name, steps.name").
+		Asc("workflows.name", "steps.name").
+		Find(&stats)
+}
+
+// statsCond selects the finished pipelines of a repo matching the filter.
+func statsCond(repo *model.Repo, f *model.PipelineFilter) builder.Cond {
+	cond := builder.NewCond().
+		And(builder.Eq{"pipelines.repo_id": repo.ID}).
+		And(builder.In("pipelines.status", finishedStatuses))
+
+	if f != nil {
+		if f.After != 0 {
+			cond = cond.And(builder.Gt{"pipelines.created": f.After})
+		}
+
+		if f.Before != 0 {
+			cond = cond.And(builder.Lt{"pipelines.created": f.Before})
+		}
+
+		if f.Branch != "" {
+			cond = cond.And(builder.Eq{"pipelines.branch": f.Branch})
+		}
+	}
+
+	return cond
+}
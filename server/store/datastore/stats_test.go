@@ -0,0 +1,106 @@
+// Copyright 2025 Woodpecker Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package datastore
+
+import (
+	"cmp"
+	"slices"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"go.woodpecker-ci.org/woodpecker/v3/server/model"
+)
+
+func TestStats(t *testing.T) {
+	store, closer := newTestStore(t, new(model.Repo), new(model.Pipeline), new(model.Workflow), new(model.Step))
+	defer closer()
+
+	repo := &model.Repo{UserID: 1, FullName: "octocat/hello-world", Owner: "octocat", Name: "hello-world", ForgeRemoteID: "1"}
+	assert.NoError(t, store.CreateRepo(repo))
+
+	createPipeline := func(status model.StatusValue, branch string, created int64, steps ...*model.Step) {
+		for i, step := range steps {
+			step.PID = i + 2
+			step.PPID = 1
+		}
+		pipeline := &model.Pipeline{RepoID: repo.ID, Status: status, Branch: branch, Started: created + 5, Finished: created + 65}
+		assert.NoError(t, store.CreatePipeline(pipeline, steps...))
+		// the created timestamp is set on insert
+		_, err := store.engine.Table("pipelines").Where("id = ?", pipeline.ID).Update(map[string]any{"created": created})
+		assert.NoError(t, err)
+
+		assert.NoError(t, store.WorkflowsCreate([]*model.Workflow{{PipelineID: pipeline.ID, PID: 1, Name: "test", State: status}}))
+	}
+
+	createPipeline(model.StatusSuccess, "main", 1000,
+		&model.Step{Name: "lint", State: model.StatusSuccess},
+		&model.Step{Name: "unit", State: model.StatusSuccess})
+	createPipeline(model.StatusFailure, "main", 2000,
+		&model.Step{Name: "lint", State: model.StatusSuccess},
+		&model.Step{Name: "unit", State: model.StatusFailure})
+	createPipeline(model.StatusKilled, "dev", 3000,
+		&model.Step{Name: "lint", State: model.StatusKilled})
+	createPipeline(model.StatusRunning, "main", 4000,
+		&model.Step{Name: "unit", State: model.StatusRunning})
+
+	createPipeline(model.StatusSuccess, "main", 2500)
+
+	pipelines, err := store.PipelineStatsList(repo, nil, 10)
+	assert.NoError(t, err)
+	assert.Len(t, pipelines, 4)
+
+	// only the newest pipelines are loaded
+	pipelines, err = store.PipelineStatsList(repo, nil, 2)
+	assert.NoError(t, err)
+	if assert.Len(t, pipelines, 2) {
+		assert.EqualValues(t, 3000, pipelines[0].Created)
+		assert.EqualValues(t, 2500, pipelines[1].Created)
+	}
+
+	pipelines, err = store.PipelineStatsList(repo, &model.PipelineFilter{Branch: "main", After: 1500, Before: 2200}, 10)
+	assert.NoError(t, err)
+	if assert.Len(t, pipelines, 1) {
+		assert.Equal(t, model.StatusFailure, pipelines[0].Status)
+		assert.EqualValues(t, 2005, pipelines[0].Started)
+		assert.EqualValues(t, 2065, pipelines[0].Finished)
+	}
+
+	counts, err := store.PipelineStatsCount(repo, &model.PipelineFilter{After: 500}, 1000)
+	assert.NoError(t, err)
+	slices.SortFunc(counts, func(a, b *model.PipelineStatusCount) int {
+		return cmp.Or(cmp.Compare(a.Bucket, b.Bucket), cmp.Compare(a.Status, b.Status))
+	})
+	assert.Equal(t, []*model.PipelineStatusCount{
+		{Bucket: 0, Status: model.StatusSuccess, Total: 1},
+		{Bucket: 1, Status: model.StatusFailure, Total: 1},
+		{Bucket: 2, Status: model.StatusKilled, Total: 1},
+		{Bucket: 2, Status: model.StatusSuccess, Total: 1},
+	}, counts)
+
+	steps, err := store.StepStatsList(repo, nil)
+	assert.NoError(t, err)
+	assert.Equal(t, []*model.StepStats{
+		{Workflow: "test", Name: "lint", Total: 2},
+		{Workflow: "test", Name: "unit", Total: 2, Failures: 1},
+	}, steps)
+
+	steps, err = store.StepStatsList(repo, &model.PipelineFilter{Before: 1500})
+	assert.NoError(t, err)
+	assert.Equal(t, []*model.StepStats{
+		{Workflow: "test", Name: "lint", Total: 1},
+		{Workflow: "test", Name: "unit", Total: 1},
+	}, steps)
+}
@@ -68,6 +68,25 @@ func (s storage) stepListWorkflow(sess *xorm.Session, workflow *model.Workflow)
 		Find(&stepList)
 }
 
+func (s storage) StepListPrevious(pipeline *model.Pipeline, workflow string) ([]*model.Step, error) {
+	stepList := make([]*model.Step, 0)
+	return stepList, s.engine.Table("steps").
+		Select("steps.*").
+		Join("INNER", "pipelines", "steps.pipeline_id = pipelines.id").
+		Join("INNER", "workflows", "workflows.pipeline_id = steps.pipeline_id AND workflows.pid = steps.ppid").
+		Where(builder.Eq{
+			"pipelines.repo_id":                        pipeline.RepoID,
+			"pipelines." + s.quoteIdentifier("commit"): pipeline.Commit,
+			"pipelines.branch":                         pipeline.Branch,
+			"workflows.name":                           workflow,
+		}).
+		And(builder.Lt{"pipelines.number": pipeline.Number}).
+		And(builder.In("steps.state", model.StatusSuccess, model.StatusFailure)).
+		Desc("pipelines.number").
+		Asc("steps.pid").
+		Find(&stepList)
+}
+
 func (s storage) stepCreate(sess *xorm.Session, steps []*model.Step) error {
 	for i := range steps {
 		// only Insert on single object ref set auto created ID back to object
@@ -0,0 +1,75 @@
+// Copyright 2025 Woodpecker Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package datastore
+
+import (
+	"xorm.io/builder"
+
+	"go.woodpecker-ci.org/woodpecker/v3/server/model"
+)
+
+func (s storage) StepFlakinessList(repo *model.Repo, p *model.ListOptions) ([]*model.StepFlakiness, error) {
+	var flakiness []*model.StepFlakiness
+	if err := s.paginate(p).
+		Where(builder.Eq{"repo_id": repo.ID}).
+		And(builder.Gt{"flakes": 0}).
+		OrderBy("flakes * 1.0 / runs DESC, flakes DESC, workflow, name").
+		Find(&flakiness); err != nil {
+		return nil, err
+	}
+	for _, f := range flakiness {
+		f.UpdateScore()
+	}
+	return flakiness, nil
+}
+
+// StepFlakinessAdd adds the runs and flakes to the counts of the step.
+func (s storage) StepFlakinessAdd(flakiness *model.StepFlakiness) error {
+	if err := s.stepFlakinessAdd(flakiness); err != nil {
+		// the insert fails on the unique index if the counts of the step were created concurrently,
+		// then they exist and can be updated
+		return s.stepFlakinessAdd(flakiness)
+	}
+	return nil
+}
+
+func (s storage) stepFlakinessAdd(flakiness *model.StepFlakiness) error {
+	sess := s.engine.NewSession()
+	defer sess.Close()
+	if err := sess.Begin(); err != nil {
+		return err
+	}
+
+	update := sess.
+		Where(builder.Eq{"repo_id": flakiness.RepoID, "workflow": flakiness.Workflow, "name": flakiness.Name}).
+		Incr("runs", flakiness.Runs).
+		Incr("flakes", flakiness.Flakes)
+	if flakiness.LastFlake != 0 {
+		update = update.SetExpr("last_flake", flakiness.LastFlake)
+	}
+	updated, err := update.Update(new(model.StepFlakiness))
+	if err != nil {
+		return err
+	}
+
+	if updated == 0 {
+		// only Insert set auto created ID back to object
+		if _, err := sess.Insert(flakiness); err != nil {
+			return err
+		}
+	}
+
+	return sess.Commit()
+}
@@ -0,0 +1,81 @@
+// Copyright 2025 Woodpecker Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package datastore
+
+import (
+	"sync"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"go.woodpecker-ci.org/woodpecker/v3/server/model"
+)
+
+func TestStepFlakiness(t *testing.T) {
+	store, closer := newTestStore(t, new(model.StepFlakiness))
+	defer closer()
+
+	repo := &model.Repo{ID: 1}
+	assert.NoError(t, store.StepFlakinessAdd(&model.StepFlakiness{RepoID: 1, Workflow: "test", Name: "unit", Runs: 1}))
+	assert.NoError(t, store.StepFlakinessAdd(&model.StepFlakiness{RepoID: 1, Workflow: "test", Name: "unit", Runs: 1, Flakes: 1, LastFlake: 100}))
+	assert.NoError(t, store.StepFlakinessAdd(&model.StepFlakiness{RepoID: 1, Workflow: "test", Name: "lint", Runs: 2}))
+	assert.NoError(t, store.StepFlakinessAdd(&model.StepFlakiness{RepoID: 1, Workflow: "e2e", Name: "browser", Runs: 4, Flakes: 1, LastFlake: 50}))
+	assert.NoError(t, store.StepFlakinessAdd(&model.StepFlakiness{RepoID: 2, Workflow: "test", Name: "unit", Runs: 1, Flakes: 1}))
+
+	flakiness, err := store.StepFlakinessList(repo, &model.ListOptions{Page: 1, PerPage: 50})
+	assert.NoError(t, err)
+	if assert.Len(t, flakiness, 2) {
+		assert.Equal(t, "unit", flakiness[0].Name)
+		assert.EqualValues(t, 2, flakiness[0].Runs)
+		assert.EqualValues(t, 1, flakiness[0].Flakes)
+		assert.EqualValues(t, 100, flakiness[0].LastFlake)
+		assert.InDelta(t, 0.5, flakiness[0].Score, 0.001)
+		assert.Equal(t, "browser", flakiness[1].Name)
+		assert.InDelta(t, 0.25, flakiness[1].Score, 0.001)
+	}
+
+	flakiness, err = store.StepFlakinessList(repo, &model.ListOptions{Page: 2, PerPage: 1})
+	assert.NoError(t, err)
+	if assert.Len(t, flakiness, 1) {
+		assert.Equal(t, "browser", flakiness[0].Name)
+	}
+}
+
+func TestStepFlakinessAddConcurrent(t *testing.T) {
+	if driver, _ := testDriverConfig(); driver == "sqlite3" {
+		t.Skip("connections to an in-memory sqlite database don't share the database")
+	}
+
+	store, closer := newTestStore(t, new(model.StepFlakiness))
+	defer closer()
+
+	const runs = 10
+	var wg sync.WaitGroup
+	for range runs {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			assert.NoError(t, store.StepFlakinessAdd(&model.StepFlakiness{RepoID: 1, Workflow: "test", Name: "unit", Runs: 1, Flakes: 1}))
+		}()
+	}
+	wg.Wait()
+
+	flakiness, err := store.StepFlakinessList(&model.Repo{ID: 1}, &model.ListOptions{All: true})
+	assert.NoError(t, err)
+	if assert.Len(t, flakiness, 1) {
+		assert.EqualValues(t, runs, flakiness[0].Runs)
+		assert.EqualValues(t, runs, flakiness[0].Flakes)
+	}
+}
@@ -248,3 +248,37 @@ func TestStepLoad(t *testing.T) {
 	assert.ErrorIs(t, err, types.RecordNotExist)
 	assert.Empty(t, step)
 }
+
+func TestStepListPrevious(t *testing.T) {
+	store, closer := newTestStore(t, new(model.Repo), new(model.Pipeline), new(model.Workflow), new(model.Step))
+	defer closer()
+
+	repo := &model.Repo{UserID: 1, FullName: "octocat/hello-world", Owner: "octocat", Name: "hello-world", ForgeRemoteID: "1"}
+	assert.NoError(t, store.CreateRepo(repo))
+
+	createPipeline := func(commit, workflow string, state model.StatusValue) *model.Pipeline {
+		pipeline := &model.Pipeline{RepoID: repo.ID, Commit: commit, Branch: "main"}
+		assert.NoError(t, store.CreatePipeline(pipeline, &model.Step{PID: 2, PPID: 1, Name: "unit", State: state}))
+		assert.NoError(t, store.WorkflowsCreate([]*model.Workflow{{PipelineID: pipeline.ID, PID: 1, Name: workflow}}))
+		return pipeline
+	}
+
+	first := createPipeline("abc", "test", model.StatusFailure)
+	createPipeline("abc", "test", model.StatusSuccess)
+	createPipeline("abc", "test", model.StatusSkipped)
+	createPipeline("abc", "lint", model.StatusFailure)
+	createPipeline("def", "test", model.StatusFailure)
+	current := createPipeline("abc", "test", model.StatusRunning)
+
+	steps, err := store.StepListPrevious(current, "test")
+	assert.NoError(t, err)
+	if assert.Len(t, steps, 2) {
+		assert.Equal(t, model.StatusSuccess, steps[0].State)
+		assert.Equal(t, model.StatusFailure, steps[1].State)
+		assert.Equal(t, first.ID, steps[1].PipelineID)
+	}
+
+	steps, err = store.StepListPrevious(first, "test")
+	assert.NoError(t, err)
+	assert.Empty(t, steps)
+}
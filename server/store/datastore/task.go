@@ -16,11 +16,17 @@ package datastore
 
 import (
 	"go.woodpecker-ci.org/woodpecker/v3/server/model"
+	"go.woodpecker-ci.org/woodpecker/v3/server/store/types"
 )
 
 func (s storage) TaskList() ([]*model.Task, error) {
 	tasks := make([]*model.Task, 0, perPage)
-	return tasks, s.engine.Find(&tasks)
+	return tasks, s.engine.OrderBy("enqueued").Find(&tasks)
+}
+
+func (s storage) TaskLoad(id string) (*model.Task, error) {
+	task := new(model.Task)
+	return task, wrapGet(s.engine.ID(id).Get(task))
 }
 
 func (s storage) TaskInsert(task *model.Task) error {
@@ -29,6 +35,19 @@ func (s storage) TaskInsert(task *model.Task) error {
 	return err
 }
 
+// TaskUpdate updates a task if its version did not change since it was loaded,
+// concurrent writers therefore get types.RecordNotExist instead of overwriting each other.
+func (s storage) TaskUpdate(task *model.Task) error {
+	count, err := s.engine.ID(task.ID).AllCols().Update(task)
+	if err != nil {
+		return err
+	}
+	if count == 0 {
+		return types.RecordNotExist
+	}
+	return nil
+}
+
 func (s storage) TaskDelete(id string) error {
 	return wrapDelete(s.engine.Where("id = ?", id).Delete(new(model.Task)))
 }
@@ -20,6 +20,7 @@ import (
 	"github.com/stretchr/testify/assert"
 
 	"go.woodpecker-ci.org/woodpecker/v3/server/model"
+	"go.woodpecker-ci.org/woodpecker/v3/server/store/types"
 )
 
 func TestTaskList(t *testing.T) {
@@ -46,3 +47,35 @@ func TestTaskList(t *testing.T) {
 	assert.NoError(t, err)
 	assert.Len(t, list, 0, "Want empty task list after delete")
 }
+
+func TestTaskUpdate(t *testing.T) {
+	store, closer := newTestStore(t, new(model.Task))
+	defer closer()
+
+	assert.NoError(t, store.TaskInsert(&model.Task{
+		ID:    "some_random_id",
+		State: model.TaskStatePending,
+	}))
+
+	first, err := store.TaskLoad("some_random_id")
+	assert.NoError(t, err)
+	second, err := store.TaskLoad("some_random_id")
+	assert.NoError(t, err)
+
+	first.State = model.TaskStateRunning
+	first.AgentID = 1
+	assert.NoError(t, store.TaskUpdate(first))
+
+	// second was loaded before the first update, so it must not overwrite it
+	second.State = model.TaskStateRunning
+	second.AgentID = 2
+	assert.ErrorIs(t, store.TaskUpdate(second), types.RecordNotExist)
+
+	task, err := store.TaskLoad("some_random_id")
+	assert.NoError(t, err)
+	assert.Equal(t, model.TaskStateRunning, task.State)
+	assert.EqualValues(t, 1, task.AgentID)
+
+	_, err = store.TaskLoad("unknown")
+	assert.ErrorIs(t, err, types.RecordNotExist)
+}
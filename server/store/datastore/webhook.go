@@ -0,0 +1,131 @@
+// Copyright 2025 Woodpecker Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package datastore
+
+import (
+	"xorm.io/builder"
+	"xorm.io/xorm"
+
+	"go.woodpecker-ci.org/woodpecker/v3/server/model"
+)
+
+func (s storage) WebhookFind(repo *model.Repo, id int64) (*model.Webhook, error) {
+	hook := new(model.Webhook)
+	return hook, wrapGet(s.engine.ID(id).Where("repo_id = ?", repo.ID).Get(hook))
+}
+
+func (s storage) WebhookList(repo *model.Repo, p *model.ListOptions) ([]*model.Webhook, error) {
+	var hooks []*model.Webhook
+	return hooks, s.paginate(p).Where("repo_id = ?", repo.ID).OrderBy("id").Find(&hooks)
+}
+
+func (s storage) OrgWebhookFind(orgID, id int64) (*model.Webhook, error) {
+	hook := new(model.Webhook)
+	return hook, wrapGet(s.engine.ID(id).Where("org_id = ? AND repo_id = ?", orgID, 0).Get(hook))
+}
+
+func (s storage) OrgWebhookList(orgID int64, p *model.ListOptions) ([]*model.Webhook, error) {
+	var hooks []*model.Webhook
+	return hooks, s.paginate(p).Where("org_id = ? AND repo_id = ?", orgID, 0).OrderBy("id").Find(&hooks)
+}
+
+// WebhookListActive returns the active webhooks of the repository and of its organization.
+func (s storage) WebhookListActive(repo *model.Repo) ([]*model.Webhook, error) {
+	cond := builder.Eq{"repo_id": repo.ID}.Or(builder.Eq{"org_id": repo.OrgID, "repo_id": 0})
+	if repo.OrgID == 0 {
+		cond = builder.Eq{"repo_id": repo.ID}
+	}
+
+	var hooks []*model.Webhook
+	return hooks, s.engine.Where(cond).And(builder.Eq{"active": true}).OrderBy("id").Find(&hooks)
+}
+
+func (s storage) WebhookCreate(hook *model.Webhook) error {
+	if err := hook.Validate(); err != nil {
+		return err
+	}
+	_, err := s.engine.Insert(hook)
+	return err
+}
+
+func (s storage) WebhookUpdate(hook *model.Webhook) error {
+	if err := hook.Validate(); err != nil {
+		return err
+	}
+	_, err := s.engine.ID(hook.ID).AllCols().Update(hook)
+	return err
+}
+
+func (s storage) WebhookDelete(hook *model.Webhook) error {
+	sess := s.engine.NewSession()
+	defer sess.Close()
+	if err := sess.Begin(); err != nil {
+		return err
+	}
+
+	if _, err := sess.Where("webhook_id = ?", hook.ID).Delete(new(model.WebhookDelivery)); err != nil {
+		return err
+	}
+	if err := wrapDelete(sess.ID(hook.ID).Delete(new(model.Webhook))); err != nil {
+		return err
+	}
+
+	return sess.Commit()
+}
+
+// deleteWebhooks removes the webhooks matching the condition including their deliveries.
+func deleteWebhooks(sess *xorm.Session, cond builder.Cond) error {
+	if _, err := sess.Where(builder.In("webhook_id", builder.Select("id").From("webhooks").Where(cond))).Delete(new(model.WebhookDelivery)); err != nil {
+		return err
+	}
+	_, err := sess.Where(cond).Delete(new(model.Webhook))
+	return err
+}
+
+func (s storage) WebhookDeliveryFind(hook *model.Webhook, id int64) (*model.WebhookDelivery, error) {
+	delivery := new(model.WebhookDelivery)
+	return delivery, wrapGet(s.engine.ID(id).Where("webhook_id = ?", hook.ID).Get(delivery))
+}
+
+// WebhookDeliveryList returns the deliveries of the webhook, the most recent first.
+func (s storage) WebhookDeliveryList(hook *model.Webhook, p *model.ListOptions) ([]*model.WebhookDelivery, error) {
+	var deliveries []*model.WebhookDelivery
+	return deliveries, s.paginate(p).Where("webhook_id = ?", hook.ID).OrderBy("id DESC").Find(&deliveries)
+}
+
+func (s storage) WebhookDeliveryCreate(delivery *model.WebhookDelivery) error {
+	_, err := s.engine.Insert(delivery)
+	return err
+}
+
+func (s storage) WebhookDeliveryUpdate(delivery *model.WebhookDelivery) error {
+	_, err := s.engine.ID(delivery.ID).AllCols().Update(delivery)
+	return err
+}
+
+// WebhookDeliveryPrune removes all but the keep most recent deliveries of the webhook.
+func (s storage) WebhookDeliveryPrune(hook *model.Webhook, keep int) error {
+	var ids []int64
+	if err := s.engine.Table("webhook_deliveries").Cols("id").Where("webhook_id = ?", hook.ID).
+		OrderBy("id DESC").Limit(1, keep).Find(&ids); err != nil {
+		return err
+	}
+	if len(ids) == 0 {
+		return nil
+	}
+
+	_, err := s.engine.Where("webhook_id = ? AND id <= ?", hook.ID, ids[0]).Delete(new(model.WebhookDelivery))
+	return err
+}
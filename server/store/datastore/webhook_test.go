@@ -0,0 +1,93 @@
+// Copyright 2025 Woodpecker Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package datastore
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"go.woodpecker-ci.org/woodpecker/v3/server/model"
+	"go.woodpecker-ci.org/woodpecker/v3/server/store/types"
+)
+
+func TestWebhooks(t *testing.T) {
+	store, closer := newTestStore(t, new(model.Webhook), new(model.WebhookDelivery), new(model.Repo))
+	defer closer()
+
+	repo := &model.Repo{ID: 1, OrgID: 2}
+	otherRepo := &model.Repo{ID: 3, OrgID: 4}
+
+	repoHook := &model.Webhook{RepoID: repo.ID, URL: "https://example.com/repo", Events: []model.HookEvent{model.HookEventPipelineFinished}, Active: true}
+	orgHook := &model.Webhook{OrgID: repo.OrgID, URL: "https://example.com/org", Events: []model.HookEvent{model.HookEventStepStatus}, Active: true}
+	inactiveHook := &model.Webhook{RepoID: repo.ID, URL: "https://example.com/inactive", Events: []model.HookEvent{model.HookEventPipelineFinished}}
+	otherHook := &model.Webhook{RepoID: otherRepo.ID, URL: "https://example.com/other", Events: []model.HookEvent{model.HookEventPipelineFinished}, Active: true}
+	for _, hook := range []*model.Webhook{repoHook, orgHook, inactiveHook, otherHook} {
+		require.NoError(t, store.WebhookCreate(hook))
+	}
+	assert.Error(t, store.WebhookCreate(&model.Webhook{RepoID: repo.ID, URL: "ftp://example.com", Events: []model.HookEvent{model.HookEventPipelineFinished}}))
+
+	hooks, err := store.WebhookList(repo, &model.ListOptions{All: true})
+	assert.NoError(t, err)
+	assert.Len(t, hooks, 2)
+
+	hooks, err = store.OrgWebhookList(repo.OrgID, &model.ListOptions{All: true})
+	assert.NoError(t, err)
+	assert.Len(t, hooks, 1)
+
+	hooks, err = store.WebhookListActive(repo)
+	assert.NoError(t, err)
+	if assert.Len(t, hooks, 2) {
+		assert.Equal(t, repoHook.ID, hooks[0].ID)
+		assert.Equal(t, orgHook.ID, hooks[1].ID)
+	}
+
+	_, err = store.WebhookFind(otherRepo, repoHook.ID)
+	assert.ErrorIs(t, err, types.RecordNotExist)
+	_, err = store.OrgWebhookFind(repo.OrgID, repoHook.ID)
+	assert.ErrorIs(t, err, types.RecordNotExist)
+
+	repoHook.URL = "https://example.com/updated"
+	assert.NoError(t, store.WebhookUpdate(repoHook))
+	hook, err := store.WebhookFind(repo, repoHook.ID)
+	assert.NoError(t, err)
+	assert.Equal(t, "https://example.com/updated", hook.URL)
+
+	for i := 0; i < 5; i++ {
+		require.NoError(t, store.WebhookDeliveryCreate(&model.WebhookDelivery{WebhookID: repoHook.ID, Event: model.HookEventPipelineFinished}))
+	}
+	assert.NoError(t, store.WebhookDeliveryPrune(repoHook, 3))
+	deliveries, err := store.WebhookDeliveryList(repoHook, &model.ListOptions{All: true})
+	assert.NoError(t, err)
+	if assert.Len(t, deliveries, 3) {
+		assert.Greater(t, deliveries[0].ID, deliveries[1].ID)
+	}
+
+	delivery := deliveries[0]
+	delivery.Success = true
+	delivery.StatusCode = 200
+	assert.NoError(t, store.WebhookDeliveryUpdate(delivery))
+	delivery, err = store.WebhookDeliveryFind(repoHook, delivery.ID)
+	assert.NoError(t, err)
+	assert.True(t, delivery.Success)
+	_, err = store.WebhookDeliveryFind(orgHook, delivery.ID)
+	assert.ErrorIs(t, err, types.RecordNotExist)
+
+	assert.NoError(t, store.WebhookDelete(repoHook))
+	count, err := store.engine.Count(new(model.WebhookDelivery))
+	assert.NoError(t, err)
+	assert.EqualValues(t, 0, count)
+}
@@ -191,6 +191,247 @@ func (_m *Store) AgentUpdate(_a0 *model.Agent) error {
 	return r0
 }
 
+// ApprovalAdd provides a mock function with given fields: _a0, _a1
+func (_m *Store) ApprovalAdd(_a0 *model.Approval, _a1 int) ([]*model.Approval, bool, error) {
+	ret := _m.Called(_a0, _a1)
+
+	if len(ret) == 0 {
+		panic("no return value specified for ApprovalAdd")
+	}
+
+	var r0 []*model.Approval
+	var r1 bool
+	var r2 error
+	if rf, ok := ret.Get(0).(func(*model.Approval, int) ([]*model.Approval, bool, error)); ok {
+		return rf(_a0, _a1)
+	}
+	if rf, ok := ret.Get(0).(func(*model.Approval, int) []*model.Approval); ok {
+		r0 = rf(_a0, _a1)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]*model.Approval)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(*model.Approval, int) bool); ok {
+		r1 = rf(_a0, _a1)
+	} else {
+		r1 = ret.Get(1).(bool)
+	}
+
+	if rf, ok := ret.Get(2).(func(*model.Approval, int) error); ok {
+		r2 = rf(_a0, _a1)
+	} else {
+		r2 = ret.Error(2)
+	}
+
+	return r0, r1, r2
+}
+
+// ApprovalList provides a mock function with given fields: _a0
+func (_m *Store) ApprovalList(_a0 *model.Pipeline) ([]*model.Approval, error) {
+	ret := _m.Called(_a0)
+
+	if len(ret) == 0 {
+		panic("no return value specified for ApprovalList")
+	}
+
+	var r0 []*model.Approval
+	var r1 error
+	if rf, ok := ret.Get(0).(func(*model.Pipeline) ([]*model.Approval, error)); ok {
+		return rf(_a0)
+	}
+	if rf, ok := ret.Get(0).(func(*model.Pipeline) []*model.Approval); ok {
+		r0 = rf(_a0)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]*model.Approval)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(*model.Pipeline) error); ok {
+		r1 = rf(_a0)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// ArtifactCreate provides a mock function with given fields: _a0
+func (_m *Store) ArtifactCreate(_a0 *model.Artifact) error {
+	ret := _m.Called(_a0)
+
+	if len(ret) == 0 {
+		panic("no return value specified for ArtifactCreate")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(*model.Artifact) error); ok {
+		r0 = rf(_a0)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// ArtifactDelete provides a mock function with given fields: _a0
+func (_m *Store) ArtifactDelete(_a0 *model.Artifact) error {
+	ret := _m.Called(_a0)
+
+	if len(ret) == 0 {
+		panic("no return value specified for ArtifactDelete")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(*model.Artifact) error); ok {
+		r0 = rf(_a0)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// ArtifactFind provides a mock function with given fields: _a0, _a1
+func (_m *Store) ArtifactFind(_a0 *model.Pipeline, _a1 int64) (*model.Artifact, error) {
+	ret := _m.Called(_a0, _a1)
+
+	if len(ret) == 0 {
+		panic("no return value specified for ArtifactFind")
+	}
+
+	var r0 *model.Artifact
+	var r1 error
+	if rf, ok := ret.Get(0).(func(*model.Pipeline, int64) (*model.Artifact, error)); ok {
+		return rf(_a0, _a1)
+	}
+	if rf, ok := ret.Get(0).(func(*model.Pipeline, int64) *model.Artifact); ok {
+		r0 = rf(_a0, _a1)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*model.Artifact)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(*model.Pipeline, int64) error); ok {
+		r1 = rf(_a0, _a1)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// ArtifactList provides a mock function with given fields: _a0
+func (_m *Store) ArtifactList(_a0 *model.Pipeline) ([]*model.Artifact, error) {
+	ret := _m.Called(_a0)
+
+	if len(ret) == 0 {
+		panic("no return value specified for ArtifactList")
+	}
+
+	var r0 []*model.Artifact
+	var r1 error
+	if rf, ok := ret.Get(0).(func(*model.Pipeline) ([]*model.Artifact, error)); ok {
+		return rf(_a0)
+	}
+	if rf, ok := ret.Get(0).(func(*model.Pipeline) []*model.Artifact); ok {
+		r0 = rf(_a0)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]*model.Artifact)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(*model.Pipeline) error); ok {
+		r1 = rf(_a0)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// ArtifactListRepo provides a mock function with given fields: _a0
+func (_m *Store) ArtifactListRepo(_a0 *model.Repo) ([]*model.Artifact, error) {
+	ret := _m.Called(_a0)
+
+	if len(ret) == 0 {
+		panic("no return value specified for ArtifactListRepo")
+	}
+
+	var r0 []*model.Artifact
+	var r1 error
+	if rf, ok := ret.Get(0).(func(*model.Repo) ([]*model.Artifact, error)); ok {
+		return rf(_a0)
+	}
+	if rf, ok := ret.Get(0).(func(*model.Repo) []*model.Artifact); ok {
+		r0 = rf(_a0)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]*model.Artifact)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(*model.Repo) error); ok {
+		r1 = rf(_a0)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// AuditEventCreate provides a mock function with given fields: _a0
+func (_m *Store) AuditEventCreate(_a0 *model.AuditEvent) error {
+	ret := _m.Called(_a0)
+
+	if len(ret) == 0 {
+		panic("no return value specified for AuditEventCreate")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(*model.AuditEvent) error); ok {
+		r0 = rf(_a0)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// AuditEventList provides a mock function with given fields: _a0, _a1
+func (_m *Store) AuditEventList(_a0 *model.AuditEventFilter, _a1 *model.ListOptions) ([]*model.AuditEvent, error) {
+	ret := _m.Called(_a0, _a1)
+
+	if len(ret) == 0 {
+		panic("no return value specified for AuditEventList")
+	}
+
+	var r0 []*model.AuditEvent
+	var r1 error
+	if rf, ok := ret.Get(0).(func(*model.AuditEventFilter, *model.ListOptions) ([]*model.AuditEvent, error)); ok {
+		return rf(_a0, _a1)
+	}
+	if rf, ok := ret.Get(0).(func(*model.AuditEventFilter, *model.ListOptions) []*model.AuditEvent); ok {
+		r0 = rf(_a0, _a1)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]*model.AuditEvent)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(*model.AuditEventFilter, *model.ListOptions) error); ok {
+		r1 = rf(_a0, _a1)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
 // Close provides a mock function with no fields
 func (_m *Store) Close() error {
 	ret := _m.Called()
@@ -574,16 +815,16 @@ func (_m *Store) DeleteUser(_a0 *model.User) error {
 	return r0
 }
 
-// ForgeCreate provides a mock function with given fields: _a0
-func (_m *Store) ForgeCreate(_a0 *model.Forge) error {
+// EnvironmentCreate provides a mock function with given fields: _a0
+func (_m *Store) EnvironmentCreate(_a0 *model.Environment) error {
 	ret := _m.Called(_a0)
 
 	if len(ret) == 0 {
-		panic("no return value specified for ForgeCreate")
+		panic("no return value specified for EnvironmentCreate")
 	}
 
 	var r0 error
-	if rf, ok := ret.Get(0).(func(*model.Forge) error); ok {
+	if rf, ok := ret.Get(0).(func(*model.Environment) error); ok {
 		r0 = rf(_a0)
 	} else {
 		r0 = ret.Error(0)
@@ -592,16 +833,16 @@ func (_m *Store) ForgeCreate(_a0 *model.Forge) error {
 	return r0
 }
 
-// ForgeDelete provides a mock function with given fields: _a0
-func (_m *Store) ForgeDelete(_a0 *model.Forge) error {
+// EnvironmentDelete provides a mock function with given fields: _a0
+func (_m *Store) EnvironmentDelete(_a0 *model.Environment) error {
 	ret := _m.Called(_a0)
 
 	if len(ret) == 0 {
-		panic("no return value specified for ForgeDelete")
+		panic("no return value specified for EnvironmentDelete")
 	}
 
 	var r0 error
-	if rf, ok := ret.Get(0).(func(*model.Forge) error); ok {
+	if rf, ok := ret.Get(0).(func(*model.Environment) error); ok {
 		r0 = rf(_a0)
 	} else {
 		r0 = ret.Error(0)
@@ -610,29 +851,29 @@ func (_m *Store) ForgeDelete(_a0 *model.Forge) error {
 	return r0
 }
 
-// ForgeGet provides a mock function with given fields: _a0
-func (_m *Store) ForgeGet(_a0 int64) (*model.Forge, error) {
-	ret := _m.Called(_a0)
+// EnvironmentFind provides a mock function with given fields: _a0, _a1
+func (_m *Store) EnvironmentFind(_a0 *model.Repo, _a1 string) (*model.Environment, error) {
+	ret := _m.Called(_a0, _a1)
 
 	if len(ret) == 0 {
-		panic("no return value specified for ForgeGet")
+		panic("no return value specified for EnvironmentFind")
 	}
 
-	var r0 *model.Forge
+	var r0 *model.Environment
 	var r1 error
-	if rf, ok := ret.Get(0).(func(int64) (*model.Forge, error)); ok {
-		return rf(_a0)
+	if rf, ok := ret.Get(0).(func(*model.Repo, string) (*model.Environment, error)); ok {
+		return rf(_a0, _a1)
 	}
-	if rf, ok := ret.Get(0).(func(int64) *model.Forge); ok {
-		r0 = rf(_a0)
+	if rf, ok := ret.Get(0).(func(*model.Repo, string) *model.Environment); ok {
+		r0 = rf(_a0, _a1)
 	} else {
 		if ret.Get(0) != nil {
-			r0 = ret.Get(0).(*model.Forge)
+			r0 = ret.Get(0).(*model.Environment)
 		}
 	}
 
-	if rf, ok := ret.Get(1).(func(int64) error); ok {
-		r1 = rf(_a0)
+	if rf, ok := ret.Get(1).(func(*model.Repo, string) error); ok {
+		r1 = rf(_a0, _a1)
 	} else {
 		r1 = ret.Error(1)
 	}
@@ -640,29 +881,29 @@ func (_m *Store) ForgeGet(_a0 int64) (*model.Forge, error) {
 	return r0, r1
 }
 
-// ForgeList provides a mock function with given fields: p
-func (_m *Store) ForgeList(p *model.ListOptions) ([]*model.Forge, error) {
-	ret := _m.Called(p)
+// EnvironmentList provides a mock function with given fields: _a0, _a1
+func (_m *Store) EnvironmentList(_a0 *model.Repo, _a1 *model.ListOptions) ([]*model.Environment, error) {
+	ret := _m.Called(_a0, _a1)
 
 	if len(ret) == 0 {
-		panic("no return value specified for ForgeList")
+		panic("no return value specified for EnvironmentList")
 	}
 
-	var r0 []*model.Forge
+	var r0 []*model.Environment
 	var r1 error
-	if rf, ok := ret.Get(0).(func(*model.ListOptions) ([]*model.Forge, error)); ok {
-		return rf(p)
+	if rf, ok := ret.Get(0).(func(*model.Repo, *model.ListOptions) ([]*model.Environment, error)); ok {
+		return rf(_a0, _a1)
 	}
-	if rf, ok := ret.Get(0).(func(*model.ListOptions) []*model.Forge); ok {
-		r0 = rf(p)
+	if rf, ok := ret.Get(0).(func(*model.Repo, *model.ListOptions) []*model.Environment); ok {
+		r0 = rf(_a0, _a1)
 	} else {
 		if ret.Get(0) != nil {
-			r0 = ret.Get(0).([]*model.Forge)
+			r0 = ret.Get(0).([]*model.Environment)
 		}
 	}
 
-	if rf, ok := ret.Get(1).(func(*model.ListOptions) error); ok {
-		r1 = rf(p)
+	if rf, ok := ret.Get(1).(func(*model.Repo, *model.ListOptions) error); ok {
+		r1 = rf(_a0, _a1)
 	} else {
 		r1 = ret.Error(1)
 	}
@@ -670,47 +911,29 @@ func (_m *Store) ForgeList(p *model.ListOptions) ([]*model.Forge, error) {
 	return r0, r1
 }
 
-// ForgeUpdate provides a mock function with given fields: _a0
-func (_m *Store) ForgeUpdate(_a0 *model.Forge) error {
-	ret := _m.Called(_a0)
-
-	if len(ret) == 0 {
-		panic("no return value specified for ForgeUpdate")
-	}
-
-	var r0 error
-	if rf, ok := ret.Get(0).(func(*model.Forge) error); ok {
-		r0 = rf(_a0)
-	} else {
-		r0 = ret.Error(0)
-	}
-
-	return r0
-}
-
-// GetActivePipelineList provides a mock function with given fields: repo
-func (_m *Store) GetActivePipelineList(repo *model.Repo) ([]*model.Pipeline, error) {
-	ret := _m.Called(repo)
+// EnvironmentSecretFind provides a mock function with given fields: _a0, _a1
+func (_m *Store) EnvironmentSecretFind(_a0 *model.Environment, _a1 string) (*model.Secret, error) {
+	ret := _m.Called(_a0, _a1)
 
 	if len(ret) == 0 {
-		panic("no return value specified for GetActivePipelineList")
+		panic("no return value specified for EnvironmentSecretFind")
 	}
 
-	var r0 []*model.Pipeline
+	var r0 *model.Secret
 	var r1 error
-	if rf, ok := ret.Get(0).(func(*model.Repo) ([]*model.Pipeline, error)); ok {
-		return rf(repo)
+	if rf, ok := ret.Get(0).(func(*model.Environment, string) (*model.Secret, error)); ok {
+		return rf(_a0, _a1)
 	}
-	if rf, ok := ret.Get(0).(func(*model.Repo) []*model.Pipeline); ok {
-		r0 = rf(repo)
+	if rf, ok := ret.Get(0).(func(*model.Environment, string) *model.Secret); ok {
+		r0 = rf(_a0, _a1)
 	} else {
 		if ret.Get(0) != nil {
-			r0 = ret.Get(0).([]*model.Pipeline)
+			r0 = ret.Get(0).(*model.Secret)
 		}
 	}
 
-	if rf, ok := ret.Get(1).(func(*model.Repo) error); ok {
-		r1 = rf(repo)
+	if rf, ok := ret.Get(1).(func(*model.Environment, string) error); ok {
+		r1 = rf(_a0, _a1)
 	} else {
 		r1 = ret.Error(1)
 	}
@@ -718,7 +941,199 @@ func (_m *Store) GetActivePipelineList(repo *model.Repo) ([]*model.Pipeline, err
 	return r0, r1
 }
 
-// GetPipeline provides a mock function with given fields: _a0
+// EnvironmentSecretList provides a mock function with given fields: _a0, _a1
+func (_m *Store) EnvironmentSecretList(_a0 *model.Environment, _a1 *model.ListOptions) ([]*model.Secret, error) {
+	ret := _m.Called(_a0, _a1)
+
+	if len(ret) == 0 {
+		panic("no return value specified for EnvironmentSecretList")
+	}
+
+	var r0 []*model.Secret
+	var r1 error
+	if rf, ok := ret.Get(0).(func(*model.Environment, *model.ListOptions) ([]*model.Secret, error)); ok {
+		return rf(_a0, _a1)
+	}
+	if rf, ok := ret.Get(0).(func(*model.Environment, *model.ListOptions) []*model.Secret); ok {
+		r0 = rf(_a0, _a1)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]*model.Secret)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(*model.Environment, *model.ListOptions) error); ok {
+		r1 = rf(_a0, _a1)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// EnvironmentUpdate provides a mock function with given fields: _a0
+func (_m *Store) EnvironmentUpdate(_a0 *model.Environment) error {
+	ret := _m.Called(_a0)
+
+	if len(ret) == 0 {
+		panic("no return value specified for EnvironmentUpdate")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(*model.Environment) error); ok {
+		r0 = rf(_a0)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// ForgeCreate provides a mock function with given fields: _a0
+func (_m *Store) ForgeCreate(_a0 *model.Forge) error {
+	ret := _m.Called(_a0)
+
+	if len(ret) == 0 {
+		panic("no return value specified for ForgeCreate")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(*model.Forge) error); ok {
+		r0 = rf(_a0)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// ForgeDelete provides a mock function with given fields: _a0
+func (_m *Store) ForgeDelete(_a0 *model.Forge) error {
+	ret := _m.Called(_a0)
+
+	if len(ret) == 0 {
+		panic("no return value specified for ForgeDelete")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(*model.Forge) error); ok {
+		r0 = rf(_a0)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// ForgeGet provides a mock function with given fields: _a0
+func (_m *Store) ForgeGet(_a0 int64) (*model.Forge, error) {
+	ret := _m.Called(_a0)
+
+	if len(ret) == 0 {
+		panic("no return value specified for ForgeGet")
+	}
+
+	var r0 *model.Forge
+	var r1 error
+	if rf, ok := ret.Get(0).(func(int64) (*model.Forge, error)); ok {
+		return rf(_a0)
+	}
+	if rf, ok := ret.Get(0).(func(int64) *model.Forge); ok {
+		r0 = rf(_a0)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*model.Forge)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(int64) error); ok {
+		r1 = rf(_a0)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// ForgeList provides a mock function with given fields: p
+func (_m *Store) ForgeList(p *model.ListOptions) ([]*model.Forge, error) {
+	ret := _m.Called(p)
+
+	if len(ret) == 0 {
+		panic("no return value specified for ForgeList")
+	}
+
+	var r0 []*model.Forge
+	var r1 error
+	if rf, ok := ret.Get(0).(func(*model.ListOptions) ([]*model.Forge, error)); ok {
+		return rf(p)
+	}
+	if rf, ok := ret.Get(0).(func(*model.ListOptions) []*model.Forge); ok {
+		r0 = rf(p)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]*model.Forge)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(*model.ListOptions) error); ok {
+		r1 = rf(p)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// ForgeUpdate provides a mock function with given fields: _a0
+func (_m *Store) ForgeUpdate(_a0 *model.Forge) error {
+	ret := _m.Called(_a0)
+
+	if len(ret) == 0 {
+		panic("no return value specified for ForgeUpdate")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(*model.Forge) error); ok {
+		r0 = rf(_a0)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// GetActivePipelineList provides a mock function with given fields: repo
+func (_m *Store) GetActivePipelineList(repo *model.Repo) ([]*model.Pipeline, error) {
+	ret := _m.Called(repo)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetActivePipelineList")
+	}
+
+	var r0 []*model.Pipeline
+	var r1 error
+	if rf, ok := ret.Get(0).(func(*model.Repo) ([]*model.Pipeline, error)); ok {
+		return rf(repo)
+	}
+	if rf, ok := ret.Get(0).(func(*model.Repo) []*model.Pipeline); ok {
+		r0 = rf(repo)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]*model.Pipeline)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(*model.Repo) error); ok {
+		r1 = rf(repo)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// GetPipeline provides a mock function with given fields: _a0
 func (_m *Store) GetPipeline(_a0 int64) (*model.Pipeline, error) {
 	ret := _m.Called(_a0)
 
@@ -1808,6 +2223,66 @@ func (_m *Store) OrgUpdate(_a0 *model.Org) error {
 	return r0
 }
 
+// OrgWebhookFind provides a mock function with given fields: _a0, _a1
+func (_m *Store) OrgWebhookFind(_a0 int64, _a1 int64) (*model.Webhook, error) {
+	ret := _m.Called(_a0, _a1)
+
+	if len(ret) == 0 {
+		panic("no return value specified for OrgWebhookFind")
+	}
+
+	var r0 *model.Webhook
+	var r1 error
+	if rf, ok := ret.Get(0).(func(int64, int64) (*model.Webhook, error)); ok {
+		return rf(_a0, _a1)
+	}
+	if rf, ok := ret.Get(0).(func(int64, int64) *model.Webhook); ok {
+		r0 = rf(_a0, _a1)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*model.Webhook)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(int64, int64) error); ok {
+		r1 = rf(_a0, _a1)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// OrgWebhookList provides a mock function with given fields: _a0, _a1
+func (_m *Store) OrgWebhookList(_a0 int64, _a1 *model.ListOptions) ([]*model.Webhook, error) {
+	ret := _m.Called(_a0, _a1)
+
+	if len(ret) == 0 {
+		panic("no return value specified for OrgWebhookList")
+	}
+
+	var r0 []*model.Webhook
+	var r1 error
+	if rf, ok := ret.Get(0).(func(int64, *model.ListOptions) ([]*model.Webhook, error)); ok {
+		return rf(_a0, _a1)
+	}
+	if rf, ok := ret.Get(0).(func(int64, *model.ListOptions) []*model.Webhook); ok {
+		r0 = rf(_a0, _a1)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]*model.Webhook)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(int64, *model.ListOptions) error); ok {
+		r1 = rf(_a0, _a1)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
 // PermFind provides a mock function with given fields: user, repo
 func (_m *Store) PermFind(user *model.User, repo *model.Repo) (*model.Perm, error) {
 	ret := _m.Called(user, repo)
@@ -1856,17 +2331,17 @@ func (_m *Store) PermUpsert(perm *model.Perm) error {
 	return r0
 }
 
-// Ping provides a mock function with no fields
-func (_m *Store) Ping() error {
-	ret := _m.Called()
+// PersonalAccessTokenCreate provides a mock function with given fields: _a0
+func (_m *Store) PersonalAccessTokenCreate(_a0 *model.PersonalAccessToken) error {
+	ret := _m.Called(_a0)
 
 	if len(ret) == 0 {
-		panic("no return value specified for Ping")
+		panic("no return value specified for PersonalAccessTokenCreate")
 	}
 
 	var r0 error
-	if rf, ok := ret.Get(0).(func() error); ok {
-		r0 = rf()
+	if rf, ok := ret.Get(0).(func(*model.PersonalAccessToken) error); ok {
+		r0 = rf(_a0)
 	} else {
 		r0 = ret.Error(0)
 	}
@@ -1874,16 +2349,16 @@ func (_m *Store) Ping() error {
 	return r0
 }
 
-// PipelineConfigCreate provides a mock function with given fields: _a0
-func (_m *Store) PipelineConfigCreate(_a0 *model.PipelineConfig) error {
+// PersonalAccessTokenDelete provides a mock function with given fields: _a0
+func (_m *Store) PersonalAccessTokenDelete(_a0 *model.PersonalAccessToken) error {
 	ret := _m.Called(_a0)
 
 	if len(ret) == 0 {
-		panic("no return value specified for PipelineConfigCreate")
+		panic("no return value specified for PersonalAccessTokenDelete")
 	}
 
 	var r0 error
-	if rf, ok := ret.Get(0).(func(*model.PipelineConfig) error); ok {
+	if rf, ok := ret.Get(0).(func(*model.PersonalAccessToken) error); ok {
 		r0 = rf(_a0)
 	} else {
 		r0 = ret.Error(0)
@@ -1892,25 +2367,265 @@ func (_m *Store) PipelineConfigCreate(_a0 *model.PipelineConfig) error {
 	return r0
 }
 
-// RegistryCreate provides a mock function with given fields: _a0
-func (_m *Store) RegistryCreate(_a0 *model.Registry) error {
+// PersonalAccessTokenFind provides a mock function with given fields: _a0
+func (_m *Store) PersonalAccessTokenFind(_a0 int64) (*model.PersonalAccessToken, error) {
 	ret := _m.Called(_a0)
 
 	if len(ret) == 0 {
-		panic("no return value specified for RegistryCreate")
+		panic("no return value specified for PersonalAccessTokenFind")
 	}
 
-	var r0 error
-	if rf, ok := ret.Get(0).(func(*model.Registry) error); ok {
+	var r0 *model.PersonalAccessToken
+	var r1 error
+	if rf, ok := ret.Get(0).(func(int64) (*model.PersonalAccessToken, error)); ok {
+		return rf(_a0)
+	}
+	if rf, ok := ret.Get(0).(func(int64) *model.PersonalAccessToken); ok {
 		r0 = rf(_a0)
 	} else {
-		r0 = ret.Error(0)
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*model.PersonalAccessToken)
+		}
 	}
 
-	return r0
-}
-
-// RegistryDelete provides a mock function with given fields: _a0
+	if rf, ok := ret.Get(1).(func(int64) error); ok {
+		r1 = rf(_a0)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// PersonalAccessTokenList provides a mock function with given fields: _a0, _a1
+func (_m *Store) PersonalAccessTokenList(_a0 *model.User, _a1 *model.ListOptions) ([]*model.PersonalAccessToken, error) {
+	ret := _m.Called(_a0, _a1)
+
+	if len(ret) == 0 {
+		panic("no return value specified for PersonalAccessTokenList")
+	}
+
+	var r0 []*model.PersonalAccessToken
+	var r1 error
+	if rf, ok := ret.Get(0).(func(*model.User, *model.ListOptions) ([]*model.PersonalAccessToken, error)); ok {
+		return rf(_a0, _a1)
+	}
+	if rf, ok := ret.Get(0).(func(*model.User, *model.ListOptions) []*model.PersonalAccessToken); ok {
+		r0 = rf(_a0, _a1)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]*model.PersonalAccessToken)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(*model.User, *model.ListOptions) error); ok {
+		r1 = rf(_a0, _a1)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// PersonalAccessTokenUpdate provides a mock function with given fields: _a0
+func (_m *Store) PersonalAccessTokenUpdate(_a0 *model.PersonalAccessToken) error {
+	ret := _m.Called(_a0)
+
+	if len(ret) == 0 {
+		panic("no return value specified for PersonalAccessTokenUpdate")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(*model.PersonalAccessToken) error); ok {
+		r0 = rf(_a0)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// Ping provides a mock function with no fields
+func (_m *Store) Ping() error {
+	ret := _m.Called()
+
+	if len(ret) == 0 {
+		panic("no return value specified for Ping")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func() error); ok {
+		r0 = rf()
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// PipelineConfigCreate provides a mock function with given fields: _a0
+func (_m *Store) PipelineConfigCreate(_a0 *model.PipelineConfig) error {
+	ret := _m.Called(_a0)
+
+	if len(ret) == 0 {
+		panic("no return value specified for PipelineConfigCreate")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(*model.PipelineConfig) error); ok {
+		r0 = rf(_a0)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// PipelineStatsCount provides a mock function with given fields: _a0, _a1, _a2
+func (_m *Store) PipelineStatsCount(_a0 *model.Repo, _a1 *model.PipelineFilter, _a2 int64) ([]*model.PipelineStatusCount, error) {
+	ret := _m.Called(_a0, _a1, _a2)
+
+	if len(ret) == 0 {
+		panic("no return value specified for PipelineStatsCount")
+	}
+
+	var r0 []*model.PipelineStatusCount
+	var r1 error
+	if rf, ok := ret.Get(0).(func(*model.Repo, *model.PipelineFilter, int64) ([]*model.PipelineStatusCount, error)); ok {
+		return rf(_a0, _a1, _a2)
+	}
+	if rf, ok := ret.Get(0).(func(*model.Repo, *model.PipelineFilter, int64) []*model.PipelineStatusCount); ok {
+		r0 = rf(_a0, _a1, _a2)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]*model.PipelineStatusCount)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(*model.Repo, *model.PipelineFilter, int64) error); ok {
+		r1 = rf(_a0, _a1, _a2)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// PipelineStatsList provides a mock function with given fields: _a0, _a1, _a2
+func (_m *Store) PipelineStatsList(_a0 *model.Repo, _a1 *model.PipelineFilter, _a2 int) ([]*model.Pipeline, error) {
+	ret := _m.Called(_a0, _a1, _a2)
+
+	if len(ret) == 0 {
+		panic("no return value specified for PipelineStatsList")
+	}
+
+	var r0 []*model.Pipeline
+	var r1 error
+	if rf, ok := ret.Get(0).(func(*model.Repo, *model.PipelineFilter, int) ([]*model.Pipeline, error)); ok {
+		return rf(_a0, _a1, _a2)
+	}
+	if rf, ok := ret.Get(0).(func(*model.Repo, *model.PipelineFilter, int) []*model.Pipeline); ok {
+		r0 = rf(_a0, _a1, _a2)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]*model.Pipeline)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(*model.Repo, *model.PipelineFilter, int) error); ok {
+		r1 = rf(_a0, _a1, _a2)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// PubsubMessageCreate provides a mock function with given fields: _a0
+func (_m *Store) PubsubMessageCreate(_a0 *model.PubsubMessage) error {
+	ret := _m.Called(_a0)
+
+	if len(ret) == 0 {
+		panic("no return value specified for PubsubMessageCreate")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(*model.PubsubMessage) error); ok {
+		r0 = rf(_a0)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// PubsubMessageList provides a mock function with given fields: _a0, _a1
+func (_m *Store) PubsubMessageList(_a0 string, _a1 int64) ([]*model.PubsubMessage, error) {
+	ret := _m.Called(_a0, _a1)
+
+	if len(ret) == 0 {
+		panic("no return value specified for PubsubMessageList")
+	}
+
+	var r0 []*model.PubsubMessage
+	var r1 error
+	if rf, ok := ret.Get(0).(func(string, int64) ([]*model.PubsubMessage, error)); ok {
+		return rf(_a0, _a1)
+	}
+	if rf, ok := ret.Get(0).(func(string, int64) []*model.PubsubMessage); ok {
+		r0 = rf(_a0, _a1)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]*model.PubsubMessage)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(string, int64) error); ok {
+		r1 = rf(_a0, _a1)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// PubsubMessagePrune provides a mock function with given fields: _a0
+func (_m *Store) PubsubMessagePrune(_a0 int64) error {
+	ret := _m.Called(_a0)
+
+	if len(ret) == 0 {
+		panic("no return value specified for PubsubMessagePrune")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(int64) error); ok {
+		r0 = rf(_a0)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// RegistryCreate provides a mock function with given fields: _a0
+func (_m *Store) RegistryCreate(_a0 *model.Registry) error {
+	ret := _m.Called(_a0)
+
+	if len(ret) == 0 {
+		panic("no return value specified for RegistryCreate")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(*model.Registry) error); ok {
+		r0 = rf(_a0)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// RegistryDelete provides a mock function with given fields: _a0
 func (_m *Store) RegistryDelete(_a0 *model.Registry) error {
 	ret := _m.Called(_a0)
 
@@ -2424,6 +3139,54 @@ func (_m *Store) StepFind(_a0 *model.Pipeline, _a1 int) (*model.Step, error) {
 	return r0, r1
 }
 
+// StepFlakinessAdd provides a mock function with given fields: _a0
+func (_m *Store) StepFlakinessAdd(_a0 *model.StepFlakiness) error {
+	ret := _m.Called(_a0)
+
+	if len(ret) == 0 {
+		panic("no return value specified for StepFlakinessAdd")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(*model.StepFlakiness) error); ok {
+		r0 = rf(_a0)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// StepFlakinessList provides a mock function with given fields: _a0, _a1
+func (_m *Store) StepFlakinessList(_a0 *model.Repo, _a1 *model.ListOptions) ([]*model.StepFlakiness, error) {
+	ret := _m.Called(_a0, _a1)
+
+	if len(ret) == 0 {
+		panic("no return value specified for StepFlakinessList")
+	}
+
+	var r0 []*model.StepFlakiness
+	var r1 error
+	if rf, ok := ret.Get(0).(func(*model.Repo, *model.ListOptions) ([]*model.StepFlakiness, error)); ok {
+		return rf(_a0, _a1)
+	}
+	if rf, ok := ret.Get(0).(func(*model.Repo, *model.ListOptions) []*model.StepFlakiness); ok {
+		r0 = rf(_a0, _a1)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]*model.StepFlakiness)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(*model.Repo, *model.ListOptions) error); ok {
+		r1 = rf(_a0, _a1)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
 // StepList provides a mock function with given fields: _a0
 func (_m *Store) StepList(_a0 *model.Pipeline) ([]*model.Step, error) {
 	ret := _m.Called(_a0)
@@ -2484,29 +3247,29 @@ func (_m *Store) StepListFromWorkflowFind(_a0 *model.Workflow) ([]*model.Step, e
 	return r0, r1
 }
 
-// StepLoad provides a mock function with given fields: _a0
-func (_m *Store) StepLoad(_a0 int64) (*model.Step, error) {
-	ret := _m.Called(_a0)
+// StepListPrevious provides a mock function with given fields: _a0, _a1
+func (_m *Store) StepListPrevious(_a0 *model.Pipeline, _a1 string) ([]*model.Step, error) {
+	ret := _m.Called(_a0, _a1)
 
 	if len(ret) == 0 {
-		panic("no return value specified for StepLoad")
+		panic("no return value specified for StepListPrevious")
 	}
 
-	var r0 *model.Step
+	var r0 []*model.Step
 	var r1 error
-	if rf, ok := ret.Get(0).(func(int64) (*model.Step, error)); ok {
-		return rf(_a0)
+	if rf, ok := ret.Get(0).(func(*model.Pipeline, string) ([]*model.Step, error)); ok {
+		return rf(_a0, _a1)
 	}
-	if rf, ok := ret.Get(0).(func(int64) *model.Step); ok {
-		r0 = rf(_a0)
+	if rf, ok := ret.Get(0).(func(*model.Pipeline, string) []*model.Step); ok {
+		r0 = rf(_a0, _a1)
 	} else {
 		if ret.Get(0) != nil {
-			r0 = ret.Get(0).(*model.Step)
+			r0 = ret.Get(0).([]*model.Step)
 		}
 	}
 
-	if rf, ok := ret.Get(1).(func(int64) error); ok {
-		r1 = rf(_a0)
+	if rf, ok := ret.Get(1).(func(*model.Pipeline, string) error); ok {
+		r1 = rf(_a0, _a1)
 	} else {
 		r1 = ret.Error(1)
 	}
@@ -2514,8 +3277,68 @@ func (_m *Store) StepLoad(_a0 int64) (*model.Step, error) {
 	return r0, r1
 }
 
-// StepUpdate provides a mock function with given fields: _a0
-func (_m *Store) StepUpdate(_a0 *model.Step) error {
+// StepLoad provides a mock function with given fields: _a0
+func (_m *Store) StepLoad(_a0 int64) (*model.Step, error) {
+	ret := _m.Called(_a0)
+
+	if len(ret) == 0 {
+		panic("no return value specified for StepLoad")
+	}
+
+	var r0 *model.Step
+	var r1 error
+	if rf, ok := ret.Get(0).(func(int64) (*model.Step, error)); ok {
+		return rf(_a0)
+	}
+	if rf, ok := ret.Get(0).(func(int64) *model.Step); ok {
+		r0 = rf(_a0)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*model.Step)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(int64) error); ok {
+		r1 = rf(_a0)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// StepStatsList provides a mock function with given fields: _a0, _a1
+func (_m *Store) StepStatsList(_a0 *model.Repo, _a1 *model.PipelineFilter) ([]*model.StepStats, error) {
+	ret := _m.Called(_a0, _a1)
+
+	if len(ret) == 0 {
+		panic("no return value specified for StepStatsList")
+	}
+
+	var r0 []*model.StepStats
+	var r1 error
+	if rf, ok := ret.Get(0).(func(*model.Repo, *model.PipelineFilter) ([]*model.StepStats, error)); ok {
+		return rf(_a0, _a1)
+	}
+	if rf, ok := ret.Get(0).(func(*model.Repo, *model.PipelineFilter) []*model.StepStats); ok {
+		r0 = rf(_a0, _a1)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]*model.StepStats)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(*model.Repo, *model.PipelineFilter) error); ok {
+		r1 = rf(_a0, _a1)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// StepUpdate provides a mock function with given fields: _a0
+func (_m *Store) StepUpdate(_a0 *model.Step) error {
 	ret := _m.Called(_a0)
 
 	if len(ret) == 0 {
@@ -2598,6 +3421,54 @@ func (_m *Store) TaskList() ([]*model.Task, error) {
 	return r0, r1
 }
 
+// TaskLoad provides a mock function with given fields: _a0
+func (_m *Store) TaskLoad(_a0 string) (*model.Task, error) {
+	ret := _m.Called(_a0)
+
+	if len(ret) == 0 {
+		panic("no return value specified for TaskLoad")
+	}
+
+	var r0 *model.Task
+	var r1 error
+	if rf, ok := ret.Get(0).(func(string) (*model.Task, error)); ok {
+		return rf(_a0)
+	}
+	if rf, ok := ret.Get(0).(func(string) *model.Task); ok {
+		r0 = rf(_a0)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*model.Task)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(string) error); ok {
+		r1 = rf(_a0)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// TaskUpdate provides a mock function with given fields: _a0
+func (_m *Store) TaskUpdate(_a0 *model.Task) error {
+	ret := _m.Called(_a0)
+
+	if len(ret) == 0 {
+		panic("no return value specified for TaskUpdate")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(*model.Task) error); ok {
+		r0 = rf(_a0)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
 // UpdatePipeline provides a mock function with given fields: _a0
 func (_m *Store) UpdatePipeline(_a0 *model.Pipeline) error {
 	ret := _m.Called(_a0)
@@ -2682,6 +3553,264 @@ func (_m *Store) UserFeed(_a0 *model.User) ([]*model.Feed, error) {
 	return r0, r1
 }
 
+// WebhookCreate provides a mock function with given fields: _a0
+func (_m *Store) WebhookCreate(_a0 *model.Webhook) error {
+	ret := _m.Called(_a0)
+
+	if len(ret) == 0 {
+		panic("no return value specified for WebhookCreate")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(*model.Webhook) error); ok {
+		r0 = rf(_a0)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// WebhookDelete provides a mock function with given fields: _a0
+func (_m *Store) WebhookDelete(_a0 *model.Webhook) error {
+	ret := _m.Called(_a0)
+
+	if len(ret) == 0 {
+		panic("no return value specified for WebhookDelete")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(*model.Webhook) error); ok {
+		r0 = rf(_a0)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// WebhookDeliveryCreate provides a mock function with given fields: _a0
+func (_m *Store) WebhookDeliveryCreate(_a0 *model.WebhookDelivery) error {
+	ret := _m.Called(_a0)
+
+	if len(ret) == 0 {
+		panic("no return value specified for WebhookDeliveryCreate")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(*model.WebhookDelivery) error); ok {
+		r0 = rf(_a0)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// WebhookDeliveryFind provides a mock function with given fields: _a0, _a1
+func (_m *Store) WebhookDeliveryFind(_a0 *model.Webhook, _a1 int64) (*model.WebhookDelivery, error) {
+	ret := _m.Called(_a0, _a1)
+
+	if len(ret) == 0 {
+		panic("no return value specified for WebhookDeliveryFind")
+	}
+
+	var r0 *model.WebhookDelivery
+	var r1 error
+	if rf, ok := ret.Get(0).(func(*model.Webhook, int64) (*model.WebhookDelivery, error)); ok {
+		return rf(_a0, _a1)
+	}
+	if rf, ok := ret.Get(0).(func(*model.Webhook, int64) *model.WebhookDelivery); ok {
+		r0 = rf(_a0, _a1)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*model.WebhookDelivery)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(*model.Webhook, int64) error); ok {
+		r1 = rf(_a0, _a1)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// WebhookDeliveryList provides a mock function with given fields: _a0, _a1
+func (_m *Store) WebhookDeliveryList(_a0 *model.Webhook, _a1 *model.ListOptions) ([]*model.WebhookDelivery, error) {
+	ret := _m.Called(_a0, _a1)
+
+	if len(ret) == 0 {
+		panic("no return value specified for WebhookDeliveryList")
+	}
+
+	var r0 []*model.WebhookDelivery
+	var r1 error
+	if rf, ok := ret.Get(0).(func(*model.Webhook, *model.ListOptions) ([]*model.WebhookDelivery, error)); ok {
+		return rf(_a0, _a1)
+	}
+	if rf, ok := ret.Get(0).(func(*model.Webhook, *model.ListOptions) []*model.WebhookDelivery); ok {
+		r0 = rf(_a0, _a1)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]*model.WebhookDelivery)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(*model.Webhook, *model.ListOptions) error); ok {
+		r1 = rf(_a0, _a1)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// WebhookDeliveryPrune provides a mock function with given fields: _a0, _a1
+func (_m *Store) WebhookDeliveryPrune(_a0 *model.Webhook, _a1 int) error {
+	ret := _m.Called(_a0, _a1)
+
+	if len(ret) == 0 {
+		panic("no return value specified for WebhookDeliveryPrune")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(*model.Webhook, int) error); ok {
+		r0 = rf(_a0, _a1)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// WebhookDeliveryUpdate provides a mock function with given fields: _a0
+func (_m *Store) WebhookDeliveryUpdate(_a0 *model.WebhookDelivery) error {
+	ret := _m.Called(_a0)
+
+	if len(ret) == 0 {
+		panic("no return value specified for WebhookDeliveryUpdate")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(*model.WebhookDelivery) error); ok {
+		r0 = rf(_a0)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// WebhookFind provides a mock function with given fields: _a0, _a1
+func (_m *Store) WebhookFind(_a0 *model.Repo, _a1 int64) (*model.Webhook, error) {
+	ret := _m.Called(_a0, _a1)
+
+	if len(ret) == 0 {
+		panic("no return value specified for WebhookFind")
+	}
+
+	var r0 *model.Webhook
+	var r1 error
+	if rf, ok := ret.Get(0).(func(*model.Repo, int64) (*model.Webhook, error)); ok {
+		return rf(_a0, _a1)
+	}
+	if rf, ok := ret.Get(0).(func(*model.Repo, int64) *model.Webhook); ok {
+		r0 = rf(_a0, _a1)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*model.Webhook)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(*model.Repo, int64) error); ok {
+		r1 = rf(_a0, _a1)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// WebhookList provides a mock function with given fields: _a0, _a1
+func (_m *Store) WebhookList(_a0 *model.Repo, _a1 *model.ListOptions) ([]*model.Webhook, error) {
+	ret := _m.Called(_a0, _a1)
+
+	if len(ret) == 0 {
+		panic("no return value specified for WebhookList")
+	}
+
+	var r0 []*model.Webhook
+	var r1 error
+	if rf, ok := ret.Get(0).(func(*model.Repo, *model.ListOptions) ([]*model.Webhook, error)); ok {
+		return rf(_a0, _a1)
+	}
+	if rf, ok := ret.Get(0).(func(*model.Repo, *model.ListOptions) []*model.Webhook); ok {
+		r0 = rf(_a0, _a1)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]*model.Webhook)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(*model.Repo, *model.ListOptions) error); ok {
+		r1 = rf(_a0, _a1)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// WebhookListActive provides a mock function with given fields: _a0
+func (_m *Store) WebhookListActive(_a0 *model.Repo) ([]*model.Webhook, error) {
+	ret := _m.Called(_a0)
+
+	if len(ret) == 0 {
+		panic("no return value specified for WebhookListActive")
+	}
+
+	var r0 []*model.Webhook
+	var r1 error
+	if rf, ok := ret.Get(0).(func(*model.Repo) ([]*model.Webhook, error)); ok {
+		return rf(_a0)
+	}
+	if rf, ok := ret.Get(0).(func(*model.Repo) []*model.Webhook); ok {
+		r0 = rf(_a0)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]*model.Webhook)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(*model.Repo) error); ok {
+		r1 = rf(_a0)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// WebhookUpdate provides a mock function with given fields: _a0
+func (_m *Store) WebhookUpdate(_a0 *model.Webhook) error {
+	ret := _m.Called(_a0)
+
+	if len(ret) == 0 {
+		panic("no return value specified for WebhookUpdate")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(*model.Webhook) error); ok {
+		r0 = rf(_a0)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
 // WorkflowGetTree provides a mock function with given fields: _a0
 func (_m *Store) WorkflowGetTree(_a0 *model.Pipeline) ([]*model.Workflow, error) {
 	ret := _m.Called(_a0)
@@ -94,6 +94,13 @@ type Store interface {
 	UpdatePipeline(*model.Pipeline) error
 	// DeletePipeline deletes a pipeline.
 	DeletePipeline(*model.Pipeline) error
+	// PipelineStatsCount counts the finished pipelines for statistics by status and by history bucket,
+	// the buckets have the given interval in seconds and start at the After timestamp of the filter.
+	PipelineStatsCount(*model.Repo, *model.PipelineFilter, int64) ([]*model.PipelineStatusCount, error)
+	// PipelineStatsList gets the status and timestamps of the newest finished pipelines for statistics, at most limit.
+	PipelineStatsList(*model.Repo, *model.PipelineFilter, int) ([]*model.Pipeline, error)
+	// StepStatsList gets how often each step of the finished pipelines ran and failed.
+	StepStatsList(*model.Repo, *model.PipelineFilter) ([]*model.StepStats, error)
 
 	// Feeds
 	UserFeed(*model.User) ([]*model.Feed, error)
@@ -144,23 +151,75 @@ type Store interface {
 	StepList(*model.Pipeline) ([]*model.Step, error)
 	StepUpdate(*model.Step) error
 	StepListFromWorkflowFind(*model.Workflow) ([]*model.Step, error)
+	// StepListPrevious gets the finished steps of the workflow with the given name in earlier
+	// pipelines of the same commit and branch, newest pipeline first.
+	StepListPrevious(*model.Pipeline, string) ([]*model.Step, error)
+
+	// Step flakiness
+	StepFlakinessList(*model.Repo, *model.ListOptions) ([]*model.StepFlakiness, error)
+	StepFlakinessAdd(*model.StepFlakiness) error
 
 	// Logs
 	LogFind(*model.Step) ([]*model.LogEntry, error)
 	LogAppend(*model.Step, []*model.LogEntry) error
 	LogDelete(*model.Step) error
 
+	// Approvals
+	ApprovalList(*model.Pipeline) ([]*model.Approval, error)
+	// ApprovalAdd saves the approval and returns all approvals of its pipeline. If the pipeline has the
+	// required number of approvals, it changes the status of the blocked pipeline to pending and returns true,
+	// this happens only once per pipeline even for concurrent approvals.
+	ApprovalAdd(*model.Approval, int) ([]*model.Approval, bool, error)
+
+	// Artifacts
+	ArtifactList(*model.Pipeline) ([]*model.Artifact, error)
+	ArtifactListRepo(*model.Repo) ([]*model.Artifact, error)
+	ArtifactFind(*model.Pipeline, int64) (*model.Artifact, error)
+	ArtifactCreate(*model.Artifact) error
+	ArtifactDelete(*model.Artifact) error
+
 	// Tasks
 	// TaskList TODO: paginate & opt filter
 	TaskList() ([]*model.Task, error)
+	TaskLoad(string) (*model.Task, error)
 	TaskInsert(*model.Task) error
+	TaskUpdate(*model.Task) error
 	TaskDelete(string) error
 
+	// Pubsub
+	PubsubMessageCreate(*model.PubsubMessage) error
+	PubsubMessageList(string, int64) ([]*model.PubsubMessage, error)
+	PubsubMessagePrune(int64) error
+
 	// ServerConfig
 	ServerConfigGet(string) (string, error)
 	ServerConfigSet(string, string) error
 	ServerConfigDelete(string) error
 
+	// Environments
+	EnvironmentFind(*model.Repo, string) (*model.Environment, error)
+	EnvironmentList(*model.Repo, *model.ListOptions) ([]*model.Environment, error)
+	EnvironmentCreate(*model.Environment) error
+	EnvironmentUpdate(*model.Environment) error
+	EnvironmentDelete(*model.Environment) error
+	EnvironmentSecretFind(*model.Environment, string) (*model.Secret, error)
+	EnvironmentSecretList(*model.Environment, *model.ListOptions) ([]*model.Secret, error)
+
+	// Webhooks
+	WebhookFind(*model.Repo, int64) (*model.Webhook, error)
+	WebhookList(*model.Repo, *model.ListOptions) ([]*model.Webhook, error)
+	OrgWebhookFind(int64, int64) (*model.Webhook, error)
+	OrgWebhookList(int64, *model.ListOptions) ([]*model.Webhook, error)
+	WebhookListActive(*model.Repo) ([]*model.Webhook, error)
+	WebhookCreate(*model.Webhook) error
+	WebhookUpdate(*model.Webhook) error
+	WebhookDelete(*model.Webhook) error
+	WebhookDeliveryFind(*model.Webhook, int64) (*model.WebhookDelivery, error)
+	WebhookDeliveryList(*model.Webhook, *model.ListOptions) ([]*model.WebhookDelivery, error)
+	WebhookDeliveryCreate(*model.WebhookDelivery) error
+	WebhookDeliveryUpdate(*model.WebhookDelivery) error
+	WebhookDeliveryPrune(*model.Webhook, int) error
+
 	// Cron
 	CronCreate(*model.Cron) error
 	CronFind(*model.Repo, int64) (*model.Cron, error)
@@ -204,6 +263,17 @@ type Store interface {
 	// Org repos
 	OrgRepoList(*model.Org, *model.ListOptions) ([]*model.Repo, error)
 
+	// Personal access tokens
+	PersonalAccessTokenCreate(*model.PersonalAccessToken) error
+	PersonalAccessTokenFind(int64) (*model.PersonalAccessToken, error)
+	PersonalAccessTokenList(*model.User, *model.ListOptions) ([]*model.PersonalAccessToken, error)
+	PersonalAccessTokenUpdate(*model.PersonalAccessToken) error
+	PersonalAccessTokenDelete(*model.PersonalAccessToken) error
+
+	// Audit log
+	AuditEventCreate(*model.AuditEvent) error
+	AuditEventList(*model.AuditEventFilter, *model.ListOptions) ([]*model.AuditEvent, error)
+
 	// Store operations
 	Ping() error
 	Close() error
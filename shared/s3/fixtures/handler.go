@@ -0,0 +1,107 @@
+// Copyright 2025 Woodpecker Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package fixtures
+
+import (
+	"encoding/xml"
+	"io"
+	"net/http"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+)
+
+// Bucket is an in-memory stand-in for a path-style addressed S3 compatible object storage.
+type Bucket struct {
+	sync.Mutex
+
+	// Objects holds the stored objects by "<bucket>/<key>".
+	Objects map[string][]byte
+
+	modified map[string]time.Time
+}
+
+// Handler returns an http.Handler which stores, returns and deletes objects
+// in memory like a MinIO server does.
+func Handler() *Bucket {
+	return &Bucket{Objects: map[string][]byte{}, modified: map[string]time.Time{}}
+}
+
+func (b *Bucket) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	if !strings.HasPrefix(r.Header.Get("Authorization"), "AWS4-HMAC-SHA256 ") {
+		w.WriteHeader(http.StatusForbidden)
+		return
+	}
+
+	key := strings.TrimPrefix(r.URL.Path, "/")
+
+	b.Lock()
+	defer b.Unlock()
+
+	switch r.Method {
+	case http.MethodPut:
+		data, err := io.ReadAll(r.Body)
+		if err != nil {
+			w.WriteHeader(http.StatusBadRequest)
+			return
+		}
+		b.Objects[key] = data
+		b.modified[key] = time.Now()
+		w.WriteHeader(http.StatusOK)
+	case http.MethodGet:
+		if r.URL.Query().Get("list-type") == "2" {
+			b.list(w, key, r.URL.Query().Get("prefix"))
+			return
+		}
+		data, ok := b.Objects[key]
+		if !ok {
+			w.WriteHeader(http.StatusNotFound)
+			return
+		}
+		_, _ = w.Write(data)
+	case http.MethodDelete:
+		delete(b.Objects, key)
+		delete(b.modified, key)
+		w.WriteHeader(http.StatusNoContent)
+	default:
+		w.WriteHeader(http.StatusMethodNotAllowed)
+	}
+}
+
+// list writes a ListObjectsV2 result of all objects in bucket starting with prefix.
+func (b *Bucket) list(w http.ResponseWriter, bucket, prefix string) {
+	type content struct {
+		Key          string    `xml:"Key"`
+		Size         int       `xml:"Size"`
+		LastModified time.Time `xml:"LastModified"`
+	}
+	result := struct {
+		XMLName     xml.Name  `xml:"ListBucketResult"`
+		Contents    []content `xml:"Contents"`
+		IsTruncated bool      `xml:"IsTruncated"`
+	}{}
+
+	bucketPrefix := strings.TrimSuffix(bucket, "/") + "/"
+	for key, data := range b.Objects {
+		if name := strings.TrimPrefix(key, bucketPrefix); name != key && strings.HasPrefix(name, prefix) {
+			result.Contents = append(result.Contents, content{Key: name, Size: len(data), LastModified: b.modified[key]})
+		}
+	}
+	sort.Slice(result.Contents, func(i, j int) bool { return result.Contents[i].Key < result.Contents[j].Key })
+
+	w.Header().Set("Content-Type", "application/xml")
+	_ = xml.NewEncoder(w).Encode(result)
+}
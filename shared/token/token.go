@@ -28,6 +28,7 @@ type Type string
 
 const (
 	UserToken       Type = "user" // user token (exp cli)
+	PersonalToken   Type = "pat"  // scoped personal access token
 	SessToken       Type = "sess" // session token (ui token requires csrf check)
 	HookToken       Type = "hook" // repo hook token
 	CsrfToken       Type = "csrf"
@@ -2,6 +2,7 @@ package token_test
 
 import (
 	"testing"
+	"time"
 
 	"github.com/golang-jwt/jwt/v5"
 	"github.com/stretchr/testify/assert"
@@ -51,3 +52,16 @@ func TestTokenWrongSecret(t *testing.T) {
 
 	assert.ErrorIs(t, err, jwt.ErrSignatureInvalid)
 }
+
+func TestTokenExpired(t *testing.T) {
+	_token := token.New(token.PersonalToken)
+	_token.Set("user-id", "1")
+	signedToken, err := _token.SignExpires(jwtSecret, time.Now().Add(-time.Minute).Unix())
+	assert.NoError(t, err)
+
+	_, err = token.Parse([]token.Type{token.PersonalToken}, signedToken, func(_ *token.Token) (string, error) {
+		return jwtSecret, nil
+	})
+
+	assert.ErrorIs(t, err, jwt.ErrTokenExpired)
+}
@@ -0,0 +1,115 @@
+// Copyright 2025 Woodpecker Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package tracing
+
+import (
+	"context"
+	"strings"
+
+	"go.opentelemetry.io/otel/propagation"
+	"go.opentelemetry.io/otel/trace"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/metadata"
+)
+
+// propagator is used independent of the global one, so the trace context is
+// passed on even if this process does not export traces itself.
+var propagator = propagation.TraceContext{}
+
+// Inject returns the trace context of ctx as a map, or nil if ctx has no span.
+func Inject(ctx context.Context) map[string]string {
+	if !trace.SpanContextFromContext(ctx).IsValid() {
+		return nil
+	}
+	carrier := propagation.MapCarrier{}
+	propagator.Inject(ctx, carrier)
+	return carrier
+}
+
+// Extract returns a copy of ctx with the remote span from the trace context.
+func Extract(ctx context.Context, traceContext map[string]string) context.Context {
+	if len(traceContext) == 0 {
+		return ctx
+	}
+	return propagator.Extract(ctx, propagation.MapCarrier(traceContext))
+}
+
+// FromMetadata returns the trace context contained in grpc metadata.
+func FromMetadata(md metadata.MD) map[string]string {
+	return Inject(propagator.Extract(context.Background(), metadataCarrier(md)))
+}
+
+// ToMetadata returns grpc metadata containing the trace context.
+func ToMetadata(traceContext map[string]string) metadata.MD {
+	md := metadata.MD{}
+	for _, key := range propagator.Fields() {
+		if value, ok := traceContext[key]; ok {
+			md.Set(key, value)
+		}
+	}
+	return md
+}
+
+// UnaryClientInterceptor sends the trace context of the call to the server.
+func UnaryClientInterceptor() grpc.UnaryClientInterceptor {
+	return func(ctx context.Context, method string, req, reply any, cc *grpc.ClientConn, invoker grpc.UnaryInvoker, opts ...grpc.CallOption) error {
+		if trace.SpanContextFromContext(ctx).IsValid() {
+			md, _ := metadata.FromOutgoingContext(ctx)
+			md = md.Copy()
+			propagator.Inject(ctx, metadataCarrier(md))
+			ctx = metadata.NewOutgoingContext(ctx, md)
+		}
+		return invoker(ctx, method, req, reply, cc, opts...)
+	}
+}
+
+// UnaryServerInterceptor continues the trace of a client. Calls without a trace context
+// are not traced, so polling agents do not create a trace per request.
+func UnaryServerInterceptor() grpc.UnaryServerInterceptor {
+	return func(ctx context.Context, req any, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (any, error) {
+		md, _ := metadata.FromIncomingContext(ctx)
+		ctx = propagator.Extract(ctx, metadataCarrier(md))
+		if !trace.SpanContextFromContext(ctx).IsRemote() {
+			return handler(ctx, req)
+		}
+
+		ctx, span := Tracer.Start(ctx, strings.TrimPrefix(info.FullMethod, "/"), trace.WithSpanKind(trace.SpanKindServer))
+		defer span.End()
+		resp, err := handler(ctx, req)
+		RecordError(span, err)
+		return resp, err
+	}
+}
+
+type metadataCarrier metadata.MD
+
+func (c metadataCarrier) Get(key string) string {
+	if values := metadata.MD(c).Get(key); len(values) > 0 {
+		return values[0]
+	}
+	return ""
+}
+
+func (c metadataCarrier) Set(key, value string) {
+	metadata.MD(c).Set(key, value)
+}
+
+func (c metadataCarrier) Keys() []string {
+	keys := make([]string, 0, len(c))
+	for key := range c {
+		keys = append(keys, key)
+	}
+	return keys
+}
@@ -0,0 +1,59 @@
+// Copyright 2025 Woodpecker Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package tracing
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"go.opentelemetry.io/otel/trace"
+)
+
+func testSpanContext() trace.SpanContext {
+	return trace.NewSpanContext(trace.SpanContextConfig{
+		TraceID:    trace.TraceID{0x4b, 0xf9, 0x2f, 0x35, 0x77, 0xb3, 0x4d, 0xa6, 0xa3, 0xce, 0x92, 0x9d, 0x0e, 0x0e, 0x47, 0x36},
+		SpanID:     trace.SpanID{0x00, 0xf0, 0x67, 0xaa, 0x0b, 0xa9, 0x02, 0xb7},
+		TraceFlags: trace.FlagsSampled,
+	})
+}
+
+func TestInjectExtract(t *testing.T) {
+	assert.Nil(t, Inject(context.Background()))
+
+	ctx := trace.ContextWithSpanContext(context.Background(), testSpanContext())
+	traceContext := Inject(ctx)
+	assert.Equal(t, map[string]string{
+		"traceparent": "00-4bf92f3577b34da6a3ce929d0e0e4736-00f067aa0ba902b7-01",
+	}, traceContext)
+
+	extracted := trace.SpanContextFromContext(Extract(context.Background(), traceContext))
+	assert.True(t, extracted.IsRemote())
+	assert.Equal(t, testSpanContext().TraceID(), extracted.TraceID())
+	assert.Equal(t, testSpanContext().SpanID(), extracted.SpanID())
+
+	ctx = context.Background()
+	assert.Equal(t, ctx, Extract(ctx, nil))
+}
+
+func TestMetadata(t *testing.T) {
+	traceContext := Inject(trace.ContextWithSpanContext(context.Background(), testSpanContext()))
+
+	md := ToMetadata(traceContext)
+	md.Set("content-type", "application/grpc")
+	assert.Equal(t, traceContext, FromMetadata(md))
+
+	assert.Nil(t, FromMetadata(nil))
+}
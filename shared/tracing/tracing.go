@@ -0,0 +1,80 @@
+// Copyright 2025 Woodpecker Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package tracing
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/rs/zerolog/log"
+	"github.com/urfave/cli/v3"
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/exporters/otlp/otlptrace/otlptracegrpc"
+	"go.opentelemetry.io/otel/sdk/resource"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	semconv "go.opentelemetry.io/otel/semconv/v1.26.0"
+
+	"go.woodpecker-ci.org/woodpecker/v3/version"
+)
+
+var Flags = []cli.Flag{
+	&cli.StringFlag{
+		Sources: cli.EnvVars("WOODPECKER_TRACING_OTLP_ENDPOINT"),
+		Name:    "tracing-otlp-endpoint",
+		Usage:   "address (host:port) of an OTLP gRPC collector traces are exported to, tracing is disabled if empty",
+	},
+	&cli.BoolFlag{
+		Sources: cli.EnvVars("WOODPECKER_TRACING_OTLP_INSECURE"),
+		Name:    "tracing-otlp-insecure",
+		Usage:   "connect to the OTLP collector without TLS",
+	},
+}
+
+// Setup configures the global tracer provider to export traces to the configured OTLP collector.
+// The returned function flushes pending spans and has to be called before the process exits.
+func Setup(ctx context.Context, c *cli.Command, serviceName string) (func(context.Context) error, error) {
+	endpoint := c.String("tracing-otlp-endpoint")
+	if endpoint == "" {
+		return func(context.Context) error { return nil }, nil
+	}
+
+	opts := []otlptracegrpc.Option{otlptracegrpc.WithEndpoint(endpoint)}
+	if c.Bool("tracing-otlp-insecure") {
+		opts = append(opts, otlptracegrpc.WithInsecure())
+	}
+	exporter, err := otlptracegrpc.New(ctx, opts...)
+	if err != nil {
+		return nil, fmt.Errorf("could not create OTLP trace exporter: %w", err)
+	}
+
+	res, err := resource.Merge(resource.Default(), resource.NewSchemaless(
+		semconv.ServiceName(serviceName),
+		semconv.ServiceVersion(version.String()),
+	))
+	if err != nil {
+		return nil, fmt.Errorf("could not create trace resource: %w", err)
+	}
+
+	provider := sdktrace.NewTracerProvider(
+		sdktrace.WithBatcher(exporter),
+		sdktrace.WithResource(res),
+	)
+	otel.SetTracerProvider(provider)
+	otel.SetTextMapPropagator(propagator)
+
+	log.Info().Msgf("exporting traces to %s", endpoint)
+
+	return provider.Shutdown, nil
+}
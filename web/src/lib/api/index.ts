@@ -27,6 +27,7 @@ interface RepoListOptions {
 interface PipelineOptions {
   branch: string;
   variables: Record<string, string>;
+  inputs?: Record<string, string | boolean | number>;
 }
 
 interface DeploymentOptions {
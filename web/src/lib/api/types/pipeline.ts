@@ -126,6 +126,15 @@ export interface PipelineStep {
   finished?: number;
   error?: string;
   type?: StepType;
+  attempts?: PipelineStepAttempt[];
+  flakiness?: number;
+}
+
+export interface PipelineStepAttempt {
+  finished: number;
+  exit_code: number;
+  error?: string;
+  log_lines: number;
 }
 
 export interface PipelineLog {
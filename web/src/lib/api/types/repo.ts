@@ -58,6 +58,9 @@ export interface Repo {
   // The amount of time in minutes before the pipeline is killed.
   timeout: number;
 
+  // The maximum number of pipelines running at the same time, 0 means unlimited.
+  max_concurrent_pipelines: number;
+
   // Whether pull requests should trigger a pipeline.
   allow_pr: boolean;
 
@@ -65,6 +68,9 @@ export interface Repo {
 
   config_file: string;
 
+  // The url of a config extension used for this repository.
+  config_extension_endpoint: string;
+
   visibility: RepoVisibility;
 
   last_pipeline_number?: number;
@@ -99,7 +105,9 @@ export enum RepoRequireApproval {
 export type RepoSettings = Pick<
   Repo,
   | 'config_file'
+  | 'config_extension_endpoint'
   | 'timeout'
+  | 'max_concurrent_pipelines'
   | 'visibility'
   | 'trusted'
   | 'require_approval'
@@ -0,0 +1,58 @@
+package woodpecker
+
+import (
+	"fmt"
+	"strconv"
+	"time"
+)
+
+const pathAuditEvents = "%s/api/audit-events"
+
+// AuditEventListOptions filters the audit log, unset fields are ignored.
+type AuditEventListOptions struct {
+	ListOptions
+	User       string
+	Action     string
+	TargetType string
+	RepoID     int64
+	OrgID      int64
+	Before     time.Time
+	After      time.Time
+}
+
+// QueryEncode returns the URL query parameters for the AuditEventListOptions.
+func (opt *AuditEventListOptions) QueryEncode() string {
+	query := opt.getURLQuery()
+	if opt.User != "" {
+		query.Add("user", opt.User)
+	}
+	if opt.Action != "" {
+		query.Add("action", opt.Action)
+	}
+	if opt.TargetType != "" {
+		query.Add("target_type", opt.TargetType)
+	}
+	if opt.RepoID != 0 {
+		query.Add("repo_id", strconv.FormatInt(opt.RepoID, 10))
+	}
+	if opt.OrgID != 0 {
+		query.Add("org_id", strconv.FormatInt(opt.OrgID, 10))
+	}
+	if !opt.Before.IsZero() {
+		query.Add("before", opt.Before.Format(time.RFC3339))
+	}
+	if !opt.After.IsZero() {
+		query.Add("after", opt.After.Format(time.RFC3339))
+	}
+	return query.Encode()
+}
+
+// AuditEventList returns the audit log, newest first.
+func (c *client) AuditEventList(opt AuditEventListOptions) ([]*AuditEvent, error) {
+	var out []*AuditEvent
+	uri := fmt.Sprintf(pathAuditEvents, c.addr)
+	if query := opt.QueryEncode(); query != "" {
+		uri += "?" + query
+	}
+	return out, c.get(uri, &out)
+}
@@ -0,0 +1,36 @@
+package woodpecker
+
+import (
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestClient_AuditEventList(t *testing.T) {
+	fixtureHandler := func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, "/api/audit-events", r.URL.Path)
+		assert.Equal(t, "action=approve&after=2025-01-02T00%3A00%3A00Z&page=2&repo_id=5&user=alice", r.URL.RawQuery)
+
+		w.WriteHeader(http.StatusOK)
+		_, err := fmt.Fprint(w, `[{"id":3,"user_login":"alice","action":"approve","target_type":"pipeline","target_id":9,"repo_id":5}]`)
+		assert.NoError(t, err)
+	}
+
+	ts := httptest.NewServer(http.HandlerFunc(fixtureHandler))
+	defer ts.Close()
+
+	client := NewClient(ts.URL, http.DefaultClient)
+	events, err := client.AuditEventList(AuditEventListOptions{
+		ListOptions: ListOptions{Page: 2},
+		User:        "alice",
+		Action:      "approve",
+		RepoID:      5,
+		After:       time.Date(2025, 1, 2, 0, 0, 0, 0, time.UTC),
+	})
+	assert.NoError(t, err)
+	assert.Equal(t, []*AuditEvent{{ID: 3, UserLogin: "alice", Action: "approve", TargetType: "pipeline", TargetID: 9, RepoID: 5}}, events)
+}
@@ -59,3 +59,14 @@ const (
 	StepTypeCommands StepType = "commands"
 	StepTypeCache    StepType = "cache"
 )
+
+// HookEvent identifies the type of event a webhook is sent for.
+type HookEvent string
+
+const (
+	HookEventPipelineCreated  HookEvent = "pipeline_created"
+	HookEventPipelineStarted  HookEvent = "pipeline_started"
+	HookEventPipelineFinished HookEvent = "pipeline_finished"
+	HookEventWorkflowStatus   HookEvent = "workflow_status"
+	HookEventStepStatus       HookEvent = "step_status"
+)
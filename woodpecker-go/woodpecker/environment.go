@@ -0,0 +1,85 @@
+package woodpecker
+
+import (
+	"fmt"
+	"net/url"
+)
+
+const (
+	pathRepoEnvironments           = "%s/api/repos/%d/environments"
+	pathRepoEnvironment            = "%s/api/repos/%d/environments/%s"
+	pathRepoEnvironmentDeployments = "%s/api/repos/%d/environments/%s/deployments"
+	pathRepoEnvironmentSecrets     = "%s/api/repos/%d/environments/%s/secrets"
+	pathRepoEnvironmentSecret      = "%s/api/repos/%d/environments/%s/secrets/%s"
+)
+
+// EnvironmentList returns the environments of the specified repository.
+func (c *client) EnvironmentList(repoID int64, opt EnvironmentListOptions) ([]*Environment, error) {
+	var out []*Environment
+	uri, _ := url.Parse(fmt.Sprintf(pathRepoEnvironments, c.addr, repoID))
+	uri.RawQuery = opt.getURLQuery().Encode()
+	return out, c.get(uri.String(), &out)
+}
+
+// Environment returns an environment by name for the specified repository.
+func (c *client) Environment(repoID int64, environment string) (*Environment, error) {
+	out := new(Environment)
+	uri := fmt.Sprintf(pathRepoEnvironment, c.addr, repoID, environment)
+	return out, c.get(uri, out)
+}
+
+// EnvironmentCreate creates an environment for the specified repository.
+func (c *client) EnvironmentCreate(repoID int64, in *EnvironmentPatch) (*Environment, error) {
+	out := new(Environment)
+	uri := fmt.Sprintf(pathRepoEnvironments, c.addr, repoID)
+	return out, c.post(uri, in, out)
+}
+
+// EnvironmentUpdate updates an environment by name for the specified repository.
+func (c *client) EnvironmentUpdate(repoID int64, environment string, in *EnvironmentPatch) (*Environment, error) {
+	out := new(Environment)
+	uri := fmt.Sprintf(pathRepoEnvironment, c.addr, repoID, environment)
+	return out, c.patch(uri, in, out)
+}
+
+// EnvironmentDelete deletes an environment by name for the specified repository.
+func (c *client) EnvironmentDelete(repoID int64, environment string) error {
+	uri := fmt.Sprintf(pathRepoEnvironment, c.addr, repoID, environment)
+	return c.delete(uri)
+}
+
+// EnvironmentDeployments returns the deployment pipelines to an environment, newest first.
+func (c *client) EnvironmentDeployments(repoID int64, environment string, opt EnvironmentListOptions) ([]*Pipeline, error) {
+	var out []*Pipeline
+	uri, _ := url.Parse(fmt.Sprintf(pathRepoEnvironmentDeployments, c.addr, repoID, environment))
+	uri.RawQuery = opt.getURLQuery().Encode()
+	return out, c.get(uri.String(), &out)
+}
+
+// EnvironmentSecretList returns the secrets of an environment of the specified repository.
+func (c *client) EnvironmentSecretList(repoID int64, environment string, opt SecretListOptions) ([]*Secret, error) {
+	var out []*Secret
+	uri, _ := url.Parse(fmt.Sprintf(pathRepoEnvironmentSecrets, c.addr, repoID, environment))
+	uri.RawQuery = opt.getURLQuery().Encode()
+	return out, c.get(uri.String(), &out)
+}
+
+// EnvironmentSecretCreate creates a secret of an environment of the specified repository.
+func (c *client) EnvironmentSecretCreate(repoID int64, environment string, in *Secret) (*Secret, error) {
+	out := new(Secret)
+	uri := fmt.Sprintf(pathRepoEnvironmentSecrets, c.addr, repoID, environment)
+	return out, c.post(uri, in, out)
+}
+
+// EnvironmentSecretUpdate updates a secret of an environment of the specified repository.
+func (c *client) EnvironmentSecretUpdate(repoID int64, environment string, in *Secret) (*Secret, error) {
+	out := new(Secret)
+	uri := fmt.Sprintf(pathRepoEnvironmentSecret, c.addr, repoID, environment, in.Name)
+	return out, c.patch(uri, in, out)
+}
+
+// EnvironmentSecretDelete deletes a secret of an environment of the specified repository.
+func (c *client) EnvironmentSecretDelete(repoID int64, environment, secret string) error {
+	uri := fmt.Sprintf(pathRepoEnvironmentSecret, c.addr, repoID, environment, secret)
+	return c.delete(uri)
+}
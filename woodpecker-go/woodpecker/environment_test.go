@@ -0,0 +1,58 @@
+package woodpecker
+
+import (
+	"fmt"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestClient_EnvironmentUpdate(t *testing.T) {
+	tests := []struct {
+		name     string
+		input    *EnvironmentPatch
+		expected string
+	}{
+		{
+			name:     "clear approvers",
+			input:    &EnvironmentPatch{RequiredApprovers: &[]string{}},
+			expected: `{"required_approvers":[]}`,
+		},
+		{
+			name:     "set branches",
+			input:    &EnvironmentPatch{AllowedBranches: &[]string{"main"}},
+			expected: `{"allowed_branches":["main"]}`,
+		},
+		{
+			name:     "unchanged",
+			input:    &EnvironmentPatch{},
+			expected: `{}`,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				if r.Method != http.MethodPatch {
+					w.WriteHeader(http.StatusMethodNotAllowed)
+					return
+				}
+				body, err := io.ReadAll(r.Body)
+				assert.NoError(t, err)
+				assert.JSONEq(t, tt.expected, string(body))
+				w.WriteHeader(http.StatusOK)
+				_, err = fmt.Fprint(w, `{"id":1,"name":"production"}`)
+				assert.NoError(t, err)
+			}))
+			defer ts.Close()
+
+			client := NewClient(ts.URL, http.DefaultClient)
+			env, err := client.EnvironmentUpdate(1, "production", tt.input)
+			assert.NoError(t, err)
+			assert.Equal(t, &Environment{ID: 1, Name: "production"}, env)
+		})
+	}
+}
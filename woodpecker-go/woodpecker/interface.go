@@ -15,6 +15,7 @@
 package woodpecker
 
 import (
+	"io"
 	"net/http"
 )
 
@@ -74,6 +75,12 @@ type Client interface {
 	// RepoDel deletes a repository.
 	RepoDel(repoID int64) error
 
+	// RepoStats returns statistics of the finished pipelines of a repository.
+	RepoStats(repoID int64, opt RepoStatsOptions) (*RepoStats, error)
+
+	// RepoFlakyStepList returns the steps of a repository which passed after they failed on the same commit.
+	RepoFlakyStepList(repoID int64, opt ListOptions) ([]*StepFlakiness, error)
+
 	// Pipeline returns a repository pipeline by number.
 	Pipeline(repoID, pipeline int64) (*Pipeline, error)
 
@@ -107,6 +114,12 @@ type Client interface {
 	// PipelineMetadata returns metadata for a pipeline.
 	PipelineMetadata(repoID int64, pipelineNumber int) ([]byte, error)
 
+	// PipelineArtifacts returns the artifacts uploaded by the steps of a pipeline.
+	PipelineArtifacts(repoID, pipeline int64) ([]*Artifact, error)
+
+	// PipelineArtifact returns the content of an artifact of a pipeline.
+	PipelineArtifact(repoID, pipeline, artifactID int64) (io.ReadCloser, error)
+
 	// StepLogEntries returns the LogEntries for the given pipeline step
 	StepLogEntries(repoID, pipeline, stepID int64) ([]*LogEntry, error)
 
@@ -183,6 +196,9 @@ type Client interface {
 	// Org returns an organization by name.
 	Org(orgID int64) (*Org, error)
 
+	// OrgPatch updates an organization.
+	OrgPatch(orgID int64, in *OrgPatch) (*Org, error)
+
 	// OrgLookup returns an organization id by name.
 	OrgLookup(orgName string) (*Org, error)
 
@@ -240,6 +256,69 @@ type Client interface {
 	// CronUpdate update an existing cron job of a repo.
 	CronUpdate(repoID int64, cron *Cron) (*Cron, error)
 
+	// WebhookList returns the webhooks of a repo.
+	WebhookList(repoID int64, opt WebhookListOptions) ([]*Webhook, error)
+
+	// Webhook returns a webhook of a repo by id.
+	Webhook(repoID, webhookID int64) (*Webhook, error)
+
+	// WebhookCreate creates a webhook in a repo.
+	WebhookCreate(repoID int64, webhook *WebhookPatch) (*Webhook, error)
+
+	// WebhookUpdate updates a webhook of a repo.
+	WebhookUpdate(repoID, webhookID int64, webhook *WebhookPatch) (*Webhook, error)
+
+	// WebhookDelete deletes a webhook of a repo.
+	WebhookDelete(repoID, webhookID int64) error
+
+	// WebhookDeliveries returns the latest deliveries of a webhook of a repo.
+	WebhookDeliveries(repoID, webhookID int64, opt WebhookListOptions) ([]*WebhookDelivery, error)
+
+	// WebhookRedeliver sends the payload of a delivery of a webhook of a repo again.
+	WebhookRedeliver(repoID, webhookID, deliveryID int64) (*WebhookDelivery, error)
+
+	// EnvironmentList returns the environments of a repo.
+	EnvironmentList(repoID int64, opt EnvironmentListOptions) ([]*Environment, error)
+
+	// Environment returns an environment of a repo by name.
+	Environment(repoID int64, environment string) (*Environment, error)
+
+	// EnvironmentCreate creates an environment in a repo.
+	EnvironmentCreate(repoID int64, environment *EnvironmentPatch) (*Environment, error)
+
+	// EnvironmentUpdate updates an environment of a repo.
+	EnvironmentUpdate(repoID int64, environment string, patch *EnvironmentPatch) (*Environment, error)
+
+	// EnvironmentDelete deletes an environment of a repo.
+	EnvironmentDelete(repoID int64, environment string) error
+
+	// EnvironmentDeployments returns the deployments to an environment of a repo.
+	EnvironmentDeployments(repoID int64, environment string, opt EnvironmentListOptions) ([]*Pipeline, error)
+
+	// EnvironmentSecretList returns the secrets of an environment of a repo.
+	EnvironmentSecretList(repoID int64, environment string, opt SecretListOptions) ([]*Secret, error)
+
+	// EnvironmentSecretCreate creates a secret of an environment of a repo.
+	EnvironmentSecretCreate(repoID int64, environment string, secret *Secret) (*Secret, error)
+
+	// EnvironmentSecretUpdate updates a secret of an environment of a repo.
+	EnvironmentSecretUpdate(repoID int64, environment string, secret *Secret) (*Secret, error)
+
+	// EnvironmentSecretDelete deletes a secret of an environment of a repo.
+	EnvironmentSecretDelete(repoID int64, environment, secret string) error
+
+	// PersonalAccessTokenList returns the personal access tokens of the current user.
+	PersonalAccessTokenList() ([]*PersonalAccessToken, error)
+
+	// PersonalAccessTokenCreate creates a personal access token for the current user.
+	PersonalAccessTokenCreate(opt *PersonalAccessTokenOptions) (*PersonalAccessToken, error)
+
+	// PersonalAccessTokenDelete revokes a personal access token of the current user.
+	PersonalAccessTokenDelete(tokenID int64) error
+
+	// AuditEventList returns the audit log, newest first.
+	AuditEventList(opt AuditEventListOptions) ([]*AuditEvent, error)
+
 	// AgentList returns a list of all registered agents.
 	AgentList() ([]*Agent, error)
 
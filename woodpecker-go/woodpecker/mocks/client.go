@@ -6,6 +6,7 @@
 package mocks
 
 import (
+	io "io"
 	http "net/http"
 
 	mock "github.com/stretchr/testify/mock"
@@ -185,6 +186,36 @@ func (_m *Client) AgentUpdate(_a0 *woodpecker.Agent) (*woodpecker.Agent, error)
 	return r0, r1
 }
 
+// AuditEventList provides a mock function with given fields: opt
+func (_m *Client) AuditEventList(opt woodpecker.AuditEventListOptions) ([]*woodpecker.AuditEvent, error) {
+	ret := _m.Called(opt)
+
+	if len(ret) == 0 {
+		panic("no return value specified for AuditEventList")
+	}
+
+	var r0 []*woodpecker.AuditEvent
+	var r1 error
+	if rf, ok := ret.Get(0).(func(woodpecker.AuditEventListOptions) ([]*woodpecker.AuditEvent, error)); ok {
+		return rf(opt)
+	}
+	if rf, ok := ret.Get(0).(func(woodpecker.AuditEventListOptions) []*woodpecker.AuditEvent); ok {
+		r0 = rf(opt)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]*woodpecker.AuditEvent)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(woodpecker.AuditEventListOptions) error); ok {
+		r1 = rf(opt)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
 // CronCreate provides a mock function with given fields: repoID, cron
 func (_m *Client) CronCreate(repoID int64, cron *woodpecker.Cron) (*woodpecker.Cron, error) {
 	ret := _m.Called(repoID, cron)
@@ -353,6 +384,282 @@ func (_m *Client) Deploy(repoID int64, pipeline int64, opt woodpecker.DeployOpti
 	return r0, r1
 }
 
+// Environment provides a mock function with given fields: repoID, environment
+func (_m *Client) Environment(repoID int64, environment string) (*woodpecker.Environment, error) {
+	ret := _m.Called(repoID, environment)
+
+	if len(ret) == 0 {
+		panic("no return value specified for Environment")
+	}
+
+	var r0 *woodpecker.Environment
+	var r1 error
+	if rf, ok := ret.Get(0).(func(int64, string) (*woodpecker.Environment, error)); ok {
+		return rf(repoID, environment)
+	}
+	if rf, ok := ret.Get(0).(func(int64, string) *woodpecker.Environment); ok {
+		r0 = rf(repoID, environment)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*woodpecker.Environment)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(int64, string) error); ok {
+		r1 = rf(repoID, environment)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// EnvironmentCreate provides a mock function with given fields: repoID, environment
+func (_m *Client) EnvironmentCreate(repoID int64, environment *woodpecker.EnvironmentPatch) (*woodpecker.Environment, error) {
+	ret := _m.Called(repoID, environment)
+
+	if len(ret) == 0 {
+		panic("no return value specified for EnvironmentCreate")
+	}
+
+	var r0 *woodpecker.Environment
+	var r1 error
+	if rf, ok := ret.Get(0).(func(int64, *woodpecker.EnvironmentPatch) (*woodpecker.Environment, error)); ok {
+		return rf(repoID, environment)
+	}
+	if rf, ok := ret.Get(0).(func(int64, *woodpecker.EnvironmentPatch) *woodpecker.Environment); ok {
+		r0 = rf(repoID, environment)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*woodpecker.Environment)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(int64, *woodpecker.EnvironmentPatch) error); ok {
+		r1 = rf(repoID, environment)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// EnvironmentDelete provides a mock function with given fields: repoID, environment
+func (_m *Client) EnvironmentDelete(repoID int64, environment string) error {
+	ret := _m.Called(repoID, environment)
+
+	if len(ret) == 0 {
+		panic("no return value specified for EnvironmentDelete")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(int64, string) error); ok {
+		r0 = rf(repoID, environment)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// EnvironmentDeployments provides a mock function with given fields: repoID, environment, opt
+func (_m *Client) EnvironmentDeployments(repoID int64, environment string, opt woodpecker.EnvironmentListOptions) ([]*woodpecker.Pipeline, error) {
+	ret := _m.Called(repoID, environment, opt)
+
+	if len(ret) == 0 {
+		panic("no return value specified for EnvironmentDeployments")
+	}
+
+	var r0 []*woodpecker.Pipeline
+	var r1 error
+	if rf, ok := ret.Get(0).(func(int64, string, woodpecker.EnvironmentListOptions) ([]*woodpecker.Pipeline, error)); ok {
+		return rf(repoID, environment, opt)
+	}
+	if rf, ok := ret.Get(0).(func(int64, string, woodpecker.EnvironmentListOptions) []*woodpecker.Pipeline); ok {
+		r0 = rf(repoID, environment, opt)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]*woodpecker.Pipeline)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(int64, string, woodpecker.EnvironmentListOptions) error); ok {
+		r1 = rf(repoID, environment, opt)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// EnvironmentList provides a mock function with given fields: repoID, opt
+func (_m *Client) EnvironmentList(repoID int64, opt woodpecker.EnvironmentListOptions) ([]*woodpecker.Environment, error) {
+	ret := _m.Called(repoID, opt)
+
+	if len(ret) == 0 {
+		panic("no return value specified for EnvironmentList")
+	}
+
+	var r0 []*woodpecker.Environment
+	var r1 error
+	if rf, ok := ret.Get(0).(func(int64, woodpecker.EnvironmentListOptions) ([]*woodpecker.Environment, error)); ok {
+		return rf(repoID, opt)
+	}
+	if rf, ok := ret.Get(0).(func(int64, woodpecker.EnvironmentListOptions) []*woodpecker.Environment); ok {
+		r0 = rf(repoID, opt)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]*woodpecker.Environment)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(int64, woodpecker.EnvironmentListOptions) error); ok {
+		r1 = rf(repoID, opt)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// EnvironmentSecretCreate provides a mock function with given fields: repoID, environment, secret
+func (_m *Client) EnvironmentSecretCreate(repoID int64, environment string, secret *woodpecker.Secret) (*woodpecker.Secret, error) {
+	ret := _m.Called(repoID, environment, secret)
+
+	if len(ret) == 0 {
+		panic("no return value specified for EnvironmentSecretCreate")
+	}
+
+	var r0 *woodpecker.Secret
+	var r1 error
+	if rf, ok := ret.Get(0).(func(int64, string, *woodpecker.Secret) (*woodpecker.Secret, error)); ok {
+		return rf(repoID, environment, secret)
+	}
+	if rf, ok := ret.Get(0).(func(int64, string, *woodpecker.Secret) *woodpecker.Secret); ok {
+		r0 = rf(repoID, environment, secret)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*woodpecker.Secret)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(int64, string, *woodpecker.Secret) error); ok {
+		r1 = rf(repoID, environment, secret)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// EnvironmentSecretDelete provides a mock function with given fields: repoID, environment, secret
+func (_m *Client) EnvironmentSecretDelete(repoID int64, environment string, secret string) error {
+	ret := _m.Called(repoID, environment, secret)
+
+	if len(ret) == 0 {
+		panic("no return value specified for EnvironmentSecretDelete")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(int64, string, string) error); ok {
+		r0 = rf(repoID, environment, secret)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// EnvironmentSecretList provides a mock function with given fields: repoID, environment, opt
+func (_m *Client) EnvironmentSecretList(repoID int64, environment string, opt woodpecker.SecretListOptions) ([]*woodpecker.Secret, error) {
+	ret := _m.Called(repoID, environment, opt)
+
+	if len(ret) == 0 {
+		panic("no return value specified for EnvironmentSecretList")
+	}
+
+	var r0 []*woodpecker.Secret
+	var r1 error
+	if rf, ok := ret.Get(0).(func(int64, string, woodpecker.SecretListOptions) ([]*woodpecker.Secret, error)); ok {
+		return rf(repoID, environment, opt)
+	}
+	if rf, ok := ret.Get(0).(func(int64, string, woodpecker.SecretListOptions) []*woodpecker.Secret); ok {
+		r0 = rf(repoID, environment, opt)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]*woodpecker.Secret)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(int64, string, woodpecker.SecretListOptions) error); ok {
+		r1 = rf(repoID, environment, opt)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// EnvironmentSecretUpdate provides a mock function with given fields: repoID, environment, secret
+func (_m *Client) EnvironmentSecretUpdate(repoID int64, environment string, secret *woodpecker.Secret) (*woodpecker.Secret, error) {
+	ret := _m.Called(repoID, environment, secret)
+
+	if len(ret) == 0 {
+		panic("no return value specified for EnvironmentSecretUpdate")
+	}
+
+	var r0 *woodpecker.Secret
+	var r1 error
+	if rf, ok := ret.Get(0).(func(int64, string, *woodpecker.Secret) (*woodpecker.Secret, error)); ok {
+		return rf(repoID, environment, secret)
+	}
+	if rf, ok := ret.Get(0).(func(int64, string, *woodpecker.Secret) *woodpecker.Secret); ok {
+		r0 = rf(repoID, environment, secret)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*woodpecker.Secret)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(int64, string, *woodpecker.Secret) error); ok {
+		r1 = rf(repoID, environment, secret)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// EnvironmentUpdate provides a mock function with given fields: repoID, environment, patch
+func (_m *Client) EnvironmentUpdate(repoID int64, environment string, patch *woodpecker.EnvironmentPatch) (*woodpecker.Environment, error) {
+	ret := _m.Called(repoID, environment, patch)
+
+	if len(ret) == 0 {
+		panic("no return value specified for EnvironmentUpdate")
+	}
+
+	var r0 *woodpecker.Environment
+	var r1 error
+	if rf, ok := ret.Get(0).(func(int64, string, *woodpecker.EnvironmentPatch) (*woodpecker.Environment, error)); ok {
+		return rf(repoID, environment, patch)
+	}
+	if rf, ok := ret.Get(0).(func(int64, string, *woodpecker.EnvironmentPatch) *woodpecker.Environment); ok {
+		r0 = rf(repoID, environment, patch)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*woodpecker.Environment)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(int64, string, *woodpecker.EnvironmentPatch) error); ok {
+		r1 = rf(repoID, environment, patch)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
 // GlobalRegistry provides a mock function with given fields: registry
 func (_m *Client) GlobalRegistry(registry string) (*woodpecker.Registry, error) {
 	ret := _m.Called(registry)
@@ -737,6 +1044,36 @@ func (_m *Client) OrgLookup(orgName string) (*woodpecker.Org, error) {
 	return r0, r1
 }
 
+// OrgPatch provides a mock function with given fields: orgID, in
+func (_m *Client) OrgPatch(orgID int64, in *woodpecker.OrgPatch) (*woodpecker.Org, error) {
+	ret := _m.Called(orgID, in)
+
+	if len(ret) == 0 {
+		panic("no return value specified for OrgPatch")
+	}
+
+	var r0 *woodpecker.Org
+	var r1 error
+	if rf, ok := ret.Get(0).(func(int64, *woodpecker.OrgPatch) (*woodpecker.Org, error)); ok {
+		return rf(orgID, in)
+	}
+	if rf, ok := ret.Get(0).(func(int64, *woodpecker.OrgPatch) *woodpecker.Org); ok {
+		r0 = rf(orgID, in)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*woodpecker.Org)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(int64, *woodpecker.OrgPatch) error); ok {
+		r1 = rf(orgID, in)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
 // OrgRegistry provides a mock function with given fields: orgID, registry
 func (_m *Client) OrgRegistry(orgID int64, registry string) (*woodpecker.Registry, error) {
 	ret := _m.Called(orgID, registry)
@@ -1013,6 +1350,84 @@ func (_m *Client) OrgSecretUpdate(orgID int64, secret *woodpecker.Secret) (*wood
 	return r0, r1
 }
 
+// PersonalAccessTokenCreate provides a mock function with given fields: opt
+func (_m *Client) PersonalAccessTokenCreate(opt *woodpecker.PersonalAccessTokenOptions) (*woodpecker.PersonalAccessToken, error) {
+	ret := _m.Called(opt)
+
+	if len(ret) == 0 {
+		panic("no return value specified for PersonalAccessTokenCreate")
+	}
+
+	var r0 *woodpecker.PersonalAccessToken
+	var r1 error
+	if rf, ok := ret.Get(0).(func(*woodpecker.PersonalAccessTokenOptions) (*woodpecker.PersonalAccessToken, error)); ok {
+		return rf(opt)
+	}
+	if rf, ok := ret.Get(0).(func(*woodpecker.PersonalAccessTokenOptions) *woodpecker.PersonalAccessToken); ok {
+		r0 = rf(opt)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*woodpecker.PersonalAccessToken)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(*woodpecker.PersonalAccessTokenOptions) error); ok {
+		r1 = rf(opt)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// PersonalAccessTokenDelete provides a mock function with given fields: tokenID
+func (_m *Client) PersonalAccessTokenDelete(tokenID int64) error {
+	ret := _m.Called(tokenID)
+
+	if len(ret) == 0 {
+		panic("no return value specified for PersonalAccessTokenDelete")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(int64) error); ok {
+		r0 = rf(tokenID)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// PersonalAccessTokenList provides a mock function with no fields
+func (_m *Client) PersonalAccessTokenList() ([]*woodpecker.PersonalAccessToken, error) {
+	ret := _m.Called()
+
+	if len(ret) == 0 {
+		panic("no return value specified for PersonalAccessTokenList")
+	}
+
+	var r0 []*woodpecker.PersonalAccessToken
+	var r1 error
+	if rf, ok := ret.Get(0).(func() ([]*woodpecker.PersonalAccessToken, error)); ok {
+		return rf()
+	}
+	if rf, ok := ret.Get(0).(func() []*woodpecker.PersonalAccessToken); ok {
+		r0 = rf()
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]*woodpecker.PersonalAccessToken)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func() error); ok {
+		r1 = rf()
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
 // Pipeline provides a mock function with given fields: repoID, pipeline
 func (_m *Client) Pipeline(repoID int64, pipeline int64) (*woodpecker.Pipeline, error) {
 	ret := _m.Called(repoID, pipeline)
@@ -1073,6 +1488,66 @@ func (_m *Client) PipelineApprove(repoID int64, pipeline int64) (*woodpecker.Pip
 	return r0, r1
 }
 
+// PipelineArtifact provides a mock function with given fields: repoID, pipeline, artifactID
+func (_m *Client) PipelineArtifact(repoID int64, pipeline int64, artifactID int64) (io.ReadCloser, error) {
+	ret := _m.Called(repoID, pipeline, artifactID)
+
+	if len(ret) == 0 {
+		panic("no return value specified for PipelineArtifact")
+	}
+
+	var r0 io.ReadCloser
+	var r1 error
+	if rf, ok := ret.Get(0).(func(int64, int64, int64) (io.ReadCloser, error)); ok {
+		return rf(repoID, pipeline, artifactID)
+	}
+	if rf, ok := ret.Get(0).(func(int64, int64, int64) io.ReadCloser); ok {
+		r0 = rf(repoID, pipeline, artifactID)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(io.ReadCloser)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(int64, int64, int64) error); ok {
+		r1 = rf(repoID, pipeline, artifactID)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// PipelineArtifacts provides a mock function with given fields: repoID, pipeline
+func (_m *Client) PipelineArtifacts(repoID int64, pipeline int64) ([]*woodpecker.Artifact, error) {
+	ret := _m.Called(repoID, pipeline)
+
+	if len(ret) == 0 {
+		panic("no return value specified for PipelineArtifacts")
+	}
+
+	var r0 []*woodpecker.Artifact
+	var r1 error
+	if rf, ok := ret.Get(0).(func(int64, int64) ([]*woodpecker.Artifact, error)); ok {
+		return rf(repoID, pipeline)
+	}
+	if rf, ok := ret.Get(0).(func(int64, int64) []*woodpecker.Artifact); ok {
+		r0 = rf(repoID, pipeline)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]*woodpecker.Artifact)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(int64, int64) error); ok {
+		r1 = rf(repoID, pipeline)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
 // PipelineCreate provides a mock function with given fields: repoID, opts
 func (_m *Client) PipelineCreate(repoID int64, opts *woodpecker.PipelineOptions) (*woodpecker.Pipeline, error) {
 	ret := _m.Called(repoID, opts)
@@ -1565,6 +2040,36 @@ func (_m *Client) RepoDel(repoID int64) error {
 	return r0
 }
 
+// RepoFlakyStepList provides a mock function with given fields: repoID, opt
+func (_m *Client) RepoFlakyStepList(repoID int64, opt woodpecker.ListOptions) ([]*woodpecker.StepFlakiness, error) {
+	ret := _m.Called(repoID, opt)
+
+	if len(ret) == 0 {
+		panic("no return value specified for RepoFlakyStepList")
+	}
+
+	var r0 []*woodpecker.StepFlakiness
+	var r1 error
+	if rf, ok := ret.Get(0).(func(int64, woodpecker.ListOptions) ([]*woodpecker.StepFlakiness, error)); ok {
+		return rf(repoID, opt)
+	}
+	if rf, ok := ret.Get(0).(func(int64, woodpecker.ListOptions) []*woodpecker.StepFlakiness); ok {
+		r0 = rf(repoID, opt)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]*woodpecker.StepFlakiness)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(int64, woodpecker.ListOptions) error); ok {
+		r1 = rf(repoID, opt)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
 // RepoList provides a mock function with given fields: opt
 func (_m *Client) RepoList(opt woodpecker.RepoListOptions) ([]*woodpecker.Repo, error) {
 	ret := _m.Called(opt)
@@ -1721,6 +2226,36 @@ func (_m *Client) RepoRepair(repoID int64) error {
 	return r0
 }
 
+// RepoStats provides a mock function with given fields: repoID, opt
+func (_m *Client) RepoStats(repoID int64, opt woodpecker.RepoStatsOptions) (*woodpecker.RepoStats, error) {
+	ret := _m.Called(repoID, opt)
+
+	if len(ret) == 0 {
+		panic("no return value specified for RepoStats")
+	}
+
+	var r0 *woodpecker.RepoStats
+	var r1 error
+	if rf, ok := ret.Get(0).(func(int64, woodpecker.RepoStatsOptions) (*woodpecker.RepoStats, error)); ok {
+		return rf(repoID, opt)
+	}
+	if rf, ok := ret.Get(0).(func(int64, woodpecker.RepoStatsOptions) *woodpecker.RepoStats); ok {
+		r0 = rf(repoID, opt)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*woodpecker.RepoStats)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(int64, woodpecker.RepoStatsOptions) error); ok {
+		r1 = rf(repoID, opt)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
 // Secret provides a mock function with given fields: repoID, secret
 func (_m *Client) Secret(repoID int64, secret string) (*woodpecker.Secret, error) {
 	ret := _m.Called(repoID, secret)
@@ -2115,6 +2650,204 @@ func (_m *Client) UserPost(_a0 *woodpecker.User) (*woodpecker.User, error) {
 	return r0, r1
 }
 
+// Webhook provides a mock function with given fields: repoID, webhookID
+func (_m *Client) Webhook(repoID int64, webhookID int64) (*woodpecker.Webhook, error) {
+	ret := _m.Called(repoID, webhookID)
+
+	if len(ret) == 0 {
+		panic("no return value specified for Webhook")
+	}
+
+	var r0 *woodpecker.Webhook
+	var r1 error
+	if rf, ok := ret.Get(0).(func(int64, int64) (*woodpecker.Webhook, error)); ok {
+		return rf(repoID, webhookID)
+	}
+	if rf, ok := ret.Get(0).(func(int64, int64) *woodpecker.Webhook); ok {
+		r0 = rf(repoID, webhookID)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*woodpecker.Webhook)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(int64, int64) error); ok {
+		r1 = rf(repoID, webhookID)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// WebhookCreate provides a mock function with given fields: repoID, webhook
+func (_m *Client) WebhookCreate(repoID int64, webhook *woodpecker.WebhookPatch) (*woodpecker.Webhook, error) {
+	ret := _m.Called(repoID, webhook)
+
+	if len(ret) == 0 {
+		panic("no return value specified for WebhookCreate")
+	}
+
+	var r0 *woodpecker.Webhook
+	var r1 error
+	if rf, ok := ret.Get(0).(func(int64, *woodpecker.WebhookPatch) (*woodpecker.Webhook, error)); ok {
+		return rf(repoID, webhook)
+	}
+	if rf, ok := ret.Get(0).(func(int64, *woodpecker.WebhookPatch) *woodpecker.Webhook); ok {
+		r0 = rf(repoID, webhook)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*woodpecker.Webhook)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(int64, *woodpecker.WebhookPatch) error); ok {
+		r1 = rf(repoID, webhook)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// WebhookDelete provides a mock function with given fields: repoID, webhookID
+func (_m *Client) WebhookDelete(repoID int64, webhookID int64) error {
+	ret := _m.Called(repoID, webhookID)
+
+	if len(ret) == 0 {
+		panic("no return value specified for WebhookDelete")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(int64, int64) error); ok {
+		r0 = rf(repoID, webhookID)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// WebhookDeliveries provides a mock function with given fields: repoID, webhookID, opt
+func (_m *Client) WebhookDeliveries(repoID int64, webhookID int64, opt woodpecker.WebhookListOptions) ([]*woodpecker.WebhookDelivery, error) {
+	ret := _m.Called(repoID, webhookID, opt)
+
+	if len(ret) == 0 {
+		panic("no return value specified for WebhookDeliveries")
+	}
+
+	var r0 []*woodpecker.WebhookDelivery
+	var r1 error
+	if rf, ok := ret.Get(0).(func(int64, int64, woodpecker.WebhookListOptions) ([]*woodpecker.WebhookDelivery, error)); ok {
+		return rf(repoID, webhookID, opt)
+	}
+	if rf, ok := ret.Get(0).(func(int64, int64, woodpecker.WebhookListOptions) []*woodpecker.WebhookDelivery); ok {
+		r0 = rf(repoID, webhookID, opt)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]*woodpecker.WebhookDelivery)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(int64, int64, woodpecker.WebhookListOptions) error); ok {
+		r1 = rf(repoID, webhookID, opt)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// WebhookList provides a mock function with given fields: repoID, opt
+func (_m *Client) WebhookList(repoID int64, opt woodpecker.WebhookListOptions) ([]*woodpecker.Webhook, error) {
+	ret := _m.Called(repoID, opt)
+
+	if len(ret) == 0 {
+		panic("no return value specified for WebhookList")
+	}
+
+	var r0 []*woodpecker.Webhook
+	var r1 error
+	if rf, ok := ret.Get(0).(func(int64, woodpecker.WebhookListOptions) ([]*woodpecker.Webhook, error)); ok {
+		return rf(repoID, opt)
+	}
+	if rf, ok := ret.Get(0).(func(int64, woodpecker.WebhookListOptions) []*woodpecker.Webhook); ok {
+		r0 = rf(repoID, opt)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]*woodpecker.Webhook)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(int64, woodpecker.WebhookListOptions) error); ok {
+		r1 = rf(repoID, opt)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// WebhookRedeliver provides a mock function with given fields: repoID, webhookID, deliveryID
+func (_m *Client) WebhookRedeliver(repoID int64, webhookID int64, deliveryID int64) (*woodpecker.WebhookDelivery, error) {
+	ret := _m.Called(repoID, webhookID, deliveryID)
+
+	if len(ret) == 0 {
+		panic("no return value specified for WebhookRedeliver")
+	}
+
+	var r0 *woodpecker.WebhookDelivery
+	var r1 error
+	if rf, ok := ret.Get(0).(func(int64, int64, int64) (*woodpecker.WebhookDelivery, error)); ok {
+		return rf(repoID, webhookID, deliveryID)
+	}
+	if rf, ok := ret.Get(0).(func(int64, int64, int64) *woodpecker.WebhookDelivery); ok {
+		r0 = rf(repoID, webhookID, deliveryID)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*woodpecker.WebhookDelivery)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(int64, int64, int64) error); ok {
+		r1 = rf(repoID, webhookID, deliveryID)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// WebhookUpdate provides a mock function with given fields: repoID, webhookID, webhook
+func (_m *Client) WebhookUpdate(repoID int64, webhookID int64, webhook *woodpecker.WebhookPatch) (*woodpecker.Webhook, error) {
+	ret := _m.Called(repoID, webhookID, webhook)
+
+	if len(ret) == 0 {
+		panic("no return value specified for WebhookUpdate")
+	}
+
+	var r0 *woodpecker.Webhook
+	var r1 error
+	if rf, ok := ret.Get(0).(func(int64, int64, *woodpecker.WebhookPatch) (*woodpecker.Webhook, error)); ok {
+		return rf(repoID, webhookID, webhook)
+	}
+	if rf, ok := ret.Get(0).(func(int64, int64, *woodpecker.WebhookPatch) *woodpecker.Webhook); ok {
+		r0 = rf(repoID, webhookID, webhook)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*woodpecker.Webhook)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(int64, int64, *woodpecker.WebhookPatch) error); ok {
+		r1 = rf(repoID, webhookID, webhook)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
 // NewClient creates a new instance of Client. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
 // The first argument is typically a *testing.T value.
 func NewClient(t interface {
@@ -22,6 +22,14 @@ func (c *client) Org(orgID int64) (*Org, error) {
 	return out, err
 }
 
+// OrgPatch updates an organization.
+func (c *client) OrgPatch(orgID int64, in *OrgPatch) (*Org, error) {
+	out := new(Org)
+	uri := fmt.Sprintf(pathOrg, c.addr, orgID)
+	err := c.patch(uri, in, out)
+	return out, err
+}
+
 // OrgLookup returns a organization by its name.
 func (c *client) OrgLookup(name string) (*Org, error) {
 	out := new(Org)
@@ -0,0 +1,31 @@
+package woodpecker
+
+import "fmt"
+
+const (
+	pathTokens = "%s/api/user/tokens"
+	pathToken  = "%s/api/user/tokens/%d"
+)
+
+// PersonalAccessTokenList returns the personal access tokens of the current user.
+func (c *client) PersonalAccessTokenList() ([]*PersonalAccessToken, error) {
+	var out []*PersonalAccessToken
+	uri := fmt.Sprintf(pathTokens, c.addr)
+	err := c.get(uri, &out)
+	return out, err
+}
+
+// PersonalAccessTokenCreate creates a personal access token for the current user.
+// The returned token contains the signed token, which can not be retrieved again.
+func (c *client) PersonalAccessTokenCreate(opt *PersonalAccessTokenOptions) (*PersonalAccessToken, error) {
+	out := new(PersonalAccessToken)
+	uri := fmt.Sprintf(pathTokens, c.addr)
+	err := c.post(uri, opt, out)
+	return out, err
+}
+
+// PersonalAccessTokenDelete revokes a personal access token of the current user.
+func (c *client) PersonalAccessTokenDelete(tokenID int64) error {
+	uri := fmt.Sprintf(pathToken, c.addr, tokenID)
+	return c.delete(uri)
+}
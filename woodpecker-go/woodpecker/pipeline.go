@@ -7,8 +7,10 @@ import (
 )
 
 const (
-	pathPipelineQueue    = "%s/api/pipelines"
-	pathPipelineMetadata = "%s/api/repos/%d/pipelines/%d/metadata"
+	pathPipelineQueue     = "%s/api/pipelines"
+	pathPipelineMetadata  = "%s/api/repos/%d/pipelines/%d/metadata"
+	pathPipelineArtifacts = "%s/api/repos/%d/pipelines/%d/artifacts"
+	pathPipelineArtifact  = "%s/api/repos/%d/pipelines/%d/artifacts/%d"
 )
 
 // PipelineQueue returns a list of enqueued pipelines.
@@ -31,3 +33,17 @@ func (c *client) PipelineMetadata(repoID int64, pipelineNumber int) ([]byte, err
 
 	return io.ReadAll(body)
 }
+
+// PipelineArtifacts returns the artifacts uploaded by the steps of a pipeline.
+func (c *client) PipelineArtifacts(repoID, pipeline int64) ([]*Artifact, error) {
+	var out []*Artifact
+	uri := fmt.Sprintf(pathPipelineArtifacts, c.addr, repoID, pipeline)
+	err := c.get(uri, &out)
+	return out, err
+}
+
+// PipelineArtifact returns the content of an artifact of a pipeline, it has to be closed by the caller.
+func (c *client) PipelineArtifact(repoID, pipeline, artifactID int64) (io.ReadCloser, error) {
+	uri := fmt.Sprintf(pathPipelineArtifact, c.addr, repoID, pipeline, artifactID)
+	return c.open(uri, http.MethodGet, nil)
+}
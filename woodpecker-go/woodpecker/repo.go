@@ -15,6 +15,8 @@ const (
 	pathRepoMove       = "%s/api/repos/%d/move"
 	pathChown          = "%s/api/repos/%d/chown"
 	pathRepair         = "%s/api/repos/%d/repair"
+	pathRepoStats      = "%s/api/repos/%d/stats"
+	pathRepoFlakySteps = "%s/api/repos/%d/flaky_steps"
 	pathPipelines      = "%s/api/repos/%d/pipelines"
 	pathPipeline       = "%s/api/repos/%d/pipelines/%v"
 	pathPipelineLogs   = "%s/api/repos/%d/logs/%d"
@@ -40,6 +42,13 @@ type PipelineListOptions struct {
 	Status      string
 }
 
+type RepoStatsOptions struct {
+	Before   time.Time
+	After    time.Time
+	Branch   string
+	Interval string
+}
+
 type CronListOptions struct {
 	ListOptions
 }
@@ -52,6 +61,14 @@ type SecretListOptions struct {
 	ListOptions
 }
 
+type WebhookListOptions struct {
+	ListOptions
+}
+
+type EnvironmentListOptions struct {
+	ListOptions
+}
+
 type DeployOptions struct {
 	DeployTo string            // override the target deploy value
 	Params   map[string]string // custom KEY=value parameters to be injected into the step environment
@@ -97,6 +114,24 @@ func (opt *PipelineListOptions) QueryEncode() string {
 	return query.Encode()
 }
 
+// QueryEncode returns the URL query parameters for the RepoStatsOptions.
+func (opt *RepoStatsOptions) QueryEncode() string {
+	query := make(url.Values)
+	if !opt.Before.IsZero() {
+		query.Add("before", opt.Before.Format(time.RFC3339))
+	}
+	if !opt.After.IsZero() {
+		query.Add("after", opt.After.Format(time.RFC3339))
+	}
+	if opt.Branch != "" {
+		query.Add("branch", opt.Branch)
+	}
+	if opt.Interval != "" {
+		query.Add("interval", opt.Interval)
+	}
+	return query.Encode()
+}
+
 // QueryEncode returns the URL query parameters for the DeployOptions.
 func (opt *DeployOptions) QueryEncode() string {
 	query := mapValues(opt.Params)
@@ -197,6 +232,23 @@ func (c *client) RepoMove(repoID int64, opt RepoMoveOptions) error {
 	return c.post(uri.String(), nil, nil)
 }
 
+// RepoStats returns statistics of the finished pipelines of a repository.
+func (c *client) RepoStats(repoID int64, opt RepoStatsOptions) (*RepoStats, error) {
+	out := new(RepoStats)
+	uri, _ := url.Parse(fmt.Sprintf(pathRepoStats, c.addr, repoID))
+	uri.RawQuery = opt.QueryEncode()
+	err := c.get(uri.String(), out)
+	return out, err
+}
+
+// RepoFlakyStepList returns the steps of a repository which passed after they failed on the same commit.
+func (c *client) RepoFlakyStepList(repoID int64, opt ListOptions) ([]*StepFlakiness, error) {
+	var out []*StepFlakiness
+	uri, _ := url.Parse(fmt.Sprintf(pathRepoFlakySteps, c.addr, repoID))
+	uri.RawQuery = opt.getURLQuery().Encode()
+	return out, c.get(uri.String(), &out)
+}
+
 // Registry returns a registry by hostname.
 func (c *client) Registry(repoID int64, hostname string) (*Registry, error) {
 	out := new(Registry)
@@ -52,6 +52,13 @@ type (
 		Security bool `json:"security"`
 	}
 
+	// ApprovalPolicy defines who has to approve a blocked pipeline.
+	ApprovalPolicy struct {
+		RequiredApprovals   int      `json:"required_approvals,omitempty"`
+		Teams               []string `json:"teams,omitempty"`
+		PreventSelfApproval bool     `json:"prevent_self_approval,omitempty"`
+	}
+
 	// Repo represents a repository.
 	Repo struct {
 		ID                           int64                `json:"id,omitempty"`
@@ -69,22 +76,26 @@ type (
 		IsSCMPrivate                 bool                 `json:"private"`
 		Trusted                      TrustedConfiguration `json:"trusted"`
 		RequireApproval              ApprovalMode         `json:"require_approval"`
+		ApprovalPolicy               ApprovalPolicy       `json:"approval_policy"`
 		IsActive                     bool                 `json:"active"`
 		AllowPull                    bool                 `json:"allow_pr"`
 		Config                       string               `json:"config_file"`
 		CancelPreviousPipelineEvents []string             `json:"cancel_previous_pipeline_events"`
 		NetrcTrustedPlugins          []string             `json:"netrc_trusted"`
+		ConfigExtensionEndpoint      string               `json:"config_extension_endpoint"`
 	}
 
 	// RepoPatch defines a repository patch request.
 	RepoPatch struct {
-		Config          *string       `json:"config_file,omitempty"`
-		IsTrusted       *bool         `json:"trusted,omitempty"`
-		RequireApproval *ApprovalMode `json:"require_approval,omitempty"`
-		Timeout         *int64        `json:"timeout,omitempty"`
-		Visibility      *string       `json:"visibility"`
-		AllowPull       *bool         `json:"allow_pr,omitempty"`
-		PipelineCounter *int          `json:"pipeline_counter,omitempty"`
+		Config                  *string         `json:"config_file,omitempty"`
+		IsTrusted               *bool           `json:"trusted,omitempty"`
+		RequireApproval         *ApprovalMode   `json:"require_approval,omitempty"`
+		ApprovalPolicy          *ApprovalPolicy `json:"approval_policy,omitempty"`
+		Timeout                 *int64          `json:"timeout,omitempty"`
+		Visibility              *string         `json:"visibility"`
+		AllowPull               *bool           `json:"allow_pr,omitempty"`
+		PipelineCounter         *int            `json:"pipeline_counter,omitempty"`
+		ConfigExtensionEndpoint *string         `json:"config_extension_endpoint,omitempty"`
 	}
 
 	PipelineError struct {
@@ -122,6 +133,8 @@ type (
 		Reviewer  string           `json:"reviewed_by"`
 		Reviewed  int64            `json:"reviewed_at"`
 		Workflows []*Workflow      `json:"workflows,omitempty"`
+		Inputs    map[string]any   `json:"inputs,omitempty"`
+		Approvals []*Approval      `json:"approvals,omitempty"`
 	}
 
 	// Workflow represents a workflow in the pipeline.
@@ -141,16 +154,17 @@ type (
 
 	// Step represents a process in the pipeline.
 	Step struct {
-		ID       int64    `json:"id"`
-		PID      int      `json:"pid"`
-		PPID     int      `json:"ppid"`
-		Name     string   `json:"name"`
-		State    string   `json:"state"`
-		Error    string   `json:"error,omitempty"`
-		ExitCode int      `json:"exit_code"`
-		Started  int64    `json:"start_time,omitempty"`
-		Stopped  int64    `json:"end_time,omitempty"`
-		Type     StepType `json:"type,omitempty"`
+		ID        int64    `json:"id"`
+		PID       int      `json:"pid"`
+		PPID      int      `json:"ppid"`
+		Name      string   `json:"name"`
+		State     string   `json:"state"`
+		Error     string   `json:"error,omitempty"`
+		ExitCode  int      `json:"exit_code"`
+		Started   int64    `json:"start_time,omitempty"`
+		Stopped   int64    `json:"end_time,omitempty"`
+		Type      StepType `json:"type,omitempty"`
+		Flakiness float64  `json:"flakiness,omitempty"`
 	}
 
 	// Registry represents a docker registry with credentials.
@@ -165,13 +179,14 @@ type (
 
 	// Secret represents a secret variable, such as a password or token.
 	Secret struct {
-		ID     int64    `json:"id"`
-		OrgID  int64    `json:"org_id"`
-		RepoID int64    `json:"repo_id"`
-		Name   string   `json:"name"`
-		Value  string   `json:"value,omitempty"`
-		Images []string `json:"images"`
-		Events []string `json:"events"`
+		ID            int64    `json:"id"`
+		OrgID         int64    `json:"org_id"`
+		RepoID        int64    `json:"repo_id"`
+		EnvironmentID int64    `json:"environment_id,omitempty"`
+		Name          string   `json:"name"`
+		Value         string   `json:"value,omitempty"`
+		Images        []string `json:"images"`
+		Events        []string `json:"events"`
 	}
 
 	// Feed represents an item in the user's feed or timeline.
@@ -235,6 +250,76 @@ type (
 		Type   LogEntryType `json:"type"`
 	}
 
+	// Approval is the JSON data of the approval of a pipeline by a user.
+	Approval struct {
+		ID         int64  `json:"id"`
+		PipelineID int64  `json:"pipeline_id"`
+		UserID     int64  `json:"user_id"`
+		Login      string `json:"login"`
+		Created    int64  `json:"created"`
+	}
+
+	// RepoStats is the JSON data of the statistics of the finished pipelines of a repository.
+	RepoStats struct {
+		Since       int64          `json:"since"`
+		Until       int64          `json:"until"`
+		Branch      string         `json:"branch,omitempty"`
+		Pipelines   int64          `json:"pipelines"`
+		Successful  int64          `json:"successful"`
+		Failed      int64          `json:"failed"`
+		Killed      int64          `json:"killed"`
+		SuccessRate float64        `json:"success_rate"`
+		Duration    DurationStats  `json:"duration"`
+		QueueWait   DurationStats  `json:"queue_wait"`
+		History     []*StatsBucket `json:"history"`
+		Steps       []*StepStats   `json:"steps"`
+	}
+
+	// DurationStats contains percentiles of durations in seconds.
+	DurationStats struct {
+		P50 int64 `json:"p50"`
+		P95 int64 `json:"p95"`
+	}
+
+	// StatsBucket contains the statistics of the pipelines created in an interval.
+	StatsBucket struct {
+		Start       int64         `json:"start"`
+		Pipelines   int64         `json:"pipelines"`
+		SuccessRate float64       `json:"success_rate"`
+		Duration    DurationStats `json:"duration"`
+	}
+
+	// StepStats contains how often a step ran and failed.
+	StepStats struct {
+		Workflow    string  `json:"workflow"`
+		Name        string  `json:"name"`
+		Total       int64   `json:"total"`
+		Failures    int64   `json:"failures"`
+		FailureRate float64 `json:"failure_rate"`
+	}
+
+	// StepFlakiness contains how often a step passed after it failed on the same commit.
+	StepFlakiness struct {
+		ID        int64   `json:"id"`
+		RepoID    int64   `json:"repo_id"`
+		Workflow  string  `json:"workflow"`
+		Name      string  `json:"name"`
+		Runs      int64   `json:"runs"`
+		Flakes    int64   `json:"flakes"`
+		LastFlake int64   `json:"last_flake,omitempty"`
+		Score     float64 `json:"score"`
+	}
+
+	// Artifact is the JSON data of a file uploaded by a step of a pipeline.
+	Artifact struct {
+		ID         int64  `json:"id"`
+		PipelineID int64  `json:"pipeline_id"`
+		StepID     int64  `json:"step_id"`
+		Name       string `json:"name"`
+		Size       int64  `json:"size"`
+		Created    int64  `json:"created"`
+	}
+
 	// Cron is the JSON data of a cron job.
 	Cron struct {
 		ID        int64  `json:"id"`
@@ -247,10 +332,100 @@ type (
 		Branch    string `json:"branch"`
 	}
 
+	// AuditEvent is the JSON data of an entry of the audit log.
+	AuditEvent struct {
+		ID         int64  `json:"id"`
+		Created    int64  `json:"created"`
+		UserID     int64  `json:"user_id"`
+		UserLogin  string `json:"user_login"`
+		Action     string `json:"action"`
+		TargetType string `json:"target_type"`
+		TargetID   int64  `json:"target_id"`
+		TargetName string `json:"target_name"`
+		RepoID     int64  `json:"repo_id"`
+		OrgID      int64  `json:"org_id"`
+		Details    string `json:"details,omitempty"`
+	}
+
+	// PersonalAccessToken is a named API token of a user with limited scopes.
+	PersonalAccessToken struct {
+		ID       int64    `json:"id"`
+		UserID   int64    `json:"user_id"`
+		Name     string   `json:"name"`
+		Scopes   []string `json:"scopes"`
+		RepoID   int64    `json:"repo_id"`
+		Expires  int64    `json:"expires"`
+		LastUsed int64    `json:"last_used"`
+		Created  int64    `json:"created"`
+		Token    string   `json:"token,omitempty"`
+	}
+
+	// PersonalAccessTokenOptions holds the options to create a personal access token.
+	PersonalAccessTokenOptions struct {
+		Name    string   `json:"name"`
+		Scopes  []string `json:"scopes"`
+		RepoID  int64    `json:"repo_id,omitempty"`
+		Expires int64    `json:"expires,omitempty"`
+	}
+
+	// Webhook is the JSON data of an outgoing webhook.
+	Webhook struct {
+		ID      int64       `json:"id"`
+		OrgID   int64       `json:"org_id"`
+		RepoID  int64       `json:"repo_id"`
+		URL     string      `json:"url"`
+		Events  []HookEvent `json:"events"`
+		Active  bool        `json:"active"`
+		Created int64       `json:"created"`
+		Updated int64       `json:"updated"`
+	}
+
+	// WebhookPatch is the JSON data for creating or updating a webhook, unset fields are not changed.
+	WebhookPatch struct {
+		URL    *string     `json:"url,omitempty"`
+		Events []HookEvent `json:"events,omitempty"`
+		Active *bool       `json:"active,omitempty"`
+	}
+
+	// WebhookDelivery is the JSON data of a payload sent to a webhook.
+	WebhookDelivery struct {
+		ID         int64     `json:"id"`
+		WebhookID  int64     `json:"webhook_id"`
+		Event      HookEvent `json:"event"`
+		Payload    string    `json:"payload"`
+		Attempts   int       `json:"attempts"`
+		Success    bool      `json:"success"`
+		StatusCode int       `json:"status_code"`
+		Error      string    `json:"error"`
+		Duration   int64     `json:"duration"`
+		Created    int64     `json:"created"`
+	}
+
+	// Environment is the JSON data of a deployment environment.
+	Environment struct {
+		ID                int64    `json:"id"`
+		RepoID            int64    `json:"repo_id"`
+		Name              string   `json:"name"`
+		URL               string   `json:"url"`
+		RequiredApprovers []string `json:"required_approvers"`
+		AllowedBranches   []string `json:"allowed_branches"`
+		Created           int64    `json:"created"`
+		Updated           int64    `json:"updated"`
+	}
+
+	// EnvironmentPatch is the JSON data for creating or updating an environment, unset fields are not changed.
+	EnvironmentPatch struct {
+		Name              *string   `json:"name,omitempty"`
+		URL               *string   `json:"url,omitempty"`
+		RequiredApprovers *[]string `json:"required_approvers,omitempty"`
+		AllowedBranches   *[]string `json:"allowed_branches,omitempty"`
+	}
+
 	// PipelineOptions is the JSON data for creating a new pipeline.
 	PipelineOptions struct {
 		Branch    string            `json:"branch"`
 		Variables map[string]string `json:"variables"`
+		Inputs    map[string]any    `json:"inputs,omitempty"`
 	}
 
 	// Agent is the JSON data for an agent.
@@ -284,8 +459,14 @@ type (
 
 	// Org is the JSON data for an organization.
 	Org struct {
-		ID     int64  `json:"id"`
-		Name   string `json:"name"`
-		IsUser bool   `json:"is_user"`
+		ID                      int64  `json:"id"`
+		Name                    string `json:"name"`
+		IsUser                  bool   `json:"is_user"`
+		ConfigExtensionEndpoint string `json:"config_extension_endpoint"`
+	}
+
+	// OrgPatch defines an organization patch request.
+	OrgPatch struct {
+		ConfigExtensionEndpoint *string `json:"config_extension_endpoint,omitempty"`
 	}
 )
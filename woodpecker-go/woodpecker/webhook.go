@@ -0,0 +1,63 @@
+package woodpecker
+
+import (
+	"fmt"
+	"net/url"
+)
+
+const (
+	pathRepoWebhooks          = "%s/api/repos/%d/webhooks"
+	pathRepoWebhook           = "%s/api/repos/%d/webhooks/%d"
+	pathRepoWebhookDeliveries = "%s/api/repos/%d/webhooks/%d/deliveries"
+	pathRepoWebhookRedeliver  = "%s/api/repos/%d/webhooks/%d/deliveries/%d/redeliver"
+)
+
+// WebhookList returns the webhooks of the specified repository.
+func (c *client) WebhookList(repoID int64, opt WebhookListOptions) ([]*Webhook, error) {
+	var out []*Webhook
+	uri, _ := url.Parse(fmt.Sprintf(pathRepoWebhooks, c.addr, repoID))
+	uri.RawQuery = opt.getURLQuery().Encode()
+	return out, c.get(uri.String(), &out)
+}
+
+// Webhook returns a webhook by webhook-id for the specified repository.
+func (c *client) Webhook(repoID, webhookID int64) (*Webhook, error) {
+	out := new(Webhook)
+	uri := fmt.Sprintf(pathRepoWebhook, c.addr, repoID, webhookID)
+	return out, c.get(uri, out)
+}
+
+// WebhookCreate creates a webhook for the specified repository.
+func (c *client) WebhookCreate(repoID int64, in *WebhookPatch) (*Webhook, error) {
+	out := new(Webhook)
+	uri := fmt.Sprintf(pathRepoWebhooks, c.addr, repoID)
+	return out, c.post(uri, in, out)
+}
+
+// WebhookUpdate updates a webhook by webhook-id for the specified repository.
+func (c *client) WebhookUpdate(repoID, webhookID int64, in *WebhookPatch) (*Webhook, error) {
+	out := new(Webhook)
+	uri := fmt.Sprintf(pathRepoWebhook, c.addr, repoID, webhookID)
+	return out, c.patch(uri, in, out)
+}
+
+// WebhookDelete deletes a webhook by webhook-id for the specified repository.
+func (c *client) WebhookDelete(repoID, webhookID int64) error {
+	uri := fmt.Sprintf(pathRepoWebhook, c.addr, repoID, webhookID)
+	return c.delete(uri)
+}
+
+// WebhookDeliveries returns the latest deliveries of a webhook, newest first.
+func (c *client) WebhookDeliveries(repoID, webhookID int64, opt WebhookListOptions) ([]*WebhookDelivery, error) {
+	var out []*WebhookDelivery
+	uri, _ := url.Parse(fmt.Sprintf(pathRepoWebhookDeliveries, c.addr, repoID, webhookID))
+	uri.RawQuery = opt.getURLQuery().Encode()
+	return out, c.get(uri.String(), &out)
+}
+
+// WebhookRedeliver sends the payload of a delivery again and returns the new delivery.
+func (c *client) WebhookRedeliver(repoID, webhookID, deliveryID int64) (*WebhookDelivery, error) {
+	out := new(WebhookDelivery)
+	uri := fmt.Sprintf(pathRepoWebhookRedeliver, c.addr, repoID, webhookID, deliveryID)
+	return out, c.post(uri, nil, out)
+}